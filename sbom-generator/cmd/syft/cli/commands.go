@@ -24,8 +24,8 @@ import (
 const indent = "  "
 
 // New constructs the `syft packages` command, aliases the root command to `syft packages`,
-// and constructs the `syft power-user` and `syft attest` commands. It is also responsible for
-// organizing flag usage and injecting the application config for each command.
+// and constructs the `syft power-user`, `syft attest` and `syft repoclosure` commands. It is also responsible
+// for organizing flag usage and injecting the application config for each command.
 // Because of how the `cobra` library behaves, the application's configuration is initialized
 // at this level. Values from the config should only be used after `app.LoadAllValues` has been called.
 // Cobra does not have knowledge of the user provided flags until the `RunE` block of each command.
@@ -47,6 +47,8 @@ func New() (*cobra.Command, error) {
 	attestCmd := Attest(v, app, ro)
 	poweruserCmd := PowerUser(v, app, ro)
 	convertCmd := Convert(v, app, ro)
+	rco := &options.RepoClosureOptions{}
+	repoClosureCmd := RepoClosure(v, app, ro, rco)
 
 	// rootCmd is currently an alias for the packages command
 	rootCmd := &cobra.Command{
@@ -81,12 +83,17 @@ func New() (*cobra.Command, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = rco.AddFlags(repoClosureCmd, v)
+	if err != nil {
+		return nil, err
+	}
 
 	// Add sub-commands.
 	rootCmd.AddCommand(packagesCmd)
 	rootCmd.AddCommand(attestCmd)
 	rootCmd.AddCommand(convertCmd)
 	rootCmd.AddCommand(poweruserCmd)
+	rootCmd.AddCommand(repoClosureCmd)
 	rootCmd.AddCommand(Completion())
 	rootCmd.AddCommand(Version(v, app))
 
@@ -21,7 +21,7 @@ func Convert(v *viper.Viper, app *config.Application, ro *options.RootOptions) *
 	cmd := &cobra.Command{
 		Use:   "convert [SOURCE-SBOM] -o [FORMAT]",
 		Short: "Convert between SBOM formats",
-		Long:  "[Experimental] Convert SBOM files to, and from, SPDX, CycloneDX and Syft's format. For more info about data loss between formats see https://github.com/anchore/syft#format-conversion-experimental",
+		Long:  "Convert SBOM files to, and from, SPDX, CycloneDX and Syft's format. For more info about what is kept between formats see https://github.com/anchore/syft#format-conversion",
 		Example: internal.Tprintf(convertExample, map[string]interface{}{
 			"appName": internal.ApplicationName,
 			"command": "convert",
@@ -0,0 +1,39 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/anchore/syft/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+type RepoClosureOptions struct {
+	Output string
+	File   string
+}
+
+var _ Interface = (*RepoClosureOptions)(nil)
+
+func (o *RepoClosureOptions) AddFlags(cmd *cobra.Command, v *viper.Viper) error {
+	cmd.Flags().StringVarP(&o.Output, "output", "o", config.RepoClosureTextOutput,
+		fmt.Sprintf("report output format, options=[%s %s]", config.RepoClosureTextOutput, config.RepoClosureJSONOutput))
+
+	cmd.Flags().StringVarP(&o.File, "file", "", "",
+		"file to write the report output to (default is STDOUT)")
+
+	return bindRepoClosureConfigOptions(cmd.Flags(), v)
+}
+
+func bindRepoClosureConfigOptions(flags *pflag.FlagSet, v *viper.Viper) error {
+	if err := v.BindPFlag("repoclosure.output", flags.Lookup("output")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("repoclosure.file", flags.Lookup("file")); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -36,6 +36,7 @@ const (
 `
 	nonImageSchemeHelp = `    {{.appName}} {{.command}} dir:path/to/yourproject                read directly from a path on disk (any directory)
     {{.appName}} {{.command}} file:path/to/yourproject/file          read directly from a path on disk (any single file)
+    {{.appName}} {{.command}} repo:https://host/path/to/repo         read a yum/dnf repository from a baseurl or a local mirror (from its repodata)
 `
 	packagesSchemeHelp = "\n" + indent + schemeHelpHeader + "\n" + imageSchemeHelp + nonImageSchemeHelp
 
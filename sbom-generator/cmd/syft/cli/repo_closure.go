@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/anchore/syft/cmd/syft/cli/options"
+	"github.com/anchore/syft/cmd/syft/cli/repoclosure"
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const repoClosureExample = `  {{.appName}} {{.command}} openEuler-22.03-LTS-x86_64-dvd.iso                            check the dependency closure of an ISO
+  {{.appName}} {{.command}} repo:https://repo.openeuler.org/openEuler-22.03-LTS/everything/x86_64   check the dependency closure of a yum/dnf repository
+  {{.appName}} {{.command}} path/to/mirror -o json --file closure.json                    write the report as JSON to a file
+
+  The command exits with a non-zero status when some requires have no provider.
+`
+
+func RepoClosure(v *viper.Viper, app *config.Application, ro *options.RootOptions, rco *options.RepoClosureOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "repoclosure [SOURCE]",
+		Short: "Report unresolved and conflicting dependencies of an ISO or a repository",
+		Long:  "Resolve the requires of every package of an ISO or a yum/dnf repository from its repodata, and report the requires without any provider, the conflicts and obsoletes between packages which are both present, and the requires with several possible providers.",
+		Example: internal.Tprintf(repoClosureExample, map[string]interface{}{
+			"appName": internal.ApplicationName,
+			"command": "repoclosure",
+		}),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := app.LoadAllValues(v, ro.Config); err != nil {
+				return fmt.Errorf("invalid application config: %w", err)
+			}
+			newLogWrapper(app)
+			logApplicationConfig(app)
+			return validateArgs(cmd, args)
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app.CheckForAppUpdate {
+				checkForApplicationUpdate()
+			}
+			return repoclosure.Run(cmd.Context(), app, args)
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,118 @@
+package repoclosure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/anchore/syft/internal/config"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/pkg/cataloger/repodata"
+	"github.com/anchore/syft/syft/source"
+)
+
+func Run(ctx context.Context, app *config.Application, args []string) error {
+	// could be an ISO, a local mirror or a repository baseurl (repo:)
+	userInput := args[0]
+	si, err := source.ParseInput(userInput, app.Platform, false)
+	if err != nil {
+		return fmt.Errorf("could not generate source input for repoclosure command: %w", err)
+	}
+
+	src, cleanup, err := source.New(*si, app.Registry.ToOptions(), app.Exclusions)
+	if cleanup != nil {
+		defer cleanup()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to construct source from user input %q: %w", si.UserInput, err)
+	}
+
+	resolver, err := src.FileResolver(source.SquashedScope)
+	if err != nil {
+		return fmt.Errorf("unable to get file resolver: %w", err)
+	}
+
+	report, err := repodata.CheckClosure(resolver)
+	if err != nil {
+		return fmt.Errorf("unable to check the closure of %q: %w", userInput, err)
+	}
+
+	out := io.Writer(os.Stdout)
+	if app.RepoClosure.File != "" {
+		f, err := os.Create(app.RepoClosure.File)
+		if err != nil {
+			return fmt.Errorf("unable to create report file: %w", err)
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				log.Warnf("unable to write to report destination: %+v", err)
+			}
+		}()
+		out = f
+	}
+
+	switch app.RepoClosure.Output {
+	case config.RepoClosureJSONOutput:
+		err = writeJSON(out, *report)
+	default:
+		err = writeText(out, *report)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to write the closure report: %w", err)
+	}
+
+	if !report.Closed() {
+		return fmt.Errorf("the dependency closure is broken: %d packages have unresolved requires", len(report.Unresolved))
+	}
+	return nil
+}
+
+func writeJSON(out io.Writer, report repodata.ClosureReport) error {
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", " ")
+	return enc.Encode(report)
+}
+
+// writeText writes the report in the layout of dnf repoclosure, followed by the other sections.
+func writeText(out io.Writer, report repodata.ClosureReport) error {
+	var sb strings.Builder
+
+	for _, unresolved := range report.Unresolved {
+		fmt.Fprintf(&sb, "package: %s\n", unresolved.Package)
+		sb.WriteString("  unresolved deps:\n")
+		for _, require := range unresolved.Requires {
+			fmt.Fprintf(&sb, "    %s\n", require)
+		}
+	}
+
+	if len(report.Conflicts) > 0 {
+		sb.WriteString("conflicting packages:\n")
+		for _, pair := range report.Conflicts {
+			fmt.Fprintf(&sb, "  %s conflicts with %s (%s)\n", pair.Package, pair.With, pair.Dependency)
+		}
+	}
+
+	if len(report.Obsoletes) > 0 {
+		sb.WriteString("obsoleted packages:\n")
+		for _, pair := range report.Obsoletes {
+			fmt.Fprintf(&sb, "  %s obsoletes %s (%s)\n", pair.Package, pair.With, pair.Dependency)
+		}
+	}
+
+	if len(report.Ambiguous) > 0 {
+		sb.WriteString("ambiguous providers:\n")
+		for _, ambiguous := range report.Ambiguous {
+			fmt.Fprintf(&sb, "  %s requires %s: chose %s over %s\n", ambiguous.Package, ambiguous.Requires, ambiguous.Provider, strings.Join(ambiguous.Alternatives, ", "))
+		}
+	}
+
+	fmt.Fprintf(&sb, "checked %d packages: %d with unresolved requires, %d conflicts, %d obsoletes, %d ambiguous providers\n",
+		report.Packages, len(report.Unresolved), len(report.Conflicts), len(report.Obsoletes), len(report.Ambiguous))
+
+	_, err := io.WriteString(out, sb.String())
+	return err
+}
@@ -0,0 +1,52 @@
+package repoclosure
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg/cataloger/repodata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteText(t *testing.T) {
+	report := repodata.ClosureReport{
+		Packages: 3,
+		Unresolved: []repodata.UnresolvedRequires{
+			{Package: "app-1.0-1.x86_64", Requires: []string{"missing", "lib >= 2"}},
+		},
+		Conflicts: []repodata.PackagePair{
+			{Package: "app-1.0-1.x86_64", Dependency: "nginx < 2", With: "nginx-1.21-1.x86_64"},
+		},
+		Ambiguous: []repodata.AmbiguousRequire{
+			{Package: "app-1.0-1.x86_64", Requires: "webserver", Provider: "httpd-2.4-1.x86_64", Alternatives: []string{"nginx-1.21-1.x86_64"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeText(&buf, report))
+	assert.Equal(t, `package: app-1.0-1.x86_64
+  unresolved deps:
+    missing
+    lib >= 2
+conflicting packages:
+  app-1.0-1.x86_64 conflicts with nginx-1.21-1.x86_64 (nginx < 2)
+ambiguous providers:
+  app-1.0-1.x86_64 requires webserver: chose httpd-2.4-1.x86_64 over nginx-1.21-1.x86_64
+checked 3 packages: 1 with unresolved requires, 1 conflicts, 0 obsoletes, 1 ambiguous providers
+`, buf.String())
+}
+
+func TestWriteJSON_noAlternatives(t *testing.T) {
+	report := repodata.ClosureReport{
+		Packages: 1,
+		Ambiguous: []repodata.AmbiguousRequire{
+			{Package: "app-1.0-1.x86_64", Requires: "webserver", Provider: "httpd-2.4-1.x86_64"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeJSON(&buf, report))
+	assert.Contains(t, buf.String(), `"provider": "httpd-2.4-1.x86_64"`)
+	assert.NotContains(t, buf.String(), `"alternatives"`)
+}
@@ -51,6 +51,7 @@ type Application struct {
 	Registry           registry           `yaml:"registry" json:"registry" mapstructure:"registry"`
 	Exclusions         []string           `yaml:"exclude" json:"exclude" mapstructure:"exclude"`
 	Attest             attest             `yaml:"attest" json:"attest" mapstructure:"attest"`
+	RepoClosure        repoClosure        `yaml:"repoclosure" json:"repoclosure" mapstructure:"repoclosure"`
 	Platform           string             `yaml:"platform" json:"platform" mapstructure:"platform"`
 	Format             format             `yaml:"format" json:"format" mapstructure:"format"`
 }
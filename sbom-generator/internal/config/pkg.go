@@ -2,6 +2,7 @@ package config
 
 import (
 	"github.com/anchore/syft/syft/pkg/cataloger"
+	"github.com/anchore/syft/syft/pkg/cataloger/repodata"
 	"github.com/spf13/viper"
 )
 
@@ -9,6 +10,11 @@ type pkg struct {
 	Cataloger               catalogerOptions `yaml:"cataloger" json:"cataloger" mapstructure:"cataloger"`
 	SearchUnindexedArchives bool             `yaml:"search-unindexed-archives" json:"search-unindexed-archives" mapstructure:"search-unindexed-archives"`
 	SearchIndexedArchives   bool             `yaml:"search-indexed-archives" json:"search-indexed-archives" mapstructure:"search-indexed-archives"`
+	RepodataMavenIndex      string           `yaml:"repodata-maven-index" json:"repodata-maven-index" mapstructure:"repodata-maven-index"`
+	RepodataUnpackRpms      bool             `yaml:"repodata-unpack-rpms" json:"repodata-unpack-rpms" mapstructure:"repodata-unpack-rpms"`
+	MavenLocalRepository    string           `yaml:"maven-local-repository" json:"maven-local-repository" mapstructure:"maven-local-repository"`
+	Parallelism             int              `yaml:"parallelism" json:"parallelism" mapstructure:"parallelism"`
+	DetectLicenses          bool             `yaml:"detect-licenses" json:"detect-licenses" mapstructure:"detect-licenses"`
 }
 
 func (cfg pkg) loadDefaultValues(v *viper.Viper) {
@@ -16,6 +22,11 @@ func (cfg pkg) loadDefaultValues(v *viper.Viper) {
 	c := cataloger.DefaultSearchConfig()
 	v.SetDefault("package.search-unindexed-archives", c.IncludeUnindexedArchives)
 	v.SetDefault("package.search-indexed-archives", c.IncludeIndexedArchives)
+	v.SetDefault("package.repodata-maven-index", "")
+	v.SetDefault("package.repodata-unpack-rpms", false)
+	v.SetDefault("package.maven-local-repository", "")
+	v.SetDefault("package.parallelism", cataloger.DefaultConfig().Parallelism)
+	v.SetDefault("package.detect-licenses", cataloger.DefaultConfig().DetectLicenses)
 }
 
 func (cfg *pkg) parseConfigValues() error {
@@ -29,5 +40,12 @@ func (cfg pkg) ToConfig() cataloger.Config {
 			IncludeUnindexedArchives: cfg.SearchUnindexedArchives,
 			Scope:                    cfg.Cataloger.ScopeOpt,
 		},
+		Repodata: repodata.Config{
+			MavenIndex: cfg.RepodataMavenIndex,
+			UnpackRpms: cfg.RepodataUnpackRpms,
+		},
+		MavenLocalRepository: cfg.MavenLocalRepository,
+		Parallelism:          cfg.Parallelism,
+		DetectLicenses:       cfg.DetectLicenses,
 	}
 }
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	RepoClosureTextOutput = "text"
+	RepoClosureJSONOutput = "json"
+)
+
+type repoClosure struct {
+	Output string `yaml:"output" json:"output" mapstructure:"output"` // -o, the format of the closure report (text or json)
+	File   string `yaml:"file" json:"file" mapstructure:"file"`       // --file, the file to write the closure report to
+}
+
+func (cfg *repoClosure) parseConfigValues() error {
+	switch cfg.Output {
+	case RepoClosureTextOutput, RepoClosureJSONOutput:
+		return nil
+	}
+	return fmt.Errorf("bad --output value for repoclosure %q, options=[%s %s]", cfg.Output, RepoClosureTextOutput, RepoClosureJSONOutput)
+}
+
+func (cfg repoClosure) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("repoclosure.output", RepoClosureTextOutput)
+}
@@ -0,0 +1,322 @@
+package file
+
+import (
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/cavaliergopher/cpio"
+	"github.com/cavaliergopher/rpm"
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz/lzma"
+	"github.com/xi2/xz"
+)
+
+// RpmPayloadReader reads the files of the cpio payload of a rpm.
+type RpmPayloadReader struct {
+	Package *rpm.Package
+	cpio    *cpio.Reader
+	closeFn func()
+}
+
+// NewRpmPayloadReader reads the header of the given rpm and returns a reader over the files of its payload, which is
+// decompressed according to the payload compression recorded in the header (gzip, bzip2, xz, lzma, zstd or none).
+func NewRpmPayloadReader(reader io.Reader) (*RpmPayloadReader, error) {
+	pkg, err := rpm.Read(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read rpm header: %w", err)
+	}
+
+	if format := pkg.PayloadFormat(); format != "" && format != "cpio" {
+		return nil, fmt.Errorf("unsupported rpm payload format: %s", format)
+	}
+
+	payload, closeFn, err := newRpmPayloadDecompressor(pkg.PayloadCompression(), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RpmPayloadReader{
+		Package: pkg,
+		cpio:    cpio.NewReader(payload),
+		closeFn: closeFn,
+	}, nil
+}
+
+func newRpmPayloadDecompressor(compression string, reader io.Reader) (io.Reader, func(), error) {
+	noopFn := func() {}
+	switch compression {
+	// rpm defaults to gzip when the header does not record the payload compression
+	case "gzip", "":
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, noopFn, fmt.Errorf("unable to read gzip rpm payload: %w", err)
+		}
+		return gzipReader, func() { gzipReader.Close() }, nil
+	case "bzip2":
+		return bzip2.NewReader(reader), noopFn, nil
+	case "xz":
+		xzReader, err := xz.NewReader(reader, 0)
+		if err != nil {
+			return nil, noopFn, fmt.Errorf("unable to read xz rpm payload: %w", err)
+		}
+		return xzReader, noopFn, nil
+	case "lzma":
+		lzmaReader, err := lzma.NewReader(reader)
+		if err != nil {
+			return nil, noopFn, fmt.Errorf("unable to read lzma rpm payload: %w", err)
+		}
+		return lzmaReader, noopFn, nil
+	case "zstd":
+		zstdReader, err := zstd.NewReader(reader)
+		if err != nil {
+			return nil, noopFn, fmt.Errorf("unable to read zstd rpm payload: %w", err)
+		}
+		return zstdReader, zstdReader.Close, nil
+	case "none", "identity":
+		return reader, noopFn, nil
+	}
+	return nil, noopFn, fmt.Errorf("unsupported rpm payload compression: %s", compression)
+}
+
+// Next advances to the next file of the payload, returning io.EOF at the end. The contents of the file can be read
+// from the payload reader until the next call to Next.
+func (r *RpmPayloadReader) Next() (*cpio.Header, error) {
+	return r.cpio.Next()
+}
+
+func (r *RpmPayloadReader) Read(p []byte) (int, error) {
+	return r.cpio.Read(p)
+}
+
+func (r *RpmPayloadReader) Close() {
+	r.closeFn()
+}
+
+// RpmPayloadPath returns the absolute path of a payload file, payloads record the paths relative to the root ("./usr/bin/bash").
+func RpmPayloadPath(header *cpio.Header) string {
+	return path.Clean("/" + strings.TrimPrefix(header.Name, "."))
+}
+
+// TraverseFilesInRpm enumerates all files of the payload of the given rpm using the visitor pattern, the contents of
+// each file are available from the given reader while the file is being visited.
+func TraverseFilesInRpm(rpmReader io.Reader, visitor func(*cpio.Header, io.Reader) error, paths ...string) error {
+	request := newZipTraverseRequest(paths...)
+
+	payload, err := NewRpmPayloadReader(rpmReader)
+	if err != nil {
+		return err
+	}
+	defer payload.Close()
+
+	for {
+		header, err := payload.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read rpm payload: %w", err)
+		}
+
+		// if no paths are given then assume that all files should be traversed
+		if len(paths) > 0 {
+			if _, ok := request[RpmPayloadPath(header)]; !ok {
+				// this file path is not of interest
+				continue
+			}
+		}
+
+		if err = visitor(header, payload); err != nil {
+			return err
+		}
+	}
+}
+
+// ExtractRpmToDir extracts select paths (all files when none are given) of the payload of the given rpm to a target
+// directory. Directories, regular files, symlinks and hard links are extracted, device nodes, fifos and sockets are not.
+// Files and symlinks replace what was already extracted at their path.
+func ExtractRpmToDir(rpmReader io.Reader, targetDir string, paths ...string) error {
+	request := newZipTraverseRequest(paths...)
+
+	// rpm only stores the contents of a set of hard links with its last member
+	pendingLinks := make(map[int64]*rpmPayloadLinks)
+
+	visitor := func(header *cpio.Header, contents io.Reader) error {
+		mode := int64(header.Mode) & cpio.ModeType
+
+		// the last member of a set of hard links is read even when it is not selected, it has the contents of the
+		// selected members
+		if len(paths) > 0 {
+			if _, ok := request[RpmPayloadPath(header)]; !ok {
+				links, ok := pendingLinks[header.Inode]
+				if mode != cpio.TypeReg || header.Links < 2 || header.Size == 0 || !ok {
+					return nil
+				}
+				delete(pendingLinks, header.Inode)
+				if err := extractRpmPayloadFile(contents, links.paths[0], links.mode); err != nil {
+					return err
+				}
+				return linkRpmPayloadFile(links.paths[0], links.paths[1:])
+			}
+		}
+
+		joinedPath, err := safeJoin(targetDir, RpmPayloadPath(header))
+		if err != nil {
+			return err
+		}
+
+		switch mode {
+		case cpio.TypeDir, cpio.TypeSymlink, cpio.TypeReg:
+			if err := ensureRpmPayloadParentDir(targetDir, joinedPath); err != nil {
+				log.Debugf("skipping rpm payload file: %+v", err)
+				return nil
+			}
+		}
+
+		switch mode {
+		case cpio.TypeSymlink, cpio.TypeReg:
+			if err := removeRpmPayloadEntry(joinedPath); err != nil {
+				log.Debugf("skipping rpm payload file: %+v", err)
+				return nil
+			}
+		}
+
+		switch mode {
+		case cpio.TypeDir:
+			if err := os.Mkdir(joinedPath, 0o755); err != nil && !os.IsExist(err) {
+				return err
+			}
+		case cpio.TypeSymlink:
+			// links are created as they are: they are not followed when extracting other files
+			return os.Symlink(header.Linkname, joinedPath)
+		case cpio.TypeReg:
+			fileMode := os.FileMode(header.Mode.Perm()) | 0o600
+			if header.Links > 1 && header.Size == 0 {
+				links, ok := pendingLinks[header.Inode]
+				if !ok {
+					links = &rpmPayloadLinks{mode: fileMode}
+					pendingLinks[header.Inode] = links
+				}
+				links.paths = append(links.paths, joinedPath)
+				return nil
+			}
+			if err := extractRpmPayloadFile(contents, joinedPath, fileMode); err != nil {
+				return err
+			}
+			var links []string
+			if pending, ok := pendingLinks[header.Inode]; ok {
+				links = pending.paths
+				delete(pendingLinks, header.Inode)
+			}
+			return linkRpmPayloadFile(joinedPath, links)
+		}
+		return nil
+	}
+
+	if err := TraverseFilesInRpm(rpmReader, visitor); err != nil {
+		return err
+	}
+
+	// no member of a set of empty hard links has contents, these are created empty once the whole payload is read
+	inodes := make([]int64, 0, len(pendingLinks))
+	for inode := range pendingLinks {
+		inodes = append(inodes, inode)
+	}
+	sort.Slice(inodes, func(i, j int) bool { return inodes[i] < inodes[j] })
+	for _, inode := range inodes {
+		links := pendingLinks[inode]
+		if err := extractRpmPayloadFile(strings.NewReader(""), links.paths[0], links.mode); err != nil {
+			return err
+		}
+		if err := linkRpmPayloadFile(links.paths[0], links.paths[1:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rpmPayloadLinks are the selected members of a set of hard links waiting for the member holding their contents.
+type rpmPayloadLinks struct {
+	paths []string
+	mode  os.FileMode
+}
+
+// linkRpmPayloadFile links the members of a set of hard links to the extracted file, replacing what was previously
+// extracted at their paths.
+func linkRpmPayloadFile(filePath string, links []string) error {
+	for _, link := range links {
+		if err := removeRpmPayloadEntry(link); err != nil {
+			return err
+		}
+		if err := os.Link(filePath, link); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureRpmPayloadParentDir creates the parent directory of a file to extract. Files below a previously extracted
+// symlink are refused, as writing them would follow the link (possibly outside of the target directory).
+func ensureRpmPayloadParentDir(targetDir, filePath string) error {
+	relativePath, err := filepath.Rel(targetDir, filepath.Dir(filePath))
+	if err != nil {
+		return err
+	}
+
+	current := targetDir
+	for _, element := range strings.Split(relativePath, string(filepath.Separator)) {
+		if element == "." {
+			continue
+		}
+		current = filepath.Join(current, element)
+		info, err := os.Lstat(current)
+		switch {
+		case os.IsNotExist(err):
+			if err := os.Mkdir(current, 0o755); err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		case info.Mode()&os.ModeSymlink != 0:
+			return fmt.Errorf("unable to extract file=%q below symlink=%q", filePath, current)
+		case !info.IsDir():
+			return fmt.Errorf("unable to extract file=%q below file=%q", filePath, current)
+		}
+	}
+	return nil
+}
+
+// removeRpmPayloadEntry removes what was previously extracted at the path of a file or symlink to extract: creating a
+// symlink over it would fail and writing a file over a symlink would follow the link. Directories are not replaced.
+func removeRpmPayloadEntry(filePath string) error {
+	info, err := os.Lstat(filePath)
+	switch {
+	case os.IsNotExist(err):
+		return nil
+	case err != nil:
+		return err
+	case info.IsDir():
+		return fmt.Errorf("unable to extract file=%q over a directory", filePath)
+	}
+	return os.Remove(filePath)
+}
+
+func extractRpmPayloadFile(contents io.Reader, filePath string, mode os.FileMode) error {
+	outputFile, err := os.OpenFile(filePath, os.O_CREATE|os.O_RDWR|os.O_TRUNC, mode)
+	if err != nil {
+		return fmt.Errorf("unable to create dest file=%q: %w", filePath, err)
+	}
+	defer outputFile.Close()
+
+	if err := SafeCopy(outputFile, contents); err != nil {
+		return fmt.Errorf("unable to copy rpm payload file=%q: %w", filePath, err)
+	}
+	return nil
+}
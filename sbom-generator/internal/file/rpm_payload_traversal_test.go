@@ -0,0 +1,284 @@
+package file
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/cavaliergopher/cpio"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ulikunitzxz "github.com/ulikunitz/xz"
+	"github.com/ulikunitz/xz/lzma"
+)
+
+type testRpmFile struct {
+	name     string
+	mode     int64
+	contents string
+	linkname string
+	inode    int64
+	links    int
+}
+
+var testRpmFiles = []testRpmFile{
+	{name: "./usr", mode: cpio.TypeDir | 0o755},
+	{name: "./usr/bin", mode: cpio.TypeDir | 0o755},
+	{name: "./usr/bin/tool", mode: cpio.TypeReg | 0o755, contents: "#!/bin/sh\n"},
+	{name: "./usr/bin/tool-link", mode: cpio.TypeSymlink | 0o777, linkname: "tool"},
+	{name: "./usr/lib/java/lib.jar", mode: cpio.TypeReg | 0o644, contents: "jar"},
+	{name: "./usr/lib/python3/site-packages/pkg/METADATA", mode: cpio.TypeReg | 0o644, contents: "Name: pkg\n"},
+	// rpm stores the contents of hard links with the last link only
+	{name: "./usr/bin/hard-a", mode: cpio.TypeReg | 0o755, inode: 1000, links: 2},
+	{name: "./usr/bin/hard-b", mode: cpio.TypeReg | 0o755, contents: "binary", inode: 1000, links: 2},
+	{name: "./dev/null", mode: cpio.TypeChar | 0o666},
+}
+
+// newTestRpm builds a minimal rpm: a lead, an empty signature and a header with the payload tags only.
+func newTestRpm(t *testing.T, compression string, files []testRpmFile) []byte {
+	t.Helper()
+
+	rpmFile := new(bytes.Buffer)
+
+	lead := make([]byte, 96)
+	copy(lead, []byte{0xED, 0xAB, 0xEE, 0xDB, 3, 0})
+	rpmFile.Write(lead)
+
+	writeHeader := func(tags map[int]string) {
+		var ids []int
+		for id := range tags {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+
+		var index, store bytes.Buffer
+		for _, id := range ids {
+			entry := make([]byte, 16)
+			binary.BigEndian.PutUint32(entry[0:4], uint32(id))
+			binary.BigEndian.PutUint32(entry[4:8], 6) // string
+			binary.BigEndian.PutUint32(entry[8:12], uint32(store.Len()))
+			binary.BigEndian.PutUint32(entry[12:16], 1)
+			index.Write(entry)
+			store.WriteString(tags[id])
+			store.WriteByte(0)
+		}
+
+		header := make([]byte, 16)
+		copy(header, []byte{0x8E, 0xAD, 0xE8, 0x01})
+		binary.BigEndian.PutUint32(header[8:12], uint32(len(ids)))
+		binary.BigEndian.PutUint32(header[12:16], uint32(store.Len()))
+		rpmFile.Write(header)
+		rpmFile.Write(index.Bytes())
+		rpmFile.Write(store.Bytes())
+	}
+
+	// signature
+	writeHeader(map[int]string{})
+	tags := map[int]string{1124: "cpio"}
+	if compression != "" {
+		tags[1125] = compression
+	}
+	writeHeader(tags)
+
+	var payload io.WriteCloser
+	switch compression {
+	case "gzip", "":
+		payload = gzip.NewWriter(rpmFile)
+	case "xz":
+		w, err := ulikunitzxz.NewWriter(rpmFile)
+		require.NoError(t, err)
+		payload = w
+	case "lzma":
+		w, err := lzma.NewWriter(rpmFile)
+		require.NoError(t, err)
+		payload = w
+	case "zstd":
+		w, err := zstd.NewWriter(rpmFile)
+		require.NoError(t, err)
+		payload = w
+	default:
+		payload = nopWriteCloser{rpmFile}
+	}
+
+	archive := cpio.NewWriter(payload)
+	for _, f := range files {
+		body := f.contents
+		if f.linkname != "" {
+			body = f.linkname
+		}
+		links := f.links
+		if links == 0 {
+			links = 1
+		}
+		require.NoError(t, archive.WriteHeader(&cpio.Header{
+			Name:  f.name,
+			Mode:  cpio.FileMode(f.mode),
+			Size:  int64(len(body)),
+			Inode: f.inode,
+			Links: links,
+		}))
+		_, err := archive.Write([]byte(body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	require.NoError(t, payload.Close())
+
+	return rpmFile.Bytes()
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func TestTraverseFilesInRpm(t *testing.T) {
+	for _, compression := range []string{"", "gzip", "xz", "lzma", "zstd", "none"} {
+		t.Run(compression, func(t *testing.T) {
+			contents := make(map[string]string)
+			visitor := func(header *cpio.Header, reader io.Reader) error {
+				b, err := io.ReadAll(reader)
+				if err != nil {
+					return err
+				}
+				contents[RpmPayloadPath(header)] = string(b)
+				return nil
+			}
+
+			rpmFile := newTestRpm(t, compression, testRpmFiles)
+			require.NoError(t, TraverseFilesInRpm(bytes.NewReader(rpmFile), visitor))
+			assert.Len(t, contents, len(testRpmFiles))
+			assert.Equal(t, "#!/bin/sh\n", contents["/usr/bin/tool"])
+
+			contents = make(map[string]string)
+			require.NoError(t, TraverseFilesInRpm(bytes.NewReader(rpmFile), visitor, "/usr/lib/java/lib.jar"))
+			assert.Equal(t, map[string]string{"/usr/lib/java/lib.jar": "jar"}, contents)
+		})
+	}
+}
+
+func TestTraverseFilesInRpm_UnsupportedCompression(t *testing.T) {
+	rpmFile := newTestRpm(t, "brotli", testRpmFiles)
+	err := TraverseFilesInRpm(bytes.NewReader(rpmFile), func(*cpio.Header, io.Reader) error { return nil })
+	assert.ErrorContains(t, err, "unsupported rpm payload compression: brotli")
+}
+
+func TestExtractRpmToDir(t *testing.T) {
+	targetDir := t.TempDir()
+	require.NoError(t, ExtractRpmToDir(bytes.NewReader(newTestRpm(t, "zstd", testRpmFiles)), targetDir))
+
+	read := func(p string) string {
+		b, err := os.ReadFile(filepath.Join(targetDir, p))
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	assert.Equal(t, "#!/bin/sh\n", read("usr/bin/tool"))
+	assert.Equal(t, "#!/bin/sh\n", read("usr/bin/tool-link"))
+	assert.Equal(t, "jar", read("usr/lib/java/lib.jar"))
+	assert.Equal(t, "Name: pkg\n", read("usr/lib/python3/site-packages/pkg/METADATA"))
+	assert.Equal(t, "binary", read("usr/bin/hard-a"))
+	assert.Equal(t, "binary", read("usr/bin/hard-b"))
+
+	link, err := os.Readlink(filepath.Join(targetDir, "usr/bin/tool-link"))
+	require.NoError(t, err)
+	assert.Equal(t, "tool", link)
+
+	_, err = os.Lstat(filepath.Join(targetDir, "dev/null"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestExtractRpmToDir_SelectPaths(t *testing.T) {
+	targetDir := t.TempDir()
+	require.NoError(t, ExtractRpmToDir(bytes.NewReader(newTestRpm(t, "xz", testRpmFiles)), targetDir, "/usr/lib/java/lib.jar"))
+
+	var extracted []string
+	require.NoError(t, filepath.Walk(targetDir, func(p string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			extracted = append(extracted, p)
+		}
+		return err
+	}))
+	assert.Equal(t, []string{filepath.Join(targetDir, "usr/lib/java/lib.jar")}, extracted)
+}
+
+func TestExtractRpmToDir_SymlinkEscape(t *testing.T) {
+	outside := t.TempDir()
+	targetDir := t.TempDir()
+
+	files := []testRpmFile{
+		{name: "./usr/lib/escape", mode: cpio.TypeSymlink | 0o777, linkname: outside},
+		{name: "./usr/lib/escape/evil", mode: cpio.TypeReg | 0o644, contents: "evil"},
+		{name: "./usr/lib/escape", mode: cpio.TypeReg | 0o644, contents: "evil"},
+	}
+	require.NoError(t, ExtractRpmToDir(bytes.NewReader(newTestRpm(t, "gzip", files)), targetDir))
+
+	entries, err := os.ReadDir(outside)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestExtractRpmToDir_SelectHardLink(t *testing.T) {
+	targetDir := t.TempDir()
+	require.NoError(t, ExtractRpmToDir(bytes.NewReader(newTestRpm(t, "gzip", testRpmFiles)), targetDir, "/usr/bin/hard-a"))
+
+	contents, err := os.ReadFile(filepath.Join(targetDir, "usr/bin/hard-a"))
+	require.NoError(t, err)
+	assert.Equal(t, "binary", string(contents))
+
+	_, err = os.Lstat(filepath.Join(targetDir, "usr/bin/hard-b"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestExtractRpmToDir_ReplaceExisting(t *testing.T) {
+	targetDir := t.TempDir()
+	rpmFile := newTestRpm(t, "gzip", testRpmFiles)
+
+	// extracting twice replaces the files and symlinks of the first extraction
+	require.NoError(t, ExtractRpmToDir(bytes.NewReader(rpmFile), targetDir))
+	require.NoError(t, ExtractRpmToDir(bytes.NewReader(rpmFile), targetDir))
+
+	files := []testRpmFile{
+		{name: "./usr/bin/tool", mode: cpio.TypeReg | 0o755, contents: "#!/bin/sh\n"},
+		{name: "./usr/bin/tool", mode: cpio.TypeSymlink | 0o777, linkname: "tool-link"},
+	}
+	require.NoError(t, ExtractRpmToDir(bytes.NewReader(newTestRpm(t, "gzip", files)), targetDir))
+
+	link, err := os.Readlink(filepath.Join(targetDir, "usr/bin/tool"))
+	require.NoError(t, err)
+	assert.Equal(t, "tool-link", link)
+}
+
+func TestExtractRpmToDir_EmptyHardLinks(t *testing.T) {
+	targetDir := t.TempDir()
+	files := []testRpmFile{
+		{name: "./usr/share/empty-a", mode: cpio.TypeReg | 0o644, inode: 2000, links: 2},
+		{name: "./usr/share/empty-b", mode: cpio.TypeReg | 0o644, inode: 2000, links: 2},
+	}
+	rpmFile := newTestRpm(t, "gzip", files)
+
+	// no member of the set has contents, extracting twice links them over the first extraction
+	require.NoError(t, ExtractRpmToDir(bytes.NewReader(rpmFile), targetDir))
+	require.NoError(t, ExtractRpmToDir(bytes.NewReader(rpmFile), targetDir))
+
+	a, err := os.Stat(filepath.Join(targetDir, "usr/share/empty-a"))
+	require.NoError(t, err)
+	b, err := os.Stat(filepath.Join(targetDir, "usr/share/empty-b"))
+	require.NoError(t, err)
+	assert.Zero(t, a.Size())
+	assert.True(t, os.SameFile(a, b))
+
+	// the selected members are created without the last member of the set
+	selectedDir := t.TempDir()
+	require.NoError(t, ExtractRpmToDir(bytes.NewReader(rpmFile), selectedDir, "/usr/share/empty-a"))
+	_, err = os.Stat(filepath.Join(selectedDir, "usr/share/empty-a"))
+	require.NoError(t, err)
+	_, err = os.Lstat(filepath.Join(selectedDir, "usr/share/empty-b"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -19,7 +19,7 @@ func encodeComponent(p pkg.Package) cyclonedx.Component {
 		props = append(props, encodeProperties(locations, "syft:location")...)
 	}
 	if hasMetadata(p) {
-		props = append(props, encodeProperties(p.Metadata, "syft:metadata")...)
+		props = append(props, encodePropertiesWith(p.Metadata, "syft:metadata", CycloneDXMetadataFields)...)
 	}
 
 	var properties *[]cyclonedx.Property
@@ -38,12 +38,53 @@ func encodeComponent(p pkg.Package) cyclonedx.Component {
 		Author:             encodeAuthor(p),
 		Publisher:          encodePublisher(p),
 		Description:        encodeDescription(p),
+		Hashes:             encodeHashes(p),
 		ExternalReferences: encodeExternalReferences(p),
 		Properties:         properties,
 		BOMRef:             deriveBomRef(p),
 	}
 }
 
+// encodeEvidence returns how the identity of a package was found: one method for each of its locations, which are
+// also listed as the occurrences of the package. The confidence of the identity is left out, since the catalogers do
+// not assess it.
+func encodeEvidence(p pkg.Package) *cyclonedx.Evidence {
+	locations := p.Locations.ToSlice()
+	if len(locations) == 0 {
+		return nil
+	}
+
+	field := cyclonedx.EvidenceIdentityFieldTypeName
+	if p.PURL != "" {
+		field = cyclonedx.EvidenceIdentityFieldTypePURL
+	}
+
+	technique := cyclonedx.EvidenceIdentityTechniqueManifestAnalysis
+	if p.MetadataType == pkg.GolangBinMetadataType {
+		technique = cyclonedx.EvidenceIdentityTechniqueBinaryAnalysis
+	}
+
+	methods := make([]cyclonedx.EvidenceIdentityMethod, len(locations))
+	occurrences := make([]cyclonedx.EvidenceOccurrence, len(locations))
+	for i, l := range locations {
+		methods[i] = cyclonedx.EvidenceIdentityMethod{
+			Technique: technique,
+			Value:     l.RealPath,
+		}
+		occurrences[i] = cyclonedx.EvidenceOccurrence{
+			Location: l.RealPath,
+		}
+	}
+
+	return &cyclonedx.Evidence{
+		Identity: &cyclonedx.EvidenceIdentity{
+			Field:   field,
+			Methods: &methods,
+		},
+		Occurrences: &occurrences,
+	}
+}
+
 func deriveBomRef(p pkg.Package) string {
 	// try and parse the PURL if possible and append syft id to it, to make
 	// the purl unique in the BOM.
@@ -108,15 +149,15 @@ func decodePackageMetadata(vals map[string]string, c *cyclonedx.Component, typ p
 		if !ok {
 			return nil
 		}
-		metaPtrTyp := reflect.PtrTo(metaTyp)
-		metaPtr := common.Decode(metaPtrTyp, vals, "syft:metadata", CycloneDXFields)
+		metaPtr := reflect.New(metaTyp).Interface()
 
-		// Map all explicit metadata properties
+		// Map all explicit metadata properties, then the metadata properties syft wrote, which take precedence
 		decodeAuthor(c.Author, metaPtr)
 		decodeGroup(c.Group, metaPtr)
 		decodePublisher(c.Publisher, metaPtr)
 		decodeDescription(c.Description, metaPtr)
 		decodeExternalReferences(c, metaPtr)
+		common.DecodeInto(metaPtr, vals, "syft:metadata", CycloneDXMetadataFields)
 
 		// return the actual interface{} -> struct ... not interface{} -> *struct
 		return common.PtrToStruct(metaPtr)
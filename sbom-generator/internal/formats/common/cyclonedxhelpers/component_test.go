@@ -50,12 +50,19 @@ func Test_encodeComponentProperties(t *testing.T) {
 			expected: &[]cyclonedx.Property{
 				{Name: "syft:package:foundBy", Value: "cataloger"},
 				{Name: "syft:location:0:path", Value: "test"},
+				{Name: "syft:metadata:architecture", Value: "x86_64"},
+				{Name: "syft:metadata:description", Value: "Meta package to pull in correct libc"},
 				{Name: "syft:metadata:gitCommitOfApkPort", Value: "97b1c2842faa3bfa30f5811ffbf16d5ff9f1a479"},
 				{Name: "syft:metadata:installedSize", Value: "4096"},
+				{Name: "syft:metadata:license", Value: "BSD"},
+				{Name: "syft:metadata:maintainer", Value: "Natanael Copa <ncopa@alpinelinux.org>"},
 				{Name: "syft:metadata:originPackage", Value: "libc-dev"},
+				{Name: "syft:metadata:package", Value: "libc-utils"},
 				{Name: "syft:metadata:pullChecksum", Value: "Q1p78yvTLG094tHE1+dToJGbmYzQE="},
 				{Name: "syft:metadata:pullDependencies", Value: "musl-utils"},
 				{Name: "syft:metadata:size", Value: "0"},
+				{Name: "syft:metadata:url", Value: "http://alpinelinux.org"},
+				{Name: "syft:metadata:version", Value: "0.7.2-r0"},
 			},
 		},
 		{
@@ -75,9 +82,13 @@ func Test_encodeComponentProperties(t *testing.T) {
 			},
 			expected: &[]cyclonedx.Property{
 				{Name: "syft:package:metadataType", Value: "DpkgMetadata"},
+				{Name: "syft:metadata:architecture", Value: "all"},
 				{Name: "syft:metadata:installedSize", Value: "3036"},
+				{Name: "syft:metadata:maintainer", Value: "GNU Libc Maintainers <debian-glibc@lists.debian.org>"},
+				{Name: "syft:metadata:package", Value: "tzdata"},
 				{Name: "syft:metadata:source", Value: "tzdata-dev"},
 				{Name: "syft:metadata:sourceVersion", Value: "1.0"},
+				{Name: "syft:metadata:version", Value: "2020a-0+deb10u1"},
 			},
 		},
 		{
@@ -126,10 +137,15 @@ func Test_encodeComponentProperties(t *testing.T) {
 			expected: &[]cyclonedx.Property{
 				{Name: "syft:package:metadataType", Value: "RpmdbMetadata"},
 				{Name: "syft:package:type", Value: "rpm"},
+				{Name: "syft:metadata:architecture", Value: "x86_64"},
+				{Name: "syft:metadata:buildTime", Value: "0"},
 				{Name: "syft:metadata:epoch", Value: "2"},
+				{Name: "syft:metadata:license", Value: "MIT"},
+				{Name: "syft:metadata:name", Value: "dive"},
 				{Name: "syft:metadata:release", Value: "1"},
 				{Name: "syft:metadata:size", Value: "12406784"},
 				{Name: "syft:metadata:sourceRpm", Value: "dive-0.9.2-1.src.rpm"},
+				{Name: "syft:metadata:version", Value: "0.9.2"},
 			},
 		},
 	}
@@ -219,3 +235,88 @@ func Test_decodeComponent(t *testing.T) {
 		})
 	}
 }
+
+func Test_decodeComponent_metadata(t *testing.T) {
+	epoch := 1
+	tests := []struct {
+		name  string
+		input pkg.Package
+	}{
+		{
+			name: "java with manifest and pom properties",
+			input: pkg.Package{
+				Name:         "app",
+				Version:      "1.0.0",
+				PURL:         "pkg:maven/org.example/app@1.0.0",
+				MetadataType: pkg.JavaMetadataType,
+				Metadata: pkg.JavaMetadata{
+					VirtualPath: "/app/app.jar",
+					Manifest: &pkg.JavaManifest{
+						Main: map[string]string{
+							"Manifest-Version": "1.0",
+							"Main-Class":       "org.example.Main",
+						},
+					},
+					PomProperties: &pkg.PomProperties{
+						Path:       "META-INF/maven/org.example/app/pom.properties",
+						GroupID:    "org.example",
+						ArtifactID: "app",
+						Version:    "1.0.0",
+					},
+				},
+			},
+		},
+		{
+			name: "go binary with build settings",
+			input: pkg.Package{
+				Name:         "github.com/example/tool",
+				Version:      "v1.2.3",
+				PURL:         "pkg:golang/github.com/example/tool@v1.2.3",
+				MetadataType: pkg.GolangBinMetadataType,
+				Metadata: pkg.GolangBinMetadata{
+					BuildSettings: map[string]string{
+						"GOARCH":       "amd64",
+						"vcs.revision": "4c1ba09d5b23b2ab4e0e2b0e2e6d9a6e1ba1e4a4",
+					},
+					GoCompiledVersion: "go1.18",
+					Architecture:      "amd64",
+				},
+			},
+		},
+		{
+			name: "rpm from repodata",
+			input: pkg.Package{
+				Name:          "bash",
+				Version:       "1:5.1.8-4.el9",
+				PURL:          "pkg:rpm/rhel/bash@5.1.8-4.el9?arch=x86_64&epoch=1",
+				ProvidesPurls: []string{"pkg:rpm/rhel/sh@5.1.8-4.el9"},
+				ExtPkgPurls:   []string{"pkg:rpm/rhel/glibc@2.34-28.el9?arch=x86_64"},
+				MetadataType:  pkg.RpmRepodataType,
+				Metadata: pkg.RpmRepodata{
+					Name:    "bash",
+					Version: "5.1.8",
+					Epoch:   &epoch,
+					Arch:    "x86_64",
+					Release: "4.el9",
+					Files: []pkg.RepodataFileRecord{
+						{Path: "/usr/bin/bash", Mode: 0755, Size: 1390000},
+					},
+					RpmProvides: []pkg.RepodataPackageRecord{
+						{PkgType: "rpm", ArtifactId: "sh", Version: "5.1.8-4.el9"},
+					},
+					UnresolvedRequires: []string{"libtinfo.so.6()(64bit)"},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := encodeComponent(test.input)
+			p := decodeComponent(&c)
+			assert.Equal(t, test.input.MetadataType, p.MetadataType)
+			assert.Equal(t, test.input.Metadata, p.Metadata)
+			assert.Equal(t, test.input.ProvidesPurls, p.ProvidesPurls)
+			assert.Equal(t, test.input.ExtPkgPurls, p.ExtPkgPurls)
+		})
+	}
+}
@@ -192,12 +192,13 @@ func collectRelationships(bom *cyclonedx.BOM, s *sbom.SBOM, idMap map[string]int
 				continue
 			}
 			for _, t := range *d.Dependencies {
-				to, toOk := idMap[t.Ref].(artifact.Identifiable)
+				to, toOk := idMap[t].(artifact.Identifiable)
 				if toOk {
+					// the kind of dependency is not recorded by CycloneDX
 					s.Relationships = append(s.Relationships, artifact.Relationship{
 						From: from,
 						To:   to,
-						Type: artifact.DependencyOfRelationship, // FIXME this information is lost
+						Type: artifact.DependsOnRelationship,
 					})
 				}
 			}
@@ -172,10 +172,8 @@ func Test_decode(t *testing.T) {
 				Dependencies: &[]cyclonedx.Dependency{
 					{
 						Ref: "p1",
-						Dependencies: &[]cyclonedx.Dependency{
-							{
-								Ref: "p2",
-							},
+						Dependencies: &[]string{
+							"p2",
 						},
 					},
 				},
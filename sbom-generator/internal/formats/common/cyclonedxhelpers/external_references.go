@@ -62,6 +62,14 @@ func encodeExternalReferences(p pkg.Package) *[]cyclonedx.ExternalReference {
 					})
 				}
 			}
+		case pkg.GolangBinMetadata:
+			if repository, revision := pkg.GolangVCS(p); repository != "" {
+				refs = append(refs, cyclonedx.ExternalReference{
+					URL:     repository,
+					Type:    cyclonedx.ERTypeVCS,
+					Comment: fmt.Sprintf("commit: %s", revision),
+				})
+			}
 		case pkg.PythonPackageMetadata:
 			if metadata.DirectURLOrigin != nil && metadata.DirectURLOrigin.URL != "" {
 				ref := cyclonedx.ExternalReference{
@@ -125,6 +133,9 @@ func decodeExternalReferences(c *cyclonedx.Component, metadata interface{}) {
 
 		meta.ArchiveDigests = digests
 	case *pkg.PythonPackageMetadata:
+		if findExternalRef(c, cyclonedx.ERTypeVCS) == nil {
+			return
+		}
 		if meta.DirectURLOrigin == nil {
 			meta.DirectURLOrigin = &pkg.PythonDirectURLOriginInfo{}
 		}
@@ -115,6 +115,34 @@ func Test_encodeExternalReferences(t *testing.T) {
 				{URL: "http://a-place.gov", Type: cyclonedx.ERTypeVCS, Comment: "commit: test"},
 			},
 		},
+		{
+			name: "from go binary with vcs info",
+			input: pkg.Package{
+				Name: "github.com/anchore/syft/v2",
+				Metadata: pkg.GolangBinMetadata{
+					BuildSettings: map[string]string{
+						"vcs":          "git",
+						"vcs.revision": "a8f0c32b0bfe6ef8cc9e4f4a8a09e1bf6a4bd3c1",
+					},
+				},
+			},
+			expected: &[]cyclonedx.ExternalReference{
+				{URL: "https://github.com/anchore/syft", Type: cyclonedx.ERTypeVCS, Comment: "commit: a8f0c32b0bfe6ef8cc9e4f4a8a09e1bf6a4bd3c1"},
+			},
+		},
+		{
+			name: "from go binary of an unknown repository",
+			input: pkg.Package{
+				Name: "example.com/app",
+				Metadata: pkg.GolangBinMetadata{
+					BuildSettings: map[string]string{
+						"vcs":          "git",
+						"vcs.revision": "a8f0c32b0bfe6ef8cc9e4f4a8a09e1bf6a4bd3c1",
+					},
+				},
+			},
+			expected: nil,
+		},
 		{
 			name: "empty",
 			input: pkg.Package{
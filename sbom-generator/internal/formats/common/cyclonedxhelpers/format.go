@@ -7,28 +7,47 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/formats/common"
 	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/internal/version"
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/sbom"
 	"github.com/anchore/syft/syft/source"
 )
 
-func ToFormatModel(s sbom.SBOM) *cyclonedx.BOM {
+// DefaultSpecVersion is the version of the CycloneDX specification documents are written for when none is selected.
+const DefaultSpecVersion = cyclonedx.SpecVersion1_4
+
+// SupportedSpecVersions are the versions of the CycloneDX specification documents can be written for.
+var SupportedSpecVersions = []cyclonedx.SpecVersion{
+	cyclonedx.SpecVersion1_2,
+	cyclonedx.SpecVersion1_3,
+	cyclonedx.SpecVersion1_4,
+	cyclonedx.SpecVersion1_5,
+}
+
+// ToFormatModel creates a BOM for the given version of the CycloneDX specification. Fields which are introduced by a
+// later version are left out, they should be dropped anyway when the BOM is encoded for that version.
+func ToFormatModel(s sbom.SBOM, specVersion cyclonedx.SpecVersion) *cyclonedx.BOM {
 	cdxBOM := cyclonedx.NewBOM()
+	cdxBOM.SpecVersion = specVersion
 	versionInfo := version.FromBuild()
 
 	// NOTE(jonasagx): cycloneDX requires URN uuids (URN returns the RFC 2141 URN form of uuid):
 	// https://github.com/CycloneDX/specification/blob/master/schema/bom-1.3-strict.schema.json#L36
 	// "pattern": "^urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$"
 	cdxBOM.SerialNumber = uuid.New().URN()
-	cdxBOM.Metadata = toBomDescriptor(internal.ApplicationName, versionInfo.Version, s.Source)
+	cdxBOM.Metadata = toBomDescriptor(internal.ApplicationName, versionInfo.Version, s.Source, specVersion)
 
 	packages := s.Artifacts.PackageCatalog.Sorted()
 	components := make([]cyclonedx.Component, len(packages))
 	for i, p := range packages {
 		components[i] = encodeComponent(p)
+		if specVersion >= cyclonedx.SpecVersion1_5 {
+			components[i].Evidence = encodeEvidence(p)
+		}
 	}
 	components = append(components, toOSComponent(s.Artifacts.LinuxDistribution)...)
 	cdxBOM.Components = &components
@@ -38,6 +57,10 @@ func ToFormatModel(s sbom.SBOM) *cyclonedx.BOM {
 		cdxBOM.Dependencies = &dependencies
 	}
 
+	if specVersion >= cyclonedx.SpecVersion1_5 {
+		cdxBOM.Formulation = toFormulation(packages)
+	}
+
 	return cdxBOM
 }
 
@@ -101,56 +124,136 @@ func toOSComponent(distro *linux.Release) []cyclonedx.Component {
 }
 
 // NewBomDescriptor returns a new BomDescriptor tailored for the current time and "syft" tool details.
-func toBomDescriptor(name, version string, srcMetadata source.Metadata) *cyclonedx.Metadata {
-	return &cyclonedx.Metadata{
+func toBomDescriptor(name, version string, srcMetadata source.Metadata, specVersion cyclonedx.SpecVersion) *cyclonedx.Metadata {
+	metadata := &cyclonedx.Metadata{
 		Timestamp: time.Now().Format(time.RFC3339),
-		Tools: &[]cyclonedx.Tool{
-			{
-				Vendor:  "anchore",
-				Name:    name,
-				Version: version,
-			},
-		},
 		Component: toBomDescriptorComponent(srcMetadata),
 	}
+
+	// tools are described as components since CycloneDX 1.5, the former tool objects are deprecated
+	if specVersion >= cyclonedx.SpecVersion1_5 {
+		metadata.Tools = &cyclonedx.ToolsChoice{
+			Components: &[]cyclonedx.Component{
+				{
+					Type:    cyclonedx.ComponentTypeApplication,
+					Author:  "anchore",
+					Name:    name,
+					Version: version,
+				},
+			},
+		}
+		metadata.Lifecycles = toLifecycles(srcMetadata)
+	} else {
+		metadata.Tools = &cyclonedx.ToolsChoice{
+			Tools: &[]cyclonedx.Tool{
+				{
+					Vendor:  "anchore",
+					Name:    name,
+					Version: version,
+				},
+			},
+		}
+	}
+
+	return metadata
+}
+
+// toLifecycles returns the phase of the product lifecycle the BOM was created in, as guessed from what was scanned:
+// an image or a file is a build artifact, a directory is usually the filesystem of a deployed system and a
+// repository holds the sources the product is built from.
+func toLifecycles(srcMetadata source.Metadata) *[]cyclonedx.Lifecycle {
+	var phase cyclonedx.LifecyclePhase
+	switch srcMetadata.Scheme {
+	case source.ImageScheme, source.FileScheme:
+		phase = cyclonedx.LifecyclePhasePostBuild
+	case source.DirectoryScheme:
+		phase = cyclonedx.LifecyclePhaseOperations
+	case source.RepoScheme:
+		phase = cyclonedx.LifecyclePhasePreBuild
+	default:
+		return nil
+	}
+	return &[]cyclonedx.Lifecycle{{Phase: phase}}
 }
 
-// used to indicate that a relationship listed under the syft artifact package can be represented as a cyclonedx dependency.
-// NOTE: CycloneDX provides the ability to describe components and their dependency on other components.
-// The dependency graph is capable of representing both direct and transitive relationships.
-// If a relationship is either direct or transitive it can be included in this function.
-// An example of a relationship to not include would be: OwnershipByFileOverlapRelationship.
-func isExpressiblePackageRelationship(ty artifact.RelationshipType) bool {
-	switch ty {
-	case artifact.RuntimeDependencyOfRelationship:
-		return true
-	case artifact.DependsOnRelationship:
-		return true
-	case artifact.DevDependencyOfRelationship:
-		return true
-	case artifact.BuildDependencyOfRelationship:
-		return true
-	case artifact.DependencyOfRelationship:
-		return true
-	}
-	return false
+// toFormulation describes how the BOM was produced: a scan of the source by each of the catalogers that found
+// packages.
+func toFormulation(packages []pkg.Package) *[]cyclonedx.Formula {
+	catalogers := internal.NewStringSet()
+	for _, p := range packages {
+		if p.FoundBy != "" {
+			catalogers.Add(p.FoundBy)
+		}
+	}
+	names := catalogers.ToSlice()
+
+	scan := []cyclonedx.TaskType{cyclonedx.TaskTypeScan}
+	tasks := make([]cyclonedx.Task, len(names))
+	for i, name := range names {
+		tasks[i] = cyclonedx.Task{
+			BOMRef:    "task:" + name,
+			UID:       name,
+			Name:      name,
+			TaskTypes: &scan,
+		}
+	}
+
+	workflow := cyclonedx.Workflow{
+		BOMRef:    "workflow:" + internal.ApplicationName,
+		UID:       internal.ApplicationName,
+		Name:      internal.ApplicationName + " scan",
+		TaskTypes: &scan,
+	}
+	if len(tasks) > 0 {
+		workflow.Tasks = &tasks
+	}
+
+	return &[]cyclonedx.Formula{
+		{
+			Workflows: &[]cyclonedx.Workflow{workflow},
+		},
+	}
 }
 
+// toDependencies lists the dependencies of each component. CycloneDX does not record the kind of a dependency, so any
+// package-to-package relationship is a dependency of the parent package (e.g. the packages it owns or contains, or
+// the weak dependencies it recommends), except for the relationships expressed from the dependency.
 func toDependencies(relationships []artifact.Relationship) []cyclonedx.Dependency {
 	result := make([]cyclonedx.Dependency, 0)
+	byRef := make(map[string]int)
+	seen := internal.NewStringSet()
 	for _, r := range relationships {
-		exists := isExpressiblePackageRelationship(r.Type)
-		if !exists {
-			log.Debugf("unable to convert relationship from CycloneDX 1.4 JSON, dropping: %+v", r)
+		_, fromPackage := r.From.(pkg.Package)
+		_, toPackage := r.To.(pkg.Package)
+		if !fromPackage || !toPackage {
+			log.Debugf("unable to convert relationship to CycloneDX, dropping: %+v", r)
 			continue
 		}
 
-		innerDeps := []cyclonedx.Dependency{}
-		innerDeps = append(innerDeps, cyclonedx.Dependency{Ref: string(r.From.ID())})
-		result = append(result, cyclonedx.Dependency{
-			Ref:          string(r.To.ID()),
-			Dependencies: &innerDeps,
-		})
+		dependent, dependency := deriveBomRef(r.From.(pkg.Package)), deriveBomRef(r.To.(pkg.Package))
+		if common.IsDependencyOfRelationship(r.Type) {
+			dependent, dependency = dependency, dependent
+		}
+
+		// the same packages may be related in several ways (e.g. a dependency which is also owned by the dependent)
+		key := dependent + "->" + dependency
+		if seen.Contains(key) {
+			continue
+		}
+		seen.Add(key)
+
+		// each component lists all of its dependencies at once
+		i, exists := byRef[dependent]
+		if !exists {
+			i = len(result)
+			byRef[dependent] = i
+			result = append(result, cyclonedx.Dependency{
+				Ref:          dependent,
+				Dependencies: &[]string{},
+			})
+		}
+		innerDeps := result[i].Dependencies
+		*innerDeps = append(*innerDeps, dependency)
 	}
 	return result
 }
@@ -168,7 +271,7 @@ func toBomDescriptorComponent(srcMetadata source.Metadata) *cyclonedx.Component
 			Name:    srcMetadata.ImageMetadata.UserInput,
 			Version: srcMetadata.ImageMetadata.ManifestDigest,
 		}
-	case source.DirectoryScheme, source.FileScheme:
+	case source.DirectoryScheme, source.FileScheme, source.RepoScheme:
 		bomRef, err := artifact.IDByHash(srcMetadata.Path)
 		if err != nil {
 			log.Warnf("unable to get fingerprint of source metadata path=%s: %+v", srcMetadata.Path, err)
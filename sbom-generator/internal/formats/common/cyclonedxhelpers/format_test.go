@@ -0,0 +1,113 @@
+package cyclonedxhelpers
+
+import (
+	"testing"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+func Test_toDependencies(t *testing.T) {
+	newPackage := func(id string) pkg.Package {
+		p := pkg.Package{Name: id}
+		p.OverrideID(artifact.ID(id))
+		return p
+	}
+	app, lib, tool, optional := newPackage("app"), newPackage("lib"), newPackage("tool"), newPackage("optional")
+	docs, plugin := newPackage("docs"), newPackage("plugin")
+
+	dependencies := toDependencies([]artifact.Relationship{
+		{From: app, To: lib, Type: artifact.DependsOnRelationship},
+		{From: tool, To: app, Type: artifact.DevDependencyOfRelationship},
+		{From: optional, To: app, Type: artifact.OptionalDependencyOfRelationship},
+		{From: lib, To: tool, Type: artifact.OwnershipByFileOverlapRelationship},
+		{From: app, To: docs, Type: artifact.RecommendsRelationship},
+		{From: plugin, To: app, Type: artifact.EnhancesRelationship},
+		{From: app, To: lib, Type: artifact.ContainsRelationship},
+		{From: app, To: source.NewLocation("/app").Coordinates, Type: artifact.ContainsRelationship},
+	})
+
+	// dependencies are listed from the dependent component, whatever the direction of the relationship
+	assert.Equal(t, []cyclonedx.Dependency{
+		{
+			Ref:          "app",
+			Dependencies: &[]string{"lib", "tool", "optional", "docs", "plugin"},
+		},
+		{
+			Ref:          "lib",
+			Dependencies: &[]string{"tool"},
+		},
+	}, dependencies)
+}
+
+func Test_toFormatModel_specVersion(t *testing.T) {
+	p := pkg.Package{
+		Name:      "lib",
+		Version:   "1.0.0",
+		FoundBy:   "dpkgdb-cataloger",
+		PURL:      "pkg:deb/debian/lib@1.0.0",
+		Locations: source.NewLocationSet(source.NewLocation("/var/lib/dpkg/status")),
+	}
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{PackageCatalog: pkg.NewCatalog(p)},
+		Source:    source.Metadata{Scheme: source.DirectoryScheme, Path: "/"},
+	}
+
+	tests := []struct {
+		name        string
+		specVersion cyclonedx.SpecVersion
+		wantV15     bool
+	}{
+		{
+			name:        "1.4 leaves out the fields introduced by 1.5",
+			specVersion: cyclonedx.SpecVersion1_4,
+		},
+		{
+			name:        "1.5",
+			specVersion: cyclonedx.SpecVersion1_5,
+			wantV15:     true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bom := ToFormatModel(s, test.specVersion)
+			assert.Equal(t, test.specVersion, bom.SpecVersion)
+
+			component := (*bom.Components)[0]
+			if !test.wantV15 {
+				assert.Nil(t, bom.Metadata.Lifecycles)
+				assert.Nil(t, bom.Formulation)
+				assert.Nil(t, component.Evidence)
+				require.NotNil(t, bom.Metadata.Tools.Tools)
+				assert.Nil(t, bom.Metadata.Tools.Components)
+				return
+			}
+
+			assert.Equal(t, &[]cyclonedx.Lifecycle{{Phase: cyclonedx.LifecyclePhaseOperations}}, bom.Metadata.Lifecycles)
+			require.NotNil(t, bom.Metadata.Tools.Components)
+			assert.Nil(t, bom.Metadata.Tools.Tools)
+
+			require.NotNil(t, component.Evidence)
+			assert.Equal(t, cyclonedx.EvidenceIdentityFieldTypePURL, component.Evidence.Identity.Field)
+			methods := *component.Evidence.Identity.Methods
+			require.Len(t, methods, 1)
+			assert.Equal(t, cyclonedx.EvidenceIdentityTechniqueManifestAnalysis, methods[0].Technique)
+			assert.Equal(t, "/var/lib/dpkg/status", methods[0].Value)
+			assert.Equal(t, &[]cyclonedx.EvidenceOccurrence{{Location: "/var/lib/dpkg/status"}}, component.Evidence.Occurrences)
+
+			require.NotNil(t, bom.Formulation)
+			workflows := *(*bom.Formulation)[0].Workflows
+			require.Len(t, workflows, 1)
+			tasks := *workflows[0].Tasks
+			require.Len(t, tasks, 1)
+			assert.Equal(t, "dpkgdb-cataloger", tasks[0].Name)
+			assert.Equal(t, &[]cyclonedx.TaskType{cyclonedx.TaskTypeScan}, tasks[0].TaskTypes)
+		})
+	}
+}
@@ -12,7 +12,7 @@ func encodeGroup(p pkg.Package) string {
 }
 
 func decodeGroup(group string, metadata interface{}) {
-	if meta, ok := metadata.(*pkg.JavaMetadata); ok {
+	if meta, ok := metadata.(*pkg.JavaMetadata); ok && group != "" {
 		if meta.PomProperties == nil {
 			meta.PomProperties = &pkg.PomProperties{}
 		}
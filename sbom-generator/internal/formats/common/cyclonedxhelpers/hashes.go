@@ -0,0 +1,32 @@
+package cyclonedxhelpers
+
+import (
+	"github.com/CycloneDX/cyclonedx-go"
+
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// encodeHashes returns the hashes of the content of a component: the go.sum (or build info) hash of a go module, or the
+// hashes pinned by a python requirement.
+func encodeHashes(p pkg.Package) *[]cyclonedx.Hash {
+	var digests []file.Digest
+	switch metadata := p.Metadata.(type) {
+	case pkg.PythonRequirementsMetadata:
+		digests = metadata.Digests
+	default:
+		digests = pkg.GolangModuleDigests(p)
+	}
+
+	var hashes []cyclonedx.Hash
+	for _, digest := range digests {
+		hashes = append(hashes, cyclonedx.Hash{
+			Algorithm: toCycloneDXAlgorithm(digest.Algorithm),
+			Value:     digest.Value,
+		})
+	}
+	if len(hashes) > 0 {
+		return &hashes
+	}
+	return nil
+}
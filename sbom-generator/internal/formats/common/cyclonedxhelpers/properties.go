@@ -8,10 +8,17 @@ import (
 
 var (
 	CycloneDXFields = common.RequiredTag("cyclonedx")
+	// CycloneDXMetadataFields names all of the fields of package metadata so it can be decoded as it was encoded, after
+	// the cyclonedx tags or else the json tags of the fields
+	CycloneDXMetadataFields = common.OptionalTags("cyclonedx", "json")
 )
 
-func encodeProperties(obj interface{}, prefix string) (out []cyclonedx.Property) {
-	for _, p := range common.Sorted(common.Encode(obj, prefix, CycloneDXFields)) {
+func encodeProperties(obj interface{}, prefix string) []cyclonedx.Property {
+	return encodePropertiesWith(obj, prefix, CycloneDXFields)
+}
+
+func encodePropertiesWith(obj interface{}, prefix string, fn common.FieldName) (out []cyclonedx.Property) {
+	for _, p := range common.Sorted(common.Encode(obj, prefix, fn)) {
 		out = append(out, cyclonedx.Property{
 			Name:  p.Name,
 			Value: p.Value,
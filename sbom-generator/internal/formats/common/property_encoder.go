@@ -43,6 +43,34 @@ func RequiredTag(tag string) FieldName {
 	}
 }
 
+// OptionalTags given tag names, will return the name defined by the first of the tags which names the field, or fall
+// back to lower camel case field name. Fields named "-" and unexported fields are skipped, and embedded fields have no
+// intermediate name.
+func OptionalTags(tags ...string) FieldName {
+	return func(f reflect.StructField) (string, bool) {
+		if f.PkgPath != "" {
+			return "", false
+		}
+		for _, tag := range tags {
+			n, ok := f.Tag.Lookup(tag)
+			if !ok {
+				continue
+			}
+			n = strings.Split(n, ",")[0]
+			if n == "-" {
+				return "", false
+			}
+			if n != "" {
+				return n, true
+			}
+		}
+		if f.Anonymous {
+			return "", true
+		}
+		return lowerFirst(f.Name), true
+	}
+}
+
 var (
 	// OptionalJSONTag uses field names defined in json tags, if available
 	OptionalJSONTag = TrimOmitempty(OptionalTag("json"))
@@ -120,6 +148,14 @@ func encode(out map[string]string, value reflect.Value, prefix string, fn FieldN
 		for idx := 0; idx < value.Len(); idx++ {
 			encode(out, value.Index(idx), fmt.Sprintf("%s:%d", prefix, idx), fn)
 		}
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			log.Warnf("skipping encoding of unsupported property: %s", prefix)
+			return
+		}
+		for _, key := range value.MapKeys() {
+			encode(out, value.MapIndex(key), fmt.Sprintf("%s:%s", prefix, key.String()), fn)
+		}
 	case reflect.Struct:
 		for i := 0; i < typ.NumField(); i++ {
 			pv := value.Field(i)
@@ -284,6 +320,22 @@ func decode(vals map[string]string, value reflect.Value, prefix string, fn Field
 		} else {
 			return false
 		}
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			log.Warnf("unable to set field: %s", prefix)
+			return false
+		}
+		m := reflect.MakeMap(typ)
+		for _, key := range mapKeys(vals, prefix, typ.Elem()) {
+			v := reflect.New(typ.Elem()).Elem()
+			if decode(vals, v, fmt.Sprintf("%s:%s", prefix, key), fn) {
+				m.SetMapIndex(reflect.ValueOf(key).Convert(typ.Key()), v)
+			}
+		}
+		if m.Len() == 0 {
+			return false
+		}
+		value.Set(m)
 	case reflect.Struct:
 		values := false
 		for i := 0; i < typ.NumField(); i++ {
@@ -307,6 +359,37 @@ func decode(vals map[string]string, value reflect.Value, prefix string, fn Field
 	return true
 }
 
+// mapKeys returns the keys of the map encoded with the given prefix: the rest of the names of the values, or up to the
+// next separator when the values of the map are not scalars themselves (e.g. a map of maps or of structs).
+func mapKeys(vals map[string]string, prefix string, elem reflect.Type) []string {
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	scalar := true
+	switch elem.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map, reflect.Struct:
+		scalar = false
+	}
+
+	var keys []string
+	seen := make(map[string]bool)
+	for name := range vals {
+		if !strings.HasPrefix(name, prefix+":") {
+			continue
+		}
+		key := strings.TrimPrefix(name, prefix+":")
+		if i := strings.Index(key, ":"); !scalar && i >= 0 {
+			key = key[:i]
+		}
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func PtrToStruct(ptr interface{}) interface{} {
 	v := reflect.ValueOf(ptr)
 	if v.IsZero() {
@@ -22,6 +22,9 @@ type T1 struct {
 	FloatArr []float64
 	BoolArr  []bool
 	T3Arr    []T3
+	StrMap   map[string]string
+	T2Map    map[string]T2
+	MapMap   map[string]map[string]string
 }
 
 type T2 struct {
@@ -83,6 +86,17 @@ func Test_EncodeDecodeCycle(t *testing.T) {
 						},
 					},
 				},
+				StrMap: map[string]string{
+					"key":          "value",
+					"key:with:sep": "other value",
+				},
+				T2Map: map[string]T2{
+					"t2 key": {"t2 map elem"},
+				},
+				MapMap: map[string]map[string]string{
+					"section 1": {"k1": "v1", "k2": "v2"},
+					"section 2": {"k3": "v3"},
+				},
 			},
 		},
 		{
@@ -102,6 +116,9 @@ func Test_EncodeDecodeCycle(t *testing.T) {
 				FloatArr: nil,
 				BoolArr:  nil,
 				T3Arr:    nil,
+				StrMap:   nil,
+				T2Map:    nil,
+				MapMap:   nil,
 			},
 		},
 		{
@@ -141,3 +158,29 @@ func Test_EncodeDecodeCycle(t *testing.T) {
 		})
 	}
 }
+
+func Test_OptionalTags(t *testing.T) {
+	type Embedded struct {
+		Inner string
+	}
+	type fields struct {
+		Embedded
+		Named      string `cyclonedx:"cdxName" json:"jsonName"`
+		JSONOnly   string `json:"jsonOnly,omitempty"`
+		Options    string `json:",omitempty"`
+		Skipped    string `cyclonedx:"-" json:"skipped"`
+		JSONHidden string `json:"-"`
+		Untagged   string
+		unexported string
+	}
+
+	var names []string
+	typ := reflect.TypeOf(fields{})
+	for i := 0; i < typ.NumField(); i++ {
+		if name, ok := OptionalTags("cyclonedx", "json")(typ.Field(i)); ok {
+			names = append(names, name)
+		}
+	}
+
+	assert.Equal(t, []string{"", "cdxName", "jsonOnly", "options", "untagged"}, names)
+}
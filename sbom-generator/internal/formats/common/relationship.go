@@ -0,0 +1,20 @@
+package common
+
+import "github.com/anchore/syft/syft/artifact"
+
+// IsDependencyOfRelationship indicates that the relationship is expressed from the dependency to the dependent
+// package, while formats such as CycloneDX or GitHub dependency snapshots list the dependencies of a (dependent)
+// package.
+func IsDependencyOfRelationship(ty artifact.RelationshipType) bool {
+	switch ty {
+	case artifact.RuntimeDependencyOfRelationship,
+		artifact.DevDependencyOfRelationship,
+		artifact.OptionalDependencyOfRelationship,
+		artifact.BuildDependencyOfRelationship,
+		artifact.DependencyOfRelationship,
+		artifact.SupplementsRelationship,
+		artifact.EnhancesRelationship:
+		return true
+	}
+	return false
+}
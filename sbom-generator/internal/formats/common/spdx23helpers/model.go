@@ -0,0 +1,140 @@
+package spdx23helpers
+
+import (
+	"fmt"
+
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+)
+
+// Version is the SPDX version of the documents.
+const Version = "SPDX-2.3"
+
+// dateLayout is the layout of the dates of a document: "YYYY-MM-DDThh:mm:ssZ", in UTC.
+const dateLayout = "2006-01-02T15:04:05Z"
+
+// derived from:
+// - https://spdx.github.io/spdx-spec/v2.3/
+// - https://github.com/spdx/spdx-spec/blob/v2.3/schemas/spdx-schema.json
+// The same model is serialized as JSON, YAML (by the JSON field names) and tag-value.
+
+type Document struct {
+	SPDXID      string `json:"SPDXID"`
+	Name        string `json:"name"`
+	SPDXVersion string `json:"spdxVersion"`
+	// One instance is required for each SPDX file produced. It provides the necessary information for forward
+	// and backward compatibility for processing tools.
+	CreationInfo CreationInfo `json:"creationInfo"`
+	// License expression for the SPDX metadata of the document, "CC0-1.0".
+	DataLicense string `json:"dataLicense"`
+	// A unique absolute URI of the document.
+	DocumentNamespace string `json:"documentNamespace"`
+	Comment           string `json:"comment,omitempty"`
+	// The licenses of the packages which are not on the SPDX license list, by their extracted text.
+	HasExtractedLicensingInfos []ExtractedLicensingInfo `json:"hasExtractedLicensingInfos,omitempty"`
+	Packages                   []Package                `json:"packages"`
+	Files                      []File                   `json:"files,omitempty"`
+	Relationships              []Relationship           `json:"relationships,omitempty"`
+}
+
+type CreationInfo struct {
+	Comment string `json:"comment,omitempty"`
+	// The date the document was created at, "YYYY-MM-DDThh:mm:ssZ".
+	Created string `json:"created"`
+	// The persons, organizations and tools which created the document, e.g. "Tool: syft-0.50.0".
+	Creators []string `json:"creators"`
+	// The version of the SPDX License List used when the document was created.
+	LicenseListVersion string `json:"licenseListVersion,omitempty"`
+}
+
+type Package struct {
+	SPDXID      string `json:"SPDXID"`
+	Name        string `json:"name"`
+	VersionInfo string `json:"versionInfo,omitempty"`
+	// The base name of the package file name. For example, zlib-1.2.5.tar.gz.
+	PackageFileName string `json:"packageFileName,omitempty"`
+	// The distributor of the package: "Person: <name>", "Organization: <name>" or NOASSERTION when it has not been
+	// determined (as opposed to the optional originator, left out when unknown).
+	Supplier string `json:"supplier,omitempty"`
+	// The person or organization which originally created the package, "Person: <name>" or "Organization: <name>".
+	Originator string `json:"originator,omitempty"`
+	// The URI the package is available for download at, NONE or NOASSERTION.
+	DownloadLocation string `json:"downloadLocation"`
+	// Whether the files of the package have been analyzed when creating the document (true by default in 2.3, so it
+	// is always written).
+	FilesAnalyzed bool       `json:"filesAnalyzed"`
+	Checksums     []Checksum `json:"checksums,omitempty"`
+	Homepage      string     `json:"homepage,omitempty"`
+	// How the package was acquired and/or changed from the original source.
+	SourceInfo       string        `json:"sourceInfo,omitempty"`
+	LicenseConcluded string        `json:"licenseConcluded,omitempty"`
+	LicenseDeclared  string        `json:"licenseDeclared,omitempty"`
+	CopyrightText    string        `json:"copyrightText,omitempty"`
+	Summary          string        `json:"summary,omitempty"`
+	Description      string        `json:"description,omitempty"`
+	Comment          string        `json:"comment,omitempty"`
+	ExternalRefs     []ExternalRef `json:"externalRefs,omitempty"`
+	// The type of the package, e.g. APPLICATION, LIBRARY or INSTALL.
+	PrimaryPackagePurpose spdxhelpers.PackagePurpose `json:"primaryPackagePurpose,omitempty"`
+	// The dates the package was released at and built at, "YYYY-MM-DDThh:mm:ssZ" (the date it is supported until is not
+	// known to syft, so it is left out).
+	ReleaseDate string       `json:"releaseDate,omitempty"`
+	BuiltDate   string       `json:"builtDate,omitempty"`
+	Annotations []Annotation `json:"annotations,omitempty"`
+}
+
+type Annotation struct {
+	// The date the comment was made at, "YYYY-MM-DDThh:mm:ssZ".
+	AnnotationDate string `json:"annotationDate"`
+	// The type of the annotation, REVIEW or OTHER.
+	AnnotationType string `json:"annotationType"`
+	// The person, organization or tool which made the comment, e.g. "Tool: syft-v0.42.0".
+	Annotator string `json:"annotator"`
+	Comment   string `json:"comment"`
+}
+
+type ExternalRef struct {
+	Comment           string                        `json:"comment,omitempty"`
+	ReferenceCategory spdxhelpers.ReferenceCategory `json:"referenceCategory"`
+	ReferenceLocator  string                        `json:"referenceLocator"`
+	ReferenceType     spdxhelpers.ExternalRefType   `json:"referenceType"`
+}
+
+type File struct {
+	SPDXID   string `json:"SPDXID"`
+	FileName string `json:"fileName"`
+	// The types of the file, e.g. BINARY or TEXT.
+	FileTypes        []string   `json:"fileTypes,omitempty"`
+	Checksums        []Checksum `json:"checksums,omitempty"`
+	LicenseConcluded string     `json:"licenseConcluded,omitempty"`
+	CopyrightText    string     `json:"copyrightText,omitempty"`
+	Comment          string     `json:"comment,omitempty"`
+}
+
+type Checksum struct {
+	// The algorithm of the checksum, e.g. SHA1, SHA256, SHA3-256 or BLAKE2b-256.
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type Relationship struct {
+	SpdxElementID      string                       `json:"spdxElementId"`
+	RelationshipType   spdxhelpers.RelationshipType `json:"relationshipType"`
+	RelatedSpdxElement string                       `json:"relatedSpdxElement"`
+	Comment            string                       `json:"comment,omitempty"`
+}
+
+type ExtractedLicensingInfo struct {
+	// "LicenseRef-<idstring>"
+	LicenseID     string `json:"licenseId"`
+	ExtractedText string `json:"extractedText"`
+	Name          string `json:"name,omitempty"`
+	Comment       string `json:"comment,omitempty"`
+}
+
+// ValidateVersion checks that a document is a SPDX 2.3 document.
+func ValidateVersion(doc *Document) error {
+	if doc.SPDXVersion != Version {
+		return fmt.Errorf("unsupported SPDX version: %q", doc.SPDXVersion)
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package spdx23helpers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/spdxlicense"
+	"github.com/anchore/syft/internal/version"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// packageManagerReferenceCategory is the category of the package manager references (purls) as spelled by SPDX 2.3,
+// which deprecates the "PACKAGE_MANAGER" spelling of the previous versions.
+const packageManagerReferenceCategory spdxhelpers.ReferenceCategory = "PACKAGE-MANAGER"
+
+// ToFormatModel creates and populates a new document struct that follows the SPDX 2.3 spec from the given cataloging
+// results.
+func ToFormatModel(s sbom.SBOM) *Document {
+	name, namespace := spdxhelpers.DocumentNameAndNamespace(s.Source)
+	created := formatDate(time.Now())
+
+	return &Document{
+		SPDXID:      toElementID("DOCUMENT"),
+		Name:        name,
+		SPDXVersion: Version,
+		CreationInfo: CreationInfo{
+			Created: created,
+			Creators: []string{
+				"Organization: Anchore, Inc",
+				"Tool: " + internal.ApplicationName + "-" + version.FromBuild().Version,
+			},
+			LicenseListVersion: spdxlicense.Version,
+		},
+		DataLicense:                "CC0-1.0",
+		DocumentNamespace:          namespace,
+		HasExtractedLicensingInfos: toExtractedLicensingInfos(s.Artifacts.PackageCatalog),
+		Packages:                   toPackages(s.Artifacts.PackageCatalog, created),
+		Files:                      toFiles(s),
+		Relationships:              toRelationships(s.Relationships),
+	}
+}
+
+// toElementID returns the SPDX identifier of an element: "SPDXRef-" followed by the ID of the element (sanitized).
+func toElementID(id artifact.ID) string {
+	return "SPDXRef-" + spdxhelpers.SanitizeElementID(string(id))
+}
+
+// formatDate returns a date as written in SPDX 2.3 documents, or nothing for the zero time (an unknown date).
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(dateLayout)
+}
+
+func toExtractedLicensingInfos(catalog *pkg.Catalog) []ExtractedLicensingInfo {
+	var results []ExtractedLicensingInfo
+	for _, l := range spdxhelpers.OtherLicenses(catalog.Sorted()) {
+		results = append(results, ExtractedLicensingInfo{
+			LicenseID:     l.ID,
+			ExtractedText: l.Text,
+			Name:          l.Text,
+		})
+	}
+	return results
+}
+
+func toPackages(catalog *pkg.Catalog, created string) []Package {
+	packages := make([]Package, 0)
+	externalCounter := spdxhelpers.ExternalCounter{
+		ProvideMap:     map[string]string{},
+		ExternalMap:    map[string]string{},
+		ExternalPkgMap: map[string][]string{},
+	}
+
+	for _, p := range catalog.Sorted() {
+		var checksums []Checksum
+		for _, digest := range spdxhelpers.PackageChecksums(p) {
+			checksums = append(checksums, Checksum{
+				Algorithm:     digest.Algorithm,
+				ChecksumValue: digest.Value,
+			})
+		}
+
+		// in SPDX 2.3 the supplier is NOASSERTION when it has not been determined
+		supplier := spdxhelpers.Supplier(p)
+		if supplier == "" {
+			supplier = spdxhelpers.NOASSERTION
+		}
+
+		packages = append(packages, Package{
+			SPDXID:           toElementID(p.ID()),
+			Name:             p.Name,
+			VersionInfo:      p.Version,
+			Supplier:         supplier,
+			Originator:       spdxhelpers.Originator(p),
+			DownloadLocation: spdxhelpers.DownloadLocation(p),
+			// the files of a java archive are analyzed to generate its digests
+			FilesAnalyzed: p.MetadataType == pkg.JavaMetadataType && len(checksums) > 0,
+			Checksums:     checksums,
+			Homepage:      spdxhelpers.Homepage(p),
+			SourceInfo:    spdxhelpers.SourceInfo(p),
+			// the concluded license is the license the SPDX file creator believes governs the package, while the
+			// declared license is what the authors of the package believe governs it
+			LicenseConcluded:      spdxhelpers.ConcludedLicense(p),
+			LicenseDeclared:       spdxhelpers.License(p),
+			CopyrightText:         spdxhelpers.NOASSERTION,
+			Summary:               spdxhelpers.Summary(p),
+			Description:           spdxhelpers.Description(p),
+			ExternalRefs:          toExternalRefs(p, &externalCounter),
+			PrimaryPackagePurpose: spdxhelpers.PrimaryPackagePurpose(p),
+			ReleaseDate:           formatDate(spdxhelpers.ReleaseDate(p)),
+			BuiltDate:             formatDate(spdxhelpers.BuiltDate(p)),
+			Annotations:           toAnnotations(p, created),
+		})
+	}
+
+	externalCounter.PrintCountInfo()
+	return packages
+}
+
+// toAnnotations returns the annotations of a package: its metadata, which SPDX has no field for, so decoding restores it.
+func toAnnotations(p pkg.Package, created string) []Annotation {
+	comment, ok := spdxhelpers.MetadataAnnotation(p)
+	if !ok {
+		return nil
+	}
+	return []Annotation{
+		{
+			AnnotationDate: created,
+			AnnotationType: spdxhelpers.OtherAnnotationType,
+			Annotator:      "Tool: " + spdxhelpers.MetadataAnnotator(),
+			Comment:        comment,
+		},
+	}
+}
+
+func toExternalRefs(p pkg.Package, externalCounter *spdxhelpers.ExternalCounter) []ExternalRef {
+	var results []ExternalRef
+	for _, ref := range spdxhelpers.ExternalRefs(p, externalCounter) {
+		category := ref.ReferenceCategory
+		if category == spdxhelpers.PackageManagerReferenceCategory {
+			category = packageManagerReferenceCategory
+		}
+		results = append(results, ExternalRef{
+			Comment:           ref.Comment,
+			ReferenceCategory: category,
+			ReferenceLocator:  ref.ReferenceLocator,
+			ReferenceType:     ref.ReferenceType,
+		})
+	}
+	return results
+}
+
+func toFiles(s sbom.SBOM) []File {
+	results := make([]File, 0)
+	artifacts := s.Artifacts
+
+	for _, coordinates := range sbom.AllCoordinates(s) {
+		var metadata *source.FileMetadata
+		if metadataForLocation, exists := artifacts.FileMetadata[coordinates]; exists {
+			metadata = &metadataForLocation
+		}
+
+		var comment string
+		if coordinates.FileSystemID != "" {
+			comment = fmt.Sprintf("layerID: %s", coordinates.FileSystemID)
+		}
+
+		results = append(results, File{
+			SPDXID:    toElementID(coordinates.ID()),
+			FileName:  coordinates.RealPath,
+			FileTypes: spdxhelpers.FileTypes(metadata),
+			Checksums: toFileChecksums(artifacts.FileDigests[coordinates]),
+			// no attempt made to determine license information
+			LicenseConcluded: spdxhelpers.NOASSERTION,
+			Comment:          comment,
+		})
+	}
+
+	// sort by real path then virtual path to ensure the result is stable across multiple runs
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].FileName < results[j].FileName
+	})
+	return results
+}
+
+func toFileChecksums(digests []file.Digest) (checksums []Checksum) {
+	for _, digest := range digests {
+		checksums = append(checksums, Checksum{
+			// SPDX names the algorithms in uppercase (e.g. SHA256)
+			Algorithm:     strings.ToUpper(digest.Algorithm),
+			ChecksumValue: digest.Value,
+		})
+	}
+	return checksums
+}
+
+func toRelationships(relationships []artifact.Relationship) (result []Relationship) {
+	for _, r := range relationships {
+		exists, relationshipType, comment := spdxhelpers.LookupRelationship(r.Type)
+
+		if !exists {
+			log.Warnf("unable to convert relationship to SPDX 2.3, dropping: %+v", r)
+			continue
+		}
+
+		from, to := r.From.ID(), r.To.ID()
+		if spdxhelpers.IsReverseRelationship(r.Type) {
+			from, to = to, from
+		}
+
+		result = append(result, Relationship{
+			SpdxElementID:      toElementID(from),
+			RelationshipType:   relationshipType,
+			RelatedSpdxElement: toElementID(to),
+			Comment:            comment,
+		})
+	}
+	return result
+}
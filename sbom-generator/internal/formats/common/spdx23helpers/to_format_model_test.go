@@ -0,0 +1,69 @@
+package spdx23helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/anchore/syft/internal/formats/common/testutils"
+)
+
+func TestToFormatModel(t *testing.T) {
+	s, bash, monolog, _ := testutils.PackagesInput()
+
+	doc := ToFormatModel(s)
+
+	assert.Equal(t, "SPDX-2.3", doc.SPDXVersion)
+	assert.Equal(t, "SPDXRef-DOCUMENT", doc.SPDXID)
+	assert.Regexp(t, `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`, doc.CreationInfo.Created)
+
+	require.Len(t, doc.Packages, 2)
+	packages := make(map[string]Package)
+	for _, p := range doc.Packages {
+		packages[p.Name] = p
+	}
+
+	rpm := packages["bash"]
+	assert.Equal(t, "SPDXRef-"+string(bash.ID()), rpm.SPDXID)
+	assert.Equal(t, "Organization: openEuler", rpm.Supplier)
+	assert.Equal(t, spdxhelpers.InstallPackagePurpose, rpm.PrimaryPackagePurpose)
+	assert.Equal(t, "2020-02-09T20:23:33Z", rpm.BuiltDate)
+	assert.Empty(t, rpm.ReleaseDate)
+	assert.Equal(t, []Checksum{{Algorithm: "SHA256", ChecksumValue: "0123abcd"}}, rpm.Checksums)
+	assert.Contains(t, rpm.ExternalRefs, ExternalRef{
+		ReferenceCategory: "PACKAGE-MANAGER",
+		ReferenceLocator:  bash.PURL,
+		ReferenceType:     spdxhelpers.PurlExternalRefType,
+	})
+
+	composer := packages["monolog/monolog"]
+	// the supplier of a package is NOASSERTION when it has not been determined
+	assert.Equal(t, spdxhelpers.NOASSERTION, composer.Supplier)
+	assert.Equal(t, spdxhelpers.LibraryPackagePurpose, composer.PrimaryPackagePurpose)
+	assert.Equal(t, "2020-12-14T13:15:25Z", composer.ReleaseDate)
+	assert.Empty(t, composer.BuiltDate)
+
+	require.Len(t, doc.Files, 1)
+	assert.Equal(t, "/usr/bin/bash", doc.Files[0].FileName)
+	assert.Equal(t, []Checksum{{Algorithm: "SHA1", ChecksumValue: "abcdef"}}, doc.Files[0].Checksums)
+
+	assert.Equal(t, []Relationship{
+		{
+			SpdxElementID:      "SPDXRef-" + string(monolog.ID()),
+			RelationshipType:   spdxhelpers.DependsOnRelationship,
+			RelatedSpdxElement: "SPDXRef-" + string(bash.ID()),
+		},
+		{
+			SpdxElementID:      "SPDXRef-" + string(bash.ID()),
+			RelationshipType:   spdxhelpers.RuntimeDependencyOfRelationship,
+			RelatedSpdxElement: "SPDXRef-" + string(monolog.ID()),
+		},
+		{
+			SpdxElementID:      "SPDXRef-" + string(bash.ID()),
+			RelationshipType:   spdxhelpers.ContainsRelationship,
+			RelatedSpdxElement: doc.Files[0].SPDXID,
+		},
+	}, doc.Relationships)
+}
@@ -0,0 +1,208 @@
+package spdx23helpers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/spdx/tools-golang/spdx"
+
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+const (
+	elementIDPrefix     = "SPDXRef-"
+	documentRefIDPrefix = "DocumentRef-"
+	personPrefix        = "Person: "
+	organizationPrefix  = "Organization: "
+)
+
+// ToSyftModel converts a SPDX 2.3 document into the syft model. The document is read as its SPDX 2.2 counterpart
+// (leaving out what SPDX 2.3 adds, which the syft model has no place for), so the conversion is shared with the SPDX
+// 2.2 formats.
+func ToSyftModel(doc *Document) (*sbom.SBOM, error) {
+	if doc == nil {
+		return nil, errors.New("cannot convert SPDX document to Syft model because document is nil")
+	}
+	return spdxhelpers.ToSyftModel(toSPDX22Document(doc))
+}
+
+func toSPDX22Document(doc *Document) *spdx.Document2_2 {
+	result := &spdx.Document2_2{
+		CreationInfo: &spdx.CreationInfo2_2{
+			SPDXVersion:        doc.SPDXVersion,
+			DataLicense:        doc.DataLicense,
+			SPDXIdentifier:     toSPDX22ElementID(doc.SPDXID),
+			DocumentName:       doc.Name,
+			DocumentNamespace:  doc.DocumentNamespace,
+			LicenseListVersion: doc.CreationInfo.LicenseListVersion,
+			Created:            doc.CreationInfo.Created,
+			CreatorComment:     doc.CreationInfo.Comment,
+			DocumentComment:    doc.Comment,
+		},
+		Packages:        make(map[spdx.ElementID]*spdx.Package2_2),
+		UnpackagedFiles: make(map[spdx.ElementID]*spdx.File2_2),
+	}
+
+	for _, creator := range doc.CreationInfo.Creators {
+		switch {
+		case strings.HasPrefix(creator, personPrefix):
+			result.CreationInfo.CreatorPersons = append(result.CreationInfo.CreatorPersons, strings.TrimPrefix(creator, personPrefix))
+		case strings.HasPrefix(creator, organizationPrefix):
+			result.CreationInfo.CreatorOrganizations = append(result.CreationInfo.CreatorOrganizations, strings.TrimPrefix(creator, organizationPrefix))
+		case strings.HasPrefix(creator, "Tool: "):
+			result.CreationInfo.CreatorTools = append(result.CreationInfo.CreatorTools, strings.TrimPrefix(creator, "Tool: "))
+		}
+	}
+
+	for _, l := range doc.HasExtractedLicensingInfos {
+		result.OtherLicenses = append(result.OtherLicenses, &spdx.OtherLicense2_2{
+			LicenseIdentifier: l.LicenseID,
+			ExtractedText:     l.ExtractedText,
+			LicenseName:       l.Name,
+			LicenseComment:    l.Comment,
+		})
+	}
+
+	for _, p := range doc.Packages {
+		converted := toSPDX22Package(p)
+		result.Packages[converted.PackageSPDXIdentifier] = converted
+
+		// the annotations of SPDX 2.2 are listed by the document, referring to what they comment on
+		for _, a := range p.Annotations {
+			annotatorType, annotator := splitAnnotator(a.Annotator)
+			result.Annotations = append(result.Annotations, &spdx.Annotation2_2{
+				Annotator:                annotator,
+				AnnotatorType:            annotatorType,
+				AnnotationDate:           a.AnnotationDate,
+				AnnotationType:           a.AnnotationType,
+				AnnotationSPDXIdentifier: spdx.MakeDocElementID("", string(converted.PackageSPDXIdentifier)),
+				AnnotationComment:        a.Comment,
+			})
+		}
+	}
+
+	for _, f := range doc.Files {
+		converted := &spdx.File2_2{
+			FileName:           f.FileName,
+			FileSPDXIdentifier: toSPDX22ElementID(f.SPDXID),
+			FileType:           f.FileTypes,
+			FileChecksums:      toSPDX22Checksums(f.Checksums),
+			LicenseConcluded:   f.LicenseConcluded,
+			FileCopyrightText:  f.CopyrightText,
+			FileComment:        f.Comment,
+		}
+		result.UnpackagedFiles[converted.FileSPDXIdentifier] = converted
+	}
+
+	for _, r := range doc.Relationships {
+		result.Relationships = append(result.Relationships, &spdx.Relationship2_2{
+			RefA:                toSPDX22DocElementID(r.SpdxElementID),
+			RefB:                toSPDX22DocElementID(r.RelatedSpdxElement),
+			Relationship:        string(r.RelationshipType),
+			RelationshipComment: r.Comment,
+		})
+	}
+
+	return result
+}
+
+func toSPDX22Package(p Package) *spdx.Package2_2 {
+	result := &spdx.Package2_2{
+		PackageName:               p.Name,
+		PackageSPDXIdentifier:     toSPDX22ElementID(p.SPDXID),
+		PackageVersion:            p.VersionInfo,
+		PackageFileName:           p.PackageFileName,
+		PackageDownloadLocation:   p.DownloadLocation,
+		FilesAnalyzed:             p.FilesAnalyzed,
+		IsFilesAnalyzedTagPresent: true,
+		PackageChecksums:          toSPDX22Checksums(p.Checksums),
+		PackageHomePage:           p.Homepage,
+		PackageSourceInfo:         p.SourceInfo,
+		PackageLicenseConcluded:   p.LicenseConcluded,
+		PackageLicenseDeclared:    p.LicenseDeclared,
+		PackageCopyrightText:      p.CopyrightText,
+		PackageSummary:            p.Summary,
+		PackageDescription:        p.Description,
+		PackageComment:            p.Comment,
+	}
+
+	// a NOASSERTION supplier (or originator) is the same as none for the syft model: it has not been determined
+	result.PackageSupplierPerson, result.PackageSupplierOrganization = splitAgent(p.Supplier)
+	result.PackageOriginatorPerson, result.PackageOriginatorOrganization = splitAgent(p.Originator)
+
+	for _, ref := range p.ExternalRefs {
+		category := ref.ReferenceCategory
+		if category == packageManagerReferenceCategory {
+			category = spdxhelpers.PackageManagerReferenceCategory
+		}
+		result.PackageExternalReferences = append(result.PackageExternalReferences, &spdx.PackageExternalReference2_2{
+			Category:           string(category),
+			RefType:            string(ref.ReferenceType),
+			Locator:            ref.ReferenceLocator,
+			ExternalRefComment: ref.Comment,
+		})
+	}
+
+	return result
+}
+
+// splitAgent returns the person or the organization of an agent, e.g. "Person: Jane Doe" or "Organization: ACME".
+func splitAgent(agent string) (person, organization string) {
+	switch {
+	case strings.HasPrefix(agent, personPrefix):
+		return strings.TrimPrefix(agent, personPrefix), ""
+	case strings.HasPrefix(agent, organizationPrefix):
+		return "", strings.TrimPrefix(agent, organizationPrefix)
+	}
+	return "", ""
+}
+
+// splitAnnotator returns the type and the name of an annotator, e.g. "Tool" and "syft-v0.42.0" for "Tool: syft-v0.42.0".
+func splitAnnotator(annotator string) (annotatorType, name string) {
+	fields := strings.SplitN(annotator, ":", 2)
+	if len(fields) != 2 {
+		return "", annotator
+	}
+	return strings.TrimSpace(fields[0]), strings.TrimSpace(fields[1])
+}
+
+func toSPDX22Checksums(checksums []Checksum) map[spdx.ChecksumAlgorithm]spdx.Checksum {
+	if len(checksums) == 0 {
+		return nil
+	}
+	results := make(map[spdx.ChecksumAlgorithm]spdx.Checksum)
+	for _, c := range checksums {
+		algorithm := spdx.ChecksumAlgorithm(c.Algorithm)
+		if _, exists := results[algorithm]; exists {
+			continue
+		}
+		results[algorithm] = spdx.Checksum{
+			Algorithm: algorithm,
+			Value:     c.ChecksumValue,
+		}
+	}
+	return results
+}
+
+// toSPDX22ElementID returns the ID of an element without its "SPDXRef-" prefix.
+func toSPDX22ElementID(id string) spdx.ElementID {
+	return spdx.ElementID(strings.TrimPrefix(id, elementIDPrefix))
+}
+
+// toSPDX22DocElementID returns the ID of an element which may be defined in another document
+// ("DocumentRef-<document>:SPDXRef-<element>"), or one of the special NONE and NOASSERTION values.
+func toSPDX22DocElementID(id string) spdx.DocElementID {
+	if id == spdxhelpers.NONE || id == spdxhelpers.NOASSERTION {
+		return spdx.DocElementID{SpecialID: id}
+	}
+	var documentRefID string
+	if strings.HasPrefix(id, documentRefIDPrefix) {
+		parts := strings.SplitN(id, ":", 2)
+		documentRefID = strings.TrimPrefix(parts[0], documentRefIDPrefix)
+		if len(parts) > 1 {
+			id = parts[1]
+		}
+	}
+	return spdx.MakeDocElementID(documentRefID, string(toSPDX22ElementID(id)))
+}
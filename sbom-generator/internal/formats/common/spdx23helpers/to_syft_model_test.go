@@ -0,0 +1,100 @@
+package spdx23helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/anchore/syft/internal/formats/common/testutils"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestToSyftModel(t *testing.T) {
+	s, _, _, _ := testutils.PackagesInput()
+
+	result, err := ToSyftModel(ToFormatModel(s))
+	require.NoError(t, err)
+
+	assert.Equal(t, source.DirectoryScheme, result.Source.Scheme)
+
+	packages := make(map[string]pkg.Package)
+	for _, p := range result.Artifacts.PackageCatalog.Sorted() {
+		packages[p.Name] = p
+	}
+	require.Len(t, packages, 2)
+
+	bash := packages["bash"]
+	assert.Equal(t, "5.1.8-6.oe2203", bash.Version)
+	assert.Equal(t, pkg.RpmPkg, bash.Type)
+	// the license is normalized into a SPDX license expression
+	assert.Equal(t, []string{"GPL-3.0-or-later"}, bash.Licenses)
+	assert.Equal(t, "openEuler", bash.Metadata.(pkg.RpmdbMetadata).Vendor)
+
+	assert.Equal(t, pkg.PhpComposerPkg, packages["monolog/monolog"].Type)
+
+	assert.Len(t, result.Artifacts.FileDigests, 1)
+
+	var types []artifact.RelationshipType
+	for _, r := range result.Relationships {
+		types = append(types, r.Type)
+	}
+	assert.ElementsMatch(t, []artifact.RelationshipType{artifact.DependsOnRelationship, artifact.RuntimeDependencyOfRelationship, artifact.ContainsRelationship}, types)
+}
+
+func TestToSyftModel_nil(t *testing.T) {
+	_, err := ToSyftModel(nil)
+	assert.Error(t, err)
+}
+
+func Test_toSPDX22Package_agents(t *testing.T) {
+	tests := []struct {
+		name                 string
+		supplier             string
+		originator           string
+		expectedSupplierOrg  string
+		expectedSupplierUser string
+		expectedOriginator   string
+	}{
+		{
+			name:     "no assertion",
+			supplier: spdxhelpers.NOASSERTION,
+		},
+		{
+			name:                "organization",
+			supplier:            "Organization: Red Hat",
+			expectedSupplierOrg: "Red Hat",
+		},
+		{
+			name:                 "person",
+			supplier:             "Person: Jane Doe",
+			originator:           "Person: John Doe",
+			expectedSupplierUser: "Jane Doe",
+			expectedOriginator:   "John Doe",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := toSPDX22Package(Package{Supplier: test.supplier, Originator: test.originator})
+			assert.Equal(t, test.expectedSupplierOrg, p.PackageSupplierOrganization)
+			assert.Equal(t, test.expectedSupplierUser, p.PackageSupplierPerson)
+			assert.Equal(t, test.expectedOriginator, p.PackageOriginatorPerson)
+			assert.False(t, p.PackageSupplierNOASSERTION)
+		})
+	}
+}
+
+func Test_toSPDX22DocElementID(t *testing.T) {
+	id := toSPDX22DocElementID("DocumentRef-other:SPDXRef-Package-1")
+	assert.Equal(t, "other", id.DocumentRefID)
+	assert.Equal(t, "Package-1", string(id.ElementRefID))
+
+	id = toSPDX22DocElementID("SPDXRef-Package-1")
+	assert.Empty(t, id.DocumentRefID)
+	assert.Equal(t, "Package-1", string(id.ElementRefID))
+
+	assert.Equal(t, spdxhelpers.NONE, toSPDX22DocElementID("NONE").SpecialID)
+}
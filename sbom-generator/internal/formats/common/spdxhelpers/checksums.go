@@ -0,0 +1,39 @@
+package spdxhelpers
+
+import (
+	"strings"
+
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// PackageChecksums returns the digests of the package file a package was cataloged from, as recorded by its metadata
+// (e.g. the digests of a java archive, a rpm, a go module or the distributions pinned by a python requirement), with
+// the algorithms named as SPDX does (uppercase).
+// see https://spdx.github.io/spdx-spec/package-information/#710-package-checksum-field
+func PackageChecksums(p pkg.Package) []file.Digest {
+	var digests []file.Digest
+	switch metadata := p.Metadata.(type) {
+	case pkg.JavaMetadata:
+		digests = metadata.ArchiveDigests
+	case pkg.RpmRepodata:
+		digests = metadata.RpmDigests
+	case pkg.RpmdbMetadata:
+		digests = metadata.RpmDigests
+	case pkg.PythonRequirementsMetadata:
+		digests = metadata.Digests
+	default:
+		if p.Type == pkg.GoModulePkg {
+			digests = pkg.GolangModuleDigests(p)
+		}
+	}
+
+	var results []file.Digest
+	for _, digest := range digests {
+		results = append(results, file.Digest{
+			Algorithm: strings.ToUpper(digest.Algorithm),
+			Value:     digest.Value,
+		})
+	}
+	return results
+}
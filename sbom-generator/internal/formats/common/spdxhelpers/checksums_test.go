@@ -0,0 +1,62 @@
+package spdxhelpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+func Test_PackageChecksums(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    pkg.Package
+		expected []file.Digest
+	}{
+		{
+			name:  "no metadata",
+			input: pkg.Package{},
+		},
+		{
+			name: "from java archive",
+			input: pkg.Package{
+				Metadata: pkg.JavaMetadata{
+					ArchiveDigests: []file.Digest{{Algorithm: "sha1", Value: "a1b2c3"}},
+				},
+			},
+			expected: []file.Digest{{Algorithm: "SHA1", Value: "a1b2c3"}},
+		},
+		{
+			name: "from python requirement hashes",
+			input: pkg.Package{
+				Metadata: pkg.PythonRequirementsMetadata{
+					Digests: []file.Digest{
+						{Algorithm: "sha256", Value: "7c1c9f6d7b0a8a18e62b4d1e4d5a1bd8c9b47c33a1a4bd1f6f1e1b3f2ff5d7b3"},
+						{Algorithm: "sha256", Value: "d2a4b2b0e7e4c9c6c2f7a3f6b1e0c1d9d5e1f4c8b7a6e5d4c3b2a1f0e9d8c7b6"},
+					},
+				},
+			},
+			expected: []file.Digest{
+				{Algorithm: "SHA256", Value: "7c1c9f6d7b0a8a18e62b4d1e4d5a1bd8c9b47c33a1a4bd1f6f1e1b3f2ff5d7b3"},
+				{Algorithm: "SHA256", Value: "d2a4b2b0e7e4c9c6c2f7a3f6b1e0c1d9d5e1f4c8b7a6e5d4c3b2a1f0e9d8c7b6"},
+			},
+		},
+		{
+			name: "from go.sum hash",
+			input: pkg.Package{
+				Type: pkg.GoModulePkg,
+				Metadata: pkg.GolangModMetadata{
+					H1Digest: "h1:Oe5jF1o9GB6ZvAu10bqfSEJYWkVWJtPOmKGp1JEWk3M=",
+				},
+			},
+			expected: []file.Digest{{Algorithm: "SHA256", Value: "39ee63175a3d181e99bc0bb5d1ba9f4842585a455626d3ce98a1a9d491169373"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, PackageChecksums(test.input))
+		})
+	}
+}
@@ -11,9 +11,8 @@ func Description(p pkg.Package) string {
 			return metadata.Description
 		case pkg.RpmRepodata:
 			return metadata.Description
-			// TODO: add to support rpmdb
-			// case pkg.RpmdbMetadata:
-			// 	return metadata.Description
+		case pkg.RpmdbMetadata:
+			return metadata.Description
 		}
 	}
 	return ""
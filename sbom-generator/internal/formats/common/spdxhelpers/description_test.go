@@ -37,6 +37,15 @@ func Test_Description(t *testing.T) {
 			},
 			expected: "a description!",
 		},
+		{
+			name: "from rpmdb",
+			input: pkg.Package{
+				Metadata: pkg.RpmdbMetadata{
+					Description: "a description!",
+				},
+			},
+			expected: "a description!",
+		},
 		{
 			// note: since this is an optional field, no value is preferred over NONE or NOASSERTION
 			name: "empty",
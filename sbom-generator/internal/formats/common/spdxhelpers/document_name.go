@@ -11,7 +11,7 @@ func DocumentName(srcMetadata source.Metadata) string {
 	switch srcMetadata.Scheme {
 	case source.ImageScheme:
 		return cleanName(srcMetadata.ImageMetadata.UserInput)
-	case source.DirectoryScheme, source.FileScheme:
+	case source.DirectoryScheme, source.FileScheme, source.RepoScheme:
 		return cleanName(srcMetadata.Path)
 	default:
 		return "unknown"
@@ -54,6 +54,15 @@ func Test_DocumentName(t *testing.T) {
 			},
 			expected: "some/path/to/place",
 		},
+		{
+			name:      "repository",
+			inputName: "my-name",
+			srcMetadata: source.Metadata{
+				Scheme: source.RepoScheme,
+				Path:   "https://repo.openeuler.org/openEuler-22.03-LTS/everything/x86_64",
+			},
+			expected: "https-/repo.openeuler.org/openEuler-22.03-LTS/everything/x86_64",
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -24,6 +24,8 @@ func DocumentNamespace(name string, srcMetadata source.Metadata) string {
 		input = "dir"
 	case source.FileScheme:
 		input = "file"
+	case source.RepoScheme:
+		input = "repo"
 	}
 
 	uniqueID := uuid.Must(uuid.NewRandom())
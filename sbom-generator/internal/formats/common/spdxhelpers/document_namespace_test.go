@@ -54,6 +54,15 @@ func Test_documentNamespace(t *testing.T) {
 			},
 			expected: "https://anchore.com/syft/file/my-name-",
 		},
+		{
+			name:      "repository",
+			inputName: "my-name",
+			srcMetadata: source.Metadata{
+				Scheme: source.RepoScheme,
+				Path:   "https://some/repo",
+			},
+			expected: "https://anchore.com/syft/repo/my-name-",
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -9,9 +9,8 @@ func Homepage(p pkg.Package) string {
 			return metadata.Homepage
 		case pkg.NpmPackageJSONMetadata:
 			return metadata.Homepage
-		// TODO: add to support rpmdb
-		// case pkg.RpmdbMetadata:
-		// 	return metadata.Homepage
+		case pkg.RpmdbMetadata:
+			return metadata.Homepage
 		case pkg.RpmRepodata:
 			return metadata.Homepage
 		}
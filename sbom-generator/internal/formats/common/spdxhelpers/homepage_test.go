@@ -37,6 +37,15 @@ func Test_Homepage(t *testing.T) {
 			},
 			expected: "http://a-place.gov",
 		},
+		{
+			name: "from rpmdb",
+			input: pkg.Package{
+				Metadata: pkg.RpmdbMetadata{
+					Homepage: "http://a-place.gov",
+				},
+			},
+			expected: "http://a-place.gov",
+		},
 		{
 			// note: since this is an optional field, no value is preferred over NONE or NOASSERTION
 			name: "empty",
@@ -1,7 +1,7 @@
 package spdxhelpers
 
 import (
-	"strings"
+	"sort"
 
 	"github.com/anchore/syft/internal/spdxlicense"
 	"github.com/anchore/syft/syft/pkg"
@@ -21,20 +21,54 @@ func License(p pkg.Package) string {
 		return NONE
 	}
 
-	// take all licenses and assume an AND expression; for information about license expressions see https://spdx.github.io/spdx-spec/appendix-IV-SPDX-license-expressions/
-	var parsedLicenses []string
-	for _, l := range p.Licenses {
-		if value, exists := spdxlicense.ID(l); exists {
-			parsedLicenses = append(parsedLicenses, value)
-		} else {
-			// log.Warnf("license: %s is not support", l)
-			parsedLicenses = append(parsedLicenses, l)
-		}
+	expression := licenseExpression(p)
+	if expression.Empty() {
+		return NOASSERTION
 	}
 
-	if len(parsedLicenses) == 0 {
-		return NOASSERTION
+	return expression.String()
+}
+
+// ConcludedLicense returns the license governing a package: its declared licenses, or else the licenses classified from
+// the license files found with it (AND-ed).
+func ConcludedLicense(p pkg.Package) string {
+	if len(p.Licenses) > 0 || len(p.DetectedLicenses) == 0 {
+		return License(p)
 	}
 
-	return strings.Join(parsedLicenses, " AND ")
+	expressions := make([]spdxlicense.Expression, len(p.DetectedLicenses))
+	for i, l := range p.DetectedLicenses {
+		expressions[i] = spdxlicense.ParseExpression(l.License)
+	}
+	return spdxlicense.JoinExpressions(expressions...).String()
+}
+
+// licenseExpression takes all licenses of a package and assumes an AND expression; for information about license
+// expressions see https://spdx.github.io/spdx-spec/appendix-IV-SPDX-license-expressions/
+func licenseExpression(p pkg.Package) spdxlicense.Expression {
+	expressions := make([]spdxlicense.Expression, len(p.Licenses))
+	for i, l := range p.Licenses {
+		expressions[i] = spdxlicense.ParseExpression(l)
+	}
+	return spdxlicense.JoinExpressions(expressions...)
+}
+
+// OtherLicenses returns the licenses of the packages which are not on the SPDX license list, as referenced by the
+// license expressions of the packages ("LicenseRef-..."), sorted by ID.
+func OtherLicenses(packages []pkg.Package) []spdxlicense.LicenseRef {
+	var results []spdxlicense.LicenseRef
+	seen := make(map[string]bool)
+	for _, p := range packages {
+		for _, ref := range licenseExpression(p).Refs {
+			if seen[ref.ID] {
+				continue
+			}
+			seen[ref.ID] = true
+			results = append(results, ref)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].ID < results[j].ID
+	})
+	return results
 }
@@ -3,6 +3,7 @@ package spdxhelpers
 import (
 	"testing"
 
+	"github.com/anchore/syft/internal/spdxlicense"
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/stretchr/testify/assert"
 )
@@ -25,8 +26,36 @@ func Test_License(t *testing.T) {
 					"made-up",
 				},
 			},
+			expected: "LicenseRef-made-up",
+		},
+		{
+			name: "blank licenses",
+			input: pkg.Package{
+				Licenses: []string{
+					" ",
+				},
+			},
 			expected: NOASSERTION,
 		},
+		{
+			name: "rpm license expression",
+			input: pkg.Package{
+				Licenses: []string{
+					"GPLv2+ and (LGPLv2+ or MIT)",
+				},
+			},
+			expected: "GPL-2.0-or-later AND (LGPL-2.0-or-later OR MIT)",
+		},
+		{
+			name: "compound licenses",
+			input: pkg.Package{
+				Licenses: []string{
+					"MIT or Apache-2.0",
+					"Public Domain",
+				},
+			},
+			expected: "(MIT OR Apache-2.0) AND LicenseRef-Public-Domain",
+		},
 		{
 			name: "with SPDX license",
 			input: pkg.Package{
@@ -71,3 +100,67 @@ func Test_License(t *testing.T) {
 		})
 	}
 }
+
+func Test_OtherLicenses(t *testing.T) {
+	packages := []pkg.Package{
+		{Licenses: []string{"Public Domain and MIT"}},
+		{Licenses: []string{"GPLv2+ with exceptions"}},
+		{Licenses: []string{"public domain", "ASL 2.0"}},
+		{Licenses: []string{"Public Domain"}},
+	}
+	assert.Equal(t, []spdxlicense.LicenseRef{
+		{ID: "LicenseRef-GPLv2-with-exceptions", Text: "GPLv2+ with exceptions"},
+		{ID: "LicenseRef-Public-Domain", Text: "Public Domain"},
+		{ID: "LicenseRef-public-domain", Text: "public domain"},
+	}, OtherLicenses(packages))
+}
+
+func Test_ConcludedLicense(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    pkg.Package
+		expected string
+	}{
+		{
+			name:     "no licenses",
+			input:    pkg.Package{},
+			expected: NONE,
+		},
+		{
+			name: "declared licenses take precedence",
+			input: pkg.Package{
+				Licenses: []string{"MIT"},
+				DetectedLicenses: []pkg.DetectedLicense{
+					{License: "ISC", Confidence: 1, Path: "/LICENSE"},
+				},
+			},
+			expected: "MIT",
+		},
+		{
+			name: "detected licenses",
+			input: pkg.Package{
+				DetectedLicenses: []pkg.DetectedLicense{
+					{License: "MIT", Confidence: 0.99, Path: "/LICENSE"},
+					{License: "Apache-2.0", Confidence: 1, Path: "/NOTICE"},
+					{License: "MIT", Confidence: 1, Path: "/LICENSE-MIT"},
+				},
+			},
+			expected: "MIT AND Apache-2.0",
+		},
+		{
+			name: "ambiguous detected licenses",
+			input: pkg.Package{
+				DetectedLicenses: []pkg.DetectedLicense{
+					{License: "GPL-2.0-only OR GPL-2.0-or-later", Confidence: 1, Path: "/COPYING"},
+					{License: "MIT", Confidence: 1, Path: "/LICENSE"},
+				},
+			},
+			expected: "(GPL-2.0-only OR GPL-2.0-or-later) AND MIT",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ConcludedLicense(test.input))
+		})
+	}
+}
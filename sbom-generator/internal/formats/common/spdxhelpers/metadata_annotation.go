@@ -0,0 +1,75 @@
+package spdxhelpers
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/version"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// OtherAnnotationType is the type of the annotations which are not reviews, e.g. the metadata annotations.
+const OtherAnnotationType = "OTHER"
+
+// metadataAnnotation is the comment of the annotation of a package with its metadata, which SPDX has no field for: the
+// metadata as written by the syft JSON format, so decoding restores it as it was cataloged.
+type metadataAnnotation struct {
+	MetadataType pkg.MetadataType `json:"metadataType"`
+	Metadata     json.RawMessage  `json:"metadata,omitempty"`
+}
+
+// MetadataAnnotator returns the tool which annotates the packages with their metadata (e.g. "syft-v0.42.0").
+func MetadataAnnotator() string {
+	return internal.ApplicationName + "-" + version.FromBuild().Version
+}
+
+// MetadataAnnotation returns the comment of the annotation recording the metadata of a package, if it has any.
+func MetadataAnnotation(p pkg.Package) (string, bool) {
+	if (p.MetadataType == "" || p.MetadataType == pkg.UnknownMetadataType) && p.Metadata == nil {
+		return "", false
+	}
+
+	annotation := metadataAnnotation{MetadataType: p.MetadataType}
+	if p.Metadata != nil {
+		metadata, err := json.Marshal(p.Metadata)
+		if err != nil {
+			log.Warnf("unable to encode metadata of package=%q as an SPDX annotation: %+v", p.Name, err)
+			return "", false
+		}
+		annotation.Metadata = metadata
+	}
+
+	comment, err := json.Marshal(annotation)
+	if err != nil {
+		log.Warnf("unable to encode metadata of package=%q as an SPDX annotation: %+v", p.Name, err)
+		return "", false
+	}
+	return string(comment), true
+}
+
+// ApplyMetadataAnnotation restores the metadata of a package from the comment of one of its annotations, if the
+// comment records the metadata of the package (other annotations are ignored).
+func ApplyMetadataAnnotation(p *pkg.Package, comment string) bool {
+	var annotation metadataAnnotation
+	if err := json.Unmarshal([]byte(comment), &annotation); err != nil || annotation.MetadataType == "" {
+		return false
+	}
+
+	var metadata interface{}
+	if typ, ok := pkg.MetadataTypeByName[annotation.MetadataType]; ok && len(annotation.Metadata) > 0 {
+		value := reflect.New(typ)
+		if err := json.Unmarshal(annotation.Metadata, value.Interface()); err != nil {
+			log.Warnf("unable to decode metadata of package=%q from SPDX annotation: %+v", p.Name, err)
+			return false
+		}
+		metadata = value.Elem().Interface()
+	}
+
+	p.MetadataType = annotation.MetadataType
+	p.Metadata = metadata
+	// the ID of a package is derived from its metadata
+	p.SetID()
+	return true
+}
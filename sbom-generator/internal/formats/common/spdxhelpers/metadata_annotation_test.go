@@ -0,0 +1,64 @@
+package spdxhelpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+func TestMetadataAnnotation(t *testing.T) {
+	tests := []struct {
+		name string
+		pkg  pkg.Package
+	}{
+		{
+			name: "metadata",
+			pkg: pkg.Package{
+				Name:         "bash",
+				Version:      "5.1.8-6.el9",
+				MetadataType: pkg.RpmdbMetadataType,
+				Metadata: pkg.RpmdbMetadata{
+					Name:    "bash",
+					Version: "5.1.8",
+					Release: "6.el9",
+					Arch:    "x86_64",
+					Vendor:  "Red Hat, Inc.",
+				},
+			},
+		},
+		{
+			name: "metadata type only",
+			pkg: pkg.Package{
+				Name:         "app",
+				MetadataType: pkg.NpmPackageJSONMetadataType,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			comment, ok := MetadataAnnotation(test.pkg)
+			require.True(t, ok)
+
+			decoded := pkg.Package{Name: test.pkg.Name, Version: test.pkg.Version}
+			require.True(t, ApplyMetadataAnnotation(&decoded, comment))
+			assert.Equal(t, test.pkg.MetadataType, decoded.MetadataType)
+			assert.Equal(t, test.pkg.Metadata, decoded.Metadata)
+		})
+	}
+}
+
+func TestMetadataAnnotation_noMetadata(t *testing.T) {
+	_, ok := MetadataAnnotation(pkg.Package{Name: "app"})
+	assert.False(t, ok)
+}
+
+func TestApplyMetadataAnnotation_otherComment(t *testing.T) {
+	p := pkg.Package{Name: "app"}
+	assert.False(t, ApplyMetadataAnnotation(&p, "reviewed by the security team"))
+	assert.False(t, ApplyMetadataAnnotation(&p, `{"reviewer": "jane"}`))
+	assert.Empty(t, p.MetadataType)
+	assert.Nil(t, p.Metadata)
+}
@@ -0,0 +1,42 @@
+package spdxhelpers
+
+import (
+	"time"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// releaseDateLayouts are the layouts of the release dates recorded by package managers, e.g. "2021-03-09T12:52:19+00:00"
+// or "2015-05-14 13:12:05" for composer packages.
+var releaseDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// ReleaseDate returns the date a package was released at, as recorded by its metadata (the zero time when unknown).
+func ReleaseDate(p pkg.Package) time.Time {
+	if metadata, ok := p.Metadata.(pkg.PhpComposerJSONMetadata); ok && metadata.Time != "" {
+		for _, layout := range releaseDateLayouts {
+			if t, err := time.Parse(layout, metadata.Time); err == nil {
+				return t.UTC()
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// BuiltDate returns the date a package was built at, as recorded by its metadata (the zero time when unknown).
+func BuiltDate(p pkg.Package) time.Time {
+	var buildTime int64
+	switch metadata := p.Metadata.(type) {
+	case pkg.RpmdbMetadata:
+		buildTime = metadata.BuildTime
+	case pkg.RpmRepodata:
+		buildTime = metadata.BuildTime
+	}
+	if buildTime <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(buildTime, 0).UTC()
+}
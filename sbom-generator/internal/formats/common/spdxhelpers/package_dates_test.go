@@ -0,0 +1,85 @@
+package spdxhelpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReleaseDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    pkg.Package
+		expected time.Time
+	}{
+		{
+			name:  "no metadata",
+			input: pkg.Package{},
+		},
+		{
+			name: "composer RFC 3339 time",
+			input: pkg.Package{
+				Metadata: pkg.PhpComposerJSONMetadata{Time: "2021-03-09T12:52:19+01:00"},
+			},
+			expected: time.Date(2021, 3, 9, 11, 52, 19, 0, time.UTC),
+		},
+		{
+			name: "composer legacy time",
+			input: pkg.Package{
+				Metadata: pkg.PhpComposerJSONMetadata{Time: "2015-05-14 13:12:05"},
+			},
+			expected: time.Date(2015, 5, 14, 13, 12, 5, 0, time.UTC),
+		},
+		{
+			name: "composer invalid time",
+			input: pkg.Package{
+				Metadata: pkg.PhpComposerJSONMetadata{Time: "yesterday"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ReleaseDate(test.input))
+		})
+	}
+}
+
+func Test_BuiltDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    pkg.Package
+		expected time.Time
+	}{
+		{
+			name:  "no metadata",
+			input: pkg.Package{},
+		},
+		{
+			name: "rpmdb",
+			input: pkg.Package{
+				Metadata: pkg.RpmdbMetadata{BuildTime: 1581279813},
+			},
+			expected: time.Date(2020, 2, 9, 20, 23, 33, 0, time.UTC),
+		},
+		{
+			name: "rpm repodata",
+			input: pkg.Package{
+				Metadata: pkg.RpmRepodata{BuildTime: 1581279813},
+			},
+			expected: time.Date(2020, 2, 9, 20, 23, 33, 0, time.UTC),
+		},
+		{
+			name: "rpm without build time",
+			input: pkg.Package{
+				Metadata: pkg.RpmdbMetadata{},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, BuiltDate(test.input))
+		})
+	}
+}
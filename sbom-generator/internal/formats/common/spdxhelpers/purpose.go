@@ -0,0 +1,41 @@
+package spdxhelpers
+
+import "github.com/anchore/syft/syft/pkg"
+
+// source: https://spdx.github.io/spdx-spec/v2.3/package-information/#724-primary-package-purpose-field
+type PackagePurpose string
+
+const (
+	ApplicationPackagePurpose     PackagePurpose = "APPLICATION"      // if the package is a software application
+	FrameworkPackagePurpose       PackagePurpose = "FRAMEWORK"        // if the package is a software framework
+	LibraryPackagePurpose         PackagePurpose = "LIBRARY"          // if the package is a software library
+	ContainerPackagePurpose       PackagePurpose = "CONTAINER"        // if the package refers to a container image which can be used by a container runtime application
+	OperatingSystemPackagePurpose PackagePurpose = "OPERATING-SYSTEM" // if the package refers to an operating system
+	DevicePackagePurpose          PackagePurpose = "DEVICE"           // if the package refers to a chipset, processor, or electronic board
+	FirmwarePackagePurpose        PackagePurpose = "FIRMWARE"         // if the package provides low level control over a device's hardware
+	SourcePackagePurpose          PackagePurpose = "SOURCE"           // if the package is a collection of source files
+	ArchivePackagePurpose         PackagePurpose = "ARCHIVE"          // if the package refers to an archived collection of files (.tar, .zip, etc.)
+	FilePackagePurpose            PackagePurpose = "FILE"             // if the package is a single file which can be independently distributed (configuration file, statically linked binary, Kubernetes deployment, etc.)
+	InstallPackagePurpose         PackagePurpose = "INSTALL"          // if the package is used to install software on disk
+	OtherPackagePurpose           PackagePurpose = "OTHER"            // if the package doesn't fit into the above categories
+)
+
+// PrimaryPackagePurpose returns the purpose of a package: the packages of the operating system are installed by its
+// package manager, while the packages of the language ecosystems are libraries (apart from the main module of a go
+// binary, which is the application itself). Nothing is returned for the packages of an unknown purpose.
+func PrimaryPackagePurpose(p pkg.Package) PackagePurpose {
+	switch p.Type {
+	case pkg.ApkPkg, pkg.DebPkg, pkg.RpmPkg, pkg.RepodataPkg:
+		return InstallPackagePurpose
+	case pkg.GoModulePkg:
+		// only the main module of a go binary has build settings
+		if metadata, ok := p.Metadata.(pkg.GolangBinMetadata); ok && len(metadata.BuildSettings) > 0 {
+			return ApplicationPackagePurpose
+		}
+		return LibraryPackagePurpose
+	case pkg.GemPkg, pkg.NpmPkg, pkg.PythonPkg, pkg.PhpComposerPkg, pkg.JavaPkg, pkg.JenkinsPluginPkg, pkg.RustPkg,
+		pkg.DartPubPkg, pkg.DotnetPkg:
+		return LibraryPackagePurpose
+	}
+	return ""
+}
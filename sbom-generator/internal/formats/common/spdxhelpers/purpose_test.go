@@ -0,0 +1,61 @@
+package spdxhelpers
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PrimaryPackagePurpose(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    pkg.Package
+		expected PackagePurpose
+	}{
+		{
+			name:     "unknown type",
+			input:    pkg.Package{},
+			expected: "",
+		},
+		{
+			name:     "rpm",
+			input:    pkg.Package{Type: pkg.RpmPkg},
+			expected: InstallPackagePurpose,
+		},
+		{
+			name:     "deb",
+			input:    pkg.Package{Type: pkg.DebPkg},
+			expected: InstallPackagePurpose,
+		},
+		{
+			name:     "npm",
+			input:    pkg.Package{Type: pkg.NpmPkg},
+			expected: LibraryPackagePurpose,
+		},
+		{
+			name: "go binary dependency",
+			input: pkg.Package{
+				Type:     pkg.GoModulePkg,
+				Metadata: pkg.GolangBinMetadata{GoCompiledVersion: "go1.18"},
+			},
+			expected: LibraryPackagePurpose,
+		},
+		{
+			name: "go binary main module",
+			input: pkg.Package{
+				Type: pkg.GoModulePkg,
+				Metadata: pkg.GolangBinMetadata{
+					GoCompiledVersion: "go1.18",
+					BuildSettings:     map[string]string{"GOOS": "linux"},
+				},
+			},
+			expected: ApplicationPackagePurpose,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, PrimaryPackagePurpose(test.input))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package spdxhelpers
+
+import (
+	"fmt"
+
+	"github.com/anchore/syft/syft/artifact"
+)
+
+// LookupRelationship returns the SPDX relationship type (and the comment qualifying it, if any) a syft relationship is
+// expressed as, if it has one.
+func LookupRelationship(ty artifact.RelationshipType) (bool, RelationshipType, string) {
+	switch ty {
+	case artifact.RuntimeDependencyOfRelationship:
+		return true, RuntimeDependencyOfRelationship, ""
+	case artifact.DependsOnRelationship:
+		return true, DependsOnRelationship, ""
+	case artifact.DependencyOfRelationship:
+		return true, DependencyOfRelationship, ""
+	case artifact.ContainsRelationship:
+		return true, ContainsRelationship, ""
+	case artifact.OwnershipByFileOverlapRelationship:
+		return true, OtherRelationship, fmt.Sprintf("%s: indicates that the parent package claims ownership of a child package since the parent metadata indicates overlap with a location that a cataloger found the child package by", ty)
+	case artifact.HasPrerequisiteRelationship:
+		return true, HasPrerequisiteRelationship, ""
+	case artifact.DevDependencyOfRelationship:
+		return true, DevDependencyOfRelationship, ""
+	case artifact.OptionalDependencyOfRelationship:
+		return true, OptionalDependencyOfRelationship, ""
+	case artifact.BuildDependencyOfRelationship:
+		return true, BuildDependencyOfRelationship, ""
+	case artifact.RecommendsRelationship:
+		return true, OptionalDependencyOfRelationship, fmt.Sprintf("%s: indicates that the related package recommends this package, which is installed along by default", ty)
+	case artifact.SuggestsRelationship:
+		return true, OptionalDependencyOfRelationship, fmt.Sprintf("%s: indicates that the related package suggests this package, which is not installed by default", ty)
+	case artifact.SupplementsRelationship:
+		return true, OptionalDependencyOfRelationship, fmt.Sprintf("%s: indicates that this package supplements the related package, and is installed along with it by default", ty)
+	case artifact.EnhancesRelationship:
+		return true, OptionalDependencyOfRelationship, fmt.Sprintf("%s: indicates that this package enhances the related package, and is not installed along with it by default", ty)
+	}
+	return false, "", ""
+}
+
+// IsReverseRelationship indicates that the SPDX relationship is expressed from the child to the parent package, e.g.
+// a package recommended by another one is an OPTIONAL_DEPENDENCY_OF it.
+func IsReverseRelationship(ty artifact.RelationshipType) bool {
+	switch ty {
+	case artifact.RecommendsRelationship, artifact.SuggestsRelationship:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package spdxhelpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/artifact"
+)
+
+func TestLookupRelationship(t *testing.T) {
+
+	tests := []struct {
+		input   artifact.RelationshipType
+		exists  bool
+		ty      RelationshipType
+		comment string
+	}{
+		{
+			input:  artifact.ContainsRelationship,
+			exists: true,
+			ty:     ContainsRelationship,
+		},
+		{
+			input:   artifact.OwnershipByFileOverlapRelationship,
+			exists:  true,
+			ty:      OtherRelationship,
+			comment: "ownership-by-file-overlap: indicates that the parent package claims ownership of a child package since the parent metadata indicates overlap with a location that a cataloger found the child package by",
+		},
+		{
+			input:  artifact.DependencyOfRelationship,
+			exists: true,
+			ty:     DependencyOfRelationship,
+		},
+		{
+			input:  artifact.HasPrerequisiteRelationship,
+			exists: true,
+			ty:     HasPrerequisiteRelationship,
+		},
+		{
+			input:  artifact.DevDependencyOfRelationship,
+			exists: true,
+			ty:     DevDependencyOfRelationship,
+		},
+		{
+			input:  artifact.OptionalDependencyOfRelationship,
+			exists: true,
+			ty:     OptionalDependencyOfRelationship,
+		},
+		{
+			input:  artifact.BuildDependencyOfRelationship,
+			exists: true,
+			ty:     BuildDependencyOfRelationship,
+		},
+		{
+			input:   artifact.RecommendsRelationship,
+			exists:  true,
+			ty:      OptionalDependencyOfRelationship,
+			comment: "recommends: indicates that the related package recommends this package, which is installed along by default",
+		},
+		{
+			input:   artifact.EnhancesRelationship,
+			exists:  true,
+			ty:      OptionalDependencyOfRelationship,
+			comment: "enhances: indicates that this package enhances the related package, and is not installed along with it by default",
+		},
+		{
+			input:  "made-up",
+			exists: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(string(test.input), func(t *testing.T) {
+			exists, ty, comment := LookupRelationship(test.input)
+			assert.Equal(t, exists, test.exists)
+			assert.Equal(t, ty, test.ty)
+			assert.Equal(t, comment, test.comment)
+		})
+	}
+}
+
+func TestIsReverseRelationship(t *testing.T) {
+	assert.True(t, IsReverseRelationship(artifact.RecommendsRelationship))
+	assert.True(t, IsReverseRelationship(artifact.SuggestsRelationship))
+	assert.False(t, IsReverseRelationship(artifact.SupplementsRelationship))
+	assert.False(t, IsReverseRelationship(artifact.DependsOnRelationship))
+}
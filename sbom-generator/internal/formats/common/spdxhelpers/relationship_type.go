@@ -168,6 +168,14 @@ const (
 	// Example: An APPLICATION foo.exe has prerequisite or dependency on bar.dll
 	HasPrerequisiteRelationship RelationshipType = "HAS_PREREQUISITE"
 
+	// RequirementDescriptionForRelationship is to be used when SPDXRef-A describes, illustrates, or specifies a requirement statement for SPDXRef-B (SPDX 2.3).
+	// Example: A requirements document describes the requirements for a package.
+	RequirementDescriptionForRelationship RelationshipType = "REQUIREMENT_DESCRIPTION_FOR"
+
+	// SpecificationForRelationship is to be used when SPDXRef-A describes, illustrates, or defines a design specification for SPDXRef-B (SPDX 2.3).
+	// Example: A design document describes the design of a package.
+	SpecificationForRelationship RelationshipType = "SPECIFICATION_FOR"
+
 	// OtherRelationship is to be used for a relationship which has not been defined in the formal SPDX specification. A description of the relationship should be included in the Relationship comments field.
 	OtherRelationship RelationshipType = "OTHER"
 )
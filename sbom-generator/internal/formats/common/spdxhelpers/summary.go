@@ -11,9 +11,8 @@ func Summary(p pkg.Package) string {
 		// 	return metadata.Summary
 		case pkg.RpmRepodata:
 			return metadata.Summary
-			// TODO: add to support rpmdb
-			// case pkg.RpmdbMetadata:
-			// 	return metadata.Description
+		case pkg.RpmdbMetadata:
+			return metadata.Summary
 		}
 	}
 	return ""
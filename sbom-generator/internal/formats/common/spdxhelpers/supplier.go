@@ -5,9 +5,14 @@ import "github.com/anchore/syft/syft/pkg"
 func Supplier(p pkg.Package) string {
 	if hasMetadata(p) {
 		switch metadata := p.Metadata.(type) {
-		// TODO: add to support rpmdb
-		// case pkg.RpmdbMetadata:
-		// 	return "Organization: " + metadata.vendor
+		case pkg.RpmdbMetadata:
+			// the vendor is the distribution, most installed packages have one
+			if metadata.Vendor != "" {
+				return "Organization: " + metadata.Vendor
+			}
+			if metadata.Packager != "" {
+				return "Organization: " + metadata.Packager
+			}
 		case pkg.RpmRepodata:
 			return "Organization: " + metadata.Packager
 		}
@@ -42,9 +42,9 @@ func ToSyftModel(doc *spdx.Document2_2) (*sbom.SBOM, error) {
 
 	collectSyftPackages(s, spdxIDMap, doc)
 
-	collectSyftFiles(s, spdxIDMap, doc)
+	packageFiles := collectSyftFiles(s, spdxIDMap, doc)
 
-	s.Relationships = toSyftRelationships(spdxIDMap, doc)
+	s.Relationships = mergeRelationships(toSyftRelationships(spdxIDMap, doc), packageFiles)
 
 	return s, nil
 }
@@ -68,6 +68,8 @@ func extractSchemeFromNamespace(ns string) source.Scheme {
 			return source.ImageScheme
 		case "dir":
 			return source.DirectoryScheme
+		case "repo":
+			return source.RepoScheme
 		}
 	}
 	return source.UnknownScheme
@@ -75,59 +77,113 @@ func extractSchemeFromNamespace(ns string) source.Scheme {
 
 func findLinuxReleaseByPURL(doc *spdx.Document2_2) *linux.Release {
 	for _, p := range doc.Packages {
-		purlValue := findPURLValue(p)
-		if purlValue == "" {
-			continue
-		}
-		purl, err := packageurl.FromString(purlValue)
-		if err != nil {
-			log.Warnf("unable to parse purl: %s", purlValue)
-			continue
-		}
-		distro := findQualifierValue(purl, pkg.PURLQualifierDistro)
-		if distro != "" {
-			parts := strings.Split(distro, "-")
-			name := parts[0]
-			version := ""
-			if len(parts) > 1 {
-				version = parts[1]
-			}
-			return &linux.Release{
-				PrettyName: name,
-				Name:       name,
-				ID:         name,
-				IDLike:     []string{name},
-				Version:    version,
-				VersionID:  version,
-			}
+		if release := LinuxReleaseFromPURL(findPURLValue(p)); release != nil {
+			return release
 		}
 	}
 
 	return nil
 }
 
+// LinuxReleaseFromPURL returns the linux distribution a package was installed on, as recorded by the distro qualifier
+// of its purl (e.g. "distro=alpine-3.16"), if any.
+func LinuxReleaseFromPURL(purlValue string) *linux.Release {
+	if purlValue == "" {
+		return nil
+	}
+	purl, err := packageurl.FromString(purlValue)
+	if err != nil {
+		log.Warnf("unable to parse purl: %s", purlValue)
+		return nil
+	}
+	distro := findQualifierValue(purl, pkg.PURLQualifierDistro)
+	if distro == "" {
+		return nil
+	}
+	parts := strings.Split(distro, "-")
+	name := parts[0]
+	version := ""
+	if len(parts) > 1 {
+		version = parts[1]
+	}
+	return &linux.Release{
+		PrettyName: name,
+		Name:       name,
+		ID:         name,
+		IDLike:     []string{name},
+		Version:    version,
+		VersionID:  version,
+	}
+}
+
 func collectSyftPackages(s *sbom.SBOM, spdxIDMap map[string]interface{}, doc *spdx.Document2_2) {
+	// the licenses which are not on the SPDX license list are referenced by ID ("LicenseRef-..."), and were found as
+	// their extracted text
+	extractedLicenses := make(map[string]string)
+	for _, l := range doc.OtherLicenses {
+		if l != nil && l.ExtractedText != "" {
+			extractedLicenses[l.LicenseIdentifier] = l.ExtractedText
+		}
+	}
+
+	// the metadata of the packages is recorded by their annotations
+	annotations := make(map[spdx.ElementID][]string)
+	for _, a := range doc.Annotations {
+		if a != nil && a.AnnotationType == OtherAnnotationType {
+			id := a.AnnotationSPDXIdentifier.ElementRefID
+			annotations[id] = append(annotations[id], a.AnnotationComment)
+		}
+	}
+
 	for _, p := range doc.Packages {
-		syftPkg := toSyftPackage(p)
-		spdxIDMap[string(p.PackageSPDXIdentifier)] = syftPkg
+		syftPkg := ToSyftPackage(p, extractedLicenses)
+		for _, comment := range annotations[p.PackageSPDXIdentifier] {
+			if ApplyMetadataAnnotation(syftPkg, comment) {
+				break
+			}
+		}
+		spdxIDMap[string(p.PackageSPDXIdentifier)] = *syftPkg
 		s.Artifacts.PackageCatalog.Add(*syftPkg)
 	}
 }
 
-func collectSyftFiles(s *sbom.SBOM, spdxIDMap map[string]interface{}, doc *spdx.Document2_2) {
+// collectSyftFiles collects the unpackaged files, and the files of the packages (as listed right after a package in
+// a tag-value document), which are contained by their package.
+func collectSyftFiles(s *sbom.SBOM, spdxIDMap map[string]interface{}, doc *spdx.Document2_2) []artifact.Relationship {
+	collect := func(f *spdx.File2_2) source.Coordinates {
+		coordinates := toSyftCoordinates(f)
+		spdxIDMap[string(f.FileSPDXIdentifier)] = coordinates
+
+		s.Artifacts.FileMetadata[coordinates] = toFileMetadata(f)
+		s.Artifacts.FileDigests[coordinates] = toFileDigests(f)
+		return coordinates
+	}
+
 	for _, f := range doc.UnpackagedFiles {
-		l := toSyftLocation(f)
-		spdxIDMap[string(f.FileSPDXIdentifier)] = l
+		collect(f)
+	}
 
-		s.Artifacts.FileMetadata[l.Coordinates] = toFileMetadata(f)
-		s.Artifacts.FileDigests[l.Coordinates] = toFileDigests(f)
+	var relationships []artifact.Relationship
+	for _, p := range doc.Packages {
+		syftPkg, ok := spdxIDMap[string(p.PackageSPDXIdentifier)].(pkg.Package)
+		if !ok {
+			continue
+		}
+		for _, f := range p.Files {
+			relationships = append(relationships, artifact.Relationship{
+				From: syftPkg,
+				To:   collect(f),
+				Type: artifact.ContainsRelationship,
+			})
+		}
 	}
+	return relationships
 }
 
 func toFileDigests(f *spdx.File2_2) (digests []file.Digest) {
 	for _, digest := range f.FileChecksums {
 		digests = append(digests, file.Digest{
-			Algorithm: string(digest.Algorithm),
+			Algorithm: strings.ToLower(string(digest.Algorithm)),
 			Value:     digest.Value,
 		})
 	}
@@ -166,19 +222,19 @@ func toSyftRelationships(spdxIDMap map[string]interface{}, doc *spdx.Document2_2
 		}
 		a := spdxIDMap[string(r.RefA.ElementRefID)]
 		b := spdxIDMap[string(r.RefB.ElementRefID)]
-		from, fromOk := a.(*pkg.Package)
-		toPackage, toPackageOk := b.(*pkg.Package)
-		toLocation, toLocationOk := b.(*source.Location)
-		if !fromOk || !(toPackageOk || toLocationOk) {
+		from, fromOk := a.(pkg.Package)
+		toPackage, toPackageOk := b.(pkg.Package)
+		toFile, toFileOk := b.(source.Coordinates)
+		if !fromOk || !(toPackageOk || toFileOk) {
 			log.Debugf("unable to find valid relationship mapping from SPDX 2.2 JSON, ignoring: (from: %+v) (to: %+v)", a, b)
 			continue
 		}
 		var to artifact.Identifiable
 		var typ artifact.RelationshipType
-		if toLocationOk {
+		if toFileOk {
 			if r.Relationship == string(ContainsRelationship) {
 				typ = artifact.ContainsRelationship
-				to = toLocation
+				to = toFile
 			}
 		} else {
 			switch RelationshipType(r.Relationship) {
@@ -194,10 +250,39 @@ func toSyftRelationships(spdxIDMap map[string]interface{}, doc *spdx.Document2_2
 			case DependsOnRelationship:
 				typ = artifact.DependsOnRelationship
 				to = toPackage
+			case DependencyOfRelationship:
+				typ = artifact.DependencyOfRelationship
+				to = toPackage
+			case HasPrerequisiteRelationship:
+				typ = artifact.HasPrerequisiteRelationship
+				to = toPackage
+			case DevDependencyOfRelationship:
+				typ = artifact.DevDependencyOfRelationship
+				to = toPackage
+			case OptionalDependencyOfRelationship:
+				// Encoding of rpm weak dependencies uses a specifically formatted comment...
+				for _, weak := range []artifact.RelationshipType{artifact.SupplementsRelationship, artifact.EnhancesRelationship} {
+					if strings.Index(r.RelationshipComment, string(weak)) == 0 {
+						typ = weak
+						to = toPackage
+					}
+				}
+				// ...and recommends and suggests are written from the recommended or suggested package
+				for _, weak := range []artifact.RelationshipType{artifact.RecommendsRelationship, artifact.SuggestsRelationship} {
+					if strings.Index(r.RelationshipComment, string(weak)) == 0 {
+						typ = weak
+						from, to = toPackage, from
+					}
+				}
+				// ...while other optional dependencies (e.g. from lock files) have no comment
+				if typ == "" {
+					typ = artifact.OptionalDependencyOfRelationship
+					to = toPackage
+				}
 			case OtherRelationship:
 				// Encoding uses a specifically formatted comment...
 				if strings.Index(r.RelationshipComment, string(artifact.OwnershipByFileOverlapRelationship)) == 0 {
-					typ = artifact.RuntimeDependencyOfRelationship
+					typ = artifact.OwnershipByFileOverlapRelationship
 					to = toPackage
 				}
 			}
@@ -213,6 +298,24 @@ func toSyftRelationships(spdxIDMap map[string]interface{}, doc *spdx.Document2_2
 	return out
 }
 
+// mergeRelationships adds the implied relationships which are not stated already.
+func mergeRelationships(stated, implied []artifact.Relationship) []artifact.Relationship {
+	type key struct {
+		from, to artifact.ID
+		typ      artifact.RelationshipType
+	}
+	seen := make(map[key]bool)
+	for _, r := range stated {
+		seen[key{r.From.ID(), r.To.ID(), r.Type}] = true
+	}
+	for _, r := range implied {
+		if !seen[key{r.From.ID(), r.To.ID(), r.Type}] {
+			stated = append(stated, r)
+		}
+	}
+	return stated
+}
+
 func toSyftCoordinates(f *spdx.File2_2) source.Coordinates {
 	const layerIDPrefix = "layerID: "
 	var fileSystemID string
@@ -228,13 +331,6 @@ func toSyftCoordinates(f *spdx.File2_2) source.Coordinates {
 	}
 }
 
-func toSyftLocation(f *spdx.File2_2) *source.Location {
-	return &source.Location{
-		Coordinates: toSyftCoordinates(f),
-		VirtualPath: f.FileName,
-	}
-}
-
 func requireAndTrimPrefix(val interface{}, prefix string) string {
 	if v, ok := val.(string); ok {
 		if i := strings.Index(v, prefix); i == 0 {
@@ -276,19 +372,28 @@ func extractPkgInfo(p *spdx.Package2_2) pkgInfo {
 	}
 }
 
-func toSyftPackage(p *spdx.Package2_2) *pkg.Package {
+// ToSyftPackage converts a SPDX package into a syft package, which type and metadata are found from its purl. The
+// licenses which are not on the SPDX license list are replaced by their extracted text, keyed by their ID.
+func ToSyftPackage(p *spdx.Package2_2, extractedLicenses map[string]string) *pkg.Package {
 	info := extractPkgInfo(p)
 	metadataType, metadata := extractMetadata(p, info)
+	// a purl which cannot be parsed is kept as it is
+	purl := findPURLValue(p)
+	if info.purl.Type != "" {
+		purl = info.purl.String()
+	}
 	sP := pkg.Package{
-		Type:         info.typ,
-		Name:         p.PackageName,
-		Version:      p.PackageVersion,
-		Licenses:     parseLicense(p.PackageLicenseDeclared),
-		CPEs:         extractCPEs(p),
-		PURL:         info.purl.String(),
-		Language:     info.lang,
-		MetadataType: metadataType,
-		Metadata:     metadata,
+		Type:          info.typ,
+		Name:          p.PackageName,
+		Version:       p.PackageVersion,
+		Licenses:      parseLicense(p.PackageLicenseDeclared, extractedLicenses),
+		CPEs:          extractCPEs(p),
+		PURL:          purl,
+		ProvidesPurls: findExternalRefLocators(p, ProvideManagerReferenceCategory),
+		ExtPkgPurls:   findExternalRefLocators(p, ExternalManagerReferenceCategory),
+		Language:      info.lang,
+		MetadataType:  metadataType,
+		Metadata:      metadata,
 	}
 
 	sP.SetID()
@@ -346,7 +451,7 @@ func extractMetadata(p *spdx.Package2_2, info pkgInfo) (pkg.MetadataType, interf
 	case pkg.JavaPkg:
 		var digests []file.Digest
 		for algorithm, value := range p.PackageChecksums {
-			digests = append(digests, file.Digest{Algorithm: string(algorithm), Value: value.Value})
+			digests = append(digests, file.Digest{Algorithm: strings.ToLower(string(algorithm)), Value: value.Value})
 		}
 		return pkg.JavaMetadataType, pkg.JavaMetadata{
 			ArchiveDigests: digests,
@@ -357,13 +462,33 @@ func extractMetadata(p *spdx.Package2_2, info pkgInfo) (pkg.MetadataType, interf
 
 func findPURLValue(p *spdx.Package2_2) string {
 	for _, r := range p.PackageExternalReferences {
-		if r.RefType == string(PurlExternalRefType) {
+		// the purls of what a package provides or embeds are not the purl of the package
+		if r.RefType == string(PurlExternalRefType) && !isProvidedOrExternalRef(r) {
 			return r.Locator
 		}
 	}
 	return ""
 }
 
+func isProvidedOrExternalRef(r *spdx.PackageExternalReference2_2) bool {
+	switch ReferenceCategory(r.Category) {
+	case ProvideManagerReferenceCategory, ExternalManagerReferenceCategory:
+		return true
+	}
+	return false
+}
+
+// findExternalRefLocators returns the locators of the external references of a package in a category (e.g. the purls
+// of what the package provides).
+func findExternalRefLocators(p *spdx.Package2_2, category ReferenceCategory) (locators []string) {
+	for _, r := range p.PackageExternalReferences {
+		if ReferenceCategory(r.Category) == category {
+			locators = append(locators, r.Locator)
+		}
+	}
+	return locators
+}
+
 func extractCPEs(p *spdx.Package2_2) (cpes []pkg.CPE) {
 	for _, r := range p.PackageExternalReferences {
 		if r.RefType == string(Cpe23ExternalRefType) {
@@ -378,9 +503,15 @@ func extractCPEs(p *spdx.Package2_2) (cpes []pkg.CPE) {
 	return cpes
 }
 
-func parseLicense(l string) []string {
-	if l == NOASSERTION || l == NONE {
+func parseLicense(l string, extractedLicenses map[string]string) []string {
+	if l == "" || l == NOASSERTION || l == NONE {
 		return nil
 	}
-	return strings.Split(l, " AND ")
+	licenses := strings.Split(l, " AND ")
+	for i, license := range licenses {
+		if text, exists := extractedLicenses[license]; exists {
+			licenses[i] = text
+		}
+	}
+	return licenses
 }
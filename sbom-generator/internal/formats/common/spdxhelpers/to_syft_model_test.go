@@ -3,6 +3,7 @@ package spdxhelpers
 import (
 	"testing"
 
+	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/source"
 	"github.com/spdx/tools-golang/spdx"
@@ -233,3 +234,89 @@ func TestExtractSourceFromNamespaces(t *testing.T) {
 		require.Equal(t, tt.expected, extractSchemeFromNamespace(tt.namespace))
 	}
 }
+
+func Test_toSyftRelationships(t *testing.T) {
+	app := pkg.Package{Name: "app"}
+	lib := pkg.Package{Name: "lib"}
+	spdxIDMap := map[string]interface{}{
+		"app": app,
+		"lib": lib,
+	}
+
+	relationship := func(a, b string, ty RelationshipType, comment string) *spdx.Relationship2_2 {
+		return &spdx.Relationship2_2{
+			RefA:                spdx.DocElementID{ElementRefID: spdx.ElementID(a)},
+			RefB:                spdx.DocElementID{ElementRefID: spdx.ElementID(b)},
+			Relationship:        string(ty),
+			RelationshipComment: comment,
+		}
+	}
+
+	tests := []struct {
+		name         string
+		relationship *spdx.Relationship2_2
+		expected     []artifact.Relationship
+	}{
+		{
+			name:         "depends on",
+			relationship: relationship("app", "lib", DependsOnRelationship, ""),
+			expected:     []artifact.Relationship{{From: app, To: lib, Type: artifact.DependsOnRelationship}},
+		},
+		{
+			name:         "has prerequisite",
+			relationship: relationship("app", "lib", HasPrerequisiteRelationship, ""),
+			expected:     []artifact.Relationship{{From: app, To: lib, Type: artifact.HasPrerequisiteRelationship}},
+		},
+		{
+			name:         "recommends is written from the recommended package",
+			relationship: relationship("lib", "app", OptionalDependencyOfRelationship, "recommends: indicates that the related package recommends this package"),
+			expected:     []artifact.Relationship{{From: app, To: lib, Type: artifact.RecommendsRelationship}},
+		},
+		{
+			name:         "supplements",
+			relationship: relationship("app", "lib", OptionalDependencyOfRelationship, "supplements: indicates that this package supplements the related package"),
+			expected:     []artifact.Relationship{{From: app, To: lib, Type: artifact.SupplementsRelationship}},
+		},
+		{
+			name:         "optional dependency of another origin",
+			relationship: relationship("lib", "app", OptionalDependencyOfRelationship, ""),
+			expected:     []artifact.Relationship{{From: lib, To: app, Type: artifact.OptionalDependencyOfRelationship}},
+		},
+		{
+			name:         "dev dependency",
+			relationship: relationship("lib", "app", DevDependencyOfRelationship, ""),
+			expected:     []artifact.Relationship{{From: lib, To: app, Type: artifact.DevDependencyOfRelationship}},
+		},
+		{
+			name:         "ownership by file overlap",
+			relationship: relationship("app", "lib", OtherRelationship, "ownership-by-file-overlap: indicates that the parent package claims ownership of a child package"),
+			expected:     []artifact.Relationship{{From: app, To: lib, Type: artifact.OwnershipByFileOverlapRelationship}},
+		},
+		{
+			name:         "other relationship",
+			relationship: relationship("app", "lib", OtherRelationship, "something else"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc := &spdx.Document2_2{
+				CreationInfo:  &spdx.CreationInfo2_2{SPDXIdentifier: "DOCUMENT"},
+				Relationships: []*spdx.Relationship2_2{test.relationship},
+			}
+			assert.Equal(t, test.expected, toSyftRelationships(spdxIDMap, doc))
+		})
+	}
+}
+
+func Test_parseLicense(t *testing.T) {
+	extractedLicenses := map[string]string{
+		"LicenseRef-Public-Domain": "Public Domain",
+	}
+
+	assert.Nil(t, parseLicense(NOASSERTION, extractedLicenses))
+	assert.Nil(t, parseLicense(NONE, extractedLicenses))
+	// a package without a license relationship (SPDX 3.0) has no declared license
+	assert.Nil(t, parseLicense("", extractedLicenses))
+	assert.Equal(t, []string{"MIT", "Public Domain", "LicenseRef-Other"}, parseLicense("MIT AND LicenseRef-Public-Domain AND LicenseRef-Other", extractedLicenses))
+}
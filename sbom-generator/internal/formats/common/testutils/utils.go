@@ -9,6 +9,8 @@ import (
 	"github.com/anchore/stereoscope/pkg/filetree"
 	"github.com/anchore/stereoscope/pkg/image"
 	"github.com/anchore/stereoscope/pkg/imagetest"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/linux"
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/sbom"
@@ -225,6 +227,73 @@ func DirectoryInput(t testing.TB) sbom.SBOM {
 	}
 }
 
+// PackagesInput returns an SBOM of an installed RPM package, a composer package depending on it and a file of the RPM
+// package, along with the packages and the coordinates of the file.
+func PackagesInput() (sbom.SBOM, pkg.Package, pkg.Package, source.Coordinates) {
+	bash := pkg.Package{
+		Name:    "bash",
+		Version: "5.1.8-6.oe2203",
+		Type:    pkg.RpmPkg,
+		PURL:    "pkg:rpm/openEuler/bash@5.1.8-6.oe2203?arch=x86_64&distro=openEuler-22.03",
+		Locations: source.NewLocationSet(
+			source.NewLocation("/var/lib/rpm/Packages"),
+		),
+		Licenses:      []string{"GPLv3+"},
+		ProvidesPurls: []string{"pkg:rpm/openEuler/sh?distro=openEuler-22.03"},
+		MetadataType:  pkg.RpmdbMetadataType,
+		Metadata: pkg.RpmdbMetadata{
+			Name:      "bash",
+			Version:   "5.1.8",
+			Release:   "6.oe2203",
+			Arch:      "x86_64",
+			Vendor:    "openEuler",
+			BuildTime: 1581279813,
+			RpmDigests: []file.Digest{
+				{Algorithm: "sha256", Value: "0123abcd"},
+			},
+		},
+	}
+	bash.SetID()
+
+	monolog := pkg.Package{
+		Name:         "monolog/monolog",
+		Version:      "2.2.0",
+		Type:         pkg.PhpComposerPkg,
+		PURL:         "pkg:composer/monolog/monolog@2.2.0",
+		Licenses:     []string{"a custom license"},
+		MetadataType: pkg.PhpComposerJSONMetadataType,
+		Metadata: pkg.PhpComposerJSONMetadata{
+			Name:    "monolog/monolog",
+			Version: "2.2.0",
+			Time:    "2020-12-14T13:15:25+00:00",
+		},
+	}
+	monolog.SetID()
+
+	coordinates := source.Coordinates{RealPath: "/usr/bin/bash", FileSystemID: "sha256:layer"}
+
+	return sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(bash, monolog),
+			FileMetadata: map[source.Coordinates]source.FileMetadata{
+				coordinates: {MIMEType: "application/x-executable"},
+			},
+			FileDigests: map[source.Coordinates][]file.Digest{
+				coordinates: {{Algorithm: "sha1", Value: "abcdef"}},
+			},
+		},
+		Relationships: []artifact.Relationship{
+			{From: monolog, To: bash, Type: artifact.DependsOnRelationship},
+			{From: bash, To: monolog, Type: artifact.RuntimeDependencyOfRelationship},
+			{From: bash, To: coordinates, Type: artifact.ContainsRelationship},
+		},
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   "/some/path",
+		},
+	}, bash, monolog, coordinates
+}
+
 func newDirectoryCatalog() *pkg.Catalog {
 	catalog := pkg.NewCatalog()
 
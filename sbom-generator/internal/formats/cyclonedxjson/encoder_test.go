@@ -5,6 +5,8 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/CycloneDX/cyclonedx-go"
+
 	"github.com/anchore/syft/internal/formats/common/testutils"
 )
 
@@ -19,6 +21,15 @@ func TestCycloneDxDirectoryEncoder(t *testing.T) {
 	)
 }
 
+func TestCycloneDx15DirectoryEncoder(t *testing.T) {
+	testutils.AssertEncoderAgainstGoldenSnapshot(t,
+		FormatWithVersion(cyclonedx.SpecVersion1_5),
+		testutils.DirectoryInput(t),
+		*updateCycloneDx,
+		cycloneDxRedactor,
+	)
+}
+
 func TestCycloneDxImageEncoder(t *testing.T) {
 	testImage := "image-simple"
 	testutils.AssertEncoderAgainstGoldenImageSnapshot(t,
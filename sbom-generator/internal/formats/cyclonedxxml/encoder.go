@@ -8,11 +8,11 @@ import (
 	"github.com/anchore/syft/syft/sbom"
 )
 
-func encoder(output io.Writer, s sbom.SBOM) error {
-	bom := cyclonedxhelpers.ToFormatModel(s)
-	enc := cyclonedx.NewBOMEncoder(output, cyclonedx.BOMFileFormatXML)
-	enc.SetPretty(true)
-
-	err := enc.Encode(bom)
-	return err
+func encoder(specVersion cyclonedx.SpecVersion) sbom.Encoder {
+	return func(output io.Writer, s sbom.SBOM) error {
+		bom := cyclonedxhelpers.ToFormatModel(s, specVersion)
+		enc := cyclonedx.NewBOMEncoder(output, cyclonedx.BOMFileFormatXML)
+		enc.SetPretty(true)
+		return enc.EncodeVersion(bom, specVersion)
+	}
 }
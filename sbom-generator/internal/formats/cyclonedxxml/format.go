@@ -8,10 +8,16 @@ import (
 
 const ID sbom.FormatID = "cyclonedx-1-xml"
 
+// Format returns the CycloneDX XML format for the default version of the specification.
 func Format() sbom.Format {
+	return FormatWithVersion(cyclonedxhelpers.DefaultSpecVersion)
+}
+
+// FormatWithVersion returns the CycloneDX XML format for the given version of the specification.
+func FormatWithVersion(specVersion cyclonedx.SpecVersion) sbom.Format {
 	return sbom.NewFormat(
 		ID,
-		encoder,
+		encoder(specVersion),
 		cyclonedxhelpers.GetDecoder(cyclonedx.BOMFileFormatXML),
 		cyclonedxhelpers.GetValidator(cyclonedx.BOMFileFormatXML),
 	)
@@ -0,0 +1,222 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+func decoder(reader io.Reader) (*sbom.SBOM, error) {
+	doc, err := decode(reader)
+	if err != nil {
+		return nil, err
+	}
+	return toSyftModel(doc), nil
+}
+
+func decode(reader io.Reader) (*DependencySnapshot, error) {
+	var doc DependencySnapshot
+	if err := json.NewDecoder(reader).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unable to decode github-json: %w", err)
+	}
+
+	// random JSON does not necessarily cause an error (e.g. SPDX)
+	if doc.Detector.Name == "" || doc.Scanned == "" {
+		return nil, fmt.Errorf("not a GitHub dependency snapshot: no detector or scan time")
+	}
+	return &doc, nil
+}
+
+// toSyftModel converts a GitHub dependency snapshot to an SBOM: the packages are found from their purls, at the
+// location of their manifest, and depend on the packages listed as their dependencies.
+func toSyftModel(doc *DependencySnapshot) *sbom.SBOM {
+	s := &sbom.SBOM{
+		Source: source.Metadata{Scheme: source.UnknownScheme},
+		Artifacts: sbom.Artifacts{
+			PackageCatalog:    pkg.NewCatalog(),
+			LinuxDistribution: toLinuxRelease(doc.Metadata),
+		},
+		Descriptor: sbom.Descriptor{
+			Name:    doc.Detector.Name,
+			Version: doc.Detector.Version,
+		},
+	}
+
+	// the manifests are read in order so the packages are always related the same way
+	var manifestNames []string
+	for name := range doc.Manifests {
+		manifestNames = append(manifestNames, name)
+	}
+	sort.Strings(manifestNames)
+
+	// the packages of each manifest by dependency name, which is how the dependencies are listed
+	packages := make(map[string]map[string]pkg.Package)
+	for _, manifestName := range manifestNames {
+		manifest := doc.Manifests[manifestName]
+		if s.Source.Scheme == source.UnknownScheme {
+			s.Source = toSource(manifest)
+		}
+
+		packages[manifestName] = make(map[string]pkg.Package)
+		for name, node := range manifest.Resolved {
+			p, err := toSyftPackage(node, toLocation(manifest))
+			if err != nil {
+				log.Warnf("unable to decode GitHub dependency %q: %+v", name, err)
+				continue
+			}
+			packages[manifestName][name] = p
+			s.Artifacts.PackageCatalog.Add(p)
+		}
+	}
+
+	for _, manifestName := range manifestNames {
+		manifest := doc.Manifests[manifestName]
+		for name, node := range manifest.Resolved {
+			from, ok := packages[manifestName][name]
+			if !ok {
+				continue
+			}
+			for _, dependency := range node.Dependencies {
+				to, ok := findDependency(packages, manifestName, manifestNames, dependency)
+				if !ok {
+					log.Debugf("unable to find GitHub dependency %q of %q, ignoring", dependency, name)
+					continue
+				}
+				s.Relationships = append(s.Relationships, artifact.Relationship{
+					From: from,
+					To:   to,
+					Type: artifact.DependsOnRelationship,
+				})
+			}
+		}
+	}
+
+	return s
+}
+
+// findDependency returns the package a dependency refers to, preferably from the manifest of the dependent package.
+func findDependency(packages map[string]map[string]pkg.Package, manifestName string, manifestNames []string, dependency string) (pkg.Package, bool) {
+	if p, ok := packages[manifestName][dependency]; ok {
+		return p, true
+	}
+	for _, name := range manifestNames {
+		if p, ok := packages[name][dependency]; ok {
+			return p, true
+		}
+	}
+	return pkg.Package{}, false
+}
+
+func toSyftPackage(node DependencyNode, location *source.Location) (pkg.Package, error) {
+	purl, err := packageurl.FromString(node.PackageURL)
+	if err != nil {
+		return pkg.Package{}, err
+	}
+
+	p := pkg.Package{
+		Name:     toPackageName(purl),
+		Version:  toPackageVersion(purl),
+		PURL:     node.PackageURL,
+		Type:     pkg.TypeFromPURL(node.PackageURL),
+		Language: pkg.LanguageFromPURL(node.PackageURL),
+	}
+	if location != nil {
+		p.Locations = source.NewLocationSet(*location)
+	}
+	p.SetID()
+	return p, nil
+}
+
+// toPackageName returns the name of a package, which includes the namespace of its purl for the ecosystems that
+// name packages by both (e.g. "@scope/name" for npm, or the path of a go module).
+func toPackageName(purl packageurl.PackageURL) string {
+	switch purl.Type {
+	case packageurl.TypeNPM, packageurl.TypeGolang, packageurl.TypeComposer:
+		if purl.Namespace != "" {
+			return purl.Namespace + "/" + purl.Name
+		}
+	}
+	return purl.Name
+}
+
+// toPackageVersion returns the version of a package, which for RPMs includes the epoch qualifier of its purl (e.g.
+// "1:5.1.8-4.el9"), as it is written by the RPM catalogers.
+func toPackageVersion(purl packageurl.PackageURL) string {
+	if purl.Type != packageurl.TypeRPM {
+		return purl.Version
+	}
+	for _, q := range purl.Qualifiers {
+		if q.Key == pkg.PURLQualifierEpoch && q.Value != "" {
+			return q.Value + ":" + purl.Version
+		}
+	}
+	return purl.Version
+}
+
+// toLocation returns the location of the packages of a manifest, which is written after the image or archive they
+// were found in (e.g. "ubuntu:18.04:/var/lib/dpkg/status").
+func toLocation(manifest Manifest) *source.Location {
+	path := manifest.File.SourceLocation
+	if path == "" {
+		return nil
+	}
+	if i := strings.Index(path, ":/"); i >= 0 {
+		path = path[i+1:]
+	}
+
+	location := source.NewLocation(path)
+	if fs, ok := manifest.Metadata["syft:filesystem"].(string); ok {
+		location.FileSystemID = fs
+	}
+	return &location
+}
+
+// toSource returns what was scanned from a manifest: the packages found in an image are on one of its layers.
+func toSource(manifest Manifest) source.Metadata {
+	path := manifest.File.SourceLocation
+	i := strings.Index(path, ":/")
+	if _, ok := manifest.Metadata["syft:filesystem"]; !ok || i < 0 {
+		return source.Metadata{Scheme: source.UnknownScheme}
+	}
+	return source.Metadata{
+		Scheme: source.ImageScheme,
+		ImageMetadata: source.ImageMetadata{
+			UserInput: strings.ReplaceAll(path[:i], "//", ":/"),
+		},
+	}
+}
+
+// toLinuxRelease reads the linux distribution from the purl of the snapshot metadata (e.g.
+// "pkg:generic/ubuntu@18.04?like=debian").
+func toLinuxRelease(metadata Metadata) *linux.Release {
+	value, ok := metadata["syft:distro"].(string)
+	if !ok {
+		return nil
+	}
+	purl, err := packageurl.FromString(value)
+	if err != nil {
+		log.Warnf("unable to decode GitHub snapshot distro %q: %+v", value, err)
+		return nil
+	}
+
+	release := &linux.Release{
+		ID:        purl.Name,
+		VersionID: purl.Version,
+	}
+	for _, q := range purl.Qualifiers {
+		if q.Key == "like" && q.Value != "" {
+			release.IDLike = strings.Split(q.Value, ",")
+		}
+	}
+	return release
+}
@@ -0,0 +1,151 @@
+package github
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/packageurl-go"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestDecoder(t *testing.T) {
+	location := source.Location{
+		Coordinates: source.Coordinates{
+			RealPath:     "/usr/lib/node_modules/app/package.json",
+			FileSystemID: "fsid-1",
+		},
+	}
+	app := pkg.Package{
+		Name:      "app",
+		Version:   "1.0.0",
+		PURL:      "pkg:npm/app@1.0.0",
+		Locations: source.NewLocationSet(location),
+	}
+	app.SetID()
+	lib := pkg.Package{
+		Name:      "@scope/lib",
+		Version:   "2.0.0",
+		PURL:      "pkg:npm/%40scope/lib@2.0.0",
+		Locations: source.NewLocationSet(location),
+	}
+	lib.SetID()
+
+	input := sbom.SBOM{
+		Source: source.Metadata{
+			Scheme: source.ImageScheme,
+			ImageMetadata: source.ImageMetadata{
+				UserInput: "node:18",
+			},
+		},
+		Artifacts: sbom.Artifacts{
+			LinuxDistribution: &linux.Release{
+				ID:        "debian",
+				VersionID: "11",
+				IDLike:    []string{"like!"},
+			},
+			PackageCatalog: pkg.NewCatalog(app, lib),
+		},
+		Relationships: []artifact.Relationship{
+			{From: app, To: lib, Type: artifact.DependsOnRelationship},
+		},
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, Format().Encode(&buffer, input))
+	require.NoError(t, Format().Validate(bytes.NewReader(buffer.Bytes())))
+
+	s, err := Format().Decode(bytes.NewReader(buffer.Bytes()))
+	require.NoError(t, err)
+
+	assert.Equal(t, source.ImageScheme, s.Source.Scheme)
+	assert.Equal(t, "node:18", s.Source.ImageMetadata.UserInput)
+	assert.Equal(t, &linux.Release{ID: "debian", VersionID: "11", IDLike: []string{"like!"}}, s.Artifacts.LinuxDistribution)
+
+	packages := make(map[string]pkg.Package)
+	for _, p := range s.Artifacts.PackageCatalog.Sorted() {
+		packages[p.Name] = p
+	}
+	require.Len(t, packages, 2)
+
+	decodedLib, ok := packages["@scope/lib"]
+	require.True(t, ok)
+	assert.Equal(t, "2.0.0", decodedLib.Version)
+	assert.Equal(t, lib.PURL, decodedLib.PURL)
+	assert.Equal(t, pkg.NpmPkg, decodedLib.Type)
+	assert.Equal(t, pkg.JavaScript, decodedLib.Language)
+	assert.Equal(t, []source.Location{location}, decodedLib.Locations.ToSlice())
+
+	require.Len(t, s.Relationships, 1)
+	assert.Equal(t, packages["app"], s.Relationships[0].From)
+	assert.Equal(t, decodedLib, s.Relationships[0].To)
+	assert.Equal(t, artifact.DependsOnRelationship, s.Relationships[0].Type)
+}
+
+func Test_toPackageVersion(t *testing.T) {
+	tests := []struct {
+		purl string
+		want string
+	}{
+		{
+			purl: "pkg:rpm/rhel/bash@5.1.8-4.el9?arch=x86_64&epoch=1",
+			want: "1:5.1.8-4.el9",
+		},
+		{
+			purl: "pkg:rpm/rhel/glibc@2.34-28.el9?arch=x86_64",
+			want: "2.34-28.el9",
+		},
+		{
+			// only RPM versions are written with their epoch
+			purl: "pkg:deb/debian/libc6@2.31-13?epoch=1",
+			want: "2.31-13",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.purl, func(t *testing.T) {
+			purl, err := packageurl.FromString(test.purl)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, toPackageVersion(purl))
+		})
+	}
+}
+
+func TestValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{
+			name:  "snapshot",
+			input: `{"version": 0, "detector": {"name": "syft", "version": "0.0.0-dev"}, "scanned": "2022-08-01T12:00:00Z", "manifests": {}}`,
+		},
+		{
+			name:    "other JSON document",
+			input:   `{"spdxVersion": "SPDX-2.2", "name": "test"}`,
+			wantErr: true,
+		},
+		{
+			name:    "not JSON",
+			input:   "SPDXVersion: SPDX-2.2\n",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Format().Validate(strings.NewReader(test.input))
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/formats/common"
 	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/internal/version"
 	"github.com/anchore/syft/syft/pkg"
@@ -94,7 +95,7 @@ func toPath(s source.Metadata, p pkg.Package) string {
 				return fmt.Sprintf("%s:/%s", inputPath, packagePath)
 			}
 			return inputPath
-		case source.DirectoryScheme:
+		case source.DirectoryScheme, source.RepoScheme:
 			if inputPath != "" {
 				return fmt.Sprintf("%s/%s", inputPath, packagePath)
 			}
@@ -173,12 +174,25 @@ func toDependencyMetadata(_ pkg.Package) Metadata {
 	return Metadata{}
 }
 
+// toDependencies lists the dependencies of a package, whatever the direction of the relationships to them (e.g. a
+// runtime dependency of the package is expressed from the dependency).
 func toDependencies(s *sbom.SBOM, p pkg.Package) (out []string) {
+	seen := internal.NewStringSet()
 	for _, r := range s.Relationships {
-		if r.From.ID() == p.ID() {
-			if p, ok := r.To.(pkg.Package); ok {
-				out = append(out, dependencyName(p))
+		dependent, dependency := r.From, r.To
+		if common.IsDependencyOfRelationship(r.Type) {
+			dependent, dependency = dependency, dependent
+		}
+		if dependent.ID() != p.ID() {
+			continue
+		}
+		if d, ok := dependency.(pkg.Package); ok {
+			name := dependencyName(d)
+			if seen.Contains(name) {
+				continue
 			}
+			seen.Add(name)
+			out = append(out, name)
 		}
 	}
 	return
@@ -9,6 +9,7 @@ import (
 
 const ID sbom.FormatID = "github-0-json"
 
+// note: this format is LOSSY relative to the syftjson format
 func Format() sbom.Format {
 	return sbom.NewFormat(
 		ID,
@@ -23,7 +24,7 @@ func Format() sbom.Format {
 
 			return err
 		},
-		nil,
-		nil,
+		decoder,
+		validator,
 	)
 }
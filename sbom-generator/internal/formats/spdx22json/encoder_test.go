@@ -31,8 +31,9 @@ func TestSPDXJSONImageEncoder(t *testing.T) {
 }
 
 func spdxJsonRedactor(s []byte) []byte {
-	// each SBOM reports the time it was generated, which is not useful during snapshot testing
-	s = regexp.MustCompile(`"created": .*`).ReplaceAll(s, []byte("redacted"))
+	// each SBOM reports the time it was generated (and its packages were annotated), which is not useful during
+	// snapshot testing
+	s = regexp.MustCompile(`"(created|annotationDate)": .*`).ReplaceAll(s, []byte("redacted"))
 
 	// each SBOM reports a unique documentNamespace when generated, this is not useful for snapshot testing
 	s = regexp.MustCompile(`"documentNamespace": .*`).ReplaceAll(s, []byte("redacted"))
@@ -22,6 +22,7 @@ import (
 // toFormatModel creates and populates a new JSON document struct that follows the SPDX 2.2 spec from the given cataloging results.
 func toFormatModel(s sbom.SBOM) *model.Document {
 	name, namespace := spdxhelpers.DocumentNameAndNamespace(s.Source)
+	created := time.Now().UTC()
 
 	return &model.Document{
 		Element: model.Element{
@@ -30,7 +31,7 @@ func toFormatModel(s sbom.SBOM) *model.Document {
 		},
 		SPDXVersion: model.Version,
 		CreationInfo: model.CreationInfo{
-			Created: time.Now().UTC(),
+			Created: created,
 			Creators: []string{
 				// note: key-value format derived from the JSON example document examples: https://github.com/spdx/spdx-spec/blob/v2.2/examples/SPDXJSONExample-v2.2.spdx.json
 				"Organization: Anchore, Inc",
@@ -38,15 +39,30 @@ func toFormatModel(s sbom.SBOM) *model.Document {
 			},
 			LicenseListVersion: spdxlicense.Version,
 		},
-		DataLicense:       "CC0-1.0",
-		DocumentNamespace: namespace,
-		Packages:          toPackages(s.Artifacts.PackageCatalog, s.Relationships),
-		Files:             toFiles(s),
-		Relationships:     toRelationships(s.Relationships),
+		DataLicense:                "CC0-1.0",
+		DocumentNamespace:          namespace,
+		HasExtractedLicensingInfos: toExtractedLicensingInfos(s.Artifacts.PackageCatalog),
+		Packages:                   toPackages(s.Artifacts.PackageCatalog, s.Relationships, created),
+		Files:                      toFiles(s),
+		Relationships:              toRelationships(s.Relationships),
 	}
 }
 
-func toPackages(catalog *pkg.Catalog, relationships []artifact.Relationship) []model.Package {
+// toExtractedLicensingInfos describes the licenses of the packages which are not on the SPDX license list, referenced
+// by the license expressions of the packages.
+func toExtractedLicensingInfos(catalog *pkg.Catalog) []model.HasExtractedLicensingInfo {
+	var results []model.HasExtractedLicensingInfo
+	for _, l := range spdxhelpers.OtherLicenses(catalog.Sorted()) {
+		results = append(results, model.HasExtractedLicensingInfo{
+			LicenseID:     l.ID,
+			ExtractedText: l.Text,
+			Name:          l.Text,
+		})
+	}
+	return results
+}
+
+func toPackages(catalog *pkg.Catalog, relationships []artifact.Relationship, created time.Time) []model.Package {
 	packages := make([]model.Package, 0)
 	externalCounter := spdxhelpers.ExternalCounter{
 		ProvideMap:     map[string]string{},
@@ -57,48 +73,21 @@ func toPackages(catalog *pkg.Catalog, relationships []artifact.Relationship) []m
 	for _, p := range catalog.Sorted() {
 		license := spdxhelpers.License(p)
 		packageSpdxID := model.ElementID(p.ID()).String()
-		filesAnalyzed := false
-
 		// we generate digest for some Java packages
 		// see page 33 of the spdx specification for 2.2
 		// spdx.github.io/spdx-spec/package-information/#710-package-checksum-field
 		var checksums []model.Checksum
-		if p.MetadataType == pkg.JavaMetadataType {
-			javaMetadata := p.Metadata.(pkg.JavaMetadata)
-			if len(javaMetadata.ArchiveDigests) > 0 {
-				filesAnalyzed = true
-				for _, digest := range javaMetadata.ArchiveDigests {
-					checksums = append(checksums, model.Checksum{
-						Algorithm:     strings.ToUpper(digest.Algorithm),
-						ChecksumValue: digest.Value,
-					})
-				}
-			}
-		} else if p.MetadataType == pkg.RpmRepodataType {
-			rpmRepodata := p.Metadata.(pkg.RpmRepodata)
-			if len(rpmRepodata.RpmDigests) > 0 {
-				for _, digest := range rpmRepodata.RpmDigests {
-					checksums = append(checksums, model.Checksum{
-						Algorithm:     strings.ToUpper(digest.Algorithm),
-						ChecksumValue: digest.Value,
-					})
-				}
-			}
+		for _, digest := range spdxhelpers.PackageChecksums(p) {
+			checksums = append(checksums, model.Checksum{
+				Algorithm:     digest.Algorithm,
+				ChecksumValue: digest.Value,
+			})
 		}
-		// TODO: add to support rpmdb
-		// else if p.MetadataType == pkg.RpmdbMetadataType{
-		// 	rpmdbMetadata := p.Metadata.(pkg.RpmdbMetadata)
-		// 	if len(rpmdbMetadata.RpmDigests) > 0 {
-		// 		for _, digest := range rpmdbMetadata.RpmDigests {
-		// 			checksums = append(checksums, model.Checksum{
-		// 				Algorithm:     strings.ToUpper(digest.Algorithm),
-		// 				ChecksumValue: digest.Value,
-		// 			})
-		// 		}
-		// 	}
-		// }
-		// note: the license concluded and declared should be the same since we are collecting license information
-		// from the project data itself (the installed package files).
+		// the files of a java archive are analyzed to generate its digests
+		filesAnalyzed := p.MetadataType == pkg.JavaMetadataType && len(checksums) > 0
+
+		// note: the license concluded and declared are the same when the license is declared by the package metadata,
+		// otherwise the license concluded is the license classified from the license files found with the package.
 		externalRefs := spdxhelpers.ExternalRefs(p, &externalCounter)
 
 		packages = append(packages, model.Package{
@@ -118,10 +107,11 @@ func toPackages(catalog *pkg.Catalog, relationships []artifact.Relationship) []m
 			VersionInfo:     p.Version,
 			Item: model.Item{
 				// The Concluded License field is the license the SPDX file creator believes governs the package
-				LicenseConcluded: license,
+				LicenseConcluded: spdxhelpers.ConcludedLicense(p),
 				Element: model.Element{
-					SPDXID: packageSpdxID,
-					Name:   p.Name,
+					SPDXID:      packageSpdxID,
+					Name:        p.Name,
+					Annotations: toAnnotations(p, created),
 				},
 			},
 		})
@@ -131,6 +121,23 @@ func toPackages(catalog *pkg.Catalog, relationships []artifact.Relationship) []m
 	return packages
 }
 
+// toAnnotations records the metadata of a package, which SPDX has no field for, as an annotation made when the
+// document was created.
+func toAnnotations(p pkg.Package, created time.Time) []model.Annotation {
+	comment, ok := spdxhelpers.MetadataAnnotation(p)
+	if !ok {
+		return nil
+	}
+	return []model.Annotation{
+		{
+			AnnotationDate: created,
+			AnnotationType: model.OtherAnnotationType,
+			Annotator:      "Tool: " + spdxhelpers.MetadataAnnotator(),
+			Comment:        comment,
+		},
+	}
+}
+
 func fileIDsForPackage(packageSpdxID string, relationships []artifact.Relationship) (fileIDs []string) {
 	for _, relationship := range relationships {
 		if relationship.Type != artifact.ContainsRelationship {
@@ -185,7 +192,7 @@ func toFiles(s sbom.SBOM) []model.File {
 			},
 			Checksums: toFileChecksums(digests),
 			FileName:  coordinates.RealPath,
-			FileTypes: toFileTypes(metadata),
+			FileTypes: spdxhelpers.FileTypes(metadata),
 		})
 	}
 
@@ -212,70 +219,26 @@ func toChecksumAlgorithm(algorithm string) string {
 	return strings.ToUpper(algorithm)
 }
 
-func toFileTypes(metadata *source.FileMetadata) (ty []string) {
-	if metadata == nil {
-		return nil
-	}
-
-	mimeTypePrefix := strings.Split(metadata.MIMEType, "/")[0]
-	switch mimeTypePrefix {
-	case "image":
-		ty = append(ty, string(spdxhelpers.ImageFileType))
-	case "video":
-		ty = append(ty, string(spdxhelpers.VideoFileType))
-	case "application":
-		ty = append(ty, string(spdxhelpers.ApplicationFileType))
-	case "text":
-		ty = append(ty, string(spdxhelpers.TextFileType))
-	case "audio":
-		ty = append(ty, string(spdxhelpers.AudioFileType))
-	}
-
-	if internal.IsExecutable(metadata.MIMEType) {
-		ty = append(ty, string(spdxhelpers.BinaryFileType))
-	}
-
-	if internal.IsArchive(metadata.MIMEType) {
-		ty = append(ty, string(spdxhelpers.ArchiveFileType))
-	}
-
-	// TODO: add support for source, spdx, and documentation file types
-	if len(ty) == 0 {
-		ty = append(ty, string(spdxhelpers.OtherFileType))
-	}
-
-	return ty
-}
-
 func toRelationships(relationships []artifact.Relationship) (result []model.Relationship) {
 	for _, r := range relationships {
-		exists, relationshipType, comment := lookupRelationship(r.Type)
+		exists, relationshipType, comment := spdxhelpers.LookupRelationship(r.Type)
 
 		if !exists {
 			log.Warnf("unable to convert relationship from SPDX 2.2 JSON, dropping: %+v", r)
 			continue
 		}
 
+		from, to := r.From.ID(), r.To.ID()
+		if spdxhelpers.IsReverseRelationship(r.Type) {
+			from, to = to, from
+		}
+
 		result = append(result, model.Relationship{
-			SpdxElementID:      model.ElementID(r.From.ID()).String(),
+			SpdxElementID:      model.ElementID(from).String(),
 			RelationshipType:   relationshipType,
-			RelatedSpdxElement: model.ElementID(r.To.ID()).String(),
+			RelatedSpdxElement: model.ElementID(to).String(),
 			Comment:            comment,
 		})
 	}
 	return result
 }
-
-func lookupRelationship(ty artifact.RelationshipType) (bool, spdxhelpers.RelationshipType, string) {
-	switch ty {
-	case artifact.RuntimeDependencyOfRelationship:
-		return true, spdxhelpers.RuntimeDependencyOfRelationship, ""
-	case artifact.DependsOnRelationship:
-		return true, spdxhelpers.DependsOnRelationship, ""
-	case artifact.ContainsRelationship:
-		return true, spdxhelpers.ContainsRelationship, ""
-	case artifact.OwnershipByFileOverlapRelationship:
-		return true, spdxhelpers.OtherRelationship, fmt.Sprintf("%s: indicates that the parent package claims ownership of a child package since the parent metadata indicates overlap with a location that a cataloger found the child package by", ty)
-	}
-	return false, "", ""
-}
@@ -9,126 +9,11 @@ import (
 
 	"github.com/anchore/syft/syft/artifact"
 
-	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
 	"github.com/anchore/syft/internal/formats/spdx22json/model"
 	"github.com/anchore/syft/syft/source"
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_toFileTypes(t *testing.T) {
-
-	tests := []struct {
-		name     string
-		metadata source.FileMetadata
-		expected []string
-	}{
-		{
-			name: "application",
-			metadata: source.FileMetadata{
-				MIMEType: "application/vnd.unknown",
-			},
-			expected: []string{
-				string(spdxhelpers.ApplicationFileType),
-			},
-		},
-		{
-			name: "archive",
-			metadata: source.FileMetadata{
-				MIMEType: "application/zip",
-			},
-			expected: []string{
-				string(spdxhelpers.ApplicationFileType),
-				string(spdxhelpers.ArchiveFileType),
-			},
-		},
-		{
-			name: "audio",
-			metadata: source.FileMetadata{
-				MIMEType: "audio/ogg",
-			},
-			expected: []string{
-				string(spdxhelpers.AudioFileType),
-			},
-		},
-		{
-			name: "video",
-			metadata: source.FileMetadata{
-				MIMEType: "video/3gpp",
-			},
-			expected: []string{
-				string(spdxhelpers.VideoFileType),
-			},
-		},
-		{
-			name: "text",
-			metadata: source.FileMetadata{
-				MIMEType: "text/html",
-			},
-			expected: []string{
-				string(spdxhelpers.TextFileType),
-			},
-		},
-		{
-			name: "image",
-			metadata: source.FileMetadata{
-				MIMEType: "image/png",
-			},
-			expected: []string{
-				string(spdxhelpers.ImageFileType),
-			},
-		},
-		{
-			name: "binary",
-			metadata: source.FileMetadata{
-				MIMEType: "application/x-sharedlib",
-			},
-			expected: []string{
-				string(spdxhelpers.ApplicationFileType),
-				string(spdxhelpers.BinaryFileType),
-			},
-		},
-	}
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			assert.ElementsMatch(t, test.expected, toFileTypes(&test.metadata))
-		})
-	}
-}
-
-func Test_lookupRelationship(t *testing.T) {
-
-	tests := []struct {
-		input   artifact.RelationshipType
-		exists  bool
-		ty      spdxhelpers.RelationshipType
-		comment string
-	}{
-		{
-			input:  artifact.ContainsRelationship,
-			exists: true,
-			ty:     spdxhelpers.ContainsRelationship,
-		},
-		{
-			input:   artifact.OwnershipByFileOverlapRelationship,
-			exists:  true,
-			ty:      spdxhelpers.OtherRelationship,
-			comment: "ownership-by-file-overlap: indicates that the parent package claims ownership of a child package since the parent metadata indicates overlap with a location that a cataloger found the child package by",
-		},
-		{
-			input:  "made-up",
-			exists: false,
-		},
-	}
-	for _, test := range tests {
-		t.Run(string(test.input), func(t *testing.T) {
-			exists, ty, comment := lookupRelationship(test.input)
-			assert.Equal(t, exists, test.exists)
-			assert.Equal(t, ty, test.ty)
-			assert.Equal(t, comment, test.comment)
-		})
-	}
-}
-
 func Test_toFileChecksums(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -255,3 +140,19 @@ func Test_fileIDsForPackage(t *testing.T) {
 		})
 	}
 }
+
+func Test_toExtractedLicensingInfos(t *testing.T) {
+	catalog := pkg.NewCatalog(
+		pkg.Package{Name: "bash", Version: "5.1.8", Licenses: []string{"GPLv3+"}},
+		pkg.Package{Name: "tzdata", Version: "2022a", Licenses: []string{"Public Domain"}},
+		pkg.Package{Name: "zlib", Version: "1.2.11", Licenses: []string{"zlib and Boost"}},
+	)
+
+	assert.Equal(t, []model.HasExtractedLicensingInfo{
+		{
+			LicenseID:     "LicenseRef-Public-Domain",
+			ExtractedText: "Public Domain",
+			Name:          "Public Domain",
+		},
+	}, toExtractedLicensingInfos(catalog))
+}
@@ -0,0 +1,164 @@
+package spdx22tagvalue
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestSPDXTagValueDecoder(t *testing.T) {
+	app := pkg.Package{
+		Name:         "app",
+		Version:      "1.0.0",
+		Type:         pkg.JavaPkg,
+		Licenses:     []string{"Apache-2.0"},
+		PURL:         "pkg:maven/org.example/app@1.0.0",
+		CPEs:         []pkg.CPE{pkg.MustCPE("cpe:2.3:a:example:app:1.0.0:*:*:*:*:*:*:*")},
+		MetadataType: pkg.JavaMetadataType,
+		Metadata: pkg.JavaMetadata{
+			ArchiveDigests: []file.Digest{{Algorithm: "sha1", Value: "a1b2c3"}},
+		},
+	}
+	app.SetID()
+	lib := pkg.Package{
+		Name:    "lib",
+		Version: "2.0.0",
+		Type:    pkg.JavaPkg,
+		PURL:    "pkg:maven/org.example/lib@2.0.0",
+	}
+	lib.SetID()
+	jar := source.Coordinates{RealPath: "/app/app.jar"}
+
+	input := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(app, lib),
+			FileDigests: map[source.Coordinates][]file.Digest{
+				jar: {{Algorithm: "sha256", Value: "d4e5f6"}},
+			},
+		},
+		Relationships: []artifact.Relationship{
+			{From: app, To: lib, Type: artifact.DependsOnRelationship},
+			{From: app, To: jar, Type: artifact.ContainsRelationship},
+		},
+		Source: source.Metadata{Scheme: source.DirectoryScheme, Path: "/app"},
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, Format().Encode(&buffer, input))
+	require.NoError(t, Format().Validate(bytes.NewReader(buffer.Bytes())))
+
+	s, err := Format().Decode(bytes.NewReader(buffer.Bytes()))
+	require.NoError(t, err)
+
+	packages := make(map[string]pkg.Package)
+	for _, p := range s.Artifacts.PackageCatalog.Sorted() {
+		packages[p.Name] = p
+	}
+	require.Len(t, packages, 2)
+
+	decodedApp := packages["app"]
+	assert.Equal(t, "1.0.0", decodedApp.Version)
+	assert.Equal(t, app.PURL, decodedApp.PURL)
+	assert.Equal(t, []string{"Apache-2.0"}, decodedApp.Licenses)
+	require.Len(t, decodedApp.CPEs, 1)
+	assert.Equal(t, pkg.CPEString(app.CPEs[0]), pkg.CPEString(decodedApp.CPEs[0]))
+	require.IsType(t, pkg.JavaMetadata{}, decodedApp.Metadata)
+	assert.Equal(t, []file.Digest{{Algorithm: "sha1", Value: "a1b2c3"}}, decodedApp.Metadata.(pkg.JavaMetadata).ArchiveDigests)
+
+	assert.Equal(t, []file.Digest{{Algorithm: "sha256", Value: "d4e5f6"}}, s.Artifacts.FileDigests[jar])
+
+	var relationships []string
+	for _, r := range s.Relationships {
+		from, ok := r.From.(pkg.Package)
+		require.True(t, ok)
+		switch to := r.To.(type) {
+		case pkg.Package:
+			relationships = append(relationships, from.Name+" "+string(r.Type)+" "+to.Name)
+		case source.Coordinates:
+			relationships = append(relationships, from.Name+" "+string(r.Type)+" "+to.RealPath)
+		}
+	}
+	assert.ElementsMatch(t, []string{
+		"app DEPENDS_ON lib",
+		"app contains /app/app.jar",
+	}, relationships)
+}
+
+func TestSPDXTagValueDecoder_packageFiles(t *testing.T) {
+	// files listed after a package belong to the package
+	input := `SPDXVersion: SPDX-2.2
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: hello
+DocumentNamespace: https://example.com/hello
+Creator: Organization: Example
+Created: 2022-08-01T12:00:00Z
+
+PackageName: hello
+SPDXID: SPDXRef-Package-hello
+PackageVersion: 2.10
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: true
+PackageVerificationCode: 5c6d8e5ab1c6e6a1f2d5b0e4c5d9a8f1b2c3d4e5
+PackageLicenseConcluded: GPL-3.0-or-later
+PackageLicenseDeclared: GPL-3.0-or-later
+PackageCopyrightText: NOASSERTION
+ExternalRef: PACKAGE-MANAGER purl pkg:deb/debian/hello@2.10
+
+FileName: /usr/bin/hello
+SPDXID: SPDXRef-File-hello
+FileChecksum: SHA1: 20862a6d08391d07d09344029533ec644fac6b21
+LicenseConcluded: GPL-3.0-or-later
+FileCopyrightText: NOASSERTION
+`
+
+	s, err := Format().Decode(strings.NewReader(input))
+	require.NoError(t, err)
+
+	packages := s.Artifacts.PackageCatalog.Sorted()
+	require.Len(t, packages, 1)
+	assert.Equal(t, "pkg:deb/debian/hello@2.10", packages[0].PURL)
+	assert.Equal(t, []string{"GPL-3.0-or-later"}, packages[0].Licenses)
+
+	hello := source.Coordinates{RealPath: "/usr/bin/hello"}
+	assert.Equal(t, []file.Digest{{Algorithm: "sha1", Value: "20862a6d08391d07d09344029533ec644fac6b21"}}, s.Artifacts.FileDigests[hello])
+
+	require.Len(t, s.Relationships, 1)
+	assert.Equal(t, artifact.ContainsRelationship, s.Relationships[0].Type)
+	assert.Equal(t, packages[0].ID(), s.Relationships[0].From.ID())
+	assert.Equal(t, hello, s.Relationships[0].To)
+}
+
+func TestSPDXTagValueValidator(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "empty document",
+			input: "",
+		},
+		{
+			name:  "JSON document",
+			input: `{"spdxVersion": "SPDX-2.2"}`,
+		},
+		{
+			name:  "unknown tag",
+			input: "foo: bar\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Error(t, Format().Validate(strings.NewReader(test.input)))
+		})
+	}
+}
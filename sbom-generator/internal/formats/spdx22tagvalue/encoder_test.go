@@ -61,8 +61,9 @@ func TestSPDXJSONSPDXIDs(t *testing.T) {
 }
 
 func spdxTagValueRedactor(s []byte) []byte {
-	// each SBOM reports the time it was generated, which is not useful during snapshot testing
-	s = regexp.MustCompile(`Created: .*`).ReplaceAll(s, []byte("redacted"))
+	// each SBOM reports the time it was generated (and its packages were annotated), which is not useful during
+	// snapshot testing
+	s = regexp.MustCompile(`(Created|AnnotationDate): .*`).ReplaceAll(s, []byte("redacted"))
 
 	// each SBOM reports a unique documentNamespace when generated, this is not useful for snapshot testing
 	s = regexp.MustCompile(`DocumentNamespace: https://anchore.com/syft/.*`).ReplaceAll(s, []byte("redacted"))
@@ -6,7 +6,7 @@ import (
 
 const ID sbom.FormatID = "spdx-2-tag-value"
 
-// note: this format is LOSSY relative to the syftjson formation, which means that decoding and validation is not supported at this time
+// note: this format is LOSSY relative to the syftjson format
 func Format() sbom.Format {
 	return sbom.NewFormat(
 		ID,
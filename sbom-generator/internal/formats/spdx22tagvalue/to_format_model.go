@@ -2,15 +2,19 @@ package spdx22tagvalue
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/anchore/syft/syft/sbom"
 
 	"github.com/anchore/syft/internal"
 	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/internal/spdxlicense"
 	"github.com/anchore/syft/internal/version"
+	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
 	"github.com/spdx/tools-golang/spdx"
 )
 
@@ -18,6 +22,7 @@ import (
 // nolint:funlen
 func toFormatModel(s sbom.SBOM) *spdx.Document2_2 {
 	name, namespace := spdxhelpers.DocumentNameAndNamespace(s.Source)
+	created := time.Now().UTC().Format(time.RFC3339)
 
 	return &spdx.Document2_2{
 		CreationInfo: &spdx.CreationInfo2_2{
@@ -73,7 +78,7 @@ func toFormatModel(s sbom.SBOM) *spdx.Document2_2 {
 
 			// 2.9: Created: data format YYYY-MM-DDThh:mm:ssZ
 			// Cardinality: mandatory, one
-			Created: time.Now().UTC().Format(time.RFC3339),
+			Created: created,
 
 			// 2.10: Creator Comment
 			// Cardinality: optional, one
@@ -83,10 +88,56 @@ func toFormatModel(s sbom.SBOM) *spdx.Document2_2 {
 			// Cardinality: optional, one
 			DocumentComment: "",
 		},
-		Packages: toFormatPackages(s.Artifacts.PackageCatalog),
+		UnpackagedFiles: toFormatFiles(s),
+		Packages:        toFormatPackages(s.Artifacts.PackageCatalog),
+		OtherLicenses:   toFormatOtherLicenses(s.Artifacts.PackageCatalog),
+		Relationships:   toFormatRelationships(s.Relationships),
+		Annotations:     toFormatAnnotations(s.Artifacts.PackageCatalog, created),
 	}
 }
 
+// toFormatAnnotations records the metadata of the packages, which SPDX has no field for, as annotations made when the
+// document was created.
+func toFormatAnnotations(catalog *pkg.Catalog, created string) (results []*spdx.Annotation2_2) {
+	for _, p := range catalog.Sorted() {
+		comment, ok := spdxhelpers.MetadataAnnotation(p)
+		if !ok {
+			continue
+		}
+		results = append(results, &spdx.Annotation2_2{
+			Annotator:                spdxhelpers.MetadataAnnotator(),
+			AnnotatorType:            "Tool",
+			AnnotationDate:           created,
+			AnnotationType:           spdxhelpers.OtherAnnotationType,
+			AnnotationSPDXIdentifier: spdx.MakeDocElementID("", string(toPackageElementID(p))),
+			AnnotationComment:        comment,
+		})
+	}
+	return results
+}
+
+// toFormatOtherLicenses describes the licenses of the packages which are not on the SPDX license list, referenced by
+// the license expressions of the packages (see https://spdx.github.io/spdx-spec/other-licensing-information-detected/)
+func toFormatOtherLicenses(catalog *pkg.Catalog) []*spdx.OtherLicense2_2 {
+	var results []*spdx.OtherLicense2_2
+	for _, l := range spdxhelpers.OtherLicenses(catalog.Sorted()) {
+		results = append(results, &spdx.OtherLicense2_2{
+			// 6.1: License Identifier: "LicenseRef-[idstring]"
+			// Cardinality: conditional (mandatory, one) if license is not on SPDX License List
+			LicenseIdentifier: l.ID,
+
+			// 6.2: Extracted Text
+			// Cardinality: conditional (mandatory, one) if there is a License Identifier assigned
+			ExtractedText: l.Text,
+
+			// 6.3: License Name: single line of text or "NOASSERTION"
+			// Cardinality: conditional (mandatory, one) if license is not on SPDX License List
+			LicenseName: l.Text,
+		})
+	}
+	return results
+}
+
 // packages populates all Package Information from the package Catalog (see https://spdx.github.io/spdx-spec/3-package-information/)
 // nolint: funlen
 func toFormatPackages(catalog *pkg.Catalog) map[spdx.ElementID]*spdx.Package2_2 {
@@ -98,8 +149,7 @@ func toFormatPackages(catalog *pkg.Catalog) map[spdx.ElementID]*spdx.Package2_2
 	}
 
 	for _, p := range catalog.Sorted() {
-		// name should be guaranteed to be unique, but semantically useful and stable
-		id := spdxhelpers.SanitizeElementID(fmt.Sprintf("Package-%+v-%s-%s", p.Type, p.Name, p.ID()))
+		id := toPackageElementID(p)
 
 		// If the Concluded License is not the same as the Declared License, a written explanation should be provided
 		// in the Comments on License field (section 3.16). With respect to NOASSERTION, a written explanation in
@@ -116,15 +166,37 @@ func toFormatPackages(catalog *pkg.Catalog) map[spdx.ElementID]*spdx.Package2_2
 			if len(javaMetadata.ArchiveDigests) > 0 {
 				filesAnalyzed = true
 				for _, digest := range javaMetadata.ArchiveDigests {
-					checksums[spdx.ChecksumAlgorithm(digest.Algorithm)] = spdx.Checksum{
-						Algorithm: spdx.ChecksumAlgorithm(digest.Algorithm),
+					algorithm := spdx.ChecksumAlgorithm(strings.ToUpper(digest.Algorithm))
+					checksums[algorithm] = spdx.Checksum{
+						Algorithm: algorithm,
 						Value:     digest.Value,
 					}
 				}
 			}
+		} else if p.Type == pkg.GoModulePkg {
+			for _, digest := range pkg.GolangModuleDigests(p) {
+				algorithm := spdx.ChecksumAlgorithm(strings.ToUpper(digest.Algorithm))
+				checksums[algorithm] = spdx.Checksum{
+					Algorithm: algorithm,
+					Value:     digest.Value,
+				}
+			}
+		} else if p.MetadataType == pkg.PythonRequirementsMetadataType {
+			// tag-value documents hold a single checksum per algorithm: the hash of one of the pinned distributions
+			requirementsMetadata := p.Metadata.(pkg.PythonRequirementsMetadata)
+			for _, digest := range requirementsMetadata.Digests {
+				algorithm := spdx.ChecksumAlgorithm(strings.ToUpper(digest.Algorithm))
+				if _, exists := checksums[algorithm]; exists {
+					continue
+				}
+				checksums[algorithm] = spdx.Checksum{
+					Algorithm: algorithm,
+					Value:     digest.Value,
+				}
+			}
 		}
 
-		results[spdx.ElementID(id)] = &spdx.Package2_2{
+		results[id] = &spdx.Package2_2{
 
 			// NOT PART OF SPEC
 			// flag: does this "package" contain files that were in fact "unpackaged",
@@ -137,7 +209,7 @@ func toFormatPackages(catalog *pkg.Catalog) map[spdx.ElementID]*spdx.Package2_2
 
 			// 3.2: Package SPDX Identifier: "SPDXRef-[idstring]"
 			// Cardinality: mandatory, one
-			PackageSPDXIdentifier: spdx.ElementID(id),
+			PackageSPDXIdentifier: id,
 
 			// 3.3: Package Version
 			// Cardinality: optional, one
@@ -223,7 +295,7 @@ func toFormatPackages(catalog *pkg.Catalog) map[spdx.ElementID]*spdx.Package2_2
 			// Cardinality: mandatory, one
 			// Purpose: Contain the license the SPDX file creator has concluded as governing the
 			// package or alternative values, if the governing license cannot be determined.
-			PackageLicenseConcluded: license,
+			PackageLicenseConcluded: spdxhelpers.ConcludedLicense(p),
 
 			// 3.14: All Licenses Info from Files: SPDX License Expression, "NONE" or "NOASSERTION"
 			// Cardinality: mandatory, one or many if filesAnalyzed is true / omitted;
@@ -296,3 +368,108 @@ func formatSPDXExternalRefs(p pkg.Package, externalCounter *spdxhelpers.External
 	}
 	return refs
 }
+
+// toPackageElementID returns the SPDX identifier of a package: its name should be guaranteed to be unique, but
+// semantically useful and stable.
+func toPackageElementID(p pkg.Package) spdx.ElementID {
+	return spdx.ElementID(spdxhelpers.SanitizeElementID(fmt.Sprintf("Package-%+v-%s-%s", p.Type, p.Name, p.ID())))
+}
+
+func toFileElementID(coordinates source.Coordinates) spdx.ElementID {
+	return spdx.ElementID(spdxhelpers.SanitizeElementID(fmt.Sprintf("File-%s", coordinates.ID())))
+}
+
+func toElementID(identifiable artifact.Identifiable) spdx.ElementID {
+	switch it := identifiable.(type) {
+	case pkg.Package:
+		return toPackageElementID(it)
+	case source.Coordinates:
+		return toFileElementID(it)
+	case source.Location:
+		return toFileElementID(it.Coordinates)
+	}
+	return spdx.ElementID(spdxhelpers.SanitizeElementID(string(identifiable.ID())))
+}
+
+// toFormatFiles populates the File Information of the files found (see https://spdx.github.io/spdx-spec/4-file-information/),
+// which are written apart from the packages: a package is related to the files it contains by relationships.
+func toFormatFiles(s sbom.SBOM) map[spdx.ElementID]*spdx.File2_2 {
+	results := make(map[spdx.ElementID]*spdx.File2_2)
+	for _, coordinates := range sbom.AllCoordinates(s) {
+		var metadata *source.FileMetadata
+		if metadataForLocation, exists := s.Artifacts.FileMetadata[coordinates]; exists {
+			metadata = &metadataForLocation
+		}
+
+		checksums := make(map[spdx.ChecksumAlgorithm]spdx.Checksum)
+		for _, digest := range s.Artifacts.FileDigests[coordinates] {
+			algorithm := spdx.ChecksumAlgorithm(strings.ToUpper(digest.Algorithm))
+			checksums[algorithm] = spdx.Checksum{
+				Algorithm: algorithm,
+				Value:     digest.Value,
+			}
+		}
+
+		var comment string
+		if coordinates.FileSystemID != "" {
+			comment = fmt.Sprintf("layerID: %s", coordinates.FileSystemID)
+		}
+
+		id := toFileElementID(coordinates)
+		results[id] = &spdx.File2_2{
+			// 4.1: File Name
+			// Cardinality: mandatory, one
+			FileName: coordinates.RealPath,
+
+			// 4.2: File SPDX Identifier: "SPDXRef-[idstring]"
+			// Cardinality: mandatory, one
+			FileSPDXIdentifier: id,
+
+			// 4.3: File Types
+			// Cardinality: optional, multiple
+			FileType: spdxhelpers.FileTypes(metadata),
+
+			// 4.4: File Checksum: may have keys for SHA1, SHA256 and/or MD5
+			// Cardinality: mandatory, one SHA1, others may be optionally provided
+			FileChecksums: checksums,
+
+			// 4.5: Concluded License: SPDX License Expression, "NONE" or "NOASSERTION"
+			// Cardinality: mandatory, one
+			// no attempt is made to determine license information
+			LicenseConcluded: "NOASSERTION",
+
+			// 4.8: Copyright Text: copyright notice(s) text, "NONE" or "NOASSERTION"
+			// Cardinality: mandatory, one
+			FileCopyrightText: "NOASSERTION",
+
+			// 4.12: File Comment
+			// Cardinality: optional, one
+			FileComment: comment,
+		}
+	}
+	return results
+}
+
+// toFormatRelationships populates the Relationships between the packages and files (see https://spdx.github.io/spdx-spec/7-relationships-between-SPDX-elements/)
+func toFormatRelationships(relationships []artifact.Relationship) (results []*spdx.Relationship2_2) {
+	for _, r := range relationships {
+		exists, relationshipType, comment := spdxhelpers.LookupRelationship(r.Type)
+		if !exists {
+			log.Warnf("unable to convert relationship to SPDX 2.2 tag-value, dropping: %+v", r)
+			continue
+		}
+
+		from, to := r.From, r.To
+		if spdxhelpers.IsReverseRelationship(r.Type) {
+			from, to = to, from
+		}
+
+		results = append(results, &spdx.Relationship2_2{
+			RefA:                spdx.MakeDocElementID("", string(toElementID(from))),
+			RefB:                spdx.MakeDocElementID("", string(toElementID(to))),
+			Relationship:        string(relationshipType),
+			RelationshipComment: comment,
+		})
+	}
+	return results
+}
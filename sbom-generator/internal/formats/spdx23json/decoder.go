@@ -0,0 +1,31 @@
+package spdx23json
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/anchore/syft/internal/formats/common/spdx23helpers"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+func decoder(reader io.Reader) (*sbom.SBOM, error) {
+	doc, err := decode(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return spdx23helpers.ToSyftModel(doc)
+}
+
+func decode(reader io.Reader) (*spdx23helpers.Document, error) {
+	var doc spdx23helpers.Document
+	if err := json.NewDecoder(reader).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unable to decode spdx-json: %w", err)
+	}
+
+	if err := spdx23helpers.ValidateVersion(&doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
@@ -0,0 +1,55 @@
+package spdx23json
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/internal/formats/common/testutils"
+)
+
+func TestSPDX23JSONDecoder(t *testing.T) {
+	input := testutils.DirectoryInput(t)
+
+	var buffer bytes.Buffer
+	require.NoError(t, Format().Encode(&buffer, input))
+
+	require.NoError(t, Format().Validate(bytes.NewReader(buffer.Bytes())))
+
+	s, err := Format().Decode(bytes.NewReader(buffer.Bytes()))
+	require.NoError(t, err)
+
+	var packages []string
+	for _, p := range s.Artifacts.PackageCatalog.Sorted() {
+		packages = append(packages, p.Name+"@"+p.Version)
+	}
+	assert.ElementsMatch(t, []string{"package-1@1.0.1", "package-2@2.0.1"}, packages)
+}
+
+func TestSPDX23JSONValidator(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "SPDX 2.2 document",
+			input: `{"spdxVersion": "SPDX-2.2", "SPDXID": "SPDXRef-DOCUMENT", "packages": []}`,
+		},
+		{
+			name:  "not a SPDX document",
+			input: `{"bomFormat": "CycloneDX"}`,
+		},
+		{
+			name:  "not JSON",
+			input: "SPDXVersion: SPDX-2.3",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Error(t, Format().Validate(strings.NewReader(test.input)))
+		})
+	}
+}
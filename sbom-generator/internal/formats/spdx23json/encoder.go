@@ -0,0 +1,20 @@
+package spdx23json
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/anchore/syft/internal/formats/common/spdx23helpers"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+func encoder(output io.Writer, s sbom.SBOM) error {
+	doc := spdx23helpers.ToFormatModel(s)
+
+	enc := json.NewEncoder(output)
+	// prevent > and < from being escaped in the payload
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", " ")
+
+	return enc.Encode(doc)
+}
@@ -0,0 +1,332 @@
+package spdx23tagvalue
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/anchore/syft/internal/formats/common/spdx23helpers"
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+const (
+	textStart = "<text>"
+	textEnd   = "</text>"
+)
+
+func decoder(reader io.Reader) (*sbom.SBOM, error) {
+	doc, err := decode(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return spdx23helpers.ToSyftModel(doc)
+}
+
+func decode(reader io.Reader) (*spdx23helpers.Document, error) {
+	doc, err := parse(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode spdx-tag-value: %w", err)
+	}
+
+	if err := spdx23helpers.ValidateVersion(doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// tagValueParser reads a document in the tag-value format: "<tag>: <value>" lines, where the value may span several
+// lines when enclosed in <text> tags. The tags describe the document until the first package, file or other license,
+// and then the last one of those; the relationships and annotations are found anywhere.
+type tagValueParser struct {
+	doc          spdx23helpers.Document
+	pkg          *spdx23helpers.Package
+	file         *spdx23helpers.File
+	license      *spdx23helpers.ExtractedLicensingInfo
+	externalRef  *spdx23helpers.ExternalRef
+	relationship *spdx23helpers.Relationship
+	// the annotations read so far, with the ID of the element each comments on (given after its annotator)
+	annotations []annotation
+}
+
+type annotation struct {
+	spdx23helpers.Annotation
+	ref string
+}
+
+func parse(reader io.Reader) (*spdx23helpers.Document, error) {
+	p := &tagValueParser{}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	var lineNumber int
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, ":", 2)
+		if len(fields) != 2 || fields[0] == "" || strings.TrimSpace(fields[0]) != fields[0] {
+			return nil, fmt.Errorf("invalid tag-value line %d: %q", lineNumber, line)
+		}
+		tag, value := fields[0], strings.TrimSpace(fields[1])
+
+		// a value enclosed in <text> tags spans lines until the closing tag
+		if strings.HasPrefix(value, textStart) {
+			value = strings.TrimPrefix(value, textStart)
+			for !strings.HasSuffix(value, textEnd) {
+				if !scanner.Scan() {
+					return nil, fmt.Errorf("unterminated %s value of the %s tag at line %d", textStart, tag, lineNumber)
+				}
+				lineNumber++
+				value += "\n" + scanner.Text()
+			}
+			value = strings.TrimSuffix(value, textEnd)
+		}
+
+		if err := p.set(tag, value); err != nil {
+			return nil, fmt.Errorf("invalid %s tag at line %d: %w", tag, lineNumber, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	p.flush()
+	p.annotate()
+	return &p.doc, nil
+}
+
+// flush adds the package, file or other license being read to the document.
+func (p *tagValueParser) flush() {
+	switch {
+	case p.pkg != nil:
+		p.doc.Packages = append(p.doc.Packages, *p.pkg)
+	case p.file != nil:
+		p.doc.Files = append(p.doc.Files, *p.file)
+	case p.license != nil:
+		p.doc.HasExtractedLicensingInfos = append(p.doc.HasExtractedLicensingInfos, *p.license)
+	}
+	p.pkg, p.file, p.license, p.externalRef = nil, nil, nil, nil
+}
+
+// annotate adds the annotations to the packages they comment on (the annotations of other elements are left out).
+func (p *tagValueParser) annotate() {
+	for i := range p.doc.Packages {
+		for _, a := range p.annotations {
+			if a.ref == p.doc.Packages[i].SPDXID {
+				p.doc.Packages[i].Annotations = append(p.doc.Packages[i].Annotations, a.Annotation)
+			}
+		}
+	}
+}
+
+// nolint:funlen,gocognit
+func (p *tagValueParser) set(tag, value string) error {
+	switch tag {
+	// the sections of the document
+	case "PackageName":
+		p.flush()
+		p.pkg = &spdx23helpers.Package{Name: value}
+		// the files of a package are analyzed unless otherwise stated
+		p.pkg.FilesAnalyzed = true
+		return nil
+	case "FileName":
+		p.flush()
+		p.file = &spdx23helpers.File{FileName: value}
+		return nil
+	case "LicenseID":
+		p.flush()
+		p.license = &spdx23helpers.ExtractedLicensingInfo{LicenseID: value}
+		return nil
+	case "Relationship":
+		fields := strings.Fields(value)
+		if len(fields) != 3 {
+			return fmt.Errorf("expected <element> <type> <related element>, got %q", value)
+		}
+		p.doc.Relationships = append(p.doc.Relationships, spdx23helpers.Relationship{
+			SpdxElementID:      fields[0],
+			RelationshipType:   spdxhelpers.RelationshipType(fields[1]),
+			RelatedSpdxElement: fields[2],
+		})
+		p.relationship = &p.doc.Relationships[len(p.doc.Relationships)-1]
+		return nil
+	case "RelationshipComment":
+		if p.relationship != nil {
+			p.relationship.Comment = value
+		}
+		return nil
+	case "Annotator":
+		p.annotations = append(p.annotations, annotation{Annotation: spdx23helpers.Annotation{Annotator: value}})
+		return nil
+	case "AnnotationDate", "AnnotationType", "SPDXREF", "AnnotationComment":
+		p.setAnnotation(tag, value)
+		return nil
+	}
+
+	switch {
+	case p.pkg != nil:
+		return p.setPackage(tag, value)
+	case p.file != nil:
+		p.setFile(tag, value)
+	case p.license != nil:
+		p.setLicense(tag, value)
+	default:
+		p.setDocument(tag, value)
+	}
+	return nil
+}
+
+func (p *tagValueParser) setAnnotation(tag, value string) {
+	if len(p.annotations) == 0 {
+		return
+	}
+	a := &p.annotations[len(p.annotations)-1]
+	switch tag {
+	case "AnnotationDate":
+		a.AnnotationDate = value
+	case "AnnotationType":
+		a.AnnotationType = value
+	case "SPDXREF":
+		a.ref = value
+	case "AnnotationComment":
+		a.Comment = value
+	}
+}
+
+func (p *tagValueParser) setDocument(tag, value string) {
+	switch tag {
+	case "SPDXVersion":
+		p.doc.SPDXVersion = value
+	case "DataLicense":
+		p.doc.DataLicense = value
+	case "SPDXID":
+		p.doc.SPDXID = value
+	case "DocumentName":
+		p.doc.Name = value
+	case "DocumentNamespace":
+		p.doc.DocumentNamespace = value
+	case "DocumentComment":
+		p.doc.Comment = value
+	case "LicenseListVersion":
+		p.doc.CreationInfo.LicenseListVersion = value
+	case "Creator":
+		p.doc.CreationInfo.Creators = append(p.doc.CreationInfo.Creators, value)
+	case "Created":
+		p.doc.CreationInfo.Created = value
+	case "CreatorComment":
+		p.doc.CreationInfo.Comment = value
+	}
+}
+
+// nolint:funlen
+func (p *tagValueParser) setPackage(tag, value string) error {
+	switch tag {
+	case "SPDXID":
+		p.pkg.SPDXID = value
+	case "PackageVersion":
+		p.pkg.VersionInfo = value
+	case "PackageFileName":
+		p.pkg.PackageFileName = value
+	case "PackageSupplier":
+		p.pkg.Supplier = value
+	case "PackageOriginator":
+		p.pkg.Originator = value
+	case "PackageDownloadLocation":
+		p.pkg.DownloadLocation = value
+	case "PrimaryPackagePurpose":
+		p.pkg.PrimaryPackagePurpose = spdxhelpers.PackagePurpose(value)
+	case "ReleaseDate":
+		p.pkg.ReleaseDate = value
+	case "BuiltDate":
+		p.pkg.BuiltDate = value
+	case "FilesAnalyzed":
+		p.pkg.FilesAnalyzed = strings.EqualFold(value, "true")
+	case "PackageChecksum":
+		checksum, err := parseChecksum(value)
+		if err != nil {
+			return err
+		}
+		p.pkg.Checksums = append(p.pkg.Checksums, checksum)
+	case "PackageHomePage":
+		p.pkg.Homepage = value
+	case "PackageSourceInfo":
+		p.pkg.SourceInfo = value
+	case "PackageLicenseConcluded":
+		p.pkg.LicenseConcluded = value
+	case "PackageLicenseDeclared":
+		p.pkg.LicenseDeclared = value
+	case "PackageCopyrightText":
+		p.pkg.CopyrightText = value
+	case "PackageSummary":
+		p.pkg.Summary = value
+	case "PackageDescription":
+		p.pkg.Description = value
+	case "PackageComment":
+		p.pkg.Comment = value
+	case "ExternalRef":
+		fields := strings.Fields(value)
+		if len(fields) != 3 {
+			return fmt.Errorf("expected <category> <type> <locator>, got %q", value)
+		}
+		p.pkg.ExternalRefs = append(p.pkg.ExternalRefs, spdx23helpers.ExternalRef{
+			ReferenceCategory: spdxhelpers.ReferenceCategory(fields[0]),
+			ReferenceType:     spdxhelpers.ExternalRefType(fields[1]),
+			ReferenceLocator:  fields[2],
+		})
+		p.externalRef = &p.pkg.ExternalRefs[len(p.pkg.ExternalRefs)-1]
+	case "ExternalRefComment":
+		if p.externalRef != nil {
+			p.externalRef.Comment = value
+		}
+	}
+	return nil
+}
+
+func (p *tagValueParser) setFile(tag, value string) {
+	switch tag {
+	case "SPDXID":
+		p.file.SPDXID = value
+	case "FileType":
+		p.file.FileTypes = append(p.file.FileTypes, value)
+	case "FileChecksum":
+		// an invalid file checksum is left out, the file is still worth reading
+		if checksum, err := parseChecksum(value); err == nil {
+			p.file.Checksums = append(p.file.Checksums, checksum)
+		}
+	case "LicenseConcluded":
+		p.file.LicenseConcluded = value
+	case "FileCopyrightText":
+		p.file.CopyrightText = value
+	case "FileComment":
+		p.file.Comment = value
+	}
+}
+
+func (p *tagValueParser) setLicense(tag, value string) {
+	switch tag {
+	case "ExtractedText":
+		p.license.ExtractedText = value
+	case "LicenseName":
+		p.license.Name = value
+	case "LicenseComment":
+		p.license.Comment = value
+	}
+}
+
+// parseChecksum reads a "<algorithm>: <value>" checksum, e.g. "SHA256: 4c1b...".
+func parseChecksum(value string) (spdx23helpers.Checksum, error) {
+	fields := strings.SplitN(value, ":", 2)
+	if len(fields) != 2 {
+		return spdx23helpers.Checksum{}, fmt.Errorf("expected <algorithm>: <value>, got %q", value)
+	}
+	return spdx23helpers.Checksum{
+		Algorithm:     strings.TrimSpace(fields[0]),
+		ChecksumValue: strings.TrimSpace(fields[1]),
+	}, nil
+}
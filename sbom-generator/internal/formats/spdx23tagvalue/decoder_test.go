@@ -0,0 +1,183 @@
+package spdx23tagvalue
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/internal/formats/common/spdx23helpers"
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/anchore/syft/internal/formats/common/testutils"
+)
+
+func TestSPDX23TagValueDecoder(t *testing.T) {
+	input := testutils.DirectoryInput(t)
+
+	var buffer bytes.Buffer
+	require.NoError(t, Format().Encode(&buffer, input))
+
+	require.NoError(t, Format().Validate(bytes.NewReader(buffer.Bytes())))
+
+	s, err := Format().Decode(bytes.NewReader(buffer.Bytes()))
+	require.NoError(t, err)
+
+	var packages []string
+	for _, p := range s.Artifacts.PackageCatalog.Sorted() {
+		packages = append(packages, p.Name+"@"+p.Version)
+	}
+	assert.ElementsMatch(t, []string{"package-1@1.0.1", "package-2@2.0.1"}, packages)
+}
+
+func TestSPDX23TagValueValidator(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "SPDX 2.2 document",
+			input: "SPDXVersion: SPDX-2.2\nSPDXID: SPDXRef-DOCUMENT\n",
+		},
+		{
+			name:  "JSON document",
+			input: `{"spdxVersion": "SPDX-2.3"}`,
+		},
+		{
+			name:  "YAML document",
+			input: "spdxVersion: SPDX-2.3\ncreationInfo:\n  created: \"2022-01-01T00:00:00Z\"\n",
+		},
+		{
+			name:  "unterminated text",
+			input: "SPDXVersion: SPDX-2.3\nDocumentComment: <text>a comment\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Error(t, Format().Validate(strings.NewReader(test.input)))
+		})
+	}
+}
+
+func Test_parse(t *testing.T) {
+	input := `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: test
+DocumentNamespace: https://example.com/test
+Creator: Tool: syft-0.50.0
+Created: 2022-08-01T12:00:00Z
+DocumentComment: <text>a comment
+spanning lines</text>
+
+##### File: /usr/bin/bash
+
+FileName: /usr/bin/bash
+SPDXID: SPDXRef-File-1
+FileType: BINARY
+FileChecksum: SHA1: abcdef
+LicenseConcluded: NOASSERTION
+
+##### Package: bash
+
+PackageName: bash
+SPDXID: SPDXRef-Package-1
+PackageVersion: 5.1.8
+PackageSupplier: NOASSERTION
+PackageDownloadLocation: NOASSERTION
+PrimaryPackagePurpose: INSTALL
+BuiltDate: 2020-02-09T20:23:33Z
+FilesAnalyzed: false
+PackageChecksum: SHA256: 0123abcd
+PackageLicenseDeclared: GPL-3.0-or-later
+ExternalRef: PACKAGE-MANAGER purl pkg:rpm/openEuler/bash@5.1.8
+ExternalRefComment: the package url
+
+##### Other Licenses
+
+LicenseID: LicenseRef-Custom
+ExtractedText: <text>custom
+license</text>
+LicenseName: Custom
+
+##### Relationships
+
+Relationship: SPDXRef-Package-1 CONTAINS SPDXRef-File-1
+RelationshipComment: the binary
+
+##### Annotations
+
+Annotator: Tool: syft-0.50.0
+AnnotationDate: 2022-08-01T12:00:00Z
+AnnotationType: OTHER
+SPDXREF: SPDXRef-Package-1
+AnnotationComment: {"metadataType":"RpmMetadata"}
+`
+
+	expected := &spdx23helpers.Document{
+		SPDXID:      "SPDXRef-DOCUMENT",
+		Name:        "test",
+		SPDXVersion: "SPDX-2.3",
+		CreationInfo: spdx23helpers.CreationInfo{
+			Created:  "2022-08-01T12:00:00Z",
+			Creators: []string{"Tool: syft-0.50.0"},
+		},
+		DataLicense:       "CC0-1.0",
+		DocumentNamespace: "https://example.com/test",
+		Comment:           "a comment\nspanning lines",
+		HasExtractedLicensingInfos: []spdx23helpers.ExtractedLicensingInfo{
+			{LicenseID: "LicenseRef-Custom", ExtractedText: "custom\nlicense", Name: "Custom"},
+		},
+		Packages: []spdx23helpers.Package{
+			{
+				SPDXID:                "SPDXRef-Package-1",
+				Name:                  "bash",
+				VersionInfo:           "5.1.8",
+				Supplier:              "NOASSERTION",
+				DownloadLocation:      "NOASSERTION",
+				PrimaryPackagePurpose: spdxhelpers.InstallPackagePurpose,
+				BuiltDate:             "2020-02-09T20:23:33Z",
+				Checksums:             []spdx23helpers.Checksum{{Algorithm: "SHA256", ChecksumValue: "0123abcd"}},
+				LicenseDeclared:       "GPL-3.0-or-later",
+				ExternalRefs: []spdx23helpers.ExternalRef{
+					{
+						Comment:           "the package url",
+						ReferenceCategory: "PACKAGE-MANAGER",
+						ReferenceLocator:  "pkg:rpm/openEuler/bash@5.1.8",
+						ReferenceType:     spdxhelpers.PurlExternalRefType,
+					},
+				},
+				Annotations: []spdx23helpers.Annotation{
+					{
+						AnnotationDate: "2022-08-01T12:00:00Z",
+						AnnotationType: "OTHER",
+						Annotator:      "Tool: syft-0.50.0",
+						Comment:        `{"metadataType":"RpmMetadata"}`,
+					},
+				},
+			},
+		},
+		Files: []spdx23helpers.File{
+			{
+				SPDXID:           "SPDXRef-File-1",
+				FileName:         "/usr/bin/bash",
+				FileTypes:        []string{"BINARY"},
+				Checksums:        []spdx23helpers.Checksum{{Algorithm: "SHA1", ChecksumValue: "abcdef"}},
+				LicenseConcluded: "NOASSERTION",
+			},
+		},
+		Relationships: []spdx23helpers.Relationship{
+			{
+				SpdxElementID:      "SPDXRef-Package-1",
+				RelationshipType:   spdxhelpers.ContainsRelationship,
+				RelatedSpdxElement: "SPDXRef-File-1",
+				Comment:            "the binary",
+			},
+		},
+	}
+
+	actual, err := parse(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
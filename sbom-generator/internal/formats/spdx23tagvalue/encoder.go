@@ -0,0 +1,168 @@
+package spdx23tagvalue
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/anchore/syft/internal/formats/common/spdx23helpers"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+func encoder(output io.Writer, s sbom.SBOM) error {
+	doc := spdx23helpers.ToFormatModel(s)
+
+	w := &tagValueWriter{writer: bufio.NewWriter(output)}
+	w.writeDocument(doc)
+	if w.err != nil {
+		return w.err
+	}
+	return w.writer.Flush()
+}
+
+// tagValueWriter writes a document in the tag-value format, see
+// https://spdx.github.io/spdx-spec/v2.3/conformance/#44-standard-data-format-requirements
+type tagValueWriter struct {
+	writer *bufio.Writer
+	err    error
+}
+
+func (w *tagValueWriter) writeDocument(doc *spdx23helpers.Document) {
+	w.tag("SPDXVersion", doc.SPDXVersion)
+	w.tag("DataLicense", doc.DataLicense)
+	w.tag("SPDXID", doc.SPDXID)
+	w.tag("DocumentName", doc.Name)
+	w.tag("DocumentNamespace", doc.DocumentNamespace)
+	w.tag("LicenseListVersion", doc.CreationInfo.LicenseListVersion)
+	for _, creator := range doc.CreationInfo.Creators {
+		w.tag("Creator", creator)
+	}
+	w.tag("Created", doc.CreationInfo.Created)
+	w.text("CreatorComment", doc.CreationInfo.Comment)
+	w.text("DocumentComment", doc.Comment)
+
+	// the files are written before the packages: a file following a package is contained in it
+	for _, f := range doc.Files {
+		w.section("File: " + f.FileName)
+		w.tag("FileName", f.FileName)
+		w.tag("SPDXID", f.SPDXID)
+		for _, ty := range f.FileTypes {
+			w.tag("FileType", ty)
+		}
+		for _, c := range f.Checksums {
+			w.tag("FileChecksum", c.Algorithm+": "+c.ChecksumValue)
+		}
+		w.tag("LicenseConcluded", f.LicenseConcluded)
+		w.text("FileCopyrightText", f.CopyrightText)
+		w.text("FileComment", f.Comment)
+	}
+
+	for _, p := range doc.Packages {
+		w.section("Package: " + p.Name)
+		w.writePackage(p)
+	}
+
+	if len(doc.HasExtractedLicensingInfos) > 0 {
+		w.section("Other Licenses")
+		for i, l := range doc.HasExtractedLicensingInfos {
+			if i > 0 {
+				w.line("")
+			}
+			w.tag("LicenseID", l.LicenseID)
+			w.text("ExtractedText", l.ExtractedText)
+			w.tag("LicenseName", l.Name)
+			w.text("LicenseComment", l.Comment)
+		}
+	}
+
+	if len(doc.Relationships) > 0 {
+		w.section("Relationships")
+		for _, r := range doc.Relationships {
+			w.tag("Relationship", fmt.Sprintf("%s %s %s", r.SpdxElementID, r.RelationshipType, r.RelatedSpdxElement))
+			w.text("RelationshipComment", r.Comment)
+		}
+	}
+
+	// the annotations are written after everything they may refer to
+	var annotated []spdx23helpers.Package
+	for _, p := range doc.Packages {
+		if len(p.Annotations) > 0 {
+			annotated = append(annotated, p)
+		}
+	}
+	if len(annotated) > 0 {
+		w.section("Annotations")
+		for i, p := range annotated {
+			for j, a := range p.Annotations {
+				if i > 0 || j > 0 {
+					w.line("")
+				}
+				w.tag("Annotator", a.Annotator)
+				w.tag("AnnotationDate", a.AnnotationDate)
+				w.tag("AnnotationType", a.AnnotationType)
+				w.tag("SPDXREF", p.SPDXID)
+				w.text("AnnotationComment", a.Comment)
+			}
+		}
+	}
+}
+
+func (w *tagValueWriter) writePackage(p spdx23helpers.Package) {
+	w.tag("PackageName", p.Name)
+	w.tag("SPDXID", p.SPDXID)
+	w.tag("PackageVersion", p.VersionInfo)
+	w.tag("PackageFileName", p.PackageFileName)
+	w.tag("PackageSupplier", p.Supplier)
+	w.tag("PackageOriginator", p.Originator)
+	w.tag("PackageDownloadLocation", p.DownloadLocation)
+	w.tag("PrimaryPackagePurpose", string(p.PrimaryPackagePurpose))
+	w.tag("ReleaseDate", p.ReleaseDate)
+	w.tag("BuiltDate", p.BuiltDate)
+	w.tag("FilesAnalyzed", fmt.Sprintf("%t", p.FilesAnalyzed))
+	for _, c := range p.Checksums {
+		w.tag("PackageChecksum", c.Algorithm+": "+c.ChecksumValue)
+	}
+	w.tag("PackageHomePage", p.Homepage)
+	w.text("PackageSourceInfo", p.SourceInfo)
+	w.tag("PackageLicenseConcluded", p.LicenseConcluded)
+	w.tag("PackageLicenseDeclared", p.LicenseDeclared)
+	w.text("PackageCopyrightText", p.CopyrightText)
+	w.text("PackageSummary", p.Summary)
+	w.text("PackageDescription", p.Description)
+	w.text("PackageComment", p.Comment)
+	for _, ref := range p.ExternalRefs {
+		w.tag("ExternalRef", fmt.Sprintf("%s %s %s", ref.ReferenceCategory, ref.ReferenceType, ref.ReferenceLocator))
+		w.text("ExternalRefComment", ref.Comment)
+	}
+}
+
+// section starts a section of the document (e.g. a package) with a comment naming it.
+func (w *tagValueWriter) section(name string) {
+	w.line("")
+	w.line("##### " + name)
+	w.line("")
+}
+
+// tag writes a single line value, if any.
+func (w *tagValueWriter) tag(tag, value string) {
+	if value == "" {
+		return
+	}
+	w.line(tag + ": " + value)
+}
+
+// text writes a free form value, if any, which is enclosed in <text> tags when it spans several lines.
+func (w *tagValueWriter) text(tag, value string) {
+	if strings.Contains(value, "\n") {
+		value = textStart + value + textEnd
+	}
+	w.tag(tag, value)
+}
+
+func (w *tagValueWriter) line(value string) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = w.writer.WriteString(value + "\n")
+}
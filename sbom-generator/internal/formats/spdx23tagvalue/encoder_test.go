@@ -0,0 +1,32 @@
+package spdx23tagvalue
+
+import (
+	"flag"
+	"regexp"
+	"testing"
+
+	"github.com/anchore/syft/internal/formats/common/testutils"
+)
+
+var updateSpdxTagValue = flag.Bool("update-spdx-tv", false, "update the *.golden files for spdx-tv encoders")
+
+func TestSPDX23TagValueDirectoryEncoder(t *testing.T) {
+	testutils.AssertEncoderAgainstGoldenSnapshot(t,
+		Format(),
+		testutils.DirectoryInput(t),
+		*updateSpdxTagValue,
+		spdxTagValueRedactor,
+	)
+}
+
+func spdxTagValueRedactor(s []byte) []byte {
+	// each SBOM reports the time it was generated (and its packages were annotated), which is not useful during
+	// snapshot testing
+	s = regexp.MustCompile(`(Created|AnnotationDate): .*`).ReplaceAll(s, []byte("redacted"))
+
+	// each SBOM reports a unique documentNamespace when generated, this is not useful for snapshot testing
+	s = regexp.MustCompile(`DocumentNamespace: https://anchore.com/syft/.*`).ReplaceAll(s, []byte("redacted"))
+
+	// the license list will be updated periodically, the value here should not be directly tested in snapshot tests
+	return regexp.MustCompile(`LicenseListVersion: .*`).ReplaceAll(s, []byte("redacted"))
+}
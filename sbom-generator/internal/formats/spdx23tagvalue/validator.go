@@ -0,0 +1,10 @@
+package spdx23tagvalue
+
+import (
+	"io"
+)
+
+func validator(reader io.Reader) error {
+	_, err := decode(reader)
+	return err
+}
@@ -0,0 +1,37 @@
+package spdx23yaml
+
+import (
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/anchore/syft/internal/formats/common/spdx23helpers"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+func decoder(reader io.Reader) (*sbom.SBOM, error) {
+	doc, err := decode(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return spdx23helpers.ToSyftModel(doc)
+}
+
+func decode(reader io.Reader) (*spdx23helpers.Document, error) {
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read spdx-yaml: %w", err)
+	}
+
+	var doc spdx23helpers.Document
+	if err := yaml.Unmarshal(contents, &doc); err != nil {
+		return nil, fmt.Errorf("unable to decode spdx-yaml: %w", err)
+	}
+
+	if err := spdx23helpers.ValidateVersion(&doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
@@ -0,0 +1,23 @@
+package spdx23yaml
+
+import (
+	"io"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/anchore/syft/internal/formats/common/spdx23helpers"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+func encoder(output io.Writer, s sbom.SBOM) error {
+	doc := spdx23helpers.ToFormatModel(s)
+
+	// the fields are named as in the SPDX JSON documents
+	contents, err := yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	_, err = output.Write(contents)
+	return err
+}
@@ -0,0 +1,32 @@
+package spdx23yaml
+
+import (
+	"flag"
+	"regexp"
+	"testing"
+
+	"github.com/anchore/syft/internal/formats/common/testutils"
+)
+
+var updateSpdxYaml = flag.Bool("update-spdx-yaml", false, "update the *.golden files for spdx-yaml encoders")
+
+func TestSPDX23YAMLDirectoryEncoder(t *testing.T) {
+	testutils.AssertEncoderAgainstGoldenSnapshot(t,
+		Format(),
+		testutils.DirectoryInput(t),
+		*updateSpdxYaml,
+		spdxYamlRedactor,
+	)
+}
+
+func spdxYamlRedactor(s []byte) []byte {
+	// each SBOM reports the time it was generated (and its packages were annotated), which is not useful during
+	// snapshot testing
+	s = regexp.MustCompile(`(created|annotationDate): .*`).ReplaceAll(s, []byte("redacted"))
+
+	// each SBOM reports a unique documentNamespace when generated, this is not useful for snapshot testing
+	s = regexp.MustCompile(`documentNamespace: .*`).ReplaceAll(s, []byte("redacted"))
+
+	// the license list will be updated periodically, the value here should not be directly tested in snapshot tests
+	return regexp.MustCompile(`licenseListVersion: .*`).ReplaceAll(s, []byte("redacted"))
+}
@@ -0,0 +1,59 @@
+package spdx30json
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/anchore/syft/internal/formats/spdx30json/model"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+func decoder(reader io.Reader) (*sbom.SBOM, error) {
+	doc, err := decode(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSyftModel(doc)
+}
+
+func decode(reader io.Reader) (*model.Document, error) {
+	var doc model.Document
+	if err := json.NewDecoder(reader).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unable to decode spdx-json: %w", err)
+	}
+
+	if version := specVersion(&doc); !strings.HasPrefix(version, "3.0") {
+		return nil, fmt.Errorf("unsupported SPDX version: %q", version)
+	}
+	return &doc, nil
+}
+
+// specVersion returns the version of the SPDX specification a document is written against, as recorded by the
+// creation info of its SpdxDocument element.
+func specVersion(doc *model.Document) string {
+	creationInfos := make(map[string]*model.CreationInfo)
+	var document *model.SpdxDocument
+	for _, element := range doc.Graph {
+		switch e := element.(type) {
+		case *model.CreationInfo:
+			creationInfos[e.ID] = e
+		case *model.SpdxDocument:
+			document = e
+		}
+	}
+	if document == nil {
+		return ""
+	}
+
+	info := document.CreationInfo.Info
+	if info == nil {
+		info = creationInfos[document.CreationInfo.ID]
+	}
+	if info == nil {
+		return ""
+	}
+	return info.SpecVersion
+}
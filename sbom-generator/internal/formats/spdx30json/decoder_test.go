@@ -0,0 +1,101 @@
+package spdx30json
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/internal/formats/common/testutils"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestSPDX30JSONDecoder(t *testing.T) {
+	input := testutils.DirectoryInput(t)
+
+	var buffer bytes.Buffer
+	require.NoError(t, Format().Encode(&buffer, input))
+
+	require.NoError(t, Format().Validate(bytes.NewReader(buffer.Bytes())))
+
+	s, err := Format().Decode(bytes.NewReader(buffer.Bytes()))
+	require.NoError(t, err)
+
+	assert.Equal(t, source.DirectoryScheme, s.Source.Scheme)
+
+	var packages []string
+	for _, p := range s.Artifacts.PackageCatalog.Sorted() {
+		packages = append(packages, p.Name+"@"+p.Version)
+	}
+	assert.ElementsMatch(t, []string{"package-1@1.0.1", "package-2@2.0.1"}, packages)
+}
+
+func TestSPDX30JSONDecoder_inlineCreationInfo(t *testing.T) {
+	// the creation info may be written within the elements, and the elements of other profiles are left out
+	input := `{
+ "@context": "https://spdx.org/rdf/3.0.1/spdx-context.jsonld",
+ "@graph": [
+  {
+   "type": "SpdxDocument",
+   "spdxId": "https://example.com/doc#SpdxDocument",
+   "creationInfo": {"type": "CreationInfo", "specVersion": "3.0.0", "created": "2024-01-01T00:00:00Z", "createdBy": []},
+   "profileConformance": ["core", "software"]
+  },
+  {
+   "type": "software_Package",
+   "spdxId": "https://example.com/doc#zlib",
+   "creationInfo": "_:creationinfo",
+   "name": "zlib",
+   "software_packageVersion": "1.2.12",
+   "software_packageUrl": "pkg:apk/alpine/zlib@1.2.12?distro=alpine-3.16"
+  },
+  {
+   "type": "security_Vulnerability",
+   "spdxId": "https://example.com/doc#CVE-2022-37434",
+   "creationInfo": "_:creationinfo"
+  }
+ ]
+}`
+
+	s, err := Format().Decode(strings.NewReader(input))
+	require.NoError(t, err)
+
+	packages := s.Artifacts.PackageCatalog.Sorted()
+	require.Len(t, packages, 1)
+	assert.Equal(t, "zlib", packages[0].Name)
+	assert.Equal(t, "alpine", s.Artifacts.LinuxDistribution.ID)
+}
+
+func TestSPDX30JSONValidator(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "SPDX 2.3 document",
+			input: `{"spdxVersion": "SPDX-2.3", "SPDXID": "SPDXRef-DOCUMENT", "packages": []}`,
+		},
+		{
+			name:  "no SpdxDocument element",
+			input: `{"@context": "https://spdx.org/rdf/3.0.1/spdx-context.jsonld", "@graph": [{"type": "CreationInfo", "@id": "_:creationinfo", "specVersion": "3.0.1"}]}`,
+		},
+		{
+			name: "SPDX 2.x version",
+			input: `{"@graph": [
+				{"type": "CreationInfo", "@id": "_:creationinfo", "specVersion": "2.3"},
+				{"type": "SpdxDocument", "spdxId": "https://example.com/doc", "creationInfo": "_:creationinfo"}
+			]}`,
+		},
+		{
+			name:  "not JSON",
+			input: "SPDXVersion: SPDX-2.3",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Error(t, Format().Validate(strings.NewReader(test.input)))
+		})
+	}
+}
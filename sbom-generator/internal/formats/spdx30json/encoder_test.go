@@ -0,0 +1,33 @@
+package spdx30json
+
+import (
+	"flag"
+	"regexp"
+	"testing"
+
+	"github.com/anchore/syft/internal/formats/common/testutils"
+)
+
+var updateSpdxJson = flag.Bool("update-spdx-json", false, "update the *.golden files for spdx-json encoders")
+
+func TestSPDX30JSONDirectoryEncoder(t *testing.T) {
+	testutils.AssertEncoderAgainstGoldenSnapshot(t,
+		Format(),
+		testutils.DirectoryInput(t),
+		*updateSpdxJson,
+		spdxJsonRedactor,
+	)
+}
+
+func spdxJsonRedactor(s []byte) []byte {
+	// each SBOM reports the time it was generated, which is not useful during snapshot testing
+	s = regexp.MustCompile(`"created": .*`).ReplaceAll(s, []byte("redacted"))
+
+	// each SBOM has a unique namespace the IDs of its elements are in, this is not useful for snapshot testing
+	s = regexp.MustCompile(`https://anchore.com/syft/[^#"]*`).ReplaceAll(s, []byte("redacted"))
+
+	// the tool version and the license list will be updated periodically, the values here should not be directly
+	// tested in snapshot tests
+	s = regexp.MustCompile(`"name": "syft-.*"`).ReplaceAll(s, []byte("redacted"))
+	return regexp.MustCompile(`"simplelicensing_licenseListVersion": .*`).ReplaceAll(s, []byte("redacted"))
+}
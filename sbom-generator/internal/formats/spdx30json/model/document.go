@@ -0,0 +1,103 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// Context is the JSON-LD context of SPDX 3.0 documents, which maps the properties of the elements to the SPDX model.
+	Context = "https://spdx.org/rdf/3.0.1/spdx-context.jsonld"
+
+	// SpecVersion is the version of the SPDX specification the documents are written against.
+	SpecVersion = "3.0.1"
+)
+
+// the types of the elements of the graph
+const (
+	CreationInfoType                = "CreationInfo"
+	SpdxDocumentType                = "SpdxDocument"
+	OrganizationType                = "Organization"
+	PersonType                      = "Person"
+	ToolType                        = "Tool"
+	PackageType                     = "software_Package"
+	FileType                        = "software_File"
+	RelationshipType                = "Relationship"
+	LifecycleScopedRelationshipType = "LifecycleScopedRelationship"
+	LicenseExpressionType           = "simplelicensing_LicenseExpression"
+	SimpleLicensingTextType         = "simplelicensing_SimpleLicensingText"
+	AnnotationType                  = "Annotation"
+)
+
+// Document is a SPDX 3.0 JSON-LD document: the graph of the elements (the creation info, the SpdxDocument, the
+// packages, files, relationships...) the document is made of, as pointers to the model types.
+type Document struct {
+	Context interface{}   `json:"@context"`
+	Graph   []interface{} `json:"@graph"`
+}
+
+// UnmarshalJSON reads the elements of the graph as the model type of their type, the elements of other types (e.g.
+// of profiles the model has no place for) are left out.
+func (d *Document) UnmarshalJSON(data []byte) error {
+	var doc struct {
+		Context interface{}       `json:"@context"`
+		Graph   []json.RawMessage `json:"@graph"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	d.Context = doc.Context
+	d.Graph = nil
+	for _, raw := range doc.Graph {
+		element, err := unmarshalElement(raw)
+		if err != nil {
+			return err
+		}
+		if element != nil {
+			d.Graph = append(d.Graph, element)
+		}
+	}
+	return nil
+}
+
+// nolint:funlen
+func unmarshalElement(data json.RawMessage) (interface{}, error) {
+	var head struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, err
+	}
+
+	var element interface{}
+	switch head.Type {
+	case CreationInfoType:
+		element = &CreationInfo{}
+	case SpdxDocumentType:
+		element = &SpdxDocument{}
+	case OrganizationType, PersonType:
+		element = &Agent{}
+	case ToolType:
+		element = &Tool{}
+	case PackageType:
+		element = &Package{}
+	case FileType:
+		element = &File{}
+	case RelationshipType, LifecycleScopedRelationshipType:
+		element = &Relationship{}
+	case LicenseExpressionType:
+		element = &LicenseExpression{}
+	case SimpleLicensingTextType:
+		element = &SimpleLicensingText{}
+	case AnnotationType:
+		element = &Annotation{}
+	default:
+		return nil, nil
+	}
+
+	if err := json.Unmarshal(data, element); err != nil {
+		return nil, fmt.Errorf("unable to decode %s element: %w", head.Type, err)
+	}
+	return element, nil
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Element holds the properties common to all the elements of a document.
+type Element struct {
+	Type                string               `json:"type"`
+	SpdxID              string               `json:"spdxId"`
+	CreationInfo        CreationInfoRef      `json:"creationInfo"`
+	Name                string               `json:"name,omitempty"`
+	Summary             string               `json:"summary,omitempty"`
+	Description         string               `json:"description,omitempty"`
+	Comment             string               `json:"comment,omitempty"`
+	VerifiedUsing       []Hash               `json:"verifiedUsing,omitempty"`
+	ExternalIdentifiers []ExternalIdentifier `json:"externalIdentifier,omitempty"`
+}
+
+// CreationInfo describes when and by whom the elements were created. It is shared by the elements of a document,
+// which reference it by its blank node ID (e.g. "_:creationinfo").
+type CreationInfo struct {
+	Type         string   `json:"type"`
+	ID           string   `json:"@id,omitempty"`
+	SpecVersion  string   `json:"specVersion"`
+	Created      string   `json:"created"`
+	CreatedBy    []string `json:"createdBy"`
+	CreatedUsing []string `json:"createdUsing,omitempty"`
+	Comment      string   `json:"comment,omitempty"`
+}
+
+// CreationInfoRef is the creation info of an element: the ID of a CreationInfo of the graph, or (as JSON-LD allows) the
+// creation info itself.
+type CreationInfoRef struct {
+	ID   string
+	Info *CreationInfo
+}
+
+func (r CreationInfoRef) MarshalJSON() ([]byte, error) {
+	if r.Info != nil {
+		return json.Marshal(r.Info)
+	}
+	return json.Marshal(r.ID)
+}
+
+func (r *CreationInfoRef) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		r.Info = &CreationInfo{}
+		return json.Unmarshal(data, r.Info)
+	}
+	return json.Unmarshal(data, &r.ID)
+}
+
+// Hash is a digest of an artifact, the algorithm is named in lowercase (e.g. "sha256").
+type Hash struct {
+	Type      string `json:"type"`
+	Algorithm string `json:"algorithm"`
+	HashValue string `json:"hashValue"`
+}
+
+// ExternalIdentifier is an identifier of an element defined outside of the document, e.g. a CPE.
+type ExternalIdentifier struct {
+	Type                   string `json:"type"`
+	ExternalIdentifierType string `json:"externalIdentifierType"`
+	Identifier             string `json:"identifier"`
+	Comment                string `json:"comment,omitempty"`
+}
+
+// SpdxDocument is the root of the document: it names the elements the document is about (the root elements), and
+// lists all the elements it is made of.
+type SpdxDocument struct {
+	Element
+	ProfileConformance []string `json:"profileConformance"`
+	RootElements       []string `json:"rootElement,omitempty"`
+	Elements           []string `json:"element,omitempty"`
+}
+
+// Agent is a person or an organization, e.g. the supplier of a package.
+type Agent struct {
+	Element
+}
+
+// Tool is a tool the elements were created with.
+type Tool struct {
+	Element
+}
+
+// Annotation is a comment on an element, see https://spdx.github.io/spdx-spec/v3.0.1/model/Core/Classes/Annotation/
+type Annotation struct {
+	Element
+	// AnnotationType is either "review" or "other"
+	AnnotationType string `json:"annotationType"`
+	Subject        string `json:"subject"`
+	Statement      string `json:"statement,omitempty"`
+}
@@ -0,0 +1,25 @@
+package model
+
+// LicenseExpression is the license of an artifact, as a SPDX license expression, see
+// https://spdx.github.io/spdx-spec/v3.0.1/model/SimpleLicensing/Classes/LicenseExpression/
+type LicenseExpression struct {
+	Element
+	LicenseExpression  string `json:"simplelicensing_licenseExpression"`
+	LicenseListVersion string `json:"simplelicensing_licenseListVersion,omitempty"`
+	// CustomIDToURI maps the licenses which are not on the SPDX license list ("LicenseRef-...") to the elements
+	// holding their text
+	CustomIDToURI []DictionaryEntry `json:"simplelicensing_customIdToUri,omitempty"`
+}
+
+// SimpleLicensingText is the text of a license which is not on the SPDX license list.
+type SimpleLicensingText struct {
+	Element
+	LicenseText string `json:"simplelicensing_licenseText"`
+}
+
+// DictionaryEntry is a key-value pair.
+type DictionaryEntry struct {
+	Type  string `json:"type"`
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
@@ -0,0 +1,15 @@
+package model
+
+// Relationship relates an element to one or more elements, see
+// https://spdx.github.io/spdx-spec/v3.0.1/model/Core/Classes/Relationship/
+type Relationship struct {
+	Element
+	From             string   `json:"from"`
+	To               []string `json:"to"`
+	RelationshipType string   `json:"relationshipType"`
+	// Completeness tells whether the related elements are all the elements the element is related to: "complete",
+	// "incomplete" or "noAssertion"
+	Completeness string `json:"completeness,omitempty"`
+	// Scope is the lifecycle phase a LifecycleScopedRelationship applies to, e.g. "build" or "runtime"
+	Scope string `json:"scope,omitempty"`
+}
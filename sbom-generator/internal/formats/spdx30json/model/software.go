@@ -0,0 +1,31 @@
+package model
+
+// Artifact holds the properties common to the artifacts (the packages and files) of a document.
+type Artifact struct {
+	Element
+	SuppliedBy   string   `json:"suppliedBy,omitempty"`
+	OriginatedBy []string `json:"originatedBy,omitempty"`
+	ReleaseTime  string   `json:"releaseTime,omitempty"`
+	BuiltTime    string   `json:"builtTime,omitempty"`
+	// see https://spdx.github.io/spdx-spec/v3.0.1/model/Software/Vocabularies/SoftwarePurpose/
+	PrimaryPurpose string `json:"software_primaryPurpose,omitempty"`
+	CopyrightText  string `json:"software_copyrightText,omitempty"`
+}
+
+// Package is a software package, see https://spdx.github.io/spdx-spec/v3.0.1/model/Software/Classes/Package/
+type Package struct {
+	Artifact
+	PackageVersion   string `json:"software_packageVersion,omitempty"`
+	PackageURL       string `json:"software_packageUrl,omitempty"`
+	DownloadLocation string `json:"software_downloadLocation,omitempty"`
+	HomePage         string `json:"software_homePage,omitempty"`
+	SourceInfo       string `json:"software_sourceInfo,omitempty"`
+}
+
+// File is a file or a directory, see https://spdx.github.io/spdx-spec/v3.0.1/model/Software/Classes/File/
+type File struct {
+	Artifact
+	// FileKind is either "file" or "directory"
+	FileKind    string `json:"software_fileKind,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
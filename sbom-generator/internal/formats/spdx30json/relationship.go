@@ -0,0 +1,105 @@
+package spdx30json
+
+import (
+	"strings"
+
+	"github.com/anchore/syft/syft/artifact"
+)
+
+// the lifecycle phases a dependency applies to, see
+// https://spdx.github.io/spdx-spec/v3.0.1/model/Core/Vocabularies/LifecycleScopeType/
+const (
+	buildScope       = "build"
+	developmentScope = "development"
+	runtimeScope     = "runtime"
+)
+
+// noAssertionCompleteness tells that nothing is known of the completeness of a relationship: syft relates the
+// packages and files it finds, which may not be all the elements related in the source.
+const noAssertionCompleteness = "noAssertion"
+
+// relationshipMapping is how a syft relationship is expressed in SPDX 3.0.
+type relationshipMapping struct {
+	syftType artifact.RelationshipType
+	// see https://spdx.github.io/spdx-spec/v3.0.1/model/Core/Vocabularies/RelationshipType/
+	relationshipType string
+	scope            string
+	// reverse indicates the relationship is expressed from the child to the parent, e.g. a package which is a
+	// dependency of another one is related to it by a "dependsOn" relationship from the other package
+	reverse bool
+	// comment qualifies the relationships which share a relationship type, it starts with the syft relationship type
+	comment string
+}
+
+// relationshipMappings are looked up in order when decoding: the relationships which share a relationship type are
+// told apart by their scope and comment, and are otherwise read as the first one of them.
+var relationshipMappings = []relationshipMapping{
+	{syftType: artifact.ContainsRelationship, relationshipType: "contains"},
+	{
+		syftType:         artifact.DependencyOfRelationship,
+		relationshipType: "dependsOn",
+		reverse:          true,
+		comment:          "dependency-of: this package depends on the related packages",
+	},
+	{syftType: artifact.DependsOnRelationship, relationshipType: "dependsOn"},
+	{syftType: artifact.RuntimeDependencyOfRelationship, relationshipType: "dependsOn", scope: runtimeScope, reverse: true},
+	{syftType: artifact.DevDependencyOfRelationship, relationshipType: "dependsOn", scope: developmentScope, reverse: true},
+	{syftType: artifact.BuildDependencyOfRelationship, relationshipType: "dependsOn", scope: buildScope, reverse: true},
+	{syftType: artifact.HasPrerequisiteRelationship, relationshipType: "hasPrerequisite"},
+	{
+		syftType:         artifact.RecommendsRelationship,
+		relationshipType: "hasOptionalDependency",
+		comment:          "recommends: the related packages are installed along by default",
+	},
+	{
+		syftType:         artifact.SuggestsRelationship,
+		relationshipType: "hasOptionalDependency",
+		comment:          "suggests: the related packages are not installed by default",
+	},
+	{
+		syftType:         artifact.SupplementsRelationship,
+		relationshipType: "hasOptionalDependency",
+		reverse:          true,
+		comment:          "supplements: this package is installed along with the related packages by default",
+	},
+	{
+		syftType:         artifact.EnhancesRelationship,
+		relationshipType: "hasOptionalDependency",
+		reverse:          true,
+		comment:          "enhances: this package is not installed along with the related packages by default",
+	},
+	{syftType: artifact.OptionalDependencyOfRelationship, relationshipType: "hasOptionalDependency", reverse: true},
+	{
+		syftType:         artifact.OwnershipByFileOverlapRelationship,
+		relationshipType: "other",
+		comment:          "ownership-by-file-overlap: this package claims ownership of the related packages since its metadata indicates overlap with a location the related packages were found by",
+	},
+}
+
+// lookupRelationship returns how a syft relationship is expressed in SPDX 3.0, if it can be.
+func lookupRelationship(ty artifact.RelationshipType) (relationshipMapping, bool) {
+	for _, m := range relationshipMappings {
+		if m.syftType == ty {
+			return m, true
+		}
+	}
+	return relationshipMapping{}, false
+}
+
+// lookupSyftRelationship returns the syft relationship a SPDX 3.0 relationship is read as, if any.
+func lookupSyftRelationship(relationshipType, scope, comment string) (relationshipMapping, bool) {
+	for _, m := range relationshipMappings {
+		if m.relationshipType != relationshipType || m.scope != scope {
+			continue
+		}
+		if m.comment != "" && !strings.HasPrefix(comment, string(m.syftType)+":") {
+			continue
+		}
+		return m, true
+	}
+	if scope != "" {
+		// the dependencies of other lifecycle phases (e.g. "test") are read as unscoped ones
+		return lookupSyftRelationship(relationshipType, "", comment)
+	}
+	return relationshipMapping{}, false
+}
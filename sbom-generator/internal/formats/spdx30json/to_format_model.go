@@ -0,0 +1,444 @@
+package spdx30json
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/anchore/syft/internal/formats/spdx30json/model"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/spdxlicense"
+	"github.com/anchore/syft/internal/version"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+const (
+	// creationInfoID is the blank node ID of the creation info shared by all the elements of a document
+	creationInfoID = "_:creationinfo"
+
+	dateLayout = "2006-01-02T15:04:05Z"
+
+	hasDeclaredLicenseRelationship  = "hasDeclaredLicense"
+	hasConcludedLicenseRelationship = "hasConcludedLicense"
+
+	packageURLIdentifierType = "packageUrl"
+	otherAnnotationType      = "other"
+)
+
+// the profiles the documents conform to, see https://spdx.github.io/spdx-spec/v3.0.1/model/Core/Vocabularies/ProfileIdentifierType/
+var profileConformance = []string{"core", "software", "simpleLicensing"}
+
+// graphBuilder builds the graph of the elements of a document, the IDs of which are IRIs in the namespace of the
+// document (e.g. "https://anchore.com/syft/dir/some/path-<uuid>#Package-<id>").
+type graphBuilder struct {
+	namespace string
+	graph     []interface{}
+	// ids are the IDs of the elements of the document, in the order they are added to the graph
+	ids []string
+	// agents are the IDs of the persons and organizations, by their name as written by SPDX 2 (e.g. "Person: ...")
+	agents map[string]string
+	// licenses are the IDs of the license expressions, by expression
+	licenses map[string]string
+	// licenseRefs are the IDs of the texts of the licenses which are not on the SPDX license list, by license ID
+	licenseRefs map[string]string
+	// relationships are the indexes of the relationships in the graph, by what they relate the elements from
+	relationships map[relationshipKey]int
+}
+
+type relationshipKey struct {
+	from    string
+	mapping relationshipMapping
+}
+
+// toFormatModel creates and populates a new document that follows the SPDX 3.0 spec from the given cataloging results.
+func toFormatModel(s sbom.SBOM) *model.Document {
+	name, namespace := spdxhelpers.DocumentNameAndNamespace(s.Source)
+
+	b := &graphBuilder{
+		namespace:     namespace,
+		agents:        make(map[string]string),
+		licenses:      make(map[string]string),
+		licenseRefs:   make(map[string]string),
+		relationships: make(map[relationshipKey]int),
+	}
+
+	organization := b.agent("Organization: Anchore, Inc")
+	tool := b.id("Tool", internal.ApplicationName)
+	b.add(tool, &model.Tool{
+		Element: b.element(model.ToolType, tool, internal.ApplicationName+"-"+version.FromBuild().Version),
+	})
+
+	var rootElements []string
+	if root := b.addSource(s.Source); root != "" {
+		rootElements = append(rootElements, root)
+	}
+
+	packages := s.Artifacts.PackageCatalog.Sorted()
+	b.addLicenseRefs(packages)
+	for _, p := range packages {
+		b.addPackage(p)
+	}
+
+	b.addFiles(s)
+
+	for _, r := range s.Relationships {
+		b.addRelationship(r)
+	}
+
+	document := model.SpdxDocument{
+		Element:            b.element(model.SpdxDocumentType, b.id("SpdxDocument", "DOCUMENT"), name),
+		ProfileConformance: profileConformance,
+		RootElements:       rootElements,
+		Elements:           b.ids,
+	}
+
+	return &model.Document{
+		Context: model.Context,
+		Graph: append([]interface{}{
+			&model.CreationInfo{
+				Type:         model.CreationInfoType,
+				ID:           creationInfoID,
+				SpecVersion:  model.SpecVersion,
+				Created:      formatDate(time.Now()),
+				CreatedBy:    []string{organization},
+				CreatedUsing: []string{tool},
+			},
+			&document,
+		}, b.graph...),
+	}
+}
+
+// id returns the ID of an element: the namespace of the document, followed by the kind of element and its ID
+// (sanitized).
+func (b *graphBuilder) id(kind, id string) string {
+	return fmt.Sprintf("%s#%s-%s", b.namespace, kind, spdxhelpers.SanitizeElementID(id))
+}
+
+func (b *graphBuilder) element(ty, id, name string) model.Element {
+	return model.Element{
+		Type:         ty,
+		SpdxID:       id,
+		CreationInfo: model.CreationInfoRef{ID: creationInfoID},
+		Name:         name,
+	}
+}
+
+func (b *graphBuilder) add(id string, element interface{}) {
+	b.ids = append(b.ids, id)
+	b.graph = append(b.graph, element)
+}
+
+// agent returns the ID of a person or an organization (e.g. "Person: Jane Doe" or "Organization: ACME"), which is
+// added to the graph the first time, if any.
+func (b *graphBuilder) agent(value string) string {
+	var ty, name string
+	switch {
+	case strings.HasPrefix(value, "Person: "):
+		ty, name = model.PersonType, strings.TrimPrefix(value, "Person: ")
+	case strings.HasPrefix(value, "Organization: "):
+		ty, name = model.OrganizationType, strings.TrimPrefix(value, "Organization: ")
+	}
+	if name == "" {
+		return ""
+	}
+
+	if id, exists := b.agents[value]; exists {
+		return id
+	}
+	id := b.id(ty, name)
+	b.agents[value] = id
+	b.add(id, &model.Agent{Element: b.element(ty, id, name)})
+	return id
+}
+
+// addSource adds the element the document is about (its root element): the image as a container package, or the
+// directory or file, if any.
+func (b *graphBuilder) addSource(src source.Metadata) string {
+	switch src.Scheme {
+	case source.ImageScheme:
+		id := b.id("Image", src.ImageMetadata.UserInput)
+		p := model.Package{Artifact: model.Artifact{
+			Element:        b.element(model.PackageType, id, src.ImageMetadata.UserInput),
+			PrimaryPurpose: "container",
+		}}
+		if algorithm, value, found := strings.Cut(src.ImageMetadata.ManifestDigest, ":"); found {
+			p.VerifiedUsing = []model.Hash{toHash(algorithm, value)}
+		}
+		b.add(id, &p)
+		return id
+	case source.DirectoryScheme, source.RepoScheme:
+		id := b.id("Directory", src.Path)
+		b.add(id, &model.File{
+			Artifact: model.Artifact{Element: b.element(model.FileType, id, src.Path)},
+			FileKind: "directory",
+		})
+		return id
+	case source.FileScheme:
+		id := b.id("File", src.Path)
+		b.add(id, &model.File{
+			Artifact: model.Artifact{Element: b.element(model.FileType, id, src.Path)},
+			FileKind: "file",
+		})
+		return id
+	}
+	return ""
+}
+
+// addLicenseRefs adds the texts of the licenses of the packages which are not on the SPDX license list.
+func (b *graphBuilder) addLicenseRefs(packages []pkg.Package) {
+	for _, ref := range spdxhelpers.OtherLicenses(packages) {
+		id := b.namespace + "#" + ref.ID
+		b.licenseRefs[ref.ID] = id
+		b.add(id, &model.SimpleLicensingText{
+			Element:     b.element(model.SimpleLicensingTextType, id, ref.ID),
+			LicenseText: ref.Text,
+		})
+	}
+}
+
+// license returns the ID of a license expression, which is added to the graph the first time; nothing is returned
+// when no license has been determined.
+func (b *graphBuilder) license(expression string) string {
+	if expression == "" || expression == spdxhelpers.NONE || expression == spdxhelpers.NOASSERTION {
+		return ""
+	}
+	if id, exists := b.licenses[expression]; exists {
+		return id
+	}
+
+	id := b.id("License", fmt.Sprintf("%d", len(b.licenses)+1))
+	b.licenses[expression] = id
+
+	l := model.LicenseExpression{
+		Element:            b.element(model.LicenseExpressionType, id, ""),
+		LicenseExpression:  expression,
+		LicenseListVersion: spdxlicense.Version,
+	}
+	// the licenses which are not on the SPDX license list reference their text
+	for _, token := range strings.FieldsFunc(expression, func(r rune) bool { return r == ' ' || r == '(' || r == ')' }) {
+		if ref, exists := b.licenseRefs[token]; exists {
+			l.CustomIDToURI = append(l.CustomIDToURI, model.DictionaryEntry{Type: "DictionaryEntry", Key: token, Value: ref})
+		}
+	}
+	b.add(id, &l)
+	return id
+}
+
+func (b *graphBuilder) addPackage(p pkg.Package) {
+	id := b.id("Package", string(p.ID()))
+
+	var originators []string
+	if originator := b.agent(spdxhelpers.Originator(p)); originator != "" {
+		originators = append(originators, originator)
+	}
+
+	var hashes []model.Hash
+	for _, digest := range spdxhelpers.PackageChecksums(p) {
+		hashes = append(hashes, toHash(digest.Algorithm, digest.Value))
+	}
+
+	var identifiers []model.ExternalIdentifier
+	for _, c := range p.CPEs {
+		identifiers = append(identifiers, model.ExternalIdentifier{
+			Type:                   "ExternalIdentifier",
+			ExternalIdentifierType: "cpe23",
+			Identifier:             pkg.CPEString(c),
+		})
+	}
+	// the purls a package provides, and those of the external packages it is made of, are told apart by the SPDX 2
+	// category of their reference
+	for _, ref := range spdxhelpers.ExternalRefs(p, &spdxhelpers.ExternalCounter{
+		ProvideMap:     map[string]string{},
+		ExternalMap:    map[string]string{},
+		ExternalPkgMap: map[string][]string{},
+	}) {
+		switch ref.ReferenceCategory {
+		case spdxhelpers.ProvideManagerReferenceCategory, spdxhelpers.ExternalManagerReferenceCategory:
+			identifiers = append(identifiers, model.ExternalIdentifier{
+				Type:                   "ExternalIdentifier",
+				ExternalIdentifierType: toIdentifierType(ref.ReferenceType),
+				Identifier:             ref.ReferenceLocator,
+				Comment:                string(ref.ReferenceCategory),
+			})
+		}
+	}
+
+	element := b.element(model.PackageType, id, p.Name)
+	element.Summary = spdxhelpers.Summary(p)
+	element.Description = spdxhelpers.Description(p)
+	element.VerifiedUsing = hashes
+	element.ExternalIdentifiers = identifiers
+
+	downloadLocation := spdxhelpers.DownloadLocation(p)
+	if downloadLocation == spdxhelpers.NONE || downloadLocation == spdxhelpers.NOASSERTION {
+		downloadLocation = ""
+	}
+
+	b.add(id, &model.Package{
+		Artifact: model.Artifact{
+			Element:        element,
+			SuppliedBy:     b.agent(spdxhelpers.Supplier(p)),
+			OriginatedBy:   originators,
+			ReleaseTime:    formatDate(spdxhelpers.ReleaseDate(p)),
+			BuiltTime:      formatDate(spdxhelpers.BuiltDate(p)),
+			PrimaryPurpose: toPurpose(spdxhelpers.PrimaryPackagePurpose(p)),
+		},
+		PackageVersion:   p.Version,
+		PackageURL:       p.PURL,
+		DownloadLocation: downloadLocation,
+		HomePage:         spdxhelpers.Homepage(p),
+		SourceInfo:       spdxhelpers.SourceInfo(p),
+	})
+
+	// the declared license is what the authors of the package believe governs it, while the concluded license is the
+	// license the document creator believes governs the package
+	if declared := b.license(spdxhelpers.License(p)); declared != "" {
+		b.relate(id, declared, relationshipMapping{relationshipType: hasDeclaredLicenseRelationship})
+	}
+	if concluded := b.license(spdxhelpers.ConcludedLicense(p)); concluded != "" {
+		b.relate(id, concluded, relationshipMapping{relationshipType: hasConcludedLicenseRelationship})
+	}
+
+	// the metadata of the package, which SPDX has no property for, is recorded by an annotation so decoding restores it
+	if statement, ok := spdxhelpers.MetadataAnnotation(p); ok {
+		annotationID := b.id("Annotation", string(p.ID()))
+		b.add(annotationID, &model.Annotation{
+			Element:        b.element(model.AnnotationType, annotationID, ""),
+			AnnotationType: otherAnnotationType,
+			Subject:        id,
+			Statement:      statement,
+		})
+	}
+}
+
+func (b *graphBuilder) addFiles(s sbom.SBOM) {
+	coordinates := sbom.AllCoordinates(s)
+	// sort by real path to ensure the result is stable across multiple runs
+	sort.SliceStable(coordinates, func(i, j int) bool {
+		return coordinates[i].RealPath < coordinates[j].RealPath
+	})
+
+	for _, c := range coordinates {
+		id := b.id("File", string(c.ID()))
+
+		element := b.element(model.FileType, id, c.RealPath)
+		for _, digest := range s.Artifacts.FileDigests[c] {
+			element.VerifiedUsing = append(element.VerifiedUsing, toHash(digest.Algorithm, digest.Value))
+		}
+		if c.FileSystemID != "" {
+			element.Comment = fmt.Sprintf("layerID: %s", c.FileSystemID)
+		}
+
+		var contentType string
+		if metadata, exists := s.Artifacts.FileMetadata[c]; exists {
+			contentType = metadata.MIMEType
+		}
+
+		b.add(id, &model.File{
+			Artifact:    model.Artifact{Element: element},
+			FileKind:    "file",
+			ContentType: contentType,
+		})
+	}
+}
+
+func (b *graphBuilder) addRelationship(r artifact.Relationship) {
+	mapping, exists := lookupRelationship(r.Type)
+	if !exists {
+		log.Warnf("unable to convert relationship to SPDX 3.0, dropping: %+v", r)
+		return
+	}
+
+	from, to := b.artifactID(r.From), b.artifactID(r.To)
+	if from == "" || to == "" {
+		log.Warnf("unable to find the elements of relationship to SPDX 3.0, dropping: %+v", r)
+		return
+	}
+	if mapping.reverse {
+		from, to = to, from
+	}
+	b.relate(from, to, mapping)
+}
+
+// artifactID returns the ID of the element of a package or file.
+func (b *graphBuilder) artifactID(identifiable artifact.Identifiable) string {
+	switch identifiable.(type) {
+	case pkg.Package, *pkg.Package:
+		return b.id("Package", string(identifiable.ID()))
+	case source.Coordinates, *source.Coordinates, source.Location, *source.Location:
+		return b.id("File", string(identifiable.ID()))
+	}
+	return ""
+}
+
+// relate relates an element to another one: the elements related the same way to an element share a relationship.
+func (b *graphBuilder) relate(from, to string, mapping relationshipMapping) {
+	key := relationshipKey{from: from, mapping: mapping}
+	if i, exists := b.relationships[key]; exists {
+		r := b.graph[i].(*model.Relationship)
+		r.To = append(r.To, to)
+		return
+	}
+
+	ty := model.RelationshipType
+	if mapping.scope != "" {
+		ty = model.LifecycleScopedRelationshipType
+	}
+
+	id := b.id("Relationship", fmt.Sprintf("%d", len(b.relationships)+1))
+	element := b.element(ty, id, "")
+	element.Comment = mapping.comment
+
+	b.relationships[key] = len(b.graph)
+	b.add(id, &model.Relationship{
+		Element:          element,
+		From:             from,
+		To:               []string{to},
+		RelationshipType: mapping.relationshipType,
+		Completeness:     noAssertionCompleteness,
+		Scope:            mapping.scope,
+	})
+}
+
+// toHash returns a hash of an artifact, with the algorithm named as SPDX 3.0 does (e.g. "sha256" or "sha3_256").
+func toHash(algorithm, value string) model.Hash {
+	return model.Hash{
+		Type:      "Hash",
+		Algorithm: strings.ReplaceAll(strings.ToLower(algorithm), "-", "_"),
+		HashValue: value,
+	}
+}
+
+// toIdentifierType returns the SPDX 3.0 type of an external identifier from the type of its SPDX 2 reference, see
+// https://spdx.github.io/spdx-spec/v3.0.1/model/Core/Vocabularies/ExternalIdentifierType/
+func toIdentifierType(ty spdxhelpers.ExternalRefType) string {
+	if ty == spdxhelpers.PurlExternalRefType {
+		return packageURLIdentifierType
+	}
+	return "other"
+}
+
+// toPurpose returns the SPDX 3.0 name of a package purpose, e.g. "operatingSystem" for "OPERATING-SYSTEM".
+func toPurpose(purpose spdxhelpers.PackagePurpose) string {
+	words := strings.Split(strings.ToLower(string(purpose)), "-")
+	for i := 1; i < len(words); i++ {
+		if words[i] != "" {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// formatDate returns a date as written in SPDX 3.0 documents, or nothing for the zero time (an unknown date).
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(dateLayout)
+}
@@ -0,0 +1,248 @@
+package spdx30json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/internal/formats/common/testutils"
+	"github.com/anchore/syft/internal/formats/spdx30json/model"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestToFormatModel(t *testing.T) {
+	s, bash, monolog, coordinates := testutils.PackagesInput()
+
+	doc := toFormatModel(s)
+
+	assert.Equal(t, model.Context, doc.Context)
+	require.IsType(t, &model.CreationInfo{}, doc.Graph[0])
+	assert.Equal(t, model.SpecVersion, doc.Graph[0].(*model.CreationInfo).SpecVersion)
+
+	require.IsType(t, &model.SpdxDocument{}, doc.Graph[1])
+	document := doc.Graph[1].(*model.SpdxDocument)
+	namespace := document.SpdxID[:len(document.SpdxID)-len("#SpdxDocument-DOCUMENT")]
+	assert.Equal(t, []string{namespace + "#Directory--some-path"}, document.RootElements)
+	// all the elements but the creation info and the document itself are elements of the document
+	assert.Len(t, document.Elements, len(doc.Graph)-2)
+
+	elements := make(map[string]interface{})
+	for _, element := range doc.Graph[2:] {
+		switch e := element.(type) {
+		case *model.Agent:
+			elements[e.SpdxID] = e
+		case *model.Tool:
+			elements[e.SpdxID] = e
+		case *model.Package:
+			elements[e.SpdxID] = e
+		case *model.File:
+			elements[e.SpdxID] = e
+		case *model.Relationship:
+			elements[e.SpdxID] = e
+		case *model.LicenseExpression:
+			elements[e.SpdxID] = e
+		case *model.SimpleLicensingText:
+			elements[e.SpdxID] = e
+		case *model.Annotation:
+			elements[e.SpdxID] = e
+		default:
+			t.Fatalf("unexpected element: %+v", e)
+		}
+	}
+
+	bashID := namespace + "#Package-" + string(bash.ID())
+	monologID := namespace + "#Package-" + string(monolog.ID())
+	fileID := namespace + "#File-" + string(coordinates.ID())
+
+	rpm := elements[bashID].(*model.Package)
+	assert.Equal(t, "bash", rpm.Name)
+	assert.Equal(t, "5.1.8-6.oe2203", rpm.PackageVersion)
+	assert.Equal(t, bash.PURL, rpm.PackageURL)
+	assert.Equal(t, "install", rpm.PrimaryPurpose)
+	assert.Equal(t, "2020-02-09T20:23:33Z", rpm.BuiltTime)
+	assert.Equal(t, []model.Hash{{Type: "Hash", Algorithm: "sha256", HashValue: "0123abcd"}}, rpm.VerifiedUsing)
+	assert.Contains(t, rpm.ExternalIdentifiers, model.ExternalIdentifier{
+		Type:                   "ExternalIdentifier",
+		ExternalIdentifierType: "packageUrl",
+		Identifier:             "pkg:rpm/openEuler/sh?distro=openEuler-22.03",
+		Comment:                "PROVIDE_MANAGER",
+	})
+	assert.Equal(t, &model.Agent{Element: model.Element{
+		Type:         model.OrganizationType,
+		SpdxID:       rpm.SuppliedBy,
+		CreationInfo: model.CreationInfoRef{ID: creationInfoID},
+		Name:         "openEuler",
+	}}, elements[rpm.SuppliedBy])
+
+	composer := elements[monologID].(*model.Package)
+	assert.Equal(t, "library", composer.PrimaryPurpose)
+	assert.Equal(t, "2020-12-14T13:15:25Z", composer.ReleaseTime)
+	assert.Empty(t, composer.SuppliedBy)
+
+	// the metadata of the packages is recorded by annotations
+	annotation, ok := elements[namespace+"#Annotation-"+string(bash.ID())].(*model.Annotation)
+	require.True(t, ok)
+	assert.Equal(t, otherAnnotationType, annotation.AnnotationType)
+	assert.Equal(t, bashID, annotation.Subject)
+	assert.Contains(t, annotation.Statement, `"metadataType":"RpmdbMetadata"`)
+
+	f := elements[fileID].(*model.File)
+	assert.Equal(t, "/usr/bin/bash", f.Name)
+	assert.Equal(t, "file", f.FileKind)
+	assert.Equal(t, "application/x-executable", f.ContentType)
+	assert.Equal(t, "layerID: sha256:layer", f.Comment)
+	assert.Equal(t, []model.Hash{{Type: "Hash", Algorithm: "sha1", HashValue: "abcdef"}}, f.VerifiedUsing)
+
+	var relationships []model.Relationship
+	licenses := make(map[string]string)
+	for _, element := range elements {
+		r, ok := element.(*model.Relationship)
+		if !ok {
+			continue
+		}
+		assert.Equal(t, noAssertionCompleteness, r.Completeness)
+		switch r.RelationshipType {
+		case hasDeclaredLicenseRelationship:
+			licenses[r.From] = elements[r.To[0]].(*model.LicenseExpression).LicenseExpression
+		case hasConcludedLicenseRelationship:
+		default:
+			relationship := *r
+			relationship.SpdxID = ""
+			relationships = append(relationships, relationship)
+		}
+	}
+
+	assert.Equal(t, "GPL-3.0-or-later", licenses[bashID])
+	require.Contains(t, licenses, monologID)
+	assert.Regexp(t, "^LicenseRef-", licenses[monologID])
+	var texts []string
+	for _, element := range elements {
+		if text, ok := element.(*model.SimpleLicensingText); ok {
+			texts = append(texts, text.LicenseText)
+		}
+	}
+	assert.Equal(t, []string{"a custom license"}, texts)
+
+	relationship := func(ty, from string, to []string, scope string) model.Relationship {
+		r := model.Relationship{
+			Element: model.Element{
+				Type:         model.RelationshipType,
+				CreationInfo: model.CreationInfoRef{ID: creationInfoID},
+			},
+			From:             from,
+			To:               to,
+			RelationshipType: ty,
+			Completeness:     noAssertionCompleteness,
+			Scope:            scope,
+		}
+		if scope != "" {
+			r.Type = model.LifecycleScopedRelationshipType
+		}
+		return r
+	}
+	assert.ElementsMatch(t, []model.Relationship{
+		relationship("dependsOn", monologID, []string{bashID}, ""),
+		// a runtime dependency of a package is related from the package depending on it
+		relationship("dependsOn", monologID, []string{bashID}, runtimeScope),
+		relationship("contains", bashID, []string{fileID}, ""),
+	}, relationships)
+}
+
+func TestToFormatModel_relatesElementsOnce(t *testing.T) {
+	s, bash, monolog, coordinates := testutils.PackagesInput()
+	other := source.Coordinates{RealPath: "/usr/share/doc/bash"}
+	s.Artifacts.FileDigests[other] = nil
+	s.Relationships = []artifact.Relationship{
+		{From: bash, To: coordinates, Type: artifact.ContainsRelationship},
+		{From: monolog, To: bash, Type: artifact.DependsOnRelationship},
+		{From: bash, To: other, Type: artifact.ContainsRelationship},
+	}
+
+	var contains []*model.Relationship
+	for _, element := range toFormatModel(s).Graph {
+		if r, ok := element.(*model.Relationship); ok && r.RelationshipType == "contains" {
+			contains = append(contains, r)
+		}
+	}
+
+	// the elements related the same way to an element share a relationship
+	require.Len(t, contains, 1)
+	assert.Len(t, contains[0].To, 2)
+}
+
+func TestToFormatModel_source(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   source.Metadata
+		expected interface{}
+	}{
+		{
+			name: "image",
+			source: source.Metadata{
+				Scheme: source.ImageScheme,
+				ImageMetadata: source.ImageMetadata{
+					UserInput:      "alpine:3.16",
+					ManifestDigest: "sha256:4ff3ca91275773af45cb4b0834e12b7eb47d1c18f770a0b151381cd227f4c253",
+				},
+			},
+			expected: model.Package{Artifact: model.Artifact{
+				Element: model.Element{
+					Type:         model.PackageType,
+					CreationInfo: model.CreationInfoRef{ID: creationInfoID},
+					Name:         "alpine:3.16",
+					VerifiedUsing: []model.Hash{
+						{Type: "Hash", Algorithm: "sha256", HashValue: "4ff3ca91275773af45cb4b0834e12b7eb47d1c18f770a0b151381cd227f4c253"},
+					},
+				},
+				PrimaryPurpose: "container",
+			}},
+		},
+		{
+			name:   "file",
+			source: source.Metadata{Scheme: source.FileScheme, Path: "/some/file.jar"},
+			expected: model.File{
+				Artifact: model.Artifact{Element: model.Element{
+					Type:         model.FileType,
+					CreationInfo: model.CreationInfoRef{ID: creationInfoID},
+					Name:         "/some/file.jar",
+				}},
+				FileKind: "file",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc := toFormatModel(sbom.SBOM{Source: test.source, Artifacts: sbom.Artifacts{PackageCatalog: pkg.NewCatalog()}})
+
+			document := doc.Graph[1].(*model.SpdxDocument)
+			require.Len(t, document.RootElements, 1)
+
+			var root interface{}
+			for _, element := range doc.Graph {
+				switch e := element.(type) {
+				case *model.Package:
+					if e.SpdxID == document.RootElements[0] {
+						e.SpdxID = ""
+						root = *e
+					}
+				case *model.File:
+					if e.SpdxID == document.RootElements[0] {
+						e.SpdxID = ""
+						root = *e
+					}
+				}
+			}
+			assert.Equal(t, test.expected, root)
+		})
+	}
+}
+
+func Test_toPurpose(t *testing.T) {
+	assert.Equal(t, "install", toPurpose("INSTALL"))
+	assert.Equal(t, "operatingSystem", toPurpose("OPERATING-SYSTEM"))
+	assert.Empty(t, toPurpose(""))
+}
@@ -0,0 +1,306 @@
+package spdx30json
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/spdx/tools-golang/spdx"
+
+	"github.com/anchore/syft/internal/formats/common/spdxhelpers"
+	"github.com/anchore/syft/internal/formats/spdx30json/model"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// graph indexes the elements of a document by ID.
+type graph struct {
+	document      *model.SpdxDocument
+	elements      map[string]interface{}
+	packages      []*model.Package
+	files         []*model.File
+	relationships []*model.Relationship
+	// annotations are the statements of the "other" annotations, by the ID of the element they comment on
+	annotations map[string][]string
+}
+
+func newGraph(doc *model.Document) (*graph, error) {
+	g := &graph{elements: make(map[string]interface{}), annotations: make(map[string][]string)}
+	for _, element := range doc.Graph {
+		switch e := element.(type) {
+		case *model.SpdxDocument:
+			g.document = e
+		case *model.Agent:
+			g.elements[e.SpdxID] = e
+		case *model.Package:
+			g.elements[e.SpdxID] = e
+			g.packages = append(g.packages, e)
+		case *model.File:
+			g.elements[e.SpdxID] = e
+			g.files = append(g.files, e)
+		case *model.Relationship:
+			g.relationships = append(g.relationships, e)
+		case *model.LicenseExpression:
+			g.elements[e.SpdxID] = e
+		case *model.SimpleLicensingText:
+			g.elements[e.SpdxID] = e
+		case *model.Annotation:
+			if e.AnnotationType == otherAnnotationType {
+				g.annotations[e.Subject] = append(g.annotations[e.Subject], e.Statement)
+			}
+		}
+	}
+	if g.document == nil {
+		return nil, errors.New("no SpdxDocument element found")
+	}
+	return g, nil
+}
+
+// agent returns the name of a person or an organization as written by SPDX 2 (e.g. "Person: Jane Doe").
+func (g *graph) agent(id string) (person, organization string) {
+	if agent, ok := g.elements[id].(*model.Agent); ok {
+		switch agent.Type {
+		case model.PersonType:
+			return agent.Name, ""
+		case model.OrganizationType:
+			return "", agent.Name
+		}
+	}
+	return "", ""
+}
+
+func toSyftModel(doc *model.Document) (*sbom.SBOM, error) {
+	if doc == nil {
+		return nil, errors.New("cannot convert SPDX document to Syft model because document is nil")
+	}
+
+	g, err := newGraph(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var root string
+	if len(g.document.RootElements) > 0 {
+		root = g.document.RootElements[0]
+	}
+
+	s := &sbom.SBOM{
+		Source: toSyftSource(g.elements[root]),
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(),
+			FileMetadata:   map[source.Coordinates]source.FileMetadata{},
+			FileDigests:    map[source.Coordinates][]file.Digest{},
+		},
+	}
+
+	// the syft packages and files, by the ID of their element
+	artifacts := make(map[string]artifact.Identifiable)
+
+	declared, concluded, extractedLicenses := g.licenses()
+	for _, p := range g.packages {
+		if p.SpdxID == root {
+			continue
+		}
+		syftPkg := spdxhelpers.ToSyftPackage(g.toSPDX22Package(p, declared[p.SpdxID], concluded[p.SpdxID]), extractedLicenses)
+		for _, statement := range g.annotations[p.SpdxID] {
+			if spdxhelpers.ApplyMetadataAnnotation(syftPkg, statement) {
+				break
+			}
+		}
+		artifacts[p.SpdxID] = *syftPkg
+		s.Artifacts.PackageCatalog.Add(*syftPkg)
+
+		if s.Artifacts.LinuxDistribution == nil {
+			s.Artifacts.LinuxDistribution = spdxhelpers.LinuxReleaseFromPURL(syftPkg.PURL)
+		}
+	}
+
+	for _, f := range g.files {
+		if f.SpdxID == root || f.FileKind == "directory" {
+			continue
+		}
+		coordinates := toSyftCoordinates(f)
+		artifacts[f.SpdxID] = coordinates
+
+		s.Artifacts.FileMetadata[coordinates] = source.FileMetadata{MIMEType: f.ContentType}
+		for _, h := range f.VerifiedUsing {
+			s.Artifacts.FileDigests[coordinates] = append(s.Artifacts.FileDigests[coordinates], file.Digest{
+				Algorithm: h.Algorithm,
+				Value:     h.HashValue,
+			})
+		}
+	}
+
+	s.Relationships = toSyftRelationships(g.relationships, artifacts)
+
+	return s, nil
+}
+
+// toSyftSource returns the source a document is about from its root element: an image (a container package), a
+// directory or a file.
+func toSyftSource(root interface{}) source.Metadata {
+	switch e := root.(type) {
+	case *model.Package:
+		if e.PrimaryPurpose == "container" {
+			src := source.Metadata{
+				Scheme:        source.ImageScheme,
+				ImageMetadata: source.ImageMetadata{UserInput: e.Name},
+			}
+			if len(e.VerifiedUsing) > 0 {
+				src.ImageMetadata.ManifestDigest = e.VerifiedUsing[0].Algorithm + ":" + e.VerifiedUsing[0].HashValue
+			}
+			return src
+		}
+	case *model.File:
+		switch e.FileKind {
+		case "directory":
+			return source.Metadata{Scheme: source.DirectoryScheme, Path: e.Name}
+		case "file":
+			return source.Metadata{Scheme: source.FileScheme, Path: e.Name}
+		}
+	}
+	return source.Metadata{Scheme: source.UnknownScheme}
+}
+
+// licenses returns the declared and concluded license expressions of the packages by the ID of their element, and the
+// text of the licenses which are not on the SPDX license list by license ID.
+func (g *graph) licenses() (declared, concluded, extractedLicenses map[string]string) {
+	declared, concluded, extractedLicenses = make(map[string]string), make(map[string]string), make(map[string]string)
+	for _, r := range g.relationships {
+		var licenses map[string]string
+		switch r.RelationshipType {
+		case hasDeclaredLicenseRelationship:
+			licenses = declared
+		case hasConcludedLicenseRelationship:
+			licenses = concluded
+		default:
+			continue
+		}
+
+		var expressions []string
+		for _, to := range r.To {
+			l, ok := g.elements[to].(*model.LicenseExpression)
+			if !ok {
+				continue
+			}
+			expressions = append(expressions, l.LicenseExpression)
+			for _, entry := range l.CustomIDToURI {
+				if text, ok := g.elements[entry.Value].(*model.SimpleLicensingText); ok {
+					extractedLicenses[entry.Key] = text.LicenseText
+				}
+			}
+		}
+		if len(expressions) > 0 {
+			licenses[r.From] = strings.Join(expressions, " AND ")
+		}
+	}
+	return declared, concluded, extractedLicenses
+}
+
+// toSPDX22Package converts a package into its SPDX 2.2 counterpart, so the syft package is found the same way as from
+// SPDX 2 documents (from its purl).
+func (g *graph) toSPDX22Package(p *model.Package, declaredLicense, concludedLicense string) *spdx.Package2_2 {
+	result := &spdx.Package2_2{
+		PackageName:             p.Name,
+		PackageSPDXIdentifier:   spdx.ElementID(p.SpdxID),
+		PackageVersion:          p.PackageVersion,
+		PackageDownloadLocation: p.DownloadLocation,
+		PackageHomePage:         p.HomePage,
+		PackageSourceInfo:       p.SourceInfo,
+		PackageLicenseDeclared:  declaredLicense,
+		PackageLicenseConcluded: concludedLicense,
+		PackageSummary:          p.Summary,
+		PackageDescription:      p.Description,
+	}
+
+	result.PackageSupplierPerson, result.PackageSupplierOrganization = g.agent(p.SuppliedBy)
+	if len(p.OriginatedBy) > 0 {
+		result.PackageOriginatorPerson, result.PackageOriginatorOrganization = g.agent(p.OriginatedBy[0])
+	}
+
+	for _, h := range p.VerifiedUsing {
+		if result.PackageChecksums == nil {
+			result.PackageChecksums = make(map[spdx.ChecksumAlgorithm]spdx.Checksum)
+		}
+		algorithm := spdx.ChecksumAlgorithm(h.Algorithm)
+		result.PackageChecksums[algorithm] = spdx.Checksum{Algorithm: algorithm, Value: h.HashValue}
+	}
+
+	if p.PackageURL != "" {
+		result.PackageExternalReferences = append(result.PackageExternalReferences, &spdx.PackageExternalReference2_2{
+			Category: string(spdxhelpers.PackageManagerReferenceCategory),
+			RefType:  string(spdxhelpers.PurlExternalRefType),
+			Locator:  p.PackageURL,
+		})
+	}
+	for _, identifier := range p.ExternalIdentifiers {
+		ref := &spdx.PackageExternalReference2_2{Locator: identifier.Identifier}
+		switch category := spdxhelpers.ReferenceCategory(identifier.Comment); {
+		case identifier.ExternalIdentifierType == "cpe23":
+			ref.Category, ref.RefType = string(spdxhelpers.SecurityReferenceCategory), string(spdxhelpers.Cpe23ExternalRefType)
+		case category == spdxhelpers.ProvideManagerReferenceCategory || category == spdxhelpers.ExternalManagerReferenceCategory:
+			// the purls the package provides, or those of the external packages it is made of
+			ref.Category, ref.RefType = string(category), string(spdxhelpers.PurlExternalRefType)
+			if identifier.ExternalIdentifierType != packageURLIdentifierType {
+				ref.RefType = string(spdxhelpers.ChecksumExternalRefType)
+			}
+		default:
+			continue
+		}
+		result.PackageExternalReferences = append(result.PackageExternalReferences, ref)
+	}
+
+	return result
+}
+
+func toSyftCoordinates(f *model.File) source.Coordinates {
+	const layerIDPrefix = "layerID: "
+	var fileSystemID string
+	if strings.HasPrefix(f.Comment, layerIDPrefix) {
+		fileSystemID = strings.TrimPrefix(f.Comment, layerIDPrefix)
+	}
+	return source.Coordinates{
+		RealPath:     f.Name,
+		FileSystemID: fileSystemID,
+	}
+}
+
+func toSyftRelationships(relationships []*model.Relationship, artifacts map[string]artifact.Identifiable) []artifact.Relationship {
+	var out []artifact.Relationship
+	for _, r := range relationships {
+		if r.RelationshipType == hasDeclaredLicenseRelationship || r.RelationshipType == hasConcludedLicenseRelationship {
+			continue
+		}
+
+		mapping, ok := lookupSyftRelationship(r.RelationshipType, r.Scope, r.Comment)
+		if !ok {
+			log.Debugf("unable to find a syft relationship for SPDX 3.0 relationship, ignoring: %+v", r)
+			continue
+		}
+
+		for _, id := range r.To {
+			from, to := artifacts[r.From], artifacts[id]
+			if mapping.reverse {
+				from, to = to, from
+			}
+
+			_, fromPackage := from.(pkg.Package)
+			_, toPackage := to.(pkg.Package)
+			_, toFile := to.(source.Coordinates)
+			if !fromPackage || !(toPackage || toFile && mapping.syftType == artifact.ContainsRelationship) {
+				log.Debugf("unable to find valid relationship mapping from SPDX 3.0 JSON, ignoring: (from: %+v) (to: %+v)", from, to)
+				continue
+			}
+
+			out = append(out, artifact.Relationship{
+				From: from,
+				To:   to,
+				Type: mapping.syftType,
+			})
+		}
+	}
+	return out
+}
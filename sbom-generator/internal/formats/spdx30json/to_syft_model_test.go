@@ -0,0 +1,136 @@
+package spdx30json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/internal/formats/common/testutils"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestToSyftModel(t *testing.T) {
+	s, rpm, _, coordinates := testutils.PackagesInput()
+
+	result, err := toSyftModel(toFormatModel(s))
+	require.NoError(t, err)
+
+	assert.Equal(t, s.Source, result.Source)
+	require.NotNil(t, result.Artifacts.LinuxDistribution)
+	assert.Equal(t, "openEuler", result.Artifacts.LinuxDistribution.ID)
+	assert.Equal(t, "22.03", result.Artifacts.LinuxDistribution.VersionID)
+
+	packages := make(map[string]pkg.Package)
+	for _, p := range result.Artifacts.PackageCatalog.Sorted() {
+		packages[p.Name] = p
+	}
+	require.Len(t, packages, 2)
+
+	bash := packages["bash"]
+	assert.Equal(t, "5.1.8-6.oe2203", bash.Version)
+	assert.Equal(t, pkg.RpmPkg, bash.Type)
+	// the license is normalized into a SPDX license expression
+	assert.Equal(t, []string{"GPL-3.0-or-later"}, bash.Licenses)
+	assert.Equal(t, []string{"pkg:rpm/openEuler/sh?distro=openEuler-22.03"}, bash.ProvidesPurls)
+	// the metadata is restored from the annotations of the packages
+	assert.Equal(t, pkg.RpmdbMetadataType, bash.MetadataType)
+	assert.Equal(t, rpm.Metadata, bash.Metadata)
+
+	monolog := packages["monolog/monolog"]
+	assert.Equal(t, pkg.PhpComposerPkg, monolog.Type)
+	// the licenses which are not on the SPDX license list are read as their text
+	assert.Equal(t, []string{"a custom license"}, monolog.Licenses)
+
+	assert.Equal(t, map[source.Coordinates]source.FileMetadata{
+		coordinates: {MIMEType: "application/x-executable"},
+	}, result.Artifacts.FileMetadata)
+	assert.Equal(t, map[source.Coordinates][]file.Digest{
+		coordinates: {{Algorithm: "sha1", Value: "abcdef"}},
+	}, result.Artifacts.FileDigests)
+
+	var relationships []string
+	for _, r := range result.Relationships {
+		relationships = append(relationships, string(r.From.ID())+" "+string(r.Type)+" "+string(r.To.ID()))
+	}
+	assert.ElementsMatch(t, []string{
+		string(monolog.ID()) + " " + string(artifact.DependsOnRelationship) + " " + string(bash.ID()),
+		string(bash.ID()) + " " + string(artifact.RuntimeDependencyOfRelationship) + " " + string(monolog.ID()),
+		string(bash.ID()) + " " + string(artifact.ContainsRelationship) + " " + string(coordinates.ID()),
+	}, relationships)
+}
+
+func TestToSyftModel_relationships(t *testing.T) {
+	s, bash, monolog, _ := testutils.PackagesInput()
+
+	// the decoded packages are told apart by name, their IDs are not the same as the IDs of the encoded ones
+	name := func(identifiable artifact.Identifiable) string {
+		p, ok := identifiable.(pkg.Package)
+		require.True(t, ok)
+		return p.Name
+	}
+
+	for _, mapping := range relationshipMappings {
+		if mapping.syftType == artifact.ContainsRelationship {
+			continue
+		}
+		t.Run(string(mapping.syftType), func(t *testing.T) {
+			s.Relationships = []artifact.Relationship{{From: monolog, To: bash, Type: mapping.syftType}}
+
+			result, err := toSyftModel(toFormatModel(s))
+			require.NoError(t, err)
+			require.Len(t, result.Relationships, 1)
+
+			r := result.Relationships[0]
+			assert.Equal(t, mapping.syftType, r.Type)
+			assert.Equal(t, monolog.Name, name(r.From))
+			assert.Equal(t, bash.Name, name(r.To))
+		})
+	}
+}
+
+func TestToSyftModel_source(t *testing.T) {
+	tests := []struct {
+		name   string
+		source source.Metadata
+	}{
+		{
+			name: "image",
+			source: source.Metadata{
+				Scheme: source.ImageScheme,
+				ImageMetadata: source.ImageMetadata{
+					UserInput:      "alpine:3.16",
+					ManifestDigest: "sha256:4ff3ca91275773af45cb4b0834e12b7eb47d1c18f770a0b151381cd227f4c253",
+				},
+			},
+		},
+		{
+			name:   "directory",
+			source: source.Metadata{Scheme: source.DirectoryScheme, Path: "/some/path"},
+		},
+		{
+			name:   "file",
+			source: source.Metadata{Scheme: source.FileScheme, Path: "/some/file.jar"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, _, _, _ := testutils.PackagesInput()
+			s.Source = test.source
+
+			result, err := toSyftModel(toFormatModel(s))
+			require.NoError(t, err)
+			assert.Equal(t, test.source, result.Source)
+			// the root element is not read as a package
+			assert.Equal(t, 2, result.Artifacts.PackageCatalog.PackageCount())
+		})
+	}
+}
+
+func TestToSyftModel_nil(t *testing.T) {
+	_, err := toSyftModel(nil)
+	assert.Error(t, err)
+}
@@ -18,16 +18,19 @@ type Package struct {
 
 // PackageBasicData contains non-ambiguous values (type-wise) from pkg.Package.
 type PackageBasicData struct {
-	ID        string               `json:"id"`
-	Name      string               `json:"name"`
-	Version   string               `json:"version"`
-	Type      pkg.Type             `json:"type"`
-	FoundBy   string               `json:"foundBy"`
-	Locations []source.Coordinates `json:"locations"`
-	Licenses  []string             `json:"licenses"`
-	Language  pkg.Language         `json:"language"`
-	CPEs      []string             `json:"cpes"`
-	PURL      string               `json:"purl"`
+	ID               string                `json:"id"`
+	Name             string                `json:"name"`
+	Version          string                `json:"version"`
+	Type             pkg.Type              `json:"type"`
+	FoundBy          string                `json:"foundBy"`
+	Locations        []source.Coordinates  `json:"locations"`
+	Licenses         []string              `json:"licenses"`
+	DetectedLicenses []pkg.DetectedLicense `json:"detectedLicenses,omitempty"`
+	Language         pkg.Language          `json:"language"`
+	CPEs             []string              `json:"cpes"`
+	PURL             string                `json:"purl"`
+	ProvidesPurls    []string              `json:"providesPurls,omitempty"`
+	ExtPkgPurls      []string              `json:"extPkgPurls,omitempty"`
 }
 
 // PackageCustomData contains ambiguous values (type-wise) from pkg.Package.
@@ -62,6 +65,10 @@ func (p *Package) UnmarshalJSON(b []byte) error {
 	}
 
 	p.MetadataType = unpacker.MetadataType
+	if len(unpacker.Metadata) == 0 {
+		// the metadata of a package converted from another format may be unknown even when its type is not
+		return nil
+	}
 
 	switch p.MetadataType {
 	case pkg.ApkMetadataType:
@@ -76,6 +83,12 @@ func (p *Package) UnmarshalJSON(b []byte) error {
 			return err
 		}
 		p.Metadata = payload
+	case pkg.RpmRepodataType:
+		var payload pkg.RpmRepodata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
 	case pkg.DpkgMetadataType:
 		var payload pkg.DpkgMetadata
 		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
@@ -112,6 +125,12 @@ func (p *Package) UnmarshalJSON(b []byte) error {
 			return err
 		}
 		p.Metadata = payload
+	case pkg.PythonRequirementsMetadataType:
+		var payload pkg.PythonRequirementsMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
 	case pkg.NpmPackageJSONMetadataType:
 		var payload pkg.NpmPackageJSONMetadata
 		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
@@ -130,6 +149,12 @@ func (p *Package) UnmarshalJSON(b []byte) error {
 			return err
 		}
 		p.Metadata = payload
+	case pkg.GolangModMetadataType:
+		var payload pkg.GolangModMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
 	case pkg.DartPubMetadataType:
 		var payload pkg.DartPubMetadata
 		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
@@ -30,7 +30,7 @@ func (s *Source) UnmarshalJSON(b []byte) error {
 	s.Type = unpacker.Type
 
 	switch s.Type {
-	case "directory", "file":
+	case "directory", "file", "repository":
 		if target, err := strconv.Unquote(string(unpacker.Target)); err == nil {
 			s.Target = target
 		} else {
@@ -44,6 +44,9 @@ func (s *Source) UnmarshalJSON(b []byte) error {
 		}
 		s.Target = payload
 
+	case "":
+		// the source of an SBOM converted from another format may be unknown, in which case there is no target
+
 	default:
 		return fmt.Errorf("unsupported package metadata type: %+v", s.Type)
 	}
@@ -183,16 +183,19 @@ func toPackageModel(p pkg.Package) model.Package {
 
 	return model.Package{
 		PackageBasicData: model.PackageBasicData{
-			ID:        string(p.ID()),
-			Name:      p.Name,
-			Version:   p.Version,
-			Type:      p.Type,
-			FoundBy:   p.FoundBy,
-			Locations: coordinates,
-			Licenses:  licenses,
-			Language:  p.Language,
-			CPEs:      cpes,
-			PURL:      p.PURL,
+			ID:               string(p.ID()),
+			Name:             p.Name,
+			Version:          p.Version,
+			Type:             p.Type,
+			FoundBy:          p.FoundBy,
+			Locations:        coordinates,
+			Licenses:         licenses,
+			DetectedLicenses: p.DetectedLicenses,
+			Language:         p.Language,
+			CPEs:             cpes,
+			PURL:             p.PURL,
+			ProvidesPurls:    p.ProvidesPurls,
+			ExtPkgPurls:      p.ExtPkgPurls,
 		},
 		PackageCustomData: model.PackageCustomData{
 			MetadataType: p.MetadataType,
@@ -240,6 +243,11 @@ func toSourceModel(src source.Metadata) (model.Source, error) {
 			Type:   "file",
 			Target: src.Path,
 		}, nil
+	case source.RepoScheme:
+		return model.Source{
+			Type:   "repository",
+			Target: src.Path,
+		}, nil
 	default:
 		return model.Source{}, fmt.Errorf("unsupported source: %q", src.Scheme)
 	}
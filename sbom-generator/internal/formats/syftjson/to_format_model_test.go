@@ -45,6 +45,17 @@ func Test_toSourceModel(t *testing.T) {
 				Target: "some/path",
 			},
 		},
+		{
+			name: "repository",
+			src: source.Metadata{
+				Scheme: source.RepoScheme,
+				Path:   "https://some/repo",
+			},
+			expected: model.Source{
+				Type:   "repository",
+				Target: "https://some/repo",
+			},
+		},
 		{
 			name: "image",
 			src: source.Metadata{
@@ -97,6 +97,10 @@ func toSyftRelationship(idMap map[string]interface{}, relationship model.Relatio
 	case artifact.OwnershipByFileOverlapRelationship:
 		fallthrough
 	case artifact.ContainsRelationship:
+	case artifact.DependsOnRelationship, artifact.DependencyOfRelationship, artifact.HasPrerequisiteRelationship:
+	case artifact.DevDependencyOfRelationship, artifact.OptionalDependencyOfRelationship:
+	case artifact.BuildDependencyOfRelationship, artifact.RuntimeDependencyOfRelationship:
+	case artifact.RecommendsRelationship, artifact.SuggestsRelationship, artifact.SupplementsRelationship, artifact.EnhancesRelationship:
 	default:
 		log.Warnf("unknown relationship type: %s", typ)
 		return nil
@@ -129,13 +133,18 @@ func toSyftSourceData(s model.Source) *source.Metadata {
 			Scheme: source.FileScheme,
 			Path:   s.Target.(string),
 		}
+	case "repository":
+		return &source.Metadata{
+			Scheme: source.RepoScheme,
+			Path:   s.Target.(string),
+		}
 	case "image":
 		return &source.Metadata{
 			Scheme:        source.ImageScheme,
 			ImageMetadata: s.Target.(source.ImageMetadata),
 		}
 	}
-	return nil
+	return &source.Metadata{Scheme: source.UnknownScheme}
 }
 
 func toSyftCatalog(pkgs []model.Package, idAliases map[string]string) *pkg.Catalog {
@@ -164,17 +173,20 @@ func toSyftPackage(p model.Package, idAliases map[string]string) pkg.Package {
 	}
 
 	out := pkg.Package{
-		Name:         p.Name,
-		Version:      p.Version,
-		FoundBy:      p.FoundBy,
-		Locations:    source.NewLocationSet(locations...),
-		Licenses:     p.Licenses,
-		Language:     p.Language,
-		Type:         p.Type,
-		CPEs:         cpes,
-		PURL:         p.PURL,
-		MetadataType: p.MetadataType,
-		Metadata:     p.Metadata,
+		Name:             p.Name,
+		Version:          p.Version,
+		FoundBy:          p.FoundBy,
+		Locations:        source.NewLocationSet(locations...),
+		Licenses:         p.Licenses,
+		DetectedLicenses: p.DetectedLicenses,
+		Language:         p.Language,
+		Type:             p.Type,
+		CPEs:             cpes,
+		PURL:             p.PURL,
+		ProvidesPurls:    p.ProvidesPurls,
+		ExtPkgPurls:      p.ExtPkgPurls,
+		MetadataType:     p.MetadataType,
+		Metadata:         p.Metadata,
 	}
 
 	// we don't know if this package ID is truly unique, however, we need to trust the user input in case there are
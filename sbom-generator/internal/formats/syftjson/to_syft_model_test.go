@@ -15,6 +15,8 @@ func Test_toSyftSourceData(t *testing.T) {
 	for _, s := range source.AllSchemes {
 		allSchemes.Add(string(s))
 	}
+	// the source of an SBOM converted from another format may be unknown
+	allSchemes.Add(string(source.UnknownScheme))
 	testedSchemes := strset.New()
 
 	tests := []struct {
@@ -44,6 +46,17 @@ func Test_toSyftSourceData(t *testing.T) {
 				Target: "some/path",
 			},
 		},
+		{
+			name: "repository",
+			expected: source.Metadata{
+				Scheme: source.RepoScheme,
+				Path:   "https://some/repo",
+			},
+			src: model.Source{
+				Type:   "repository",
+				Target: "https://some/repo",
+			},
+		},
 		{
 			name: "image",
 			expected: source.Metadata{
@@ -65,6 +78,13 @@ func Test_toSyftSourceData(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "unknown",
+			expected: source.Metadata{
+				Scheme: source.UnknownScheme,
+			},
+			src: model.Source{},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
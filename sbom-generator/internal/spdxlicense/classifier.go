@@ -0,0 +1,275 @@
+package spdxlicense
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MinConfidence is the minimum share of a license template which must be found in a file for the file to be classified
+// as that license.
+const MinConfidence = 0.9
+
+// templateVariable marks the text of a license template which varies between the copies of the license (e.g. the name
+// of the copyright holder).
+const templateVariable = "<<var>>"
+
+// maxClassifiedSize is the maximum number of bytes read from a file to classify (larger files are truncated).
+const maxClassifiedSize = 1 << 20
+
+// templatesFS holds the templates of the licenses the classifier detects, one directory per SPDX license ID holding
+// one or more templates: the license text of the SPDX license list and the standard header of the license, if any (e.g.
+// the Apache-2.0 header). The templates are generated by generate/license_templates.
+//
+//go:embed templates
+var templatesFS embed.FS
+
+var (
+	// copyrightLineExp matches the copyright notices of a file, which vary between the copies of a license
+	copyrightLineExp = regexp.MustCompile(`(?i)^\s*(copyright\s*(\(c\)|©|[0-9]{4})|\(c\)\s*[0-9]{4}|©)`)
+
+	// listMarkerExp matches the bullets and numbers of the list items starting a line (e.g. "1.", "(a)" or "*"), which
+	// vary between the copies of a license
+	listMarkerExp = regexp.MustCompile(`^\s*(\(?([0-9]{1,2}|[a-z]|[ivx]{1,4})[.)]|[*•-])\s+`)
+
+	// wordExp matches the words of a license text, once lowercased; the punctuation, quotes and markup are ignored
+	wordExp = regexp.MustCompile(`[a-z0-9]+`)
+
+	// versionVariantExp matches the suffixes of the GNU license IDs telling whether later versions of the license may be
+	// used, which the license text alone does not state
+	versionVariantExp = regexp.MustCompile(`-(only|or-later)$`)
+
+	// equivalentWords are the spelling variants treated as the same word, see
+	// https://spdx.github.io/spdx-spec/v2.3/license-matching-guidelines-and-templates/#b810-equivalent-words
+	equivalentWords = map[string]string{
+		"acknowledgement": "acknowledgment",
+		"authorised":      "authorized",
+		"http":            "https",
+		"licence":         "license",
+		"licences":        "licenses",
+		"organisation":    "organization",
+	}
+)
+
+// LicenseMatch is a license classified from the contents of a file.
+type LicenseMatch struct {
+	// ID is the SPDX license ID, or the OR expression of the license IDs the file matches alike when ambiguous (e.g.
+	// "GPL-2.0-only OR GPL-2.0-or-later")
+	ID string
+	// Ambiguous indicates that the file matches several licenses alike (e.g. the text of the GPL-2.0 does not tell
+	// whether the later versions of the license may be used)
+	Ambiguous bool
+	// Confidence is the share of the license template found in the file, from MinConfidence to 1 (to two decimal places)
+	Confidence float64
+}
+
+// licenseTemplate is the normalized text of a license template, as the set of its word trigrams.
+type licenseTemplate struct {
+	id string
+	// family is the license ID without its version variant (e.g. "GPL-2.0" for GPL-2.0-only and GPL-2.0-or-later)
+	family   string
+	trigrams map[string]struct{}
+	// subsumedBy are the IDs of the licenses whose templates include most of this one (e.g. BSD-3-Clause for
+	// BSD-2-Clause), which take precedence when both are found in a file
+	subsumedBy map[string]bool
+}
+
+var licenseTemplates = mustLoadTemplates()
+
+func mustLoadTemplates() []licenseTemplate {
+	templates, err := loadTemplates(templatesFS, "templates")
+	if err != nil {
+		panic(err)
+	}
+	return templates
+}
+
+func loadTemplates(fsys fs.FS, root string) ([]licenseTemplate, error) {
+	var templates []licenseTemplate
+	err := fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		f, err := fsys.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		contents, err := io.ReadAll(f)
+		if err != nil {
+			return err
+		}
+
+		// the variable parts of a template (e.g. the name of the copyright holder) are left out of its trigrams
+		id := path.Base(path.Dir(p))
+		t := licenseTemplate{
+			id:       id,
+			family:   versionVariantExp.ReplaceAllString(id, ""),
+			trigrams: make(map[string]struct{}),
+		}
+		for _, part := range strings.Split(string(contents), templateVariable) {
+			words, err := normalizeLicenseText(strings.NewReader(part))
+			if err != nil {
+				return err
+			}
+			for trigram := range trigrams(words) {
+				t.trigrams[trigram] = struct{}{}
+			}
+		}
+		templates = append(templates, t)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the license templates: %w", err)
+	}
+
+	for i := range templates {
+		templates[i].subsumedBy = make(map[string]bool)
+		for j := range templates {
+			// the version variants of a license share their text, so neither takes precedence over the other
+			if templates[i].family != templates[j].family && coverage(templates[i].trigrams, templates[j].trigrams) >= MinConfidence {
+				templates[i].subsumedBy[templates[j].id] = true
+			}
+		}
+	}
+	return templates, nil
+}
+
+// ClassifyLicenses returns the licenses whose templates are found in a file (e.g. a LICENSE or COPYING file), sorted
+// by ID. The templates are bundled, so no network access is needed. When several version variants of a license are
+// found (e.g. GPL-2.0-only and GPL-2.0-or-later, which share their text), a single ambiguous match is returned for them.
+func ClassifyLicenses(reader io.Reader) ([]LicenseMatch, error) {
+	words, err := normalizeLicenseText(io.LimitReader(reader, maxClassifiedSize))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the license file: %w", err)
+	}
+	if len(words) == 0 {
+		return nil, nil
+	}
+	text := trigrams(words)
+
+	confidences := make(map[string]float64)
+	for _, t := range licenseTemplates {
+		if c := coverage(t.trigrams, text); c >= MinConfidence && c > confidences[t.id] {
+			confidences[t.id] = c
+		}
+	}
+
+	// the version variants found of each license, by family
+	var families []string
+	variants := make(map[string][]licenseTemplate)
+	for _, t := range licenseTemplates {
+		if _, found := confidences[t.id]; !found || isSubsumed(t, confidences) {
+			continue
+		}
+		if _, exists := variants[t.family]; !exists {
+			families = append(families, t.family)
+		}
+		variants[t.family] = appendTemplate(variants[t.family], t)
+	}
+
+	var results []LicenseMatch
+	for _, family := range families {
+		results = append(results, newLicenseMatch(variants[family], confidences))
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].ID < results[j].ID
+	})
+	return results, nil
+}
+
+// appendTemplate adds a template to the templates of a family, unless a template of the same license is already there.
+func appendTemplate(templates []licenseTemplate, t licenseTemplate) []licenseTemplate {
+	for _, existing := range templates {
+		if existing.id == t.id {
+			return templates
+		}
+	}
+	return append(templates, t)
+}
+
+// newLicenseMatch returns the match of the version variants of a license found in a file, which is ambiguous when more
+// than one variant is found.
+func newLicenseMatch(templates []licenseTemplate, confidences map[string]float64) LicenseMatch {
+	var ids []string
+	var confidence float64
+	for _, t := range templates {
+		ids = append(ids, t.id)
+		confidence = math.Max(confidence, confidences[t.id])
+	}
+	sort.Strings(ids)
+	return LicenseMatch{
+		ID:         strings.Join(ids, " OR "),
+		Ambiguous:  len(ids) > 1,
+		Confidence: math.Round(confidence*100) / 100,
+	}
+}
+
+// isSubsumed indicates that a license is found as a part of another license also found in the file.
+func isSubsumed(t licenseTemplate, confidences map[string]float64) bool {
+	for id := range t.subsumedBy {
+		if _, found := confidences[id]; found {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeLicenseText returns the words of a license text, leaving out the copyright notices.
+func normalizeLicenseText(reader io.Reader) ([]string, error) {
+	var words []string
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxClassifiedSize)
+	for scanner.Scan() {
+		line := strings.ToLower(scanner.Text())
+		if copyrightLineExp.MatchString(line) {
+			continue
+		}
+		line = listMarkerExp.ReplaceAllString(line, "")
+		for _, word := range wordExp.FindAllString(line, -1) {
+			if equivalent, exists := equivalentWords[word]; exists {
+				word = equivalent
+			}
+			words = append(words, word)
+		}
+	}
+	return words, scanner.Err()
+}
+
+// trigrams returns the sequences of three consecutive words of a text (or the whole text, when shorter).
+func trigrams(words []string) map[string]struct{} {
+	results := make(map[string]struct{})
+	if len(words) < 3 {
+		if len(words) > 0 {
+			results[strings.Join(words, " ")] = struct{}{}
+		}
+		return results
+	}
+	for i := 0; i+3 <= len(words); i++ {
+		results[strings.Join(words[i:i+3], " ")] = struct{}{}
+	}
+	return results
+}
+
+// coverage returns the share of the trigrams of a template found in a text.
+func coverage(template, text map[string]struct{}) float64 {
+	if len(template) == 0 {
+		return 0
+	}
+	var found int
+	for t := range template {
+		if _, exists := text[t]; exists {
+			found++
+		}
+	}
+	return float64(found) / float64(len(template))
+}
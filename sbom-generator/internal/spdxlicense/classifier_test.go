@@ -0,0 +1,118 @@
+package spdxlicense
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyLicenses(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		expected []string
+	}{
+		{
+			// reflowed, with a title and a copyright notice
+			fixture:  "test-fixtures/license-files/MIT",
+			expected: []string{"MIT"},
+		},
+		{
+			// bulleted, naming the copyright holder
+			fixture:  "test-fixtures/license-files/BSD-3-Clause",
+			expected: []string{"BSD-3-Clause"},
+		},
+		{
+			fixture:  "test-fixtures/license-files/ISC",
+			expected: []string{"ISC"},
+		},
+		{
+			// the ISC license without its notice requirement
+			fixture:  "test-fixtures/license-files/0BSD",
+			expected: []string{"0BSD"},
+		},
+		{
+			// the license header within a notice
+			fixture:  "test-fixtures/license-files/NOTICE",
+			expected: []string{"Apache-2.0"},
+		},
+		{
+			fixture:  "test-fixtures/license-files/DUAL",
+			expected: []string{"ISC", "MIT"},
+		},
+		{
+			// the GPL-2.0 text does not tell whether the later versions of the license may be used
+			fixture:  "test-fixtures/license-files/COPYING",
+			expected: []string{"GPL-2.0-only OR GPL-2.0-or-later"},
+		},
+		{
+			fixture:  "test-fixtures/license-files/README",
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			f, err := os.Open(test.fixture)
+			require.NoError(t, err)
+			defer f.Close()
+
+			matches, err := ClassifyLicenses(f)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, m := range matches {
+				ids = append(ids, m.ID)
+				assert.GreaterOrEqual(t, m.Confidence, MinConfidence)
+				assert.LessOrEqual(t, m.Confidence, 1.0)
+			}
+			assert.Equal(t, test.expected, ids)
+		})
+	}
+}
+
+func TestClassifyLicenses_templates(t *testing.T) {
+	// each template is classified as its license, and only as its license (or as all the version variants of its
+	// license, which share their text)
+	err := fs.WalkDir(templatesFS, "templates", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		contents, err := fs.ReadFile(templatesFS, p)
+		require.NoError(t, err)
+
+		t.Run(p, func(t *testing.T) {
+			text := strings.ReplaceAll(string(contents), templateVariable, "the copyright holders")
+			matches, err := ClassifyLicenses(strings.NewReader(text))
+			require.NoError(t, err)
+			require.Len(t, matches, 1)
+
+			id := path.Base(path.Dir(p))
+			if family := versionVariantExp.ReplaceAllString(id, ""); family != id {
+				assert.Equal(t, family+"-only OR "+family+"-or-later", matches[0].ID)
+				assert.True(t, matches[0].Ambiguous)
+			} else {
+				assert.Equal(t, id, matches[0].ID)
+				assert.False(t, matches[0].Ambiguous)
+			}
+		})
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func Test_normalizeLicenseText(t *testing.T) {
+	text := `Copyright (c) 2020 Someone
+(c) 2021 Someone Else
+
+1. Redistributions of the "Licence" -- in source form;
+   * must retain the above copyright notice.`
+
+	words, err := normalizeLicenseText(strings.NewReader(text))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"redistributions", "of", "the", "license", "in", "source", "form", "must", "retain", "the", "above", "copyright", "notice"}, words)
+}
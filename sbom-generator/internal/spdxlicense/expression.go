@@ -0,0 +1,275 @@
+package spdxlicense
+
+import (
+	"regexp"
+	"strings"
+)
+
+// LicenseRefPrefix is the prefix of the IDs of the licenses which are not on the SPDX license list.
+const LicenseRefPrefix = "LicenseRef-"
+
+// licenseRefInvalidCharsExp matches the characters not allowed in a license reference ID (letters, numbers, "." and
+// "-" only).
+var licenseRefInvalidCharsExp = regexp.MustCompile(`[^A-Za-z0-9.\-]+`)
+
+// LicenseRef is a license which is not on the SPDX license list, identified by a "LicenseRef-" ID and described by the
+// text it was found as (e.g. "LicenseRef-Public-Domain" for "Public Domain").
+type LicenseRef struct {
+	ID   string
+	Text string
+}
+
+// LicenseRefID returns the license reference ID of a license which is not on the SPDX license list.
+func LicenseRefID(text string) string {
+	id := strings.Trim(licenseRefInvalidCharsExp.ReplaceAllString(strings.TrimSpace(text), "-"), "-")
+	if id == "" {
+		id = "unknown"
+	}
+	return LicenseRefPrefix + id
+}
+
+// Expression is a license value normalized into a valid SPDX license expression, e.g. "GPLv2+ and LGPLv2+" into
+// "GPL-2.0-or-later AND LGPL-2.0-or-later".
+type Expression struct {
+	root *expressionNode
+	// Refs are the licenses of the expression which are not on the SPDX license list
+	Refs []LicenseRef
+}
+
+// String returns the SPDX license expression, or nothing for an empty license value.
+func (e Expression) String() string {
+	if e.root == nil {
+		return ""
+	}
+	return e.root.String()
+}
+
+// Empty indicates that the license value did not name any license.
+func (e Expression) Empty() bool {
+	return e.root == nil
+}
+
+// ParseExpression normalizes a license value found in package metadata into a SPDX license expression: the license
+// names are mapped to SPDX license IDs (including the Fedora and openEuler short names, e.g. "ASL 2.0" or "GPLv2+"), and
+// the AND, OR and WITH operators are recognized in any case (the Fedora and openEuler expressions use lowercase
+// operators). The licenses which do not map to SPDX license IDs become license references, and a value which is not
+// a valid expression becomes a single license reference.
+func ParseExpression(value string) Expression {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Expression{}
+	}
+
+	p := &expressionParser{tokens: tokenizeExpression(value)}
+	root, ok := p.parseOr()
+	if !ok || p.pos != len(p.tokens) {
+		root = newLicenseRefNode(value)
+	}
+
+	e := Expression{root: root}
+	e.Refs = root.refs(nil)
+	return e
+}
+
+// JoinExpressions returns the conjunction (AND) of expressions, leaving out the empty and duplicated ones.
+func JoinExpressions(expressions ...Expression) Expression {
+	var result Expression
+	seen := make(map[string]bool)
+	for _, e := range expressions {
+		if e.root == nil || seen[e.String()] {
+			continue
+		}
+		seen[e.String()] = true
+
+		if result.root == nil {
+			result.root = e.root
+		} else {
+			result.root = &expressionNode{operator: andOperator, left: result.root, right: e.root}
+		}
+	}
+	if result.root != nil {
+		result.Refs = result.root.refs(nil)
+	}
+	return result
+}
+
+const (
+	andOperator  = "AND"
+	orOperator   = "OR"
+	withOperator = "WITH"
+)
+
+// operatorPrecedence is the precedence of the license expression operators, see
+// https://spdx.github.io/spdx-spec/SPDX-license-expressions/#d45-order-of-precedence-and-parentheses
+var operatorPrecedence = map[string]int{
+	orOperator:   1,
+	andOperator:  2,
+	withOperator: 3,
+}
+
+// expressionNode is either a license (or license exception), or an operator applied to two sub-expressions.
+type expressionNode struct {
+	// id is the SPDX ID (or license reference ID) of a license
+	id string
+	// ref is the text of a license which is not on the SPDX license list
+	ref string
+
+	operator    string
+	left, right *expressionNode
+}
+
+func newLicenseRefNode(text string) *expressionNode {
+	return &expressionNode{id: LicenseRefID(text), ref: text}
+}
+
+func (n *expressionNode) String() string {
+	if n.operator == "" {
+		return n.id
+	}
+	return n.operand(n.left) + " " + n.operator + " " + n.operand(n.right)
+}
+
+// operand renders a sub-expression, enclosed in parentheses when its operator binds less than this one.
+func (n *expressionNode) operand(child *expressionNode) string {
+	if child.operator != "" && operatorPrecedence[child.operator] < operatorPrecedence[n.operator] {
+		return "(" + child.String() + ")"
+	}
+	return child.String()
+}
+
+func (n *expressionNode) refs(results []LicenseRef) []LicenseRef {
+	if n.operator != "" {
+		return n.right.refs(n.left.refs(results))
+	}
+	if n.ref == "" {
+		return results
+	}
+	for _, r := range results {
+		if r.ID == n.id {
+			return results
+		}
+	}
+	return append(results, LicenseRef{ID: n.id, Text: n.ref})
+}
+
+// tokenizeExpression splits a license expression into parentheses, operators and license names (which may contain
+// spaces, e.g. "ASL 2.0" or "Public Domain").
+func tokenizeExpression(value string) []string {
+	var tokens []string
+	var name []string
+	flush := func() {
+		if len(name) > 0 {
+			tokens = append(tokens, strings.Join(name, " "))
+			name = nil
+		}
+	}
+
+	for _, field := range strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(value)) {
+		switch upper := strings.ToUpper(field); upper {
+		case "(", ")", andOperator, orOperator, withOperator:
+			flush()
+			tokens = append(tokens, upper)
+		default:
+			name = append(name, field)
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+// expressionParser is a recursive descent parser of tokenized license expressions.
+type expressionParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *expressionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *expressionParser) parseOr() (*expressionNode, bool) {
+	return p.parseBinary(orOperator, p.parseAnd)
+}
+
+func (p *expressionParser) parseAnd() (*expressionNode, bool) {
+	return p.parseBinary(andOperator, p.parseWith)
+}
+
+func (p *expressionParser) parseBinary(operator string, operand func() (*expressionNode, bool)) (*expressionNode, bool) {
+	left, ok := operand()
+	if !ok {
+		return nil, false
+	}
+	for p.peek() == operator {
+		p.pos++
+		right, ok := operand()
+		if !ok {
+			return nil, false
+		}
+		left = &expressionNode{operator: operator, left: left, right: right}
+	}
+	return left, true
+}
+
+func (p *expressionParser) parseWith() (*expressionNode, bool) {
+	if p.peek() == "(" {
+		p.pos++
+		node, ok := p.parseOr()
+		if !ok || p.peek() != ")" {
+			return nil, false
+		}
+		p.pos++
+		return node, true
+	}
+
+	name := p.peek()
+	if !isLicenseName(name) {
+		return nil, false
+	}
+	p.pos++
+
+	if p.peek() != withOperator {
+		return newLicenseNode(name), true
+	}
+	p.pos++
+	exception := p.peek()
+	if !isLicenseName(exception) {
+		return nil, false
+	}
+	p.pos++
+
+	license := newLicenseNode(name)
+	exceptionID, exists := ExceptionID(exception)
+	if !exists || license.ref != "" {
+		// some short names read as an exception (e.g. "BSD with advertising"), others are not on the SPDX license list
+		// (e.g. "GPLv2+ with exceptions")
+		return newLicenseNode(name + " with " + exception), true
+	}
+	return &expressionNode{operator: withOperator, left: license, right: &expressionNode{id: exceptionID}}, true
+}
+
+func isLicenseName(token string) bool {
+	switch token {
+	case "", "(", ")", andOperator, orOperator, withOperator:
+		return false
+	}
+	return true
+}
+
+// newLicenseNode returns the license of a name: its SPDX license ID, or else a license reference.
+func newLicenseNode(name string) *expressionNode {
+	if strings.HasPrefix(name, LicenseRefPrefix) {
+		return &expressionNode{id: name}
+	}
+	if id, exists := ID(name); exists {
+		return &expressionNode{id: id}
+	}
+	if id, exists := FedoraID(name); exists {
+		return &expressionNode{id: id}
+	}
+	return newLicenseRefNode(name)
+}
@@ -0,0 +1,77 @@
+package spdxlicense
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		refs     []LicenseRef
+	}{
+		{value: "MIT", expected: "MIT"},
+		{value: "mit", expected: "MIT"},
+		{value: "GPL-2", expected: "GPL-2.0"},
+		{value: "GPLv2+", expected: "GPL-2.0-or-later"},
+		{value: "ASL 2.0", expected: "Apache-2.0"},
+		{value: "GPLv2+ and LGPLv2+", expected: "GPL-2.0-or-later AND LGPL-2.0-or-later"},
+		{value: "(GPLv2+ or MIT) and ASL 2.0", expected: "(GPL-2.0-or-later OR MIT) AND Apache-2.0"},
+		{value: "GPLv2+ or MIT and ASL 2.0", expected: "GPL-2.0-or-later OR MIT AND Apache-2.0"},
+		{value: "((MIT))", expected: "MIT"},
+		{value: "MulanPSL-2.0", expected: "MulanPSL-2.0"},
+		{value: "Mulan PSL v2", expected: "MulanPSL-2.0"},
+		{value: "GPL-2.0-only WITH Classpath-exception-2.0", expected: "GPL-2.0-only WITH Classpath-exception-2.0"},
+		{value: "GPLv2 with classpath-exception-2.0 or MIT", expected: "GPL-2.0-only WITH Classpath-exception-2.0 OR MIT"},
+		{value: "BSD with advertising", expected: "BSD-4-Clause"},
+		{
+			value:    "GPLv2+ with exceptions",
+			expected: "LicenseRef-GPLv2-with-exceptions",
+			refs:     []LicenseRef{{ID: "LicenseRef-GPLv2-with-exceptions", Text: "GPLv2+ with exceptions"}},
+		},
+		{
+			value:    "Public Domain and BSD and Public Domain",
+			expected: "LicenseRef-Public-Domain AND LicenseRef-BSD AND LicenseRef-Public-Domain",
+			refs: []LicenseRef{
+				{ID: "LicenseRef-Public-Domain", Text: "Public Domain"},
+				{ID: "LicenseRef-BSD", Text: "BSD"},
+			},
+		},
+		{value: "LicenseRef-Proprietary", expected: "LicenseRef-Proprietary"},
+		{
+			// not a valid expression
+			value:    "MIT and (GPLv2+",
+			expected: "LicenseRef-MIT-and-GPLv2",
+			refs:     []LicenseRef{{ID: "LicenseRef-MIT-and-GPLv2", Text: "MIT and (GPLv2+"}},
+		},
+		{value: "  ", expected: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			e := ParseExpression(test.value)
+			assert.Equal(t, test.expected, e.String())
+			assert.Equal(t, test.refs, e.Refs)
+		})
+	}
+}
+
+func TestJoinExpressions(t *testing.T) {
+	e := JoinExpressions(
+		ParseExpression("GPLv2+ or MIT"),
+		ParseExpression(""),
+		ParseExpression("Public Domain"),
+		ParseExpression("GPLv2+ or MIT"),
+	)
+	assert.Equal(t, "(GPL-2.0-or-later OR MIT) AND LicenseRef-Public-Domain", e.String())
+	assert.Equal(t, []LicenseRef{{ID: "LicenseRef-Public-Domain", Text: "Public Domain"}}, e.Refs)
+
+	assert.True(t, JoinExpressions().Empty())
+}
+
+func TestLicenseRefID(t *testing.T) {
+	assert.Equal(t, "LicenseRef-Copyright-only", LicenseRefID("Copyright only"))
+	assert.Equal(t, "LicenseRef-GPLv2-with-exceptions", LicenseRefID("GPLv2+ with exceptions"))
+	assert.Equal(t, "LicenseRef-unknown", LicenseRefID("++"))
+}
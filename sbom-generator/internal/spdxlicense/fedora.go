@@ -0,0 +1,114 @@
+package spdxlicense
+
+import "strings"
+
+// fedoraLicenseIDs maps the (legacy) short license names of the Fedora, openEuler and other rpm based distributions
+// to SPDX license IDs. The ambiguous names (e.g. "BSD", which stands for several BSD licenses) are not mapped.
+// See https://fedoraproject.org/wiki/Licensing:Main#Good_Licenses
+var fedoraLicenseIDs = map[string]string{
+	"agplv3":                    "AGPL-3.0-only",
+	"agplv3+":                   "AGPL-3.0-or-later",
+	"artistic 2.0":              "Artistic-2.0",
+	"artistic clarified":        "ClArtistic",
+	"asl 1.0":                   "Apache-1.0",
+	"asl 1.1":                   "Apache-1.1",
+	"asl 2.0":                   "Apache-2.0",
+	"boost":                     "BSL-1.0",
+	"bsd with advertising":      "BSD-4-Clause",
+	"cc0":                       "CC0-1.0",
+	"cddl":                      "CDDL-1.0",
+	"epl":                       "EPL-1.0",
+	"gfdl":                      "GFDL-1.1-or-later",
+	"gpl+":                      "GPL-1.0-or-later",
+	"gplv1":                     "GPL-1.0-only",
+	"gplv2":                     "GPL-2.0-only",
+	"gplv2+":                    "GPL-2.0-or-later",
+	"gplv3":                     "GPL-3.0-only",
+	"gplv3+":                    "GPL-3.0-or-later",
+	"lgplv2":                    "LGPL-2.0-only",
+	"lgplv2+":                   "LGPL-2.0-or-later",
+	"lgplv2.1":                  "LGPL-2.1-only",
+	"lgplv2.1+":                 "LGPL-2.1-or-later",
+	"lgplv3":                    "LGPL-3.0-only",
+	"lgplv3+":                   "LGPL-3.0-or-later",
+	"mpl 1.0":                   "MPL-1.0",
+	"mpl 1.1":                   "MPL-1.1",
+	"mpl 2.0":                   "MPL-2.0",
+	"mplv1.0":                   "MPL-1.0",
+	"mplv1.1":                   "MPL-1.1",
+	"mplv2.0":                   "MPL-2.0",
+	"mulan psl v2":              "MulanPSL-2.0",
+	"mulanpslv2":                "MulanPSL-2.0",
+	"ofl":                       "OFL-1.1",
+	"php":                       "PHP-3.01",
+	"python":                    "Python-2.0",
+	"qpl":                       "QPL-1.0",
+	"upl":                       "UPL-1.0",
+	"wtfpl":                     "WTFPL",
+	"zlib":                      "Zlib",
+	"zlib with acknowledgement": "zlib-acknowledgement",
+}
+
+// FedoraID returns the SPDX license ID of a Fedora (or openEuler) short license name, e.g. "Apache-2.0" for "ASL 2.0".
+func FedoraID(name string) (string, bool) {
+	value, exists := fedoraLicenseIDs[strings.ToLower(strings.Join(strings.Fields(name), " "))]
+	return value, exists
+}
+
+// exceptionIDs are the IDs of the SPDX license exceptions (by lowercase ID), which may follow a license in a WITH
+// expression. See https://spdx.org/licenses/exceptions-index.html
+var exceptionIDs = map[string]string{}
+
+func init() {
+	for _, id := range []string{
+		"389-exception",
+		"Autoconf-exception-2.0",
+		"Autoconf-exception-3.0",
+		"Bison-exception-2.2",
+		"Bootloader-exception",
+		"Classpath-exception-2.0",
+		"CLISP-exception-2.0",
+		"DigiRule-FOSS-exception",
+		"eCos-exception-2.0",
+		"Fawkes-Runtime-exception",
+		"FLTK-exception",
+		"Font-exception-2.0",
+		"freertos-exception-2.0",
+		"GCC-exception-2.0",
+		"GCC-exception-3.1",
+		"gnu-javamail-exception",
+		"GPL-3.0-linking-exception",
+		"GPL-3.0-linking-source-exception",
+		"GPL-CC-1.0",
+		"i2p-gpl-java-exception",
+		"LGPL-3.0-linking-exception",
+		"Libtool-exception",
+		"Linux-syscall-note",
+		"LLVM-exception",
+		"LZMA-exception",
+		"mif-exception",
+		"Nokia-Qt-exception-1.1",
+		"OCaml-LGPL-linking-exception",
+		"OCCT-exception-1.0",
+		"OpenJDK-assembly-exception-1.0",
+		"openvpn-openssl-exception",
+		"PS-or-PDF-font-exception-20170817",
+		"Qt-GPL-exception-1.0",
+		"Qt-LGPL-exception-1.1",
+		"Qwt-exception-1.0",
+		"SHL-2.0",
+		"SHL-2.1",
+		"Swift-exception",
+		"u-boot-exception-2.0",
+		"Universal-FOSS-exception-1.0",
+		"WxWindows-exception-3.1",
+	} {
+		exceptionIDs[strings.ToLower(id)] = id
+	}
+}
+
+// ExceptionID returns the SPDX ID of a license exception, e.g. "Classpath-exception-2.0" for "classpath-exception-2.0".
+func ExceptionID(name string) (string, bool) {
+	value, exists := exceptionIDs[strings.ToLower(name)]
+	return value, exists
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/anchore/syft/internal/spdxlicense"
+)
+
+// This program generates the license templates of the classifier (templates/<ID>/license.txt and header.txt) from the
+// SPDX license list data of the bundled license list version.
+const (
+	templatesDir = "templates"
+	urlFormat    = "https://raw.githubusercontent.com/spdx/license-list-data/v%s/json/details/%s.json"
+)
+
+// licenseIDs are the licenses the classifier detects
+var licenseIDs = []string{
+	"0BSD",
+	"AGPL-3.0-only",
+	"AGPL-3.0-or-later",
+	"Apache-2.0",
+	"BSD-2-Clause",
+	"BSD-3-Clause",
+	"BSL-1.0",
+	"CC0-1.0",
+	"EPL-1.0",
+	"EPL-2.0",
+	"GPL-2.0-only",
+	"GPL-2.0-or-later",
+	"GPL-3.0-only",
+	"GPL-3.0-or-later",
+	"ISC",
+	"LGPL-2.1-only",
+	"LGPL-2.1-or-later",
+	"LGPL-3.0-only",
+	"LGPL-3.0-or-later",
+	"MIT",
+	"MPL-2.0",
+	"Unlicense",
+	"WTFPL",
+	"Zlib",
+}
+
+// headerIDs are the licenses whose standard header is also a template, as it is commonly found on its own in the
+// notices of a package (the GNU license headers are left out, since they tell the version variants apart while the
+// license texts do not)
+var headerIDs = map[string]bool{
+	"Apache-2.0": true,
+	"MPL-2.0":    true,
+}
+
+type LicenseDetails struct {
+	ID                    string `json:"licenseId"`
+	Text                  string `json:"licenseText"`
+	StandardLicenseHeader string `json:"standardLicenseHeader"`
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	for _, id := range licenseIDs {
+		details, err := getLicenseDetails(id)
+		if err != nil {
+			return err
+		}
+
+		dir := filepath.Join(templatesDir, id)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("unable to create %q: %+v", dir, err)
+		}
+		if err := writeTemplate(filepath.Join(dir, "license.txt"), details.Text); err != nil {
+			return err
+		}
+		if headerIDs[id] {
+			if err := writeTemplate(filepath.Join(dir, "header.txt"), details.StandardLicenseHeader); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func getLicenseDetails(id string) (*LicenseDetails, error) {
+	url := fmt.Sprintf(urlFormat, spdxlicense.Version, id)
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the license details of %q: %+v", id, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Fatalf("unable to close body: %+v", err)
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to get the license details of %q: %s", id, resp.Status)
+	}
+
+	var details LicenseDetails
+	if err = json.NewDecoder(resp.Body).Decode(&details); err != nil {
+		return nil, fmt.Errorf("unable to decode the license details of %q: %+v", id, err)
+	}
+	return &details, nil
+}
+
+func writeTemplate(p, text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return fmt.Errorf("no license text for %q", p)
+	}
+	if err := os.WriteFile(p, []byte(text+"\n"), 0644); err != nil { //nolint:gosec
+		return fmt.Errorf("unable to write %q: %+v", p, err)
+	}
+	return nil
+}
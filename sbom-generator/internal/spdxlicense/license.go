@@ -13,6 +13,7 @@ import (
 // See the debian link for more details on the spdx license differences
 
 //go:generate go run generate/generate_license_list.go
+//go:generate go run generate/license_templates/generate_license_templates.go
 
 func ID(id string) (string, bool) {
 	value, exists := licenseIDs[strings.ToLower(id)]
@@ -16,6 +16,10 @@ const (
 	// DevDependencyOfRelationship is a proxy for the SPDX 2.2.1 DEV_DEPENDENCY_OF relationship.
 	DevDependencyOfRelationship RelationshipType = "dev-dependency-of"
 
+	// OptionalDependencyOfRelationship is a proxy for the SPDX 2.2.1 OPTIONAL_DEPENDENCY_OF relationship: the parent
+	// package is an optional dependency of the child package (e.g. an npm optionalDependencies entry).
+	OptionalDependencyOfRelationship RelationshipType = "optional-dependency-of"
+
 	// BuildDependencyOfRelationship is a proxy for the SPDX 2.2.1 BUILD_DEPENDENCY_OF relationship.
 	BuildDependencyOfRelationship RelationshipType = "build-dependency-of"
 
@@ -24,6 +28,26 @@ const (
 
 	// DependencyOfRelationship is a proxy for the SPDX 2.2.1 DEPENDS_ON	relationship.
 	DependsOnRelationship RelationshipType = "DEPENDS_ON"
+
+	// HasPrerequisiteRelationship is a proxy for the SPDX 2.2 HAS_PREREQUISITE relationship: the child package must
+	// be installed before the parent package, e.g. a rpm Requires(pre) or Requires(post) used by scriptlets.
+	HasPrerequisiteRelationship RelationshipType = "has-prerequisite"
+
+	// RecommendsRelationship (supports package-to-package linkages) indicates a weak dependency: the parent package
+	// recommends the child package, which is installed along by default but may be left out (rpm Recommends).
+	RecommendsRelationship RelationshipType = "recommends"
+
+	// SuggestsRelationship (supports package-to-package linkages) indicates a weak dependency: the parent package
+	// suggests the child package, which is not installed by default (rpm Suggests).
+	SuggestsRelationship RelationshipType = "suggests"
+
+	// SupplementsRelationship (supports package-to-package linkages) is the reverse of RecommendsRelationship: the
+	// parent package is installed along by default with the child package (rpm Supplements).
+	SupplementsRelationship RelationshipType = "supplements"
+
+	// EnhancesRelationship (supports package-to-package linkages) is the reverse of SuggestsRelationship: the parent
+	// package is an optional addition to the child package (rpm Enhances).
+	EnhancesRelationship RelationshipType = "enhances"
 )
 
 type RelationshipType string
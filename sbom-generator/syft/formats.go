@@ -4,11 +4,16 @@ import (
 	"bytes"
 	"strings"
 
+	"github.com/anchore/syft/internal/formats/common/cyclonedxhelpers"
 	"github.com/anchore/syft/internal/formats/cyclonedxjson"
 	"github.com/anchore/syft/internal/formats/cyclonedxxml"
 	"github.com/anchore/syft/internal/formats/github"
 	"github.com/anchore/syft/internal/formats/spdx22json"
 	"github.com/anchore/syft/internal/formats/spdx22tagvalue"
+	"github.com/anchore/syft/internal/formats/spdx23json"
+	"github.com/anchore/syft/internal/formats/spdx23tagvalue"
+	"github.com/anchore/syft/internal/formats/spdx23yaml"
+	"github.com/anchore/syft/internal/formats/spdx30json"
 	"github.com/anchore/syft/internal/formats/syftjson"
 	"github.com/anchore/syft/internal/formats/table"
 	"github.com/anchore/syft/internal/formats/text"
@@ -17,14 +22,18 @@ import (
 
 // these have been exported for the benefit of API users
 const (
-	JSONFormatID          = syftjson.ID
-	TextFormatID          = text.ID
-	TableFormatID         = table.ID
-	CycloneDxXMLFormatID  = cyclonedxxml.ID
-	CycloneDxJSONFormatID = cyclonedxjson.ID
-	GitHubID              = github.ID
-	SPDXTagValueFormatID  = spdx22tagvalue.ID
-	SPDXJSONFormatID      = spdx22json.ID
+	JSONFormatID           = syftjson.ID
+	TextFormatID           = text.ID
+	TableFormatID          = table.ID
+	CycloneDxXMLFormatID   = cyclonedxxml.ID
+	CycloneDxJSONFormatID  = cyclonedxjson.ID
+	GitHubID               = github.ID
+	SPDXTagValueFormatID   = spdx22tagvalue.ID
+	SPDXJSONFormatID       = spdx22json.ID
+	SPDX23TagValueFormatID = spdx23tagvalue.ID
+	SPDX23JSONFormatID     = spdx23json.ID
+	SPDX23YAMLFormatID     = spdx23yaml.ID
+	SPDX30JSONFormatID     = spdx30json.ID
 )
 
 var formats []sbom.Format
@@ -35,6 +44,12 @@ func init() {
 		cyclonedxxml.Format(),
 		cyclonedxjson.Format(),
 		github.Format(),
+		spdx30json.Format(),
+		// the SPDX 2.3 formats validate the version of the documents, and are identified before the SPDX 2.2 ones
+		// (a JSON document is a YAML document as well, so JSON is identified first)
+		spdx23json.Format(),
+		spdx23tagvalue.Format(),
+		spdx23yaml.Format(),
 		spdx22tagvalue.Format(),
 		spdx22json.Format(),
 		table.Format(),
@@ -66,6 +81,17 @@ func FormatByName(name string) sbom.Format {
 		}
 	}
 
+	// handle the CycloneDX formats for a given version of the specification (e.g. "cyclonedx-1.5-json")
+	for _, specVersion := range cyclonedxhelpers.SupportedSpecVersions {
+		versionedName := "cyclonedx" + specVersion.String()
+		switch cleanName {
+		case versionedName, versionedName + "xml":
+			return cyclonedxxml.FormatWithVersion(specVersion)
+		case versionedName + "json":
+			return cyclonedxjson.FormatWithVersion(specVersion)
+		}
+	}
+
 	// handle any aliases for any supported format
 	switch cleanName {
 	case "json", "syftjson":
@@ -76,10 +102,18 @@ func FormatByName(name string) sbom.Format {
 		return FormatByID(cyclonedxjson.ID)
 	case "github", "githubjson":
 		return FormatByID(github.ID)
-	case "spdx", "spdxtv", "spdxtagvalue":
+	case "spdx", "spdxtv", "spdxtagvalue", "spdx22", "spdx2.2", "spdx22tv", "spdx22tagvalue", "spdx2.2tagvalue":
 		return FormatByID(spdx22tagvalue.ID)
-	case "spdxjson":
+	case "spdxjson", "spdx22json", "spdx2.2json":
 		return FormatByID(spdx22json.ID)
+	case "spdx23", "spdx2.3", "spdx23tv", "spdx23tagvalue", "spdx2.3tagvalue":
+		return FormatByID(spdx23tagvalue.ID)
+	case "spdx23json", "spdx2.3json":
+		return FormatByID(spdx23json.ID)
+	case "spdxyaml", "spdx23yaml", "spdx2.3yaml":
+		return FormatByID(spdx23yaml.ID)
+	case "spdx3", "spdx30", "spdx3.0", "spdx3json", "spdx30json", "spdx3.0json", "spdxjsonld":
+		return FormatByID(spdx30json.ID)
 	case "table":
 		return FormatByID(table.ID)
 	case "text":
@@ -11,10 +11,16 @@ import (
 	"github.com/anchore/syft/internal/formats/github"
 	"github.com/anchore/syft/internal/formats/spdx22json"
 	"github.com/anchore/syft/internal/formats/spdx22tagvalue"
+	"github.com/anchore/syft/internal/formats/spdx23json"
+	"github.com/anchore/syft/internal/formats/spdx23tagvalue"
+	"github.com/anchore/syft/internal/formats/spdx23yaml"
+	"github.com/anchore/syft/internal/formats/spdx30json"
 	"github.com/anchore/syft/internal/formats/syftjson"
 	"github.com/anchore/syft/internal/formats/table"
 	"github.com/anchore/syft/internal/formats/text"
+	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
 	"github.com/stretchr/testify/require"
 
 	"github.com/stretchr/testify/assert"
@@ -104,6 +110,23 @@ func TestFormatByName(t *testing.T) {
 			want: spdx22tagvalue.ID,
 		},
 
+		{
+			name: "spdx-2.2",
+			want: spdx22tagvalue.ID,
+		},
+		{
+			name: "spdx-2.3",
+			want: spdx23tagvalue.ID,
+		},
+		{
+			name: "spdx-2.3-tag-value",
+			want: spdx23tagvalue.ID,
+		},
+		{
+			name: "spdx23-tv",
+			want: spdx23tagvalue.ID,
+		},
+
 		// SPDX JSON
 		{
 			name: "spdx-json",
@@ -113,6 +136,46 @@ func TestFormatByName(t *testing.T) {
 			name: "spdx-2-json",
 			want: spdx22json.ID,
 		},
+		{
+			name: "spdx-2.2-json",
+			want: spdx22json.ID,
+		},
+		{
+			name: "spdx-2.3-json",
+			want: spdx23json.ID,
+		},
+		{
+			name: "spdx23-json",
+			want: spdx23json.ID,
+		},
+
+		// SPDX YAML
+		{
+			name: "spdx-yaml",
+			want: spdx23yaml.ID,
+		},
+		{
+			name: "spdx-2.3-yaml",
+			want: spdx23yaml.ID,
+		},
+
+		// SPDX 3.0 JSON-LD
+		{
+			name: "spdx-3.0-json",
+			want: spdx30json.ID,
+		},
+		{
+			name: "spdx3",
+			want: spdx30json.ID,
+		},
+		{
+			name: "spdx-3-json",
+			want: spdx30json.ID,
+		},
+		{
+			name: "spdx-json-ld",
+			want: spdx30json.ID,
+		},
 
 		// Cyclonedx JSON
 		{
@@ -123,6 +186,10 @@ func TestFormatByName(t *testing.T) {
 			name: "cyclonedx-1-json",
 			want: cyclonedxjson.ID,
 		},
+		{
+			name: "cyclonedx-1.5-json",
+			want: cyclonedxjson.ID,
+		},
 
 		// Cyclonedx XML
 		{
@@ -137,6 +204,14 @@ func TestFormatByName(t *testing.T) {
 			name: "cyclonedx-1-xml",
 			want: cyclonedxxml.ID,
 		},
+		{
+			name: "cyclonedx-1.2",
+			want: cyclonedxxml.ID,
+		},
+		{
+			name: "cyclonedx-1.5-xml",
+			want: cyclonedxxml.ID,
+		},
 
 		// Syft Table
 		{
@@ -194,3 +269,65 @@ func TestFormatByName(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatByName_cycloneDxSpecVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{
+			name: "cyclonedx-json",
+			want: `"specVersion": "1.4"`,
+		},
+		{
+			name: "cyclonedx-1.2-json",
+			want: `"specVersion": "1.2"`,
+		},
+		{
+			name: "cyclonedx-1.5-json",
+			want: `"specVersion": "1.5"`,
+		},
+		{
+			name: "cyclonedx-1.3-xml",
+			want: `xmlns="http://cyclonedx.org/schema/bom/1.3"`,
+		},
+		{
+			name: "cyclonedx-1.5",
+			want: `xmlns="http://cyclonedx.org/schema/bom/1.5"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := FormatByName(tt.name)
+			require.NotNil(t, f)
+
+			var buf bytes.Buffer
+			require.NoError(t, f.Encode(&buf, sbom.SBOM{Artifacts: sbom.Artifacts{PackageCatalog: pkg.NewCatalog()}}))
+			assert.Contains(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestIdentify_encodedFormats(t *testing.T) {
+	p := pkg.Package{
+		Name:    "lib",
+		Version: "1.0.0",
+		PURL:    "pkg:deb/debian/lib@1.0.0",
+	}
+	p.SetID()
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{PackageCatalog: pkg.NewCatalog(p)},
+		Source:    source.Metadata{Scheme: source.DirectoryScheme, Path: "/"},
+	}
+
+	for _, id := range []sbom.FormatID{github.ID, spdx22tagvalue.ID} {
+		t.Run(string(id), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, FormatByID(id).Encode(&buf, s))
+
+			frmt := IdentifyFormat(buf.Bytes())
+			require.NotNil(t, frmt)
+			assert.Equal(t, id, frmt.ID())
+		})
+	}
+}
@@ -60,11 +60,14 @@ func CatalogPackages(src *source.Source, cfg cataloger.Config) (*pkg.Catalog, []
 	case source.DirectoryScheme:
 		log.Info("cataloging directory")
 		catalogers = cataloger.DirectoryCatalogers(cfg)
+	case source.RepoScheme:
+		log.Info("cataloging repository")
+		catalogers = cataloger.RepoCatalogers(cfg)
 	default:
 		return nil, nil, nil, fmt.Errorf("unable to determine cataloger set from scheme=%+v", src.Metadata.Scheme)
 	}
 
-	catalog, relationships, err := cataloger.Catalog(resolver, release, catalogers...)
+	catalog, relationships, err := cataloger.CatalogWithConfig(resolver, release, cfg, catalogers...)
 	if err != nil {
 		return nil, nil, nil, err
 	}
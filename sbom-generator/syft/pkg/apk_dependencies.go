@@ -0,0 +1,268 @@
+package pkg
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/anchore/syft/syft/artifact"
+)
+
+// apkDependency is a single dependency of an apk package, e.g. "so:libc.musl-x86_64.so.1" or "busybox>=1.35".
+type apkDependency struct {
+	Name     string
+	Operator string
+	Version  string
+	Conflict bool
+}
+
+// parseApkDependencies parses the space separated dependencies of an installed apk package (the "D:" record).
+// See https://wiki.alpinelinux.org/wiki/Apk_spec
+func parseApkDependencies(field string) []apkDependency {
+	var dependencies []apkDependency
+	for _, token := range strings.Fields(field) {
+		var dependency apkDependency
+		if strings.HasPrefix(token, "!") {
+			dependency.Conflict = true
+			token = token[1:]
+		}
+		if i := strings.IndexAny(token, "<>=~"); i >= 0 {
+			operatorEnd := i + strings.IndexFunc(token[i:], func(r rune) bool {
+				return !strings.ContainsRune("<>=~", r)
+			})
+			if operatorEnd < i {
+				operatorEnd = len(token)
+			}
+			dependency.Operator = token[i:operatorEnd]
+			dependency.Version = token[operatorEnd:]
+			token = token[:i]
+		}
+		// pinned repositories (e.g. "name@testing") don't change the name of the package
+		if i := strings.Index(token, "@"); i >= 0 {
+			token = token[:i]
+		}
+		dependency.Name = token
+		if dependency.Name != "" {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	return dependencies
+}
+
+// parseApkProvides parses the space separated "name=version" or unversioned "name" provides of an installed apk
+// package (the "p:" record).
+func parseApkProvides(field string) []apkDependency {
+	var provides []apkDependency
+	for _, token := range strings.Fields(field) {
+		provide := apkDependency{Name: token}
+		if i := strings.Index(token, "="); i >= 0 {
+			provide = apkDependency{Name: token[:i], Operator: "=", Version: token[i+1:]}
+		}
+		provides = append(provides, provide)
+	}
+	return provides
+}
+
+func (d apkDependency) satisfiedBy(c providedCapability) bool {
+	if d.Operator == "" {
+		return true
+	}
+	// unversioned provides never satisfy versioned dependencies
+	if c.Version == "" {
+		return false
+	}
+
+	switch d.Operator {
+	case "~", "~=":
+		return c.Version == d.Version || strings.HasPrefix(c.Version, d.Version+".") ||
+			strings.HasPrefix(c.Version, d.Version+"-") || strings.HasPrefix(c.Version, d.Version+"_")
+	}
+
+	comparison := compareApkVersions(c.Version, d.Version)
+	switch d.Operator {
+	case "<":
+		return comparison < 0
+	case "<=", "=<":
+		return comparison <= 0
+	case "=":
+		return comparison == 0
+	case ">=", "=>":
+		return comparison >= 0
+	case ">":
+		return comparison > 0
+	}
+	return false
+}
+
+func apkDependencyRelationships(catalog *Catalog) []artifact.Relationship {
+	pkgs := catalog.Sorted(ApkPkg)
+
+	providers := make(capabilityIndex)
+	for _, p := range pkgs {
+		metadata, ok := p.Metadata.(ApkMetadata)
+		if !ok {
+			continue
+		}
+		providers.add(metadata.Package, p, metadata.Version)
+		for _, provide := range parseApkProvides(metadata.Provides) {
+			providers.add(provide.Name, p, provide.Version)
+		}
+		// path dependencies (e.g. "/bin/sh") are satisfied by the packages installing the path
+		for _, f := range metadata.Files {
+			providers.add(f.Path, p, "")
+		}
+	}
+
+	edges := newDependencyEdges()
+	for _, p := range pkgs {
+		metadata, ok := p.Metadata.(ApkMetadata)
+		if !ok {
+			continue
+		}
+		for _, dependency := range parseApkDependencies(metadata.PullDependencies) {
+			if dependency.Conflict {
+				continue
+			}
+			var satisfying []providedCapability
+			for _, c := range providers[dependency.Name] {
+				if dependency.satisfiedBy(c) {
+					satisfying = append(satisfying, c)
+				}
+			}
+			if satisfiedBySelf(p, satisfying) {
+				continue
+			}
+			for _, provider := range uniquePackages(satisfying) {
+				edges.add(p, provider, artifact.DependsOnRelationship)
+			}
+		}
+	}
+	return edges.relationships()
+}
+
+// apk version tokens, ordered the way apk-tools orders them when two versions differ in the kind of their tokens: a
+// version continuing with a lower kind of token is the greater version.
+const (
+	apkTokenDigit = iota
+	apkTokenLetter
+	apkTokenSuffix
+	apkTokenRevision
+	apkTokenEnd
+)
+
+// apkSuffixes orders the version suffixes: pre-releases sort before the version without a suffix (zero), the others
+// after it.
+var apkSuffixes = map[string]int{
+	"alpha": -4,
+	"beta":  -3,
+	"pre":   -2,
+	"rc":    -1,
+	"cvs":   1,
+	"svn":   2,
+	"git":   3,
+	"hg":    4,
+	"p":     5,
+}
+
+type apkVersionToken struct {
+	kind  int
+	value int
+}
+
+// tokenizeApkVersion splits a "1.2.3a_rc1_p2-r4" version into its numbers, letter, suffixes (each followed by their
+// optional number) and revision.
+func tokenizeApkVersion(version string) []apkVersionToken {
+	var tokens []apkVersionToken
+
+	readNumber := func() (int, bool) {
+		end := 0
+		for end < len(version) && version[end] >= '0' && version[end] <= '9' {
+			end++
+		}
+		if end == 0 {
+			return 0, false
+		}
+		n, _ := strconv.Atoi(version[:end])
+		version = version[end:]
+		return n, true
+	}
+
+	for {
+		n, ok := readNumber()
+		if !ok {
+			break
+		}
+		tokens = append(tokens, apkVersionToken{kind: apkTokenDigit, value: n})
+		if !strings.HasPrefix(version, ".") {
+			break
+		}
+		version = version[1:]
+	}
+
+	if version != "" && version[0] >= 'a' && version[0] <= 'z' {
+		tokens = append(tokens, apkVersionToken{kind: apkTokenLetter, value: int(version[0])})
+		version = version[1:]
+	}
+
+	for strings.HasPrefix(version, "_") {
+		version = version[1:]
+		end := 0
+		for end < len(version) && version[end] >= 'a' && version[end] <= 'z' {
+			end++
+		}
+		tokens = append(tokens, apkVersionToken{kind: apkTokenSuffix, value: apkSuffixes[version[:end]]})
+		version = version[end:]
+		if n, ok := readNumber(); ok {
+			tokens = append(tokens, apkVersionToken{kind: apkTokenDigit, value: n})
+		}
+	}
+
+	if strings.HasPrefix(version, "-r") {
+		version = version[2:]
+		n, _ := readNumber()
+		tokens = append(tokens, apkVersionToken{kind: apkTokenRevision, value: n})
+	}
+
+	return tokens
+}
+
+// compareApkVersions compares two apk package versions the way apk-tools does.
+// See https://gitlab.alpinelinux.org/alpine/apk-tools/-/blob/master/src/version.c
+func compareApkVersions(a, b string) int {
+	aTokens, bTokens := tokenizeApkVersion(a), tokenizeApkVersion(b)
+
+	for i := 0; ; i++ {
+		at, bt := apkVersionToken{kind: apkTokenEnd}, apkVersionToken{kind: apkTokenEnd}
+		if i < len(aTokens) {
+			at = aTokens[i]
+		}
+		if i < len(bTokens) {
+			bt = bTokens[i]
+		}
+
+		if at.kind == apkTokenEnd && bt.kind == apkTokenEnd {
+			return 0
+		}
+
+		if at.kind == bt.kind {
+			if at.value < bt.value {
+				return -1
+			}
+			if at.value > bt.value {
+				return 1
+			}
+			continue
+		}
+
+		// the longer version is the greater one unless it continues with a pre-release suffix
+		if at.kind == apkTokenSuffix && at.value < 0 {
+			return -1
+		}
+		if bt.kind == apkTokenSuffix && bt.value < 0 {
+			return 1
+		}
+		if at.kind > bt.kind {
+			return -1
+		}
+		return 1
+	}
+}
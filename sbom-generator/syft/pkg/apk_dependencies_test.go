@@ -0,0 +1,89 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseApkDependencies(t *testing.T) {
+	assert.Equal(t, []apkDependency{
+		{Name: "so:libc.musl-x86_64.so.1"},
+		{Name: "busybox", Operator: ">=", Version: "1.35.0"},
+		{Name: "openssl", Operator: "~", Version: "3.0"},
+		{Name: "edge-pkg", Operator: "<", Version: "2"},
+		{Name: "old-pkg", Conflict: true},
+		{Name: "/bin/sh"},
+	}, parseApkDependencies("so:libc.musl-x86_64.so.1 busybox>=1.35.0 openssl~3.0 edge-pkg@testing<2 !old-pkg /bin/sh"))
+}
+
+func TestParseApkProvides(t *testing.T) {
+	assert.Equal(t, []apkDependency{
+		{Name: "so:libc.musl-x86_64.so.1", Operator: "=", Version: "1"},
+		{Name: "cmd:getconf", Operator: "=", Version: "1.2.3-r4"},
+		{Name: "virtual-pkg"},
+	}, parseApkProvides("so:libc.musl-x86_64.so.1=1 cmd:getconf=1.2.3-r4 virtual-pkg"))
+}
+
+func TestCompareApkVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "1.2.3", b: "1.2.3", expected: 0},
+		{a: "1.2.3", b: "1.2.4", expected: -1},
+		{a: "1.10", b: "1.9", expected: 1},
+		{a: "1.2", b: "1.2.1", expected: -1},
+		{a: "1.2.3-r0", b: "1.2.3-r1", expected: -1},
+		{a: "1.2.3", b: "1.2.3-r1", expected: -1},
+		{a: "1.2.3_rc1", b: "1.2.3", expected: -1},
+		{a: "1.2.3_alpha2", b: "1.2.3_beta1", expected: -1},
+		{a: "1.2.3_p1", b: "1.2.3", expected: 1},
+		{a: "1.2.3a", b: "1.2.3", expected: 1},
+		{a: "1.2.3a", b: "1.2.3.1", expected: -1},
+		{a: "1.1.1t-r2", b: "1.1.1s-r5", expected: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.a+" vs "+test.b, func(t *testing.T) {
+			assert.Equal(t, test.expected, compareApkVersions(test.a, test.b))
+			assert.Equal(t, -test.expected, compareApkVersions(test.b, test.a))
+		})
+	}
+}
+
+func TestApkDependencyRelationships(t *testing.T) {
+	newApk := func(metadata ApkMetadata) Package {
+		p := Package{
+			Name:         metadata.Package,
+			Version:      metadata.Version,
+			Type:         ApkPkg,
+			MetadataType: ApkMetadataType,
+			Metadata:     metadata,
+		}
+		p.SetID()
+		return p
+	}
+
+	app := newApk(ApkMetadata{
+		Package:          "app",
+		Version:          "1.0-r0",
+		PullDependencies: "so:libc.musl-x86_64.so.1 busybox>=1.35 /bin/sh ssl_client~1.2 !conflicting libfoo>2 cmd:missing",
+	})
+	musl := newApk(ApkMetadata{Package: "musl", Version: "1.2.3-r4", Provides: "so:libc.musl-x86_64.so.1=1"})
+	busybox := newApk(ApkMetadata{Package: "busybox", Version: "1.36.1-r0", Files: []ApkFileRecord{{Path: "/bin/busybox"}, {Path: "/bin/sh"}}})
+	sslClient := newApk(ApkMetadata{Package: "ssl_client", Version: "1.2.13-r1"})
+	conflicting := newApk(ApkMetadata{Package: "conflicting", Version: "1"})
+	libfoo := newApk(ApkMetadata{Package: "libfoo-compat", Version: "3", Provides: "libfoo"})
+
+	catalog := NewCatalog(app, musl, busybox, sslClient, conflicting, libfoo)
+
+	assert.ElementsMatch(t, []artifact.Relationship{
+		{From: app, To: musl, Type: artifact.DependsOnRelationship},
+		// both the versioned package and the path resolve to busybox, which is related once
+		{From: app, To: busybox, Type: artifact.DependsOnRelationship},
+		{From: app, To: sslClient, Type: artifact.DependsOnRelationship},
+		// conflicts are not dependencies, and unversioned provides don't satisfy versioned dependencies
+	}, RelationshipsByDependencies(catalog))
+}
@@ -33,6 +33,7 @@ type ApkMetadata struct {
 	Size             int             `mapstructure:"S" json:"size" cyclonedx:"size"`
 	InstalledSize    int             `mapstructure:"I" json:"installedSize" cyclonedx:"installedSize"`
 	PullDependencies string          `mapstructure:"D" json:"pullDependencies" cyclonedx:"pullDependencies"`
+	Provides         string          `mapstructure:"-" json:"provides,omitempty"`
 	PullChecksum     string          `mapstructure:"C" json:"pullChecksum" cyclonedx:"pullChecksum"`
 	GitCommitOfAport string          `mapstructure:"c" json:"gitCommitOfApkPort" cyclonedx:"gitCommitOfApkPort"`
 	Files            []ApkFileRecord `json:"files"`
@@ -138,6 +138,9 @@ func parseApkDBEntry(reader io.Reader) (*pkg.ApkMetadata, error) {
 				Algorithm: "sha1",
 				Value:     value,
 			}
+		case "p":
+			// mapstructure falls back to case insensitive matching, which would confuse provides with the package name
+			entry.Provides = value
 		case "I", "S":
 			// coerce to integer
 			iVal, err := strconv.Atoi(value)
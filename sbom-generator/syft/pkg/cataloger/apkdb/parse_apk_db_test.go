@@ -99,6 +99,7 @@ func TestSinglePackageDetails(t *testing.T) {
 				Size:             37944,
 				InstalledSize:    151552,
 				PullDependencies: "scanelf so:libc.musl-x86_64.so.1",
+				Provides:         "cmd:getconf cmd:getent cmd:iconv cmd:ldconfig cmd:ldd",
 				PullChecksum:     "Q1bTtF5526tETKfL+lnigzIDvm+2o=",
 				GitCommitOfAport: "4024cc3b29ad4c65544ad068b8f59172b5494306",
 				Files: []pkg.ApkFileRecord{
@@ -178,6 +179,7 @@ func TestSinglePackageDetails(t *testing.T) {
 				Size:             19917,
 				InstalledSize:    409600,
 				PullDependencies: "/bin/sh so:libc.musl-x86_64.so.1",
+				Provides:         "cmd:mkmntdirs",
 				PullChecksum:     "Q1myMNfd7u5v5UTgNHeq1e31qTjZU=",
 				GitCommitOfAport: "e1c51734fa96fa4bac92e9f14a474324c67916fc",
 				Files: []pkg.ApkFileRecord{
@@ -693,6 +695,7 @@ func TestMultiplePackages(t *testing.T) {
 						Size:             37944,
 						InstalledSize:    151552,
 						PullDependencies: "scanelf so:libc.musl-x86_64.so.1",
+						Provides:         "cmd:getconf cmd:getent cmd:iconv cmd:ldconfig cmd:ldd",
 						PullChecksum:     "Q1bTtF5526tETKfL+lnigzIDvm+2o=",
 						GitCommitOfAport: "4024cc3b29ad4c65544ad068b8f59172b5494306",
 						Files: []pkg.ApkFileRecord{
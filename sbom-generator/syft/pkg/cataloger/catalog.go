@@ -2,6 +2,8 @@ package cataloger
 
 import (
 	"fmt"
+	"runtime"
+	"sync"
 
 	"github.com/anchore/syft/internal/bus"
 	"github.com/anchore/syft/internal/log"
@@ -39,65 +41,87 @@ func newMonitor() (*progress.Manual, *progress.Manual) {
 	return &filesProcessed, &packagesDiscovered
 }
 
+// catalogResult is the outcome of running a single cataloger.
+type catalogResult struct {
+	Packages      []pkg.Package
+	Relationships []artifact.Relationship
+	Error         error
+	// the package-file relationships of each of the packages (by index)
+	owningRelationships [][]artifact.Relationship
+}
+
 // Catalog a given source (container image or filesystem) with the given catalogers, returning all discovered packages.
 // In order to efficiently retrieve contents from a underlying container image the content fetch requests are
 // done in bulk. Specifically, all files of interest are collected from each catalogers and accumulated into a single
-// request.
+// request. Catalogers run concurrently, up to the parallelism of the default configuration (see CatalogWithConfig).
 func Catalog(resolver source.FileResolver, release *linux.Release, catalogers ...Cataloger) (*pkg.Catalog, []artifact.Relationship, error) {
+	return CatalogWithConfig(resolver, release, DefaultConfig(), catalogers...)
+}
+
+// CatalogWithConfig catalogs a given source like Catalog. Up to the parallelism of the given configuration, catalogers
+// run concurrently, and so does the completion of the packages they find (CPEs, PURLs and package-file relationships);
+// the results are merged in the order of the catalogers and of their packages, so the output does not depend on the
+// parallelism.
+func CatalogWithConfig(resolver source.FileResolver, release *linux.Release, cfg Config, catalogers ...Cataloger) (*pkg.Catalog, []artifact.Relationship, error) {
 	catalog := pkg.NewCatalog()
 	var allRelationships []artifact.Relationship
 
 	filesProcessed, packagesDiscovered := newMonitor()
 
-	// perform analysis, accumulating errors for each failed analysis
-	var errs error
-	for _, c := range catalogers {
-		// find packages from the underlying raw data
-		log.Infof("cataloging with %q", c.Name())
-		packages, relationships, err := c.Catalog(resolver)
-		if err != nil {
-			errs = multierror.Append(errs, err)
-			continue
-		}
-
-		catalogedPackages := len(packages)
+	parallelism := cfg.Parallelism
+	if parallelism < 1 {
+		parallelism = runtime.NumCPU()
+	}
+	// read once: viper is not safe for concurrent use
+	includeCPEs := viper.GetViper().GetBool("format.include-cpe")
 
-		log.Debugf("discovered %d packages", catalogedPackages)
-		packagesDiscovered.N += int64(catalogedPackages)
+	var licenses *licenseDetector
+	if cfg.DetectLicenses {
+		licenses = newLicenseDetector(resolver)
+	}
 
-		for _, p := range packages {
-			v := viper.GetViper()
-			if v.GetBool("format.include-cpe") {
-				// generate CPEs (note: this is excluded from package ID, so is safe to mutate)
-				p.CPEs = cpe.Generate(p)
-			}
+	results := make([]catalogResult, len(catalogers))
+	parallelize(len(catalogers), parallelism, func(i int) {
+		results[i] = runCataloger(catalogers[i], resolver)
+	}, func(i int) {
+		// the progress is only updated from here, as catalogers complete
+		packagesDiscovered.N += int64(len(results[i].Packages))
+	})
 
-			// generate PURL (note: this is excluded from package ID, so is safe to mutate)
-			p.PURL = pkg.URL(p, release)
-			providesPurls, extPkgPurls := pkg.URLs(p, release)
-			p.ProvidesPurls = providesPurls
-			p.ExtPkgPurls = extPkgPurls
-
-			// if we were not able to identify the language we have an opportunity
-			// to try and get this value from the PURL. Worst case we assert that
-			// we could not identify the language at either stage and set UnknownLanguage
-			if p.Language == "" {
-				p.Language = pkg.LanguageFromPURL(p.PURL)
-			}
+	// every package is completed on its own, the results are written back to where the package was found
+	type packageIndex struct {
+		result, pkg int
+	}
+	var packageIndexes []packageIndex
+	for r := range results {
+		results[r].owningRelationships = make([][]artifact.Relationship, len(results[r].Packages))
+		for p := range results[r].Packages {
+			packageIndexes = append(packageIndexes, packageIndex{result: r, pkg: p})
+		}
+	}
+	parallelize(len(packageIndexes), parallelism, func(i int) {
+		result := &results[packageIndexes[i].result]
+		p := packageIndexes[i].pkg
+		result.Packages[p], result.owningRelationships[p] = completePackage(result.Packages[p], resolver, release, includeCPEs, licenses)
+	}, func(int) {})
 
-			// create file-to-package relationships for files owned by the package
-			owningRelationships, err := packageFileOwnershipRelationships(p, resolver)
-			if err != nil {
-				log.Warnf("unable to create any package-file relationships for package name=%q: %w", p.Name, err)
-			} else {
-				allRelationships = append(allRelationships, owningRelationships...)
-			}
+	// perform analysis, accumulating errors for each failed analysis
+	var errs error
+	for _, result := range results {
+		if result.Error != nil {
+			errs = multierror.Append(errs, result.Error)
+			continue
+		}
 
+		for _, p := range result.Packages {
 			// add to catalog
 			catalog.Add(p)
 		}
 
-		allRelationships = append(allRelationships, relationships...)
+		for _, owningRelationships := range result.owningRelationships {
+			allRelationships = append(allRelationships, owningRelationships...)
+		}
+		allRelationships = append(allRelationships, result.Relationships...)
 	}
 
 	allRelationships = append(allRelationships, pkg.NewRelationships(catalog)...)
@@ -112,6 +136,90 @@ func Catalog(resolver source.FileResolver, release *linux.Release, catalogers ..
 	return catalog, allRelationships, nil
 }
 
+// parallelize calls work with every index below n, up to parallelism calls running concurrently. done is called with
+// the index of each call as it completes, from the calling goroutine only.
+func parallelize(n, parallelism int, work func(i int), done func(i int)) {
+	next := make(chan int)
+	completed := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				work(i)
+				completed <- i
+			}
+		}()
+	}
+
+	go func() {
+		for i := 0; i < n; i++ {
+			next <- i
+		}
+		close(next)
+		wg.Wait()
+		close(completed)
+	}()
+
+	for i := range completed {
+		done(i)
+	}
+}
+
+// runCataloger finds the packages of a single cataloger.
+func runCataloger(c Cataloger, resolver source.FileResolver) catalogResult {
+	// find packages from the underlying raw data
+	log.Infof("cataloging with %q", c.Name())
+	packages, relationships, err := c.Catalog(resolver)
+	if err != nil {
+		return catalogResult{Error: err}
+	}
+
+	log.Debugf("discovered %d packages", len(packages))
+
+	return catalogResult{
+		Packages:      packages,
+		Relationships: relationships,
+	}
+}
+
+// completePackage adds the CPEs, PURLs and detected licenses (unless no license detector is given) to a package found by
+// a cataloger, and returns it along with the relationships to the files it owns.
+func completePackage(p pkg.Package, resolver source.FileResolver, release *linux.Release, includeCPEs bool, licenses *licenseDetector) (pkg.Package, []artifact.Relationship) {
+	if includeCPEs {
+		// generate CPEs (note: this is excluded from package ID, so is safe to mutate)
+		p.CPEs = cpe.Generate(p)
+	}
+
+	// generate PURL (note: this is excluded from package ID, so is safe to mutate)
+	p.PURL = pkg.URL(p, release)
+	providesPurls, extPkgPurls := pkg.URLs(p, release)
+	p.ProvidesPurls = providesPurls
+	p.ExtPkgPurls = extPkgPurls
+
+	// if we were not able to identify the language we have an opportunity
+	// to try and get this value from the PURL. Worst case we assert that
+	// we could not identify the language at either stage and set UnknownLanguage
+	if p.Language == "" {
+		p.Language = pkg.LanguageFromPURL(p.PURL)
+	}
+
+	// classify the license files found with the package (note: this is excluded from package ID, so is safe to mutate)
+	if licenses != nil {
+		p.DetectedLicenses = append(p.DetectedLicenses, licenses.detectLicenses(p)...)
+	}
+
+	// create file-to-package relationships for files owned by the package
+	owningRelationships, err := packageFileOwnershipRelationships(p, resolver)
+	if err != nil {
+		log.Warnf("unable to create any package-file relationships for package name=%q: %w", p.Name, err)
+		return p, nil
+	}
+	return p, owningRelationships
+}
+
 func packageFileOwnershipRelationships(p pkg.Package, resolver source.FilePathResolver) ([]artifact.Relationship, error) {
 	fileOwner, ok := p.Metadata.(pkg.FileOwner)
 	if !ok {
@@ -0,0 +1,119 @@
+package cataloger
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCataloger struct {
+	name  string
+	delay time.Duration
+	count int
+	err   error
+}
+
+func (c fakeCataloger) Name() string {
+	return c.name
+}
+
+func (c fakeCataloger) Catalog(_ source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	// later catalogers finish first
+	time.Sleep(c.delay)
+	if c.err != nil {
+		return nil, nil, c.err
+	}
+
+	var pkgs []pkg.Package
+	for i := 0; i < c.count; i++ {
+		name := fmt.Sprintf("%s-%d", c.name, i)
+		p := pkg.Package{
+			Name:         name,
+			Version:      "1.0",
+			Type:         pkg.ApkPkg,
+			MetadataType: pkg.ApkMetadataType,
+			Metadata: pkg.ApkMetadata{
+				Package: name,
+				Files:   []pkg.ApkFileRecord{{Path: "/lib/" + name}, {Path: "/bin/" + name}},
+			},
+		}
+		p.SetID()
+		pkgs = append(pkgs, p)
+	}
+
+	var relationships []artifact.Relationship
+	for i := 1; i < len(pkgs); i++ {
+		relationships = append(relationships, artifact.Relationship{
+			From: pkgs[i],
+			To:   pkgs[0],
+			Type: artifact.DependencyOfRelationship,
+		})
+	}
+	return pkgs, relationships, nil
+}
+
+func fakeCatalogers() []Cataloger {
+	var catalogers []Cataloger
+	for i := 0; i < 6; i++ {
+		catalogers = append(catalogers, fakeCataloger{
+			name:  fmt.Sprintf("cataloger-%d", i),
+			delay: time.Duration(6-i) * time.Millisecond,
+			count: i + 1,
+		})
+	}
+	return catalogers
+}
+
+func TestCatalog_Parallelism(t *testing.T) {
+	var paths []string
+	for _, c := range fakeCatalogers() {
+		for i := 0; i < c.(fakeCataloger).count; i++ {
+			name := fmt.Sprintf("%s-%d", c.Name(), i)
+			paths = append(paths, "/lib/"+name, "/bin/"+name)
+		}
+	}
+	resolver := source.NewMockResolverForPaths(paths...)
+
+	expectedCatalog, expectedRelationships, err := CatalogWithConfig(resolver, nil, Config{Parallelism: 1}, fakeCatalogers()...)
+	require.NoError(t, err)
+	require.Equal(t, 21, expectedCatalog.PackageCount())
+
+	var owned int
+	for _, r := range expectedRelationships {
+		if _, ok := r.To.(source.Coordinates); ok && r.Type == artifact.ContainsRelationship {
+			owned++
+		}
+	}
+	require.Equal(t, 42, owned)
+	for _, p := range expectedCatalog.Sorted() {
+		require.NotEmpty(t, p.PURL)
+	}
+
+	for _, parallelism := range []int{0, 2, 4, 16} {
+		t.Run(fmt.Sprintf("parallelism %d", parallelism), func(t *testing.T) {
+			catalog, relationships, err := CatalogWithConfig(resolver, nil, Config{Parallelism: parallelism}, fakeCatalogers()...)
+			require.NoError(t, err)
+
+			assert.Equal(t, expectedCatalog.Sorted(), catalog.Sorted())
+			assert.Equal(t, expectedRelationships, relationships)
+		})
+	}
+}
+
+func TestCatalog_Errors(t *testing.T) {
+	catalogers := append(fakeCatalogers(),
+		fakeCataloger{name: "failing-1", err: fmt.Errorf("first failure")},
+		fakeCataloger{name: "failing-2", err: fmt.Errorf("second failure")},
+	)
+
+	_, _, err := Catalog(source.NewMockResolverForPaths(), nil, catalogers...)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "first failure")
+	assert.Contains(t, err.Error(), "second failure")
+}
@@ -61,13 +61,23 @@ func DirectoryCatalogers(cfg Config) []Cataloger {
 		deb.NewDpkgdbCataloger(),
 		rpmdb.NewRpmdbCataloger(),
 		java.NewJavaCataloger(cfg.Java()),
+		java.NewJavaPomCataloger(cfg.Java()),
+		java.NewJavaGradleLockfileCataloger(),
 		apkdb.NewApkdbCataloger(),
 		golang.NewGoModuleBinaryCataloger(),
 		golang.NewGoModFileCataloger(),
 		rust.NewCargoLockCataloger(),
 		dart.NewPubspecLockCataloger(),
 		dotnet.NewDotnetDepsCataloger(),
-		repodata.NewRepodataCataloger(),
+		repodata.NewRepodataCataloger(cfg.RepodataConfig()),
+	}
+}
+
+// RepoCatalogers returns a slice of locally implemented catalogers that are fit for detecting packages published in a
+// yum/dnf repository (from its repodata)
+func RepoCatalogers(cfg Config) []Cataloger {
+	return []Cataloger{
+		repodata.NewRepodataCataloger(cfg.RepodataConfig()),
 	}
 }
 
@@ -83,12 +93,14 @@ func AllCatalogers(cfg Config) []Cataloger {
 		deb.NewDpkgdbCataloger(),
 		rpmdb.NewRpmdbCataloger(),
 		java.NewJavaCataloger(cfg.Java()),
+		java.NewJavaPomCataloger(cfg.Java()),
+		java.NewJavaGradleLockfileCataloger(),
 		apkdb.NewApkdbCataloger(),
 		golang.NewGoModuleBinaryCataloger(),
 		golang.NewGoModFileCataloger(),
 		rust.NewCargoLockCataloger(),
 		dart.NewPubspecLockCataloger(),
 		dotnet.NewDotnetDepsCataloger(),
-		repodata.NewRepodataCataloger(),
+		repodata.NewRepodataCataloger(cfg.RepodataConfig()),
 	}
 }
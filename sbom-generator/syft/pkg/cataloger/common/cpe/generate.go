@@ -29,6 +29,10 @@ func newCPE(product, vendor, version, targetSW string) *wfn.Attributes {
 // generate the minimal set of representative CPEs, which implies that optional fields should not be included
 // (such as target SW).
 func Generate(p pkg.Package) []pkg.CPE {
+	if isGoStdlib(p) {
+		return generateGoStdlibCPEs(p)
+	}
+
 	vendors := candidateVendors(p)
 	products := candidateProducts(p)
 	if len(products) == 0 {
@@ -3,8 +3,32 @@ package cpe
 import (
 	"net/url"
 	"strings"
+
+	"github.com/facebookincubator/nvdtools/wfn"
+
+	"github.com/anchore/syft/syft/pkg"
 )
 
+// goStdlibName is the name given to the go standard library compiled into a binary.
+const goStdlibName = "stdlib"
+
+func isGoStdlib(p pkg.Package) bool {
+	return p.Language == pkg.Go && p.Name == goStdlibName
+}
+
+// generateGoStdlibCPEs returns the CPE the vulnerabilities of the go toolchain are reported against, for example
+// cpe:2.3:a:golang:go:1.19.3:*:*:*:*:*:*:* for the stdlib of go1.19.3.
+func generateGoStdlibCPEs(p pkg.Package) []pkg.CPE {
+	version := strings.TrimPrefix(p.Version, "go")
+	if version == "" {
+		return nil
+	}
+	if cpe := newCPE("go", "golang", version, wfn.Any); cpe != nil {
+		return []pkg.CPE{*cpe}
+	}
+	return nil
+}
+
 // candidateProductForGo attempts to find a single product name in a best-effort attempt. This implementation prefers
 // to return no vendor over returning potentially nonsensical results.
 func candidateProductForGo(name string) string {
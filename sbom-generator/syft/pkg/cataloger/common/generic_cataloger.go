@@ -63,11 +63,24 @@ func (c *GenericCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package,
 			packages = append(packages, *p)
 		}
 
-		relationships = append(relationships, discoveredRelationships...)
+		for _, r := range discoveredRelationships {
+			r.From = catalogedPackage(r.From)
+			r.To = catalogedPackage(r.To)
+			relationships = append(relationships, r)
+		}
 	}
 	return packages, relationships, nil
 }
 
+// catalogedPackage replaces the references to the packages discovered by a parser, whose IDs are only known once
+// cataloged, by the cataloged packages.
+func catalogedPackage(i artifact.Identifiable) artifact.Identifiable {
+	if p, ok := i.(*pkg.Package); ok {
+		return *p
+	}
+	return i
+}
+
 // SelectFiles takes a set of file trees and resolves and file references of interest for future cataloging
 func (c *GenericCataloger) selectFiles(resolver source.FilePathResolver) map[source.Location]ParserFn {
 	var parserByLocation = make(map[source.Location]ParserFn)
@@ -69,3 +69,34 @@ func TestGenericCataloger(t *testing.T) {
 		}
 	}
 }
+
+func TestGenericCataloger_Relationships(t *testing.T) {
+	relatingParser := func(_ string, _ io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+		app := &pkg.Package{Name: "app"}
+		lib := &pkg.Package{Name: "lib"}
+		tool := &pkg.Package{Name: "tool"}
+		return []*pkg.Package{app, lib, tool}, []artifact.Relationship{
+			NewDependencyRelationship(app, lib, artifact.DependsOnRelationship),
+			NewDependencyRelationship(app, tool, artifact.DevDependencyOfRelationship),
+		}, nil
+	}
+
+	resolver := source.NewMockResolverForPaths("test-fixtures/a-path.txt")
+	cataloger := NewGenericCataloger(nil, map[string]ParserFn{"**/a-path.txt": relatingParser}, "some-cataloger")
+
+	pkgs, relationships, err := cataloger.Catalog(resolver)
+	assert.NoError(t, err)
+	assert.Len(t, pkgs, 3)
+
+	byName := make(map[string]pkg.Package)
+	for _, p := range pkgs {
+		assert.NotEmpty(t, p.ID())
+		byName[p.Name] = p
+	}
+
+	// relationships refer to the cataloged packages (with their IDs) instead of the discovered ones
+	assert.Equal(t, []artifact.Relationship{
+		{From: byName["app"], To: byName["lib"], Type: artifact.DependsOnRelationship},
+		{From: byName["tool"], To: byName["app"], Type: artifact.DevDependencyOfRelationship},
+	}, relationships)
+}
@@ -0,0 +1,60 @@
+package common
+
+import (
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/spdxlicense"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// licenseFileNameExp matches the names of the files which may hold the license of a package, e.g. "LICENSE",
+// "LICENSE-MIT", "COPYING.txt" or "NOTICE.md".
+var licenseFileNameExp = regexp.MustCompile(`(?i)^(licen[cs]e|copying|notice)(-[a-z0-9.-]+)?(\.(md|txt|rst|markdown|html?))?$`)
+
+// licenseFileBaseNames and licenseFileSuffixes make up the names of the license files looked for in a directory
+var (
+	licenseFileBaseNames = []string{"LICENSE", "LICENCE", "COPYING", "NOTICE"}
+	licenseFileSuffixes  = []string{"", ".md", ".txt", ".rst", "-MIT", "-APACHE"}
+)
+
+// IsLicenseFile indicates that a file may hold the license of a package, by its name.
+func IsLicenseFile(p string) bool {
+	return licenseFileNameExp.MatchString(path.Base(p))
+}
+
+// LicenseFileNames returns the names of the license files to look for in the directory of a package, in their
+// common spellings (e.g. "LICENSE", "License.md" or "license.txt").
+func LicenseFileNames() []string {
+	var names []string
+	for _, base := range licenseFileBaseNames {
+		for _, name := range []string{base, strings.ToLower(base), base[:1] + strings.ToLower(base[1:])} {
+			for _, suffix := range licenseFileSuffixes {
+				names = append(names, name+suffix)
+			}
+		}
+	}
+	return names
+}
+
+// DetectLicenses classifies the licenses of a license file against the bundled SPDX license templates.
+func DetectLicenses(p string, reader io.Reader) []pkg.DetectedLicense {
+	matches, err := spdxlicense.ClassifyLicenses(reader)
+	if err != nil {
+		log.Warnf("unable to classify the license file %q: %+v", p, err)
+		return nil
+	}
+
+	var results []pkg.DetectedLicense
+	for _, m := range matches {
+		results = append(results, pkg.DetectedLicense{
+			License:    m.ID,
+			Confidence: m.Confidence,
+			Path:       p,
+		})
+	}
+	return results
+}
@@ -0,0 +1,60 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+func TestIsLicenseFile(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{path: "/node_modules/left-pad/LICENSE", expected: true},
+		{path: "LICENSE.md", expected: true},
+		{path: "licence.txt", expected: true},
+		{path: "LICENSE-APACHE", expected: true},
+		{path: "COPYING", expected: true},
+		{path: "META-INF/NOTICE.txt", expected: true},
+		{path: "LICENSES", expected: false},
+		{path: "license.go", expected: false},
+		{path: "README.md", expected: false},
+		{path: "licensed.txt", expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsLicenseFile(test.path))
+		})
+	}
+}
+
+func TestLicenseFileNames(t *testing.T) {
+	names := LicenseFileNames()
+	assert.Contains(t, names, "LICENSE")
+	assert.Contains(t, names, "License.md")
+	assert.Contains(t, names, "copying.txt")
+	assert.Contains(t, names, "LICENSE-MIT")
+	for _, name := range names {
+		assert.True(t, IsLicenseFile(name), name)
+	}
+}
+
+func TestDetectLicenses(t *testing.T) {
+	text := `Permission to use, copy, modify, and/or distribute this software for any purpose with or without fee is hereby
+granted, provided that the above copyright notice and this permission notice appear in all copies.
+
+THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES WITH REGARD TO THIS SOFTWARE INCLUDING ALL
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR ANY SPECIAL, DIRECT,
+INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF OR IN CONNECTION WITH THE USE OR PERFORMANCE OF
+THIS SOFTWARE.`
+
+	assert.Equal(t, []pkg.DetectedLicense{
+		{License: "ISC", Confidence: 1, Path: "/LICENSE"},
+	}, DetectLicenses("/LICENSE", strings.NewReader(text)))
+	assert.Empty(t, DetectLicenses("/NOTICE", strings.NewReader("This product includes software developed by someone.")))
+}
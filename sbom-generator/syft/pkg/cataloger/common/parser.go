@@ -8,4 +8,17 @@ import (
 )
 
 // ParserFn standardizes a function signature for parser functions that accept the virtual file path (not usable for file reads) and contents and return any discovered packages from that file
+// along with their relationships, which refer to the discovered packages by pointer (see NewDependencyRelationship).
 type ParserFn func(string, io.Reader) ([]*pkg.Package, []artifact.Relationship, error)
+
+// NewDependencyRelationship relates a discovered package to one of its discovered dependencies the way the relationship
+// type reads: the dependent package DEPENDS_ON the dependency, while the dependency is a DEV_DEPENDENCY_OF (or an
+// OPTIONAL_, BUILD_, RUNTIME_DEPENDENCY_OF) the dependent package.
+func NewDependencyRelationship(dependent, dependency *pkg.Package, ty artifact.RelationshipType) artifact.Relationship {
+	switch ty {
+	case artifact.DevDependencyOfRelationship, artifact.OptionalDependencyOfRelationship,
+		artifact.BuildDependencyOfRelationship, artifact.RuntimeDependencyOfRelationship, artifact.DependencyOfRelationship:
+		return artifact.Relationship{From: dependency, To: dependent, Type: ty}
+	}
+	return artifact.Relationship{From: dependent, To: dependency, Type: ty}
+}
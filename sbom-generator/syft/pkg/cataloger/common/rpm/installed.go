@@ -0,0 +1,118 @@
+package rpm
+
+import (
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// sense flags of a require which is needed by the scriptlets of the package (see rpmsenseFlags_e in rpm)
+const (
+	sensePrereq     = 1 << 6
+	senseScriptPre  = 1 << 9
+	senseScriptPost = 1 << 10
+	senseRpmlib     = 1 << 24
+)
+
+// Capability is a provide or require as recorded in a rpm header: the capability, its rpmsenseFlags_e and the
+// [epoch:]version[-release] it is constrained to.
+type Capability struct {
+	Name  string
+	Flags int
+	EVR   string
+}
+
+func (c Capability) String() string {
+	return c.dependency().String()
+}
+
+func (c Capability) dependency() Dependency {
+	dependency := Dependency{
+		Name: c.Name,
+		Pre:  c.Flags&(sensePrereq|senseScriptPre|senseScriptPost) != 0,
+	}
+	if sense := c.Flags & (senseLess | senseGreater | senseEqual); sense != 0 && c.EVR != "" {
+		for flags, value := range senseFlags {
+			if value == sense {
+				dependency.Flags = flags
+				dependency.EVR = ParseEVR(c.EVR)
+			}
+		}
+	}
+	return dependency
+}
+
+// InstalledPackage is a package of a rpmdb, with the capabilities it provides and requires and the files it installs.
+type InstalledPackage struct {
+	Package  pkg.Package
+	Arch     string
+	Epoch    string
+	Version  string
+	Release  string
+	Provides []Capability
+	Requires []Capability
+	Files    []string
+}
+
+// ResolveInstalledDependencies relates installed packages to the installed packages satisfying their requires,
+// resolved the same way as the requires of the packages of a repository (see Resolver). The requires no
+// installed package satisfies are returned keyed by the requiring package.
+func ResolveInstalledDependencies(rpms []InstalledPackage) ([]artifact.Relationship, map[artifact.ID][]string) {
+	packages := make([]*Package, 0, len(rpms))
+	for i, r := range rpms {
+		p := &Package{
+			Key:  i,
+			Name: r.Package.Name,
+			Arch: r.Arch,
+			EVR:  EVR{Epoch: r.Epoch, Version: r.Version, Release: r.Release},
+		}
+		for _, provide := range r.Provides {
+			p.Provides = append(p.Provides, provide.dependency())
+		}
+		for _, require := range r.Requires {
+			// rpmlib features are provided by rpm itself
+			if require.Flags&senseRpmlib != 0 {
+				continue
+			}
+			p.Requires = append(p.Requires, require.dependency())
+		}
+		packages = append(packages, p)
+	}
+
+	requiredFiles := make(map[string]bool)
+	for _, p := range packages {
+		for _, f := range p.RequiredFiles() {
+			requiredFiles[f] = true
+		}
+	}
+
+	files := make(map[string][]*Package)
+	for i, r := range rpms {
+		for _, f := range r.Files {
+			if requiredFiles[f] {
+				files[f] = append(files[f], packages[i])
+			}
+		}
+	}
+
+	resolution := NewResolver(packages, files).Resolve()
+
+	relationships := make([]artifact.Relationship, 0)
+	unresolved := make(map[artifact.ID][]string)
+	for i, r := range rpms {
+		for _, resolved := range resolution.Dependencies[i] {
+			dependency := rpms[resolved.Key].Package
+			if dependency.ID() == r.Package.ID() {
+				continue
+			}
+			relationships = append(relationships, artifact.Relationship{
+				From: r.Package,
+				To:   dependency,
+				Type: resolved.Type,
+			})
+		}
+		if requires, exists := resolution.Unresolved[i]; exists {
+			unresolved[r.Package.ID()] = requires
+		}
+	}
+	return relationships, unresolved
+}
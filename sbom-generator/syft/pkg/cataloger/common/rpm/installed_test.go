@@ -0,0 +1,84 @@
+package rpm
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapability_Dependency(t *testing.T) {
+	tests := []struct {
+		capability Capability
+		expected   Dependency
+		str        string
+	}{
+		{
+			capability: Capability{Name: "libc.so.6()(64bit)"},
+			expected:   Dependency{Name: "libc.so.6()(64bit)"},
+			str:        "libc.so.6()(64bit)",
+		},
+		{
+			capability: Capability{Name: "bash", Flags: senseEqual, EVR: "1:5.1.8-6"},
+			expected:   Dependency{Name: "bash", Flags: "EQ", EVR: EVR{Epoch: "1", Version: "5.1.8", Release: "6"}},
+			str:        "bash = 1:5.1.8-6",
+		},
+		{
+			capability: Capability{Name: "filesystem", Flags: sensePrereq | senseGreater | senseEqual, EVR: "3"},
+			expected:   Dependency{Name: "filesystem", Flags: "GE", EVR: EVR{Version: "3"}, Pre: true},
+			str:        "filesystem >= 3",
+		},
+		{
+			capability: Capability{Name: "glibc", Flags: senseScriptPost | senseLess, EVR: "2.40"},
+			expected:   Dependency{Name: "glibc", Flags: "LT", EVR: EVR{Version: "2.40"}, Pre: true},
+			str:        "glibc < 2.40",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.str, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.capability.dependency())
+			assert.Equal(t, test.str, test.capability.String())
+		})
+	}
+}
+
+func TestResolveInstalledDependencies(t *testing.T) {
+	newInstalledRpm := func(name, version string, provides []Capability, requires []Capability, files ...string) InstalledPackage {
+		p := pkg.Package{Name: name, Version: version, Type: pkg.RpmPkg}
+		p.SetID()
+		return InstalledPackage{
+			Package:  p,
+			Arch:     "x86_64",
+			Version:  version,
+			Release:  "1",
+			Provides: append([]Capability{{Name: name, Flags: senseEqual, EVR: version + "-1"}}, provides...),
+			Requires: requires,
+			Files:    files,
+		}
+	}
+
+	rpms := []InstalledPackage{
+		newInstalledRpm("app", "1.0", nil, []Capability{
+			{Name: "lib", Flags: senseGreater | senseEqual, EVR: "2.0"},
+			{Name: "/usr/bin/sh"},
+			{Name: "rpmlib(PayloadIsZstd)", Flags: senseRpmlib | senseLess | senseEqual, EVR: "5.4.18-1"},
+			{Name: "(missing or lib)"},
+			{Name: "(/usr/bin/gawk if lib)"},
+			{Name: "unknown"},
+		}),
+		newInstalledRpm("lib", "2.1", nil, nil),
+		newInstalledRpm("bash", "5.1", nil, nil, "/usr/bin/sh", "/usr/bin/bash"),
+		newInstalledRpm("gawk", "5.1", nil, nil, "/usr/bin/gawk"),
+	}
+
+	relationships, unresolved := ResolveInstalledDependencies(rpms)
+
+	assert.ElementsMatch(t, []artifact.Relationship{
+		{From: rpms[0].Package, To: rpms[1].Package, Type: artifact.DependsOnRelationship},
+		{From: rpms[0].Package, To: rpms[2].Package, Type: artifact.DependsOnRelationship},
+		{From: rpms[0].Package, To: rpms[3].Package, Type: artifact.DependsOnRelationship},
+	}, relationships)
+	assert.Equal(t, map[artifact.ID][]string{rpms[0].Package.ID(): {"unknown"}}, unresolved)
+}
@@ -0,0 +1,411 @@
+/*
+Package rpm resolves the dependencies between rpm packages, whether they are the packages of a repository (repodata) or
+the packages installed in a rpmdb.
+*/
+package rpm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+)
+
+const noarch = "noarch"
+
+// requires on rpmlib features are provided by rpm itself, not by any package of the repository
+const rpmlibPrefix = "rpmlib("
+
+// Package is a rpm with the dependencies it declares. Key identifies the package among the packages being resolved
+// together (e.g. the pkgKey of repodata).
+type Package struct {
+	Key         int
+	Name        string
+	Arch        string
+	EVR         EVR
+	Provides    []Dependency
+	Requires    []Dependency
+	Conflicts   []Dependency
+	Obsoletes   []Dependency
+	Recommends  []Dependency
+	Suggests    []Dependency
+	Supplements []Dependency
+	Enhances    []Dependency
+}
+
+// weakDependencies are the weak dependencies of the package with the relationship type they are resolved to.
+func (p *Package) weakDependencies() map[artifact.RelationshipType][]Dependency {
+	return map[artifact.RelationshipType][]Dependency{
+		artifact.RecommendsRelationship:  p.Recommends,
+		artifact.SuggestsRelationship:    p.Suggests,
+		artifact.SupplementsRelationship: p.Supplements,
+		artifact.EnhancesRelationship:    p.Enhances,
+	}
+}
+
+// RequiredFiles are the files named by the requires and weak dependencies of the package, including the ones within
+// rich dependencies, e.g. "(/usr/bin/foo if bar)". Packages implicitly provide their files, so these have to be known to
+// resolve the dependencies.
+func (p *Package) RequiredFiles() []string {
+	var files []string
+	dependencies := append([]Dependency{}, p.Requires...)
+	for _, weakDependencies := range p.weakDependencies() {
+		dependencies = append(dependencies, weakDependencies...)
+	}
+	for _, dependency := range dependencies {
+		if !isRichDependency(dependency.Name) {
+			if strings.HasPrefix(dependency.Name, "/") {
+				files = append(files, dependency.Name)
+			}
+			continue
+		}
+		// an invalid rich dependency is reported when it is resolved
+		if rich, err := parseRichDependency(dependency.Name); err == nil {
+			files = append(files, rich.files()...)
+		}
+	}
+	return files
+}
+
+func (p *Package) String() string {
+	return fmt.Sprintf("%s-%s.%s", p.Name, p.EVR, p.Arch)
+}
+
+type packageProvide struct {
+	Package *Package
+	Provide Dependency
+}
+
+// PackagePair is a conflicts or obsoletes dependency of a package matching another package of the repository.
+type PackagePair struct {
+	Package    *Package
+	Dependency Dependency
+	With       *Package
+}
+
+// AmbiguousRequire is a require satisfied by several different packages, of which Provider was chosen.
+type AmbiguousRequire struct {
+	Require      Dependency
+	Provider     *Package
+	Alternatives []*Package
+}
+
+// ResolvedDependency is a package a package depends on, and the kind of the dependency.
+type ResolvedDependency struct {
+	Key  int
+	Type artifact.RelationshipType
+}
+
+// dependencyPrecedence orders the kinds of dependencies from the strongest: when a package depends several ways on
+// another one, only the strongest dependency is kept.
+var dependencyPrecedence = []artifact.RelationshipType{
+	artifact.HasPrerequisiteRelationship,
+	artifact.DependsOnRelationship,
+	artifact.RecommendsRelationship,
+	artifact.SupplementsRelationship,
+	artifact.SuggestsRelationship,
+	artifact.EnhancesRelationship,
+}
+
+func dependencyStrength(ty artifact.RelationshipType) int {
+	for i, t := range dependencyPrecedence {
+		if t == ty {
+			return len(dependencyPrecedence) - i
+		}
+	}
+	return 0
+}
+
+// Resolution is the outcome of resolving the requires of every package of a repository.
+type Resolution struct {
+	// Dependencies are the packages chosen to satisfy the requires and weak dependencies of a package, keyed by its
+	// pkgKey. Requires marked pre (needed by scriptlets) are prerequisites.
+	Dependencies map[int][]ResolvedDependency
+	// Unresolved are the requires of a package no package of the repository satisfies, keyed by its pkgKey
+	Unresolved map[int][]string
+	// Ambiguous are the requires of a package satisfied by differently named packages, keyed by its pkgKey
+	Ambiguous map[int][]AmbiguousRequire
+}
+
+// Resolver resolves requires against the provides and files of a repository, like dnf would do when
+// installing from it:
+//   - versioned requires are matched against versioned provides with rpm's EVR comparison
+//   - file requires are matched against provides and the files of the filelists
+//   - rich (boolean) dependencies are evaluated against what the repository has available
+//   - if several packages satisfy a require, a single provider is chosen deterministically (see bestProvider)
+type Resolver struct {
+	packages  []*Package
+	providers map[string][]packageProvide
+	files     map[string][]*Package
+}
+
+// NewResolver creates a resolver over the given packages, with the packages owning each of the files required by some
+// package (see RequiredFiles).
+func NewResolver(packages []*Package, files map[string][]*Package) *Resolver {
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Key < packages[j].Key
+	})
+
+	providers := make(map[string][]packageProvide)
+	for _, p := range packages {
+		for _, provide := range p.Provides {
+			providers[provide.Name] = append(providers[provide.Name], packageProvide{Package: p, Provide: provide})
+		}
+	}
+
+	return &Resolver{
+		packages:  packages,
+		providers: providers,
+		files:     files,
+	}
+}
+
+// Packages are the packages of the resolver, in Key order.
+func (r *Resolver) Packages() []*Package {
+	return r.packages
+}
+
+// Resolve resolves the requires and weak dependencies of every package.
+func (r *Resolver) Resolve() Resolution {
+	resolution := Resolution{
+		Dependencies: make(map[int][]ResolvedDependency),
+		Unresolved:   make(map[int][]string),
+		Ambiguous:    make(map[int][]AmbiguousRequire),
+	}
+
+	for _, p := range r.packages {
+		dependencies := make(map[int]artifact.RelationshipType)
+		addDependency := func(provider *Package, ty artifact.RelationshipType) {
+			if existing, exists := dependencies[provider.Key]; !exists || dependencyStrength(ty) > dependencyStrength(existing) {
+				dependencies[provider.Key] = ty
+			}
+		}
+
+		for _, require := range p.Requires {
+			providers, resolved := r.resolveRequire(p, require)
+			if !resolved {
+				resolution.Unresolved[p.Key] = append(resolution.Unresolved[p.Key], require.String())
+				continue
+			}
+			ty := artifact.DependsOnRelationship
+			if require.Pre {
+				ty = artifact.HasPrerequisiteRelationship
+			}
+			for _, provider := range providers {
+				addDependency(provider, ty)
+			}
+
+			if len(providers) == 1 && !isRichDependency(require.Name) {
+				if alternatives := r.alternatives(require, providers[0]); len(alternatives) > 0 {
+					resolution.Ambiguous[p.Key] = append(resolution.Ambiguous[p.Key], AmbiguousRequire{
+						Require:      require,
+						Provider:     providers[0],
+						Alternatives: alternatives,
+					})
+				}
+			}
+		}
+
+		// weak dependencies don't need to be satisfied, the ones which are not are left out
+		for ty, weakDependencies := range p.weakDependencies() {
+			for _, weakDependency := range weakDependencies {
+				providers, resolved := r.resolveRequire(p, weakDependency)
+				if !resolved {
+					log.Debugf("package %s: %s %s is not available", p, ty, weakDependency)
+					continue
+				}
+				for _, provider := range providers {
+					addDependency(provider, ty)
+				}
+			}
+		}
+
+		for key, ty := range dependencies {
+			resolution.Dependencies[p.Key] = append(resolution.Dependencies[p.Key], ResolvedDependency{Key: key, Type: ty})
+		}
+		sort.Slice(resolution.Dependencies[p.Key], func(i, j int) bool {
+			return resolution.Dependencies[p.Key][i].Key < resolution.Dependencies[p.Key][j].Key
+		})
+	}
+
+	return resolution
+}
+
+// resolveRequire returns the packages a require of the given package depends on. A require satisfied by the package
+// itself is resolved without any provider.
+func (r *Resolver) resolveRequire(p *Package, require Dependency) ([]*Package, bool) {
+	if strings.HasPrefix(require.Name, rpmlibPrefix) {
+		return nil, true
+	}
+
+	if isRichDependency(require.Name) {
+		rich, err := parseRichDependency(require.Name)
+		if err != nil {
+			log.Debugf("package %s: %+v", p.Name, err)
+			return nil, false
+		}
+		return r.resolveRich(p, rich)
+	}
+
+	candidates := r.candidates(require)
+	if len(candidates) == 0 {
+		return nil, false
+	}
+	for _, candidate := range candidates {
+		if candidate == p {
+			return nil, true
+		}
+	}
+	return []*Package{bestProvider(p, require, candidates)}, true
+}
+
+func (r *Resolver) resolveRich(p *Package, rich richDependency) ([]*Package, bool) {
+	switch rich.Operator {
+	case "":
+		return r.resolveRequire(p, rich.Dependency)
+	case "and", "with":
+		var providers []*Package
+		for _, operand := range rich.Operands {
+			operandProviders, resolved := r.resolveRich(p, operand)
+			if !resolved {
+				return nil, false
+			}
+			providers = append(providers, operandProviders...)
+		}
+		return providers, true
+	case "or":
+		for _, operand := range rich.Operands {
+			if providers, resolved := r.resolveRich(p, operand); resolved {
+				return providers, true
+			}
+		}
+		return nil, false
+	case "if", "unless":
+		// the condition is about what would be installed, which is approximated with what the repository provides
+		_, available := r.resolveRich(p, rich.Operands[1])
+		if available == (rich.Operator == "if") {
+			return r.resolveRich(p, rich.Operands[0])
+		}
+		if len(rich.Operands) > 2 {
+			return r.resolveRich(p, rich.Operands[2])
+		}
+		return nil, true
+	case "without":
+		return r.resolveRich(p, rich.Operands[0])
+	}
+	return nil, false
+}
+
+// alternatives are the packages which satisfy a require as well as the chosen provider, but are not named like it.
+// Several versions of the same package are not ambiguous, the choice only matters between different packages.
+func (r *Resolver) alternatives(require Dependency, provider *Package) []*Package {
+	var alternatives []*Package
+	for _, candidate := range r.candidates(require) {
+		if candidate.Name != provider.Name {
+			alternatives = append(alternatives, candidate)
+		}
+	}
+	return alternatives
+}
+
+// Conflicts are the pairs of packages of the repository which conflict with each other.
+func (r *Resolver) Conflicts() []PackagePair {
+	var pairs []PackagePair
+	for _, p := range r.packages {
+		for _, conflict := range p.Conflicts {
+			seen := make(map[int]bool)
+			for _, provide := range r.providers[conflict.Name] {
+				if provide.Package == p || seen[provide.Package.Key] || !rangesOverlap(provide.Provide, conflict) {
+					continue
+				}
+				seen[provide.Package.Key] = true
+				pairs = append(pairs, PackagePair{Package: p, Dependency: conflict, With: provide.Package})
+			}
+		}
+	}
+	return pairs
+}
+
+// Obsoletes are the pairs of packages of the repository of which one obsoletes the other. Obsoletes only match
+// package names, not provides.
+func (r *Resolver) Obsoletes() []PackagePair {
+	byName := make(map[string][]*Package)
+	for _, p := range r.packages {
+		byName[p.Name] = append(byName[p.Name], p)
+	}
+
+	var pairs []PackagePair
+	for _, p := range r.packages {
+		for _, obsolete := range p.Obsoletes {
+			for _, obsoleted := range byName[obsolete.Name] {
+				if obsoleted == p || !rangesOverlap(Dependency{Name: obsoleted.Name, Flags: "EQ", EVR: obsoleted.EVR}, obsolete) {
+					continue
+				}
+				pairs = append(pairs, PackagePair{Package: p, Dependency: obsolete, With: obsoleted})
+			}
+		}
+	}
+	return pairs
+}
+
+// candidates are all the packages satisfying a (non rich) require, in pkgKey order.
+func (r *Resolver) candidates(require Dependency) []*Package {
+	seen := make(map[int]bool)
+	var candidates []*Package
+	add := func(p *Package) {
+		if !seen[p.Key] {
+			seen[p.Key] = true
+			candidates = append(candidates, p)
+		}
+	}
+
+	for _, provide := range r.providers[require.Name] {
+		if rangesOverlap(provide.Provide, require) {
+			add(provide.Package)
+		}
+	}
+
+	// packages implicitly provide all of their files
+	if strings.HasPrefix(require.Name, "/") && require.Flags == "" {
+		for _, p := range r.files[require.Name] {
+			add(p)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Key < candidates[j].Key
+	})
+	return candidates
+}
+
+// bestProvider chooses among several packages satisfying the same require. In order it prefers a package named after
+// the required capability, a package with an architecture compatible with the requiring package, the highest EVR,
+// and finally the lowest name and pkgKey so that the choice doesn't depend on the order of the repodata.
+func bestProvider(p *Package, require Dependency, candidates []*Package) *Package {
+	sorted := make([]*Package, len(candidates))
+	copy(sorted, candidates)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if (a.Name == require.Name) != (b.Name == require.Name) {
+			return a.Name == require.Name
+		}
+		if compatibleArch(p.Arch, a.Arch) != compatibleArch(p.Arch, b.Arch) {
+			return compatibleArch(p.Arch, a.Arch)
+		}
+		if rc := compareEVR(a.EVR, b.EVR); rc != 0 {
+			return rc > 0
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Key < b.Key
+	})
+	return sorted[0]
+}
+
+func compatibleArch(arch, providerArch string) bool {
+	return arch == providerArch || arch == noarch || providerArch == noarch
+}
@@ -0,0 +1,194 @@
+package rpm
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPackage(key int, name string, arch string, evr string, provides []Dependency, requires ...Dependency) *Package {
+	return &Package{
+		Key:      key,
+		Name:     name,
+		Arch:     arch,
+		EVR:      ParseEVR(evr),
+		Provides: append([]Dependency{{Name: name, Flags: "EQ", EVR: ParseEVR(evr)}}, provides...),
+		Requires: requires,
+	}
+}
+
+func TestResolver(t *testing.T) {
+	requires := func(name string, flags string, evr string) Dependency {
+		return Dependency{Name: name, Flags: flags, EVR: ParseEVR(evr)}
+	}
+
+	tests := []struct {
+		name                 string
+		packages             []*Package
+		files                map[int][]string
+		expectedDependencies []int
+		expectedTypes        []artifact.RelationshipType
+		expectedUnresolved   []string
+	}{
+		{
+			name: "versioned require picks the satisfying provider",
+			packages: []*Package{
+				newTestPackage(1, "app", "x86_64", "1.0-1", nil, requires("lib", "GE", "2.0")),
+				newTestPackage(2, "lib", "x86_64", "1.5-1", nil),
+				newTestPackage(3, "lib", "x86_64", "2.1-1", nil),
+			},
+			expectedDependencies: []int{3},
+		},
+		{
+			name: "the highest version wins among several providers",
+			packages: []*Package{
+				newTestPackage(1, "app", "x86_64", "1.0-1", nil, requires("lib", "", "")),
+				newTestPackage(2, "lib", "x86_64", "2.10-1", nil),
+				newTestPackage(3, "lib", "x86_64", "2.9-1", nil),
+			},
+			expectedDependencies: []int{2},
+		},
+		{
+			name: "a package named after the capability wins over other providers",
+			packages: []*Package{
+				newTestPackage(1, "app", "x86_64", "1.0-1", nil, requires("mta", "", "")),
+				newTestPackage(2, "exim", "x86_64", "9.0-1", []Dependency{{Name: "mta"}}),
+				newTestPackage(3, "mta", "x86_64", "1.0-1", nil),
+			},
+			expectedDependencies: []int{3},
+		},
+		{
+			name: "a provider of a compatible architecture wins",
+			packages: []*Package{
+				newTestPackage(1, "app", "x86_64", "1.0-1", nil, requires("libfoo.so.1", "", "")),
+				newTestPackage(2, "foo", "i686", "2.0-1", []Dependency{{Name: "libfoo.so.1"}}),
+				newTestPackage(3, "foo", "x86_64", "1.0-1", []Dependency{{Name: "libfoo.so.1"}}),
+			},
+			expectedDependencies: []int{3},
+		},
+		{
+			name: "ties are broken by name",
+			packages: []*Package{
+				newTestPackage(1, "app", "x86_64", "1.0-1", nil, requires("webserver", "", "")),
+				newTestPackage(2, "nginx", "x86_64", "1.0-1", []Dependency{{Name: "webserver"}}),
+				newTestPackage(3, "httpd", "x86_64", "1.0-1", []Dependency{{Name: "webserver"}}),
+			},
+			expectedDependencies: []int{3},
+		},
+		{
+			name: "file requires are resolved through the filelists",
+			packages: []*Package{
+				newTestPackage(1, "app", "x86_64", "1.0-1", nil, requires("/usr/bin/python3", "", "")),
+				newTestPackage(2, "python3", "x86_64", "3.9-1", nil),
+			},
+			files:                map[int][]string{2: {"/usr/bin/python3"}},
+			expectedDependencies: []int{2},
+		},
+		{
+			name: "requires satisfied by the package itself and rpmlib are not dependencies",
+			packages: []*Package{
+				newTestPackage(1, "app", "x86_64", "1.0-1", []Dependency{{Name: "/bin/app"}},
+					requires("/bin/app", "", ""), requires("rpmlib(PayloadIsZstd)", "LE", "5.4.18-1")),
+				newTestPackage(2, "other", "x86_64", "1.0-1", []Dependency{{Name: "/bin/app"}}),
+			},
+		},
+		{
+			name: "unresolved requires are recorded",
+			packages: []*Package{
+				newTestPackage(1, "app", "x86_64", "1.0-1", nil,
+					requires("lib", "GE", "3.0"), requires("missing", "", ""), requires("(lib or missing)", "", "")),
+				newTestPackage(2, "lib", "x86_64", "2.0-1", nil),
+			},
+			expectedDependencies: []int{2},
+			expectedUnresolved:   []string{"lib >= 3.0", "missing"},
+		},
+		{
+			name: "rich dependencies",
+			packages: []*Package{
+				newTestPackage(1, "app", "x86_64", "1.0-1", nil,
+					requires("(missing or b)", "", ""),
+					requires("(c if b)", "", ""),
+					requires("(missing if b else d)", "", ""),
+					requires("(missing unless b)", "", "")),
+				newTestPackage(2, "b", "x86_64", "1.0-1", nil),
+				newTestPackage(3, "c", "x86_64", "1.0-1", nil),
+				newTestPackage(4, "d", "x86_64", "1.0-1", nil),
+			},
+			expectedDependencies: []int{2, 3},
+			expectedUnresolved:   []string{"(missing if b else d)"},
+		},
+		{
+			name: "pre requires and weak dependencies",
+			packages: func() []*Package {
+				app := newTestPackage(1, "app", "x86_64", "1.0-1", nil,
+					Dependency{Name: "shadow", Pre: true}, requires("lib", "", ""))
+				app.Recommends = []Dependency{requires("docs", "", ""), requires("lib", "", ""), requires("missing", "", "")}
+				app.Suggests = []Dependency{requires("extras", "", "")}
+				app.Supplements = []Dependency{requires("(desktop and lib)", "", "")}
+				app.Enhances = []Dependency{requires("shell", "", "")}
+				return []*Package{
+					app,
+					newTestPackage(2, "shadow", "x86_64", "4.9-1", nil),
+					newTestPackage(3, "lib", "x86_64", "1.0-1", nil),
+					newTestPackage(4, "docs", "noarch", "1.0-1", nil),
+					newTestPackage(5, "extras", "noarch", "1.0-1", nil),
+					newTestPackage(6, "desktop", "x86_64", "1.0-1", nil),
+					newTestPackage(7, "shell", "x86_64", "1.0-1", nil),
+				}
+			}(),
+			expectedDependencies: []int{2, 3, 4, 5, 6, 7},
+			expectedTypes: []artifact.RelationshipType{
+				artifact.HasPrerequisiteRelationship,
+				artifact.DependsOnRelationship,
+				artifact.RecommendsRelationship,
+				artifact.SuggestsRelationship,
+				artifact.SupplementsRelationship,
+				artifact.EnhancesRelationship,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			files := make(map[string][]*Package)
+			for _, p := range test.packages {
+				for _, f := range test.files[p.Key] {
+					files[f] = append(files[f], p)
+				}
+			}
+
+			resolution := NewResolver(test.packages, files).Resolve()
+			var keys []int
+			var types []artifact.RelationshipType
+			for _, dependency := range resolution.Dependencies[1] {
+				keys = append(keys, dependency.Key)
+				types = append(types, dependency.Type)
+			}
+			assert.Equal(t, test.expectedDependencies, keys)
+			if test.expectedTypes != nil {
+				assert.Equal(t, test.expectedTypes, types)
+			}
+			assert.Equal(t, test.expectedUnresolved, resolution.Unresolved[1])
+		})
+	}
+}
+
+func TestPackage_RequiredFiles(t *testing.T) {
+	p := newTestPackage(1, "app", "x86_64", "1.0-1", nil,
+		Dependency{Name: "/usr/bin/sh"},
+		Dependency{Name: "lib"},
+		Dependency{Name: "(/usr/bin/gawk if (lib or /usr/lib/libfoo.so))"},
+		Dependency{Name: "(/usr/bin/invalid"},
+	)
+	p.Recommends = []Dependency{{Name: "/usr/bin/vim"}}
+	p.Suggests = []Dependency{{Name: "(/usr/bin/emacs or nano)"}}
+
+	assert.ElementsMatch(t, []string{
+		"/usr/bin/sh",
+		"/usr/bin/gawk",
+		"/usr/lib/libfoo.so",
+		"/usr/bin/vim",
+		"/usr/bin/emacs",
+	}, p.RequiredFiles())
+}
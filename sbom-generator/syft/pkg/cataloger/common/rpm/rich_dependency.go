@@ -0,0 +1,174 @@
+package rpm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// operators of rich (boolean) dependencies, e.g. "(foo >= 1.0 if bar)", see
+// https://rpm-software-management.github.io/rpm/manual/boolean_dependencies.html
+var richOperators = map[string]bool{
+	"and":     true,
+	"or":      true,
+	"if":      true,
+	"unless":  true,
+	"with":    true,
+	"without": true,
+}
+
+// Dependency is a provide, require, conflict, obsolete or weak dependency of a package: a capability optionally
+// constrained to a version range (Flags is one of "LT", "GT", "EQ", "LE" or "GE"). Pre marks the requires needed by the
+// scriptlets of the package.
+type Dependency struct {
+	Name  string
+	Flags string
+	EVR   EVR
+	Pre   bool
+}
+
+func (d Dependency) String() string {
+	if d.Flags == "" {
+		return d.Name
+	}
+	for operator, flags := range senseOperators {
+		if flags == d.Flags && operator != "==" {
+			return fmt.Sprintf("%s %s %s", d.Name, operator, d.EVR)
+		}
+	}
+	return d.Name
+}
+
+// richDependency is either a plain dependency (no operator) or an operator applied to its operands. For "if" and
+// "unless" the operands are the dependency, the condition and the optional "else" branch.
+type richDependency struct {
+	Operator   string
+	Dependency Dependency
+	Operands   []richDependency
+}
+
+// files are the files named by the plain dependencies within the rich dependency.
+func (d richDependency) files() []string {
+	if d.Operator == "" {
+		if strings.HasPrefix(d.Dependency.Name, "/") {
+			return []string{d.Dependency.Name}
+		}
+		return nil
+	}
+	var files []string
+	for _, operand := range d.Operands {
+		files = append(files, operand.files()...)
+	}
+	return files
+}
+
+func isRichDependency(name string) bool {
+	return strings.HasPrefix(name, "(")
+}
+
+func parseRichDependency(dependency string) (richDependency, error) {
+	tokens := tokenizeRichDependency(dependency)
+	rich, next, err := parseRichOperand(tokens, 0)
+	if err != nil {
+		return richDependency{}, fmt.Errorf("invalid rich dependency %q: %w", dependency, err)
+	}
+	if next != len(tokens) {
+		return richDependency{}, fmt.Errorf("invalid rich dependency %q: unexpected %q", dependency, tokens[next])
+	}
+	return rich, nil
+}
+
+func tokenizeRichDependency(dependency string) []string {
+	var tokens []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range dependency {
+		switch r {
+		case '(', ')':
+			flush()
+			tokens = append(tokens, string(r))
+		case ' ', '\t':
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+func parseRichOperand(tokens []string, pos int) (richDependency, int, error) {
+	if pos >= len(tokens) {
+		return richDependency{}, pos, fmt.Errorf("unexpected end of dependency")
+	}
+
+	if tokens[pos] == "(" {
+		return parseRichGroup(tokens, pos+1)
+	}
+
+	if tokens[pos] == ")" || richOperators[tokens[pos]] || tokens[pos] == "else" {
+		return richDependency{}, pos, fmt.Errorf("unexpected %q", tokens[pos])
+	}
+
+	dependency := Dependency{Name: tokens[pos]}
+	pos++
+	if pos+1 < len(tokens) {
+		if flags, ok := senseOperators[tokens[pos]]; ok {
+			dependency.Flags = flags
+			dependency.EVR = ParseEVR(tokens[pos+1])
+			pos += 2
+		}
+	}
+	return richDependency{Dependency: dependency}, pos, nil
+}
+
+func parseRichGroup(tokens []string, pos int) (richDependency, int, error) {
+	first, pos, err := parseRichOperand(tokens, pos)
+	if err != nil {
+		return richDependency{}, pos, err
+	}
+
+	group := richDependency{Operands: []richDependency{first}}
+	for {
+		if pos >= len(tokens) {
+			return richDependency{}, pos, fmt.Errorf("missing ')'")
+		}
+
+		operator := tokens[pos]
+		if operator == ")" {
+			pos++
+			break
+		}
+
+		switch {
+		case operator == "else":
+			if (group.Operator != "if" && group.Operator != "unless") || len(group.Operands) != 2 {
+				return richDependency{}, pos, fmt.Errorf("unexpected 'else'")
+			}
+		case !richOperators[operator]:
+			return richDependency{}, pos, fmt.Errorf("unknown operator %q", operator)
+		case group.Operator == "":
+			group.Operator = operator
+		case group.Operator != operator || (operator != "and" && operator != "or" && operator != "with"):
+			return richDependency{}, pos, fmt.Errorf("operator %q cannot follow %q without parentheses", operator, group.Operator)
+		}
+
+		operand, next, err := parseRichOperand(tokens, pos+1)
+		if err != nil {
+			return richDependency{}, next, err
+		}
+		group.Operands = append(group.Operands, operand)
+		pos = next
+	}
+
+	// redundant parentheses around a single dependency
+	if group.Operator == "" {
+		return first, pos, nil
+	}
+	return group, pos, nil
+}
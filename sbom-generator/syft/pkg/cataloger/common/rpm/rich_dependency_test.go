@@ -0,0 +1,67 @@
+package rpm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRichDependency(t *testing.T) {
+	simple := func(name string, flags string, evr string) richDependency {
+		return richDependency{Dependency: Dependency{Name: name, Flags: flags, EVR: ParseEVR(evr)}}
+	}
+
+	tests := []struct {
+		dependency string
+		expected   richDependency
+	}{
+		{
+			dependency: "(foo or bar)",
+			expected:   richDependency{Operator: "or", Operands: []richDependency{simple("foo", "", ""), simple("bar", "", "")}},
+		},
+		{
+			dependency: "(foo >= 1:1.0-1 and bar < 2)",
+			expected:   richDependency{Operator: "and", Operands: []richDependency{simple("foo", "GE", "1:1.0-1"), simple("bar", "LT", "2")}},
+		},
+		{
+			dependency: "(foo if bar else baz)",
+			expected:   richDependency{Operator: "if", Operands: []richDependency{simple("foo", "", ""), simple("bar", "", ""), simple("baz", "", "")}},
+		},
+		{
+			dependency: "(foo or (bar and baz))",
+			expected: richDependency{Operator: "or", Operands: []richDependency{
+				simple("foo", "", ""),
+				{Operator: "and", Operands: []richDependency{simple("bar", "", ""), simple("baz", "", "")}},
+			}},
+		},
+		{
+			dependency: "((foo))",
+			expected:   simple("foo", "", ""),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dependency, func(t *testing.T) {
+			actual, err := parseRichDependency(test.dependency)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestParseRichDependency_Invalid(t *testing.T) {
+	for _, dependency := range []string{
+		"(foo or bar",
+		"(foo or bar and baz)",
+		"(foo else bar)",
+		"(foo xor bar)",
+		"(foo or bar) baz",
+		"()",
+	} {
+		t.Run(dependency, func(t *testing.T) {
+			_, err := parseRichDependency(dependency)
+			assert.Error(t, err)
+		})
+	}
+}
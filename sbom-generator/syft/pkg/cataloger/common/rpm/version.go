@@ -0,0 +1,221 @@
+package rpm
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// sense flags of a dependency, as stored in the flags column of repodata (see rpmsenseFlags_e in rpm)
+const (
+	senseLess    = 1 << 1
+	senseGreater = 1 << 2
+	senseEqual   = 1 << 3
+)
+
+var senseFlags = map[string]int{
+	"LT": senseLess,
+	"GT": senseGreater,
+	"EQ": senseEqual,
+	"LE": senseLess | senseEqual,
+	"GE": senseGreater | senseEqual,
+}
+
+// operators used by the version comparisons of rich (boolean) dependencies
+var senseOperators = map[string]string{
+	"<":  "LT",
+	">":  "GT",
+	"=":  "EQ",
+	"==": "EQ",
+	"<=": "LE",
+	">=": "GE",
+}
+
+// EVR is the [epoch:]version[-release] of a package or of a dependency.
+type EVR struct {
+	Epoch   string
+	Version string
+	Release string
+}
+
+// ParseEVR splits a "[epoch:]version[-release]" string.
+func ParseEVR(evr string) EVR {
+	var result EVR
+	if i := strings.Index(evr, ":"); i >= 0 {
+		result.Epoch = evr[:i]
+		evr = evr[i+1:]
+	}
+	if i := strings.LastIndex(evr, "-"); i >= 0 {
+		result.Release = evr[i+1:]
+		evr = evr[:i]
+	}
+	result.Version = evr
+	return result
+}
+
+func (e EVR) String() string {
+	evr := e.Version
+	if e.Epoch != "" && e.Epoch != "0" {
+		evr = e.Epoch + ":" + evr
+	}
+	if e.Release != "" {
+		evr = evr + "-" + e.Release
+	}
+	return evr
+}
+
+// compareEVR compares epoch, version and release the way rpm does: a missing epoch is 0, and the release is only
+// compared when both sides have one.
+func compareEVR(a, b EVR) int {
+	if rc := compareEpoch(a.Epoch, b.Epoch); rc != 0 {
+		return rc
+	}
+	if rc := rpmvercmp(a.Version, b.Version); rc != 0 {
+		return rc
+	}
+	if a.Release == "" || b.Release == "" {
+		return 0
+	}
+	return rpmvercmp(a.Release, b.Release)
+}
+
+func compareEpoch(a, b string) int {
+	epochA, _ := strconv.Atoi(a)
+	epochB, _ := strconv.Atoi(b)
+	switch {
+	case epochA < epochB:
+		return -1
+	case epochA > epochB:
+		return 1
+	}
+	return 0
+}
+
+// rpmvercmp is a port of rpmvercmp() from rpm (lib/rpmvercmp.c): versions are split into alternating numeric and
+// alphabetic segments, numeric segments are newer than alphabetic ones, "~" sorts before anything (even the end of
+// the version) and "^" sorts after the end of the version but before anything else.
+func rpmvercmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	one, two := a, b
+	for len(one) > 0 || len(two) > 0 {
+		one = strings.TrimLeftFunc(one, isVersionSeparator)
+		two = strings.TrimLeftFunc(two, isVersionSeparator)
+
+		if strings.HasPrefix(one, "~") || strings.HasPrefix(two, "~") {
+			if !strings.HasPrefix(one, "~") {
+				return 1
+			}
+			if !strings.HasPrefix(two, "~") {
+				return -1
+			}
+			one, two = one[1:], two[1:]
+			continue
+		}
+
+		if strings.HasPrefix(one, "^") || strings.HasPrefix(two, "^") {
+			if len(one) == 0 {
+				return -1
+			}
+			if len(two) == 0 {
+				return 1
+			}
+			if !strings.HasPrefix(one, "^") {
+				return 1
+			}
+			if !strings.HasPrefix(two, "^") {
+				return -1
+			}
+			one, two = one[1:], two[1:]
+			continue
+		}
+
+		if len(one) == 0 || len(two) == 0 {
+			break
+		}
+
+		isNum := isDigit(rune(one[0]))
+		segmentFn := isAlpha
+		if isNum {
+			segmentFn = isDigit
+		}
+		segOne := leadingSegment(one, segmentFn)
+		segTwo := leadingSegment(two, segmentFn)
+		one, two = one[len(segOne):], two[len(segTwo):]
+
+		// segments of different types: the numeric one is newer
+		if len(segTwo) == 0 {
+			if isNum {
+				return 1
+			}
+			return -1
+		}
+
+		if isNum {
+			segOne = strings.TrimLeft(segOne, "0")
+			segTwo = strings.TrimLeft(segTwo, "0")
+			if len(segOne) != len(segTwo) {
+				if len(segOne) > len(segTwo) {
+					return 1
+				}
+				return -1
+			}
+		}
+
+		if rc := strings.Compare(segOne, segTwo); rc != 0 {
+			return rc
+		}
+	}
+
+	if len(one) == 0 && len(two) == 0 {
+		return 0
+	}
+	if len(one) == 0 {
+		return -1
+	}
+	return 1
+}
+
+func leadingSegment(s string, fn func(rune) bool) string {
+	for i, r := range s {
+		if !fn(r) {
+			return s[:i]
+		}
+	}
+	return s
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isAlpha(r rune) bool {
+	return r < unicode.MaxASCII && unicode.IsLetter(r)
+}
+
+func isVersionSeparator(r rune) bool {
+	return !isDigit(r) && !isAlpha(r) && r != '~' && r != '^'
+}
+
+// rangesOverlap reports whether a provided version range satisfies a required one (see rpmdsCompare in rpm). A
+// dependency without a version matches any version.
+func rangesOverlap(provide, require Dependency) bool {
+	provideSense := senseFlags[provide.Flags]
+	requireSense := senseFlags[require.Flags]
+	if provideSense == 0 || requireSense == 0 {
+		return true
+	}
+
+	sense := compareEVR(provide.EVR, require.EVR)
+	switch {
+	case sense < 0:
+		return provideSense&senseGreater != 0 || requireSense&senseLess != 0
+	case sense > 0:
+		return provideSense&senseLess != 0 || requireSense&senseGreater != 0
+	}
+	return (provideSense&senseEqual != 0 && requireSense&senseEqual != 0) ||
+		(provideSense&senseLess != 0 && requireSense&senseLess != 0) ||
+		(provideSense&senseGreater != 0 && requireSense&senseGreater != 0)
+}
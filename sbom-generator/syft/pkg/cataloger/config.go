@@ -1,16 +1,29 @@
 package cataloger
 
 import (
+	"runtime"
+
 	"github.com/anchore/syft/syft/pkg/cataloger/java"
+	"github.com/anchore/syft/syft/pkg/cataloger/repodata"
 )
 
 type Config struct {
-	Search SearchConfig
+	Search   SearchConfig
+	Repodata repodata.Config
+	// MavenLocalRepository is the local maven repository of the parent poms of the maven projects
+	MavenLocalRepository string
+	// Parallelism is the number of catalogers to run concurrently (the number of CPUs when below 1)
+	Parallelism int
+	// DetectLicenses enables classifying the license files found in the directories of the packages (e.g. of installed
+	// npm packages or vendored go modules)
+	DetectLicenses bool
 }
 
 func DefaultConfig() Config {
 	return Config{
-		Search: DefaultSearchConfig(),
+		Search:         DefaultSearchConfig(),
+		Parallelism:    runtime.NumCPU(),
+		DetectLicenses: true,
 	}
 }
 
@@ -18,5 +31,12 @@ func (c Config) Java() java.Config {
 	return java.Config{
 		SearchUnindexedArchives: c.Search.IncludeUnindexedArchives,
 		SearchIndexedArchives:   c.Search.IncludeIndexedArchives,
+		MavenLocalRepository:    c.MavenLocalRepository,
 	}
 }
+
+func (c Config) RepodataConfig() repodata.Config {
+	cfg := c.Repodata
+	cfg.Java = c.Java()
+	return cfg
+}
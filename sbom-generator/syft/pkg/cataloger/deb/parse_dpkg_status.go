@@ -80,6 +80,10 @@ func parseDpkgStatusEntry(reader *bufio.Reader) (pkg.DpkgMetadata, error) {
 		entry.Source = name
 	}
 
+	entry.Depends = splitDpkgRelationships(dpkgFields["Depends"])
+	entry.PreDepends = splitDpkgRelationships(dpkgFields["PreDepends"])
+	entry.Provides = splitDpkgRelationships(dpkgFields["Provides"])
+
 	// there may be an optional conffiles section that we should persist as files
 	if conffilesSection, exists := dpkgFields["Conffiles"]; exists && conffilesSection != nil {
 		if sectionStr, ok := conffilesSection.(string); ok {
@@ -154,6 +158,24 @@ func extractSourceVersion(source string) (string, string) {
 	return match["name"], match["version"]
 }
 
+// splitDpkgRelationships splits the value of a package relationship field (e.g. "libc6 (>= 2.34), zlib1g") into
+// its comma separated entries, a field may be folded over several lines.
+func splitDpkgRelationships(field interface{}) []string {
+	value, ok := field.(string)
+	if !ok {
+		return nil
+	}
+
+	var relationships []string
+	for _, relationship := range strings.Split(value, ",") {
+		relationship = strings.Join(strings.Fields(relationship), " ")
+		if relationship != "" {
+			relationships = append(relationships, relationship)
+		}
+	}
+	return relationships
+}
+
 // handleNewKeyValue parse a new key-value pair from the given unprocessed line
 func handleNewKeyValue(line string) (key string, val interface{}, err error) {
 	if i := strings.Index(line, ":"); i > 0 {
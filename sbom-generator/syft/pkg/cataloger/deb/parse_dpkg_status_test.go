@@ -39,6 +39,18 @@ func TestSinglePackage(t *testing.T) {
 				Architecture:  "amd64",
 				InstalledSize: 4064,
 				Maintainer:    "APT Development Team <deity@lists.debian.org>",
+				Depends: []string{
+					"adduser",
+					"gpgv | gpgv2 | gpgv1",
+					"debian-archive-keyring",
+					"libapt-pkg5.0 (>= 1.7.0~alpha3~)",
+					"libc6 (>= 2.15)",
+					"libgcc1 (>= 1:3.0)",
+					"libgnutls30 (>= 3.6.6)",
+					"libseccomp2 (>= 1.0.1)",
+					"libstdc++6 (>= 5.2)",
+				},
+				Provides: []string{"apt-transport-https (= 1.8.2)"},
 				Files: []pkg.DpkgFileRecord{
 					{
 						Path: "/etc/apt/apt.conf.d/01autoremove",
@@ -85,6 +97,18 @@ func TestSinglePackage(t *testing.T) {
 				Architecture:  "amd64",
 				InstalledSize: 4000,
 				Maintainer:    "APT Development Team <deity@lists.debian.org>",
+				Depends: []string{
+					"adduser",
+					"gpgv | gpgv2 | gpgv1",
+					"debian-archive-keyring",
+					"libapt-pkg5.0 (>= 1.7.0~alpha3~)",
+					"libc6 (>= 2.15)",
+					"libgcc1 (>= 1:3.0)",
+					"libgnutls30 (>= 3.6.6)",
+					"libseccomp2 (>= 1.0.1)",
+					"libstdc++6 (>= 5.2)",
+				},
+				Provides: []string{"apt-transport-https (= 1.8.2)"},
 			},
 		}}
 
@@ -128,6 +152,8 @@ func TestMultiplePackages(t *testing.T) {
 					Architecture:  "all",
 					InstalledSize: 3036,
 					Maintainer:    "GNU Libc Maintainers <debian-glibc@lists.debian.org>",
+					Depends:       []string{"debconf (>= 0.5) | debconf-2.0"},
+					Provides:      []string{"tzdata-buster"},
 					Files:         []pkg.DpkgFileRecord{},
 				},
 				{
@@ -136,6 +162,22 @@ func TestMultiplePackages(t *testing.T) {
 					Architecture:  "amd64",
 					InstalledSize: 4327,
 					Maintainer:    "LaMont Jones <lamont@debian.org>",
+					Depends:       []string{"fdisk", "login (>= 1:4.5-1.1~)"},
+					PreDepends: []string{
+						"libaudit1 (>= 1:2.2.1)",
+						"libblkid1 (>= 2.31.1)",
+						"libc6 (>= 2.25)",
+						"libcap-ng0 (>= 0.7.9)",
+						"libmount1 (>= 2.25)",
+						"libpam0g (>= 0.99.7.1)",
+						"libselinux1 (>= 2.6-3~)",
+						"libsmartcols1 (>= 2.33)",
+						"libsystemd0",
+						"libtinfo6 (>= 6)",
+						"libudev1 (>= 183)",
+						"libuuid1 (>= 2.16)",
+						"zlib1g (>= 1:1.1.4)",
+					},
 					Files: []pkg.DpkgFileRecord{
 						{
 							Path: "/etc/default/hwclock",
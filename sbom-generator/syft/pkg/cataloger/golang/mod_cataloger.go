@@ -4,14 +4,89 @@ Package golang provides a concrete Cataloger implementation for go.mod files.
 package golang
 
 import (
-	"github.com/anchore/syft/syft/pkg/cataloger/common"
+	"fmt"
+	"path"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
 )
 
+const goModCatalogerName = "go-mod-file-cataloger"
+
+// GoModCataloger catalogs the modules required by go.mod files, along with their go.sum hashes.
+type GoModCataloger struct{}
+
 // NewGoModFileCataloger returns a new Go module cataloger object.
-func NewGoModFileCataloger() *common.GenericCataloger {
-	globParsers := map[string]common.ParserFn{
-		"**/go.mod": parseGoMod,
+func NewGoModFileCataloger() *GoModCataloger {
+	return &GoModCataloger{}
+}
+
+// Name returns a string that uniquely describes a cataloger
+func (c *GoModCataloger) Name() string {
+	return goModCatalogerName
+}
+
+// Catalog is given an object to resolve file references and content, this function returns the main module of each
+// go.mod file and the modules it requires.
+func (c *GoModCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	locations, err := resolver.FilesByGlob("**/go.mod")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find files by glob: %w", err)
+	}
+
+	var packages []pkg.Package
+	var relationships []artifact.Relationship
+	for _, location := range locations {
+		reader, err := resolver.FileContentsByLocation(location)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to fetch contents at location=%v: %w", location, err)
+		}
+
+		discoveredPackages, discoveredRelationships, err := parseGoMod(location.RealPath, reader, readGoSum(resolver, location))
+		internal.CloseAndLogError(reader, location.VirtualPath)
+		if err != nil {
+			log.Warnf("cataloger '%s' failed to parse entries at location=%+v: %+v", goModCatalogerName, location, err)
+			continue
+		}
+
+		for _, p := range discoveredPackages {
+			p.FoundBy = goModCatalogerName
+			p.Locations.Add(location)
+			p.SetID()
+			packages = append(packages, *p)
+		}
+
+		// the relationships refer to the discovered packages, whose IDs are only known once cataloged
+		for _, r := range discoveredRelationships {
+			r.From = *r.From.(*pkg.Package)
+			r.To = *r.To.(*pkg.Package)
+			relationships = append(relationships, r)
+		}
 	}
+	return packages, relationships, nil
+}
 
-	return common.NewGenericCataloger(nil, globParsers, "go-mod-file-cataloger")
+// readGoSum returns the hashes of the go.sum next to a go.mod, if any.
+func readGoSum(resolver source.FileResolver, goMod source.Location) goSum {
+	location := resolver.RelativeFileByPath(goMod, path.Join(path.Dir(goMod.RealPath), "go.sum"))
+	if location == nil {
+		return nil
+	}
+
+	reader, err := resolver.FileContentsByLocation(*location)
+	if err != nil {
+		log.Warnf("unable to read go.sum at location=%+v: %+v", location, err)
+		return nil
+	}
+	defer internal.CloseAndLogError(reader, location.VirtualPath)
+
+	sums, err := parseGoSum(reader)
+	if err != nil {
+		log.Warnf("unable to parse go.sum at location=%+v: %+v", location, err)
+		return nil
+	}
+	return sums
 }
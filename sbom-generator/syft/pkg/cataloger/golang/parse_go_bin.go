@@ -8,8 +8,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
 	"runtime/debug"
 	"strings"
+	"time"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
 
 	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/syft/pkg"
@@ -19,6 +24,9 @@ import (
 
 const GOARCH = "GOARCH"
 
+// stdlibName is the name of the package of the go standard library.
+const stdlibName = "stdlib"
+
 var (
 	// errUnrecognizedFormat is returned when a given executable file doesn't
 	// appear to be in a known format, or it breaks the rules of that format,
@@ -30,13 +38,99 @@ func makeGoMainPackage(mod *debug.BuildInfo, arch string, location source.Locati
 	gbs := getBuildSettings(mod.Settings)
 	main := newGoBinaryPackage(&mod.Main, mod.GoVersion, arch, location, gbs)
 
-	if v, ok := gbs["vcs.revision"]; ok {
-		main.Version = v
+	if main.Version == "" || main.Version == develVersion {
+		if v := getMainModuleVersion(main.Name, gbs); v != "" {
+			main.Version = v
+			main.SetID()
+		}
 	}
 
 	return main
 }
 
+// getMainModuleVersion derives the version of a main module built from a repository checkout, which go stamps as
+// "(devel)" (before go 1.24): the release version set by the linker flags (e.g. -X main.version=v1.2.3) or else the
+// pseudo-version of the revision the binary was built from.
+func getMainModuleVersion(path string, buildSettings map[string]string) string {
+	if v := getLinkerFlagsVersion(path, buildSettings["-ldflags"]); v != "" {
+		return v
+	}
+
+	revision := buildSettings["vcs.revision"]
+	committed, err := time.Parse(time.RFC3339Nano, buildSettings["vcs.time"])
+	if revision == "" || err != nil {
+		return ""
+	}
+	if len(revision) > 12 {
+		revision = revision[:12]
+	}
+
+	major := ""
+	if _, pathMajor, ok := module.SplitPathVersion(path); ok && pathMajor != "" {
+		major = strings.TrimLeft(pathMajor, "/.")
+	}
+
+	version := module.PseudoVersion(major, "", committed, revision)
+	if buildSettings["vcs.modified"] == "true" {
+		// go 1.24+ marks the versions of the binaries built from a modified checkout the same way
+		version += "+dirty"
+	}
+	return version
+}
+
+// linkerFlagsVersionExp matches a semantic version set by the linker flags on a variable named version, for example
+// "-X github.com/anchore/syft.version=0.60.1" (...and the package path "github.com/anchore/syft" and the value "0.60.1"
+// are captured).
+var linkerFlagsVersionExp = regexp.MustCompile(`-X\s+['"]?([^\s=]+)\.(?i:version)=['"]?v?([0-9]+\.[0-9]+\.[0-9]+[^\s'"]*)`)
+
+// getLinkerFlagsVersion returns the version set by the linker flags on a version variable of the main package or of a
+// package of the main module (e.g. "github.com/anchore/syft/internal/version"). The version variables of packages
+// outside of the main module (e.g. of a dependency) don't tell the version of the main module.
+func getLinkerFlagsVersion(mainPath, ldflags string) string {
+	for _, matches := range linkerFlagsVersionExp.FindAllStringSubmatch(ldflags, -1) {
+		pkgPath := matches[1]
+		if pkgPath != "main" && pkgPath != mainPath && !strings.HasPrefix(pkgPath, mainPath+"/") {
+			continue
+		}
+		if version := "v" + matches[2]; semver.IsValid(version) {
+			return version
+		}
+	}
+	return ""
+}
+
+// makeGoStdlibPackage returns the go standard library the binary was compiled with as a package (pkg:golang/stdlib),
+// which is where the vulnerabilities of the go toolchain are reported.
+func makeGoStdlibPackage(mod *debug.BuildInfo, arch string, location source.Location) *pkg.Package {
+	// e.g. "go1.19.3", "go1.20 X:boringcrypto" or "devel go1.21-e4d7fc6 Mon Jan 16 19:17:46 2023 +0000"
+	fields := strings.Fields(mod.GoVersion)
+	if len(fields) == 0 || fields[0] == "devel" {
+		return nil
+	}
+	version := fields[0]
+	if !strings.HasPrefix(version, "go") {
+		version = "go" + version
+	}
+
+	p := pkg.Package{
+		FoundBy:      catalogerName,
+		Name:         stdlibName,
+		Version:      version,
+		Language:     pkg.Go,
+		Type:         pkg.GoModulePkg,
+		Locations:    source.NewLocationSet(location),
+		MetadataType: pkg.GolangBinMetadataType,
+		Metadata: pkg.GolangBinMetadata{
+			GoCompiledVersion: mod.GoVersion,
+			Architecture:      arch,
+		},
+	}
+
+	p.SetID()
+
+	return &p
+}
+
 func newGoBinaryPackage(dep *debug.Module, goVersion, architecture string, location source.Location, buildSettings map[string]string) pkg.Package {
 	if dep.Replace != nil {
 		dep = dep.Replace
@@ -176,6 +270,10 @@ func buildGoPkgInfo(location source.Location, mod *debug.BuildInfo, arch string)
 		pkgs = append(pkgs, newGoBinaryPackage(dep, mod.GoVersion, arch, location, nil))
 	}
 
+	if stdlib := makeGoStdlibPackage(mod, arch, location); stdlib != nil {
+		pkgs = append(pkgs, *stdlib)
+	}
+
 	// NOTE(jonasagx): this use happened originally while creating unit tests. It might never
 	// happen in the wild, but I kept it as a safeguard against empty modules.
 	var empty debug.Module
@@ -150,6 +150,27 @@ func TestBuildGoPkgInfo(t *testing.T) {
 		},
 	}
 
+	expectedStdlib := pkg.Package{
+		Name:     "stdlib",
+		FoundBy:  catalogerName,
+		Language: pkg.Go,
+		Type:     pkg.GoModulePkg,
+		Version:  "go1.18",
+		Locations: source.NewLocationSet(
+			source.Location{
+				Coordinates: source.Coordinates{
+					RealPath:     "/a-path",
+					FileSystemID: "layer-id",
+				},
+			},
+		),
+		MetadataType: pkg.GolangBinMetadataType,
+		Metadata: pkg.GolangBinMetadata{
+			GoCompiledVersion: goCompiledVersion,
+			Architecture:      archDetails,
+		},
+	}
+
 	tests := []struct {
 		name     string
 		mod      *debug.BuildInfo
@@ -206,6 +227,7 @@ func TestBuildGoPkgInfo(t *testing.T) {
 						H1Digest:          "h1:VSVdnH7cQ7V+B33qSJHTCRlNgra1607Q8PzEmnvb2Ic=",
 					},
 				},
+				expectedStdlib,
 			},
 		},
 		{
@@ -220,7 +242,7 @@ func TestBuildGoPkgInfo(t *testing.T) {
 					{Key: "GOAMD64", Value: "v1"},
 				},
 			},
-			expected: []pkg.Package{expectedMain},
+			expected: []pkg.Package{expectedStdlib, expectedMain},
 		},
 		{
 			name: "buildGoPkgInfo parses a populated mod string and returns packages but no source info",
@@ -289,6 +311,7 @@ func TestBuildGoPkgInfo(t *testing.T) {
 						H1Digest:          "h1:DYssiUV1pBmKqzKsm4mqXx8artqC0Q8HgZsVI3lMsAg=",
 					},
 				},
+				expectedStdlib,
 				expectedMain,
 			},
 		},
@@ -361,6 +384,7 @@ func TestBuildGoPkgInfo(t *testing.T) {
 						Architecture:      archDetails,
 						H1Digest:          "h1:Ihq/mm/suC88gF8WFcVwk+OV6Tq+wyA1O0E5UEvDglI="},
 				},
+				expectedStdlib,
 				expectedMain,
 			},
 		},
@@ -383,3 +407,151 @@ func TestBuildGoPkgInfo(t *testing.T) {
 		})
 	}
 }
+
+func Test_getMainModuleVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		buildSettings map[string]string
+		expected      string
+	}{
+		{
+			name: "pseudo-version from vcs info",
+			path: "github.com/anchore/syft",
+			buildSettings: map[string]string{
+				"vcs":          "git",
+				"vcs.revision": "a8f0c32b0bfe6ef8cc9e4f4a8a09e1bf6a4bd3c1",
+				"vcs.time":     "2022-10-12T14:33:21Z",
+				"vcs.modified": "false",
+			},
+			expected: "v0.0.0-20221012143321-a8f0c32b0bfe",
+		},
+		{
+			name: "pseudo-version of a major version module",
+			path: "github.com/anchore/syft/v2",
+			buildSettings: map[string]string{
+				"vcs.revision": "a8f0c32b0bfe6ef8cc9e4f4a8a09e1bf6a4bd3c1",
+				"vcs.time":     "2022-10-12T14:33:21Z",
+			},
+			expected: "v2.0.0-20221012143321-a8f0c32b0bfe",
+		},
+		{
+			name: "pseudo-version of a modified checkout",
+			path: "github.com/anchore/syft",
+			buildSettings: map[string]string{
+				"vcs.revision": "a8f0c32b0bfe6ef8cc9e4f4a8a09e1bf6a4bd3c1",
+				"vcs.time":     "2022-10-12T14:33:21Z",
+				"vcs.modified": "true",
+			},
+			expected: "v0.0.0-20221012143321-a8f0c32b0bfe+dirty",
+		},
+		{
+			name: "release version from linker flags",
+			path: "github.com/anchore/syft",
+			buildSettings: map[string]string{
+				"-ldflags":     "-w -s -extldflags '-static' -X github.com/spf13/cobra.version=1.5.0 -X github.com/anchore/syft.version=0.60.1 -X github.com/anchore/syft.gitCommit=a8f0c32",
+				"vcs.revision": "a8f0c32b0bfe6ef8cc9e4f4a8a09e1bf6a4bd3c1",
+				"vcs.time":     "2022-10-12T14:33:21Z",
+			},
+			expected: "v0.60.1",
+		},
+		{
+			name: "linker flags version of a package of the main module",
+			path: "github.com/anchore/syft",
+			buildSettings: map[string]string{
+				"-ldflags":     "-X github.com/spf13/cobra.version=1.5.0 -X github.com/anchore/syft/internal/version.version=0.60.1",
+				"vcs.revision": "a8f0c32b0bfe6ef8cc9e4f4a8a09e1bf6a4bd3c1",
+				"vcs.time":     "2022-10-12T14:33:21Z",
+			},
+			expected: "v0.60.1",
+		},
+		{
+			name: "linker flags version of packages outside of the main module",
+			path: "github.com/anchore/syft",
+			buildSettings: map[string]string{
+				"-ldflags":     "-X github.com/spf13/cobra.version=1.5.0 -X github.com/anchore/syft-extras/version.version=0.9.0",
+				"vcs.revision": "a8f0c32b0bfe6ef8cc9e4f4a8a09e1bf6a4bd3c1",
+				"vcs.time":     "2022-10-12T14:33:21Z",
+			},
+			expected: "v0.0.0-20221012143321-a8f0c32b0bfe",
+		},
+		{
+			name: "release version from main package linker flags",
+			path: "github.com/anchore/syft",
+			buildSettings: map[string]string{
+				"-ldflags": "-X main.Version=v1.2.3-rc.1",
+			},
+			expected: "v1.2.3-rc.1",
+		},
+		{
+			name: "unversioned linker flags",
+			path: "github.com/anchore/syft",
+			buildSettings: map[string]string{
+				"-ldflags": "-X main.version=dev",
+			},
+			expected: "",
+		},
+		{
+			name: "revision without time",
+			path: "github.com/anchore/syft",
+			buildSettings: map[string]string{
+				"vcs.revision": "a8f0c32b0bfe6ef8cc9e4f4a8a09e1bf6a4bd3c1",
+			},
+			expected: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, getMainModuleVersion(test.path, test.buildSettings))
+		})
+	}
+}
+
+func Test_makeGoMainPackage_version(t *testing.T) {
+	location := source.NewLocation("/a-path")
+	settings := []debug.BuildSetting{
+		{Key: "vcs", Value: "git"},
+		{Key: "vcs.revision", Value: "a8f0c32b0bfe6ef8cc9e4f4a8a09e1bf6a4bd3c1"},
+		{Key: "vcs.time", Value: "2022-10-12T14:33:21Z"},
+	}
+
+	devel := makeGoMainPackage(&debug.BuildInfo{
+		GoVersion: "go1.19.3",
+		Main:      debug.Module{Path: "github.com/anchore/syft", Version: "(devel)"},
+		Settings:  settings,
+	}, "amd64", location)
+	assert.Equal(t, "v0.0.0-20221012143321-a8f0c32b0bfe", devel.Version)
+
+	// the version stamped from a tag (go 1.24+) is kept
+	tagged := makeGoMainPackage(&debug.BuildInfo{
+		GoVersion: "go1.24.0",
+		Main:      debug.Module{Path: "github.com/anchore/syft", Version: "v0.60.1"},
+		Settings:  settings,
+	}, "amd64", location)
+	assert.Equal(t, "v0.60.1", tagged.Version)
+	assert.NotEqual(t, devel.ID(), tagged.ID())
+}
+
+func Test_makeGoStdlibPackage(t *testing.T) {
+	tests := []struct {
+		goVersion string
+		expected  string
+	}{
+		{goVersion: "go1.19.3", expected: "go1.19.3"},
+		{goVersion: "go1.20 X:boringcrypto", expected: "go1.20"},
+		{goVersion: "devel go1.21-e4d7fc6 Mon Jan 16 19:17:46 2023 +0000", expected: ""},
+		{goVersion: "", expected: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.goVersion, func(t *testing.T) {
+			p := makeGoStdlibPackage(&debug.BuildInfo{GoVersion: test.goVersion}, "amd64", source.NewLocation("/a-path"))
+			if test.expected == "" {
+				assert.Nil(t, p)
+				return
+			}
+			require.NotNil(t, p)
+			assert.Equal(t, "stdlib", p.Name)
+			assert.Equal(t, test.expected, p.Version)
+		})
+	}
+}
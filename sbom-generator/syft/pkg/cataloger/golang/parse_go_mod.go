@@ -1,20 +1,51 @@
 package golang
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
 
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
-	"golang.org/x/mod/modfile"
 )
 
-// parseGoMod takes a go.mod and lists all packages discovered.
-func parseGoMod(path string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
-	packages := make(map[string]*pkg.Package)
+// develVersion is the version go gives to the modules built from a local directory.
+const develVersion = "(devel)"
+
+// goSum maps the "path version" of the modules of a go.sum file to the hash of their content.
+type goSum map[string]string
+
+// parseGoSum reads the "path version h1:hash" lines of a go.sum file, leaving out the hashes of the go.mod files of the
+// modules ("path version/go.mod h1:hash").
+func parseGoSum(reader io.Reader) (goSum, error) {
+	sums := make(goSum)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		sums[fields[0]+" "+fields[1]] = fields[2]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read go.sum: %w", err)
+	}
+	return sums, nil
+}
+
+func (s goSum) hash(m module.Version) string {
+	return s[m.Path+" "+m.Version]
+}
 
+// parseGoMod takes a go.mod and lists its main module and the modules it requires, once replaced and excluded, related
+// to the main module.
+func parseGoMod(path string, reader io.Reader, sums goSum) ([]*pkg.Package, []artifact.Relationship, error) {
 	contents, err := ioutil.ReadAll(reader)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to read go module: %w", err)
@@ -25,40 +56,97 @@ func parseGoMod(path string, reader io.Reader) ([]*pkg.Package, []artifact.Relat
 		return nil, nil, fmt.Errorf("failed to parse go module: %w", err)
 	}
 
-	for _, m := range file.Require {
-		packages[m.Mod.Path] = &pkg.Package{
-			Name:     m.Mod.Path,
-			Version:  m.Mod.Version,
-			Language: pkg.Go,
-			Type:     pkg.GoModulePkg,
+	// exclude directives apply to a specific version of a module
+	excluded := make(map[module.Version]bool)
+	for _, m := range file.Exclude {
+		excluded[m.Mod] = true
+	}
+
+	var packages []*pkg.Package
+	for _, r := range file.Require {
+		if excluded[r.Mod] {
+			continue
 		}
+		packages = append(packages, newGoModulePackage(r, file.Replace, sums))
+	}
+
+	sort.SliceStable(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+
+	if file.Module == nil || file.Module.Mod.Path == "" {
+		return packages, nil, nil
+	}
+
+	var goVersion string
+	if file.Go != nil {
+		goVersion = file.Go.Version
+	}
+	main := &pkg.Package{
+		Name:         file.Module.Mod.Path,
+		Language:     pkg.Go,
+		Type:         pkg.GoModulePkg,
+		MetadataType: pkg.GolangModMetadataType,
+		Metadata: pkg.GolangModMetadata{
+			GoVersion: goVersion,
+		},
 	}
 
-	// remove any old packages and replace with new ones...
-	for _, m := range file.Replace {
-		packages[m.New.Path] = &pkg.Package{
-			Name:     m.New.Path,
-			Version:  m.New.Version,
-			Language: pkg.Go,
-			Type:     pkg.GoModulePkg,
+	relationships := make([]artifact.Relationship, len(packages))
+	for i, p := range packages {
+		relationships[i] = artifact.Relationship{
+			From: main,
+			To:   p,
+			Type: artifact.DependsOnRelationship,
 		}
 	}
 
-	// remove any packages from the exclude fields
-	for _, m := range file.Exclude {
-		delete(packages, m.Mod.Path)
+	return append([]*pkg.Package{main}, packages...), relationships, nil
+}
+
+// newGoModulePackage returns the package of a required module, or of the module replacing it. A module replaced by a
+// local directory keeps its path, with the version go gives to the modules built from source.
+func newGoModulePackage(r *modfile.Require, replaces []*modfile.Replace, sums goSum) *pkg.Package {
+	mod := r.Mod
+	metadata := pkg.GolangModMetadata{
+		Indirect: r.Indirect,
 	}
 
-	pkgsSlice := make([]*pkg.Package, len(packages))
-	idx := 0
-	for _, p := range packages {
-		pkgsSlice[idx] = p
-		idx++
+	if replace := findReplace(mod, replaces); replace != nil {
+		metadata.Replaces = mod.String()
+		if modfile.IsDirectoryPath(replace.New.Path) {
+			metadata.Dir = replace.New.Path
+			mod = module.Version{Path: mod.Path, Version: develVersion}
+		} else {
+			mod = replace.New
+		}
 	}
+	metadata.H1Digest = sums.hash(mod)
 
-	sort.SliceStable(pkgsSlice, func(i, j int) bool {
-		return pkgsSlice[i].Name < pkgsSlice[j].Name
-	})
+	return &pkg.Package{
+		Name:         mod.Path,
+		Version:      mod.Version,
+		Language:     pkg.Go,
+		Type:         pkg.GoModulePkg,
+		MetadataType: pkg.GolangModMetadataType,
+		Metadata:     metadata,
+	}
+}
 
-	return pkgsSlice, nil, nil
+// findReplace returns the replace directive of a module: the replacement of its specific version, or else the
+// replacement of all of its versions.
+func findReplace(mod module.Version, replaces []*modfile.Replace) *modfile.Replace {
+	var found *modfile.Replace
+	for _, r := range replaces {
+		if r.Old.Path != mod.Path {
+			continue
+		}
+		if r.Old.Version == mod.Version {
+			return r
+		}
+		if r.Old.Version == "" {
+			found = r
+		}
+	}
+	return found
 }
@@ -5,8 +5,12 @@ import (
 	"testing"
 
 	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
 )
 
 func TestParseGoMod(t *testing.T) {
@@ -17,11 +21,20 @@ func TestParseGoMod(t *testing.T) {
 		{
 			fixture: "test-fixtures/one-package",
 			expected: map[string]pkg.Package{
+				"github.com/anchore/syft": {
+					Name:         "github.com/anchore/syft",
+					Language:     pkg.Go,
+					Type:         pkg.GoModulePkg,
+					MetadataType: pkg.GolangModMetadataType,
+					Metadata:     pkg.GolangModMetadata{GoVersion: "1.14"},
+				},
 				"github.com/bmatcuk/doublestar": {
-					Name:     "github.com/bmatcuk/doublestar",
-					Version:  "v1.3.1",
-					Language: pkg.Go,
-					Type:     pkg.GoModulePkg,
+					Name:         "github.com/bmatcuk/doublestar",
+					Version:      "v1.3.1",
+					Language:     pkg.Go,
+					Type:         pkg.GoModulePkg,
+					MetadataType: pkg.GolangModMetadataType,
+					Metadata:     pkg.GolangModMetadata{},
 				},
 			},
 		},
@@ -29,35 +42,55 @@ func TestParseGoMod(t *testing.T) {
 
 			fixture: "test-fixtures/many-packages",
 			expected: map[string]pkg.Package{
+				"github.com/anchore/syft": {
+					Name:         "github.com/anchore/syft",
+					Language:     pkg.Go,
+					Type:         pkg.GoModulePkg,
+					MetadataType: pkg.GolangModMetadataType,
+					Metadata:     pkg.GolangModMetadata{GoVersion: "1.14"},
+				},
 				"github.com/anchore/go-testutils": {
-					Name:     "github.com/anchore/go-testutils",
-					Version:  "v0.0.0-20200624184116-66aa578126db",
-					Language: pkg.Go,
-					Type:     pkg.GoModulePkg,
+					Name:         "github.com/anchore/go-testutils",
+					Version:      "v0.0.0-20200624184116-66aa578126db",
+					Language:     pkg.Go,
+					Type:         pkg.GoModulePkg,
+					MetadataType: pkg.GolangModMetadataType,
+					Metadata:     pkg.GolangModMetadata{},
 				},
 				"github.com/anchore/go-version": {
-					Name:     "github.com/anchore/go-version",
-					Version:  "v1.2.2-0.20200701162849-18adb9c92b9b",
-					Language: pkg.Go,
-					Type:     pkg.GoModulePkg,
+					Name:         "github.com/anchore/go-version",
+					Version:      "v1.2.2-0.20200701162849-18adb9c92b9b",
+					Language:     pkg.Go,
+					Type:         pkg.GoModulePkg,
+					MetadataType: pkg.GolangModMetadataType,
+					Metadata:     pkg.GolangModMetadata{},
 				},
 				"github.com/anchore/stereoscope": {
-					Name:     "github.com/anchore/stereoscope",
-					Version:  "v0.0.0-20200706164556-7cf39d7f4639",
-					Language: pkg.Go,
-					Type:     pkg.GoModulePkg,
+					Name:         "github.com/anchore/stereoscope",
+					Version:      "v0.0.0-20200706164556-7cf39d7f4639",
+					Language:     pkg.Go,
+					Type:         pkg.GoModulePkg,
+					MetadataType: pkg.GolangModMetadataType,
+					Metadata:     pkg.GolangModMetadata{},
 				},
 				"github.com/bmatcuk/doublestar": {
-					Name:     "github.com/bmatcuk/doublestar",
-					Version:  "v8.8.8",
-					Language: pkg.Go,
-					Type:     pkg.GoModulePkg,
+					Name:         "github.com/bmatcuk/doublestar",
+					Version:      "v8.8.8",
+					Language:     pkg.Go,
+					Type:         pkg.GoModulePkg,
+					MetadataType: pkg.GolangModMetadataType,
+					Metadata: pkg.GolangModMetadata{
+						Indirect: true,
+						Replaces: "github.com/bmatcuk/doublestar@v1.3.1",
+					},
 				},
 				"github.com/go-test/deep": {
-					Name:     "github.com/go-test/deep",
-					Version:  "v1.0.6",
-					Language: pkg.Go,
-					Type:     pkg.GoModulePkg,
+					Name:         "github.com/go-test/deep",
+					Version:      "v1.0.6",
+					Language:     pkg.Go,
+					Type:         pkg.GoModulePkg,
+					MetadataType: pkg.GolangModMetadataType,
+					Metadata:     pkg.GolangModMetadata{},
 				},
 			},
 		},
@@ -70,8 +103,7 @@ func TestParseGoMod(t *testing.T) {
 				t.Fatalf(err.Error())
 			}
 
-			// TODO: no relationships are under test yet
-			actual, _, err := parseGoMod(test.fixture, f)
+			actual, relationships, err := parseGoMod(test.fixture, f, nil)
 			if err != nil {
 				t.Fatalf(err.Error())
 			}
@@ -80,6 +112,16 @@ func TestParseGoMod(t *testing.T) {
 				t.Fatalf("unexpected length: %d", len(actual))
 			}
 
+			// the main module depends on every other module
+			if len(relationships) != len(actual)-1 {
+				t.Errorf("unexpected relationships: %d", len(relationships))
+			}
+			for _, r := range relationships {
+				if r.From != actual[0] || r.Type != artifact.DependsOnRelationship {
+					t.Errorf("unexpected relationship: %+v", r)
+				}
+			}
+
 			for _, a := range actual {
 				e, ok := test.expected[a.Name]
 				if !ok {
@@ -105,3 +147,81 @@ func TestParseGoMod(t *testing.T) {
 		})
 	}
 }
+
+func TestParseGoMod_replaceAndSums(t *testing.T) {
+	f, err := os.Open("test-fixtures/go-sum/go.mod")
+	require.NoError(t, err)
+	defer f.Close()
+
+	s, err := os.Open("test-fixtures/go-sum/go.sum")
+	require.NoError(t, err)
+	defer s.Close()
+	sums, err := parseGoSum(s)
+	require.NoError(t, err)
+
+	actual, _, err := parseGoMod("test-fixtures/go-sum/go.mod", f, sums)
+	require.NoError(t, err)
+
+	var names []string
+	for _, p := range actual {
+		names = append(names, p.Name+"@"+p.Version)
+	}
+	assert.Equal(t, []string{
+		"example.com/app@",
+		"example.com/local@(devel)",
+		"github.com/google/uuid@v1.3.0",
+		"github.com/pkg/errors@v0.9.1",
+		"golang.org/x/text@v0.3.8",
+	}, names)
+
+	assert.Equal(t, pkg.GolangModMetadata{
+		Replaces: "example.com/local@v1.0.0",
+		Dir:      "../local",
+	}, actual[1].Metadata)
+	assert.Equal(t, pkg.GolangModMetadata{
+		H1Digest: "h1:t6JiXgmwXMjEs8VusXIJk2BXHsn+wx8BZdTaoZ5fu7I=",
+	}, actual[2].Metadata)
+	// the version specific replace wins over the replace of all versions
+	assert.Equal(t, pkg.GolangModMetadata{
+		Indirect: true,
+		Replaces: "golang.org/x/text@v0.3.7",
+		H1Digest: "h1:qgOY6WgZOaTkIIMiVjBQcw93ERBE4m30iBm00nkL0i8=",
+	}, actual[4].Metadata)
+}
+
+func TestParseGoSum(t *testing.T) {
+	f, err := os.Open("test-fixtures/go-sum/go.sum")
+	require.NoError(t, err)
+	defer f.Close()
+
+	sums, err := parseGoSum(f)
+	require.NoError(t, err)
+	assert.Equal(t, goSum{
+		"github.com/google/uuid v1.3.0": "h1:t6JiXgmwXMjEs8VusXIJk2BXHsn+wx8BZdTaoZ5fu7I=",
+		"github.com/pkg/errors v0.9.1":  "h1:FEBLx1zS214owpjy7qsBeixbURkuhQAwrK5UwLGTwt4=",
+		"golang.org/x/text v0.3.8":      "h1:qgOY6WgZOaTkIIMiVjBQcw93ERBE4m30iBm00nkL0i8=",
+	}, sums)
+}
+
+func TestGoModCataloger(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/go-sum")
+	require.NoError(t, err)
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	packages, relationships, err := NewGoModFileCataloger().Catalog(resolver)
+	require.NoError(t, err)
+	require.Len(t, packages, 5)
+	assert.Len(t, relationships, 4)
+
+	for _, p := range packages {
+		assert.Equal(t, goModCatalogerName, p.FoundBy)
+		assert.NotEmpty(t, p.ID())
+	}
+	// the hashes of the sibling go.sum are known
+	assert.Equal(t, "h1:t6JiXgmwXMjEs8VusXIJk2BXHsn+wx8BZdTaoZ5fu7I=", packages[2].Metadata.(pkg.GolangModMetadata).H1Digest)
+
+	for _, r := range relationships {
+		assert.Equal(t, packages[0].ID(), r.From.ID())
+	}
+}
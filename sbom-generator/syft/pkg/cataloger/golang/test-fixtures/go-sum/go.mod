@@ -0,0 +1,18 @@
+module example.com/app
+
+go 1.19
+
+require (
+	example.com/local v1.0.0
+	github.com/google/uuid v1.3.0
+	github.com/pkg/errors v0.9.1 // indirect
+	golang.org/x/text v0.3.7 // indirect
+)
+
+exclude github.com/pkg/errors v0.9.0
+
+replace example.com/local => ../local
+
+replace golang.org/x/text => golang.org/x/text v0.3.6
+
+replace golang.org/x/text v0.3.7 => golang.org/x/text v0.3.8
@@ -0,0 +1,87 @@
+package java
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common/cpe"
+)
+
+// MavenCoordinates identify a java archive within a maven repository (groupId:artifactId:version).
+type MavenCoordinates struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+}
+
+func (c MavenCoordinates) String() string {
+	return fmt.Sprintf("%s:%s:%s", c.GroupID, c.ArtifactID, c.Version)
+}
+
+// IdentifyArchive returns the maven coordinates of the given java archive from the pom.properties it embeds, as long as
+// there is only one, or lacking those from its manifest. Nested archives are not inspected. Nil is returned when the
+// archive does not carry enough information to be identified.
+func IdentifyArchive(virtualPath string, reader io.Reader) (*MavenCoordinates, error) {
+	parser, cleanupFn, err := newJavaArchiveParser(virtualPath, reader, false)
+	// note: even on error, we should always run cleanup functions
+	defer cleanupFn()
+	if err != nil {
+		return nil, err
+	}
+
+	// a single pom.properties describes the archive itself, which is more reliable than what the manifest (or the
+	// group guessed from it) tells
+	properties, err := pomPropertiesByParentPath(parser.archivePath, virtualPath, parser.fileManifest.GlobMatch(pomPropertiesGlob))
+	if err != nil {
+		return nil, err
+	}
+	if len(properties) == 1 {
+		for _, p := range properties {
+			if coordinates := coordinatesFromPomProperties(p); coordinates != nil {
+				return coordinates, nil
+			}
+		}
+	}
+
+	pkgs, _, err := parser.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	// the main package has the manifest and, when one of the pom.properties describes it, the pom properties
+	if len(pkgs) > 0 {
+		if metadata, ok := pkgs[0].Metadata.(pkg.JavaMetadata); ok && metadata.Manifest != nil {
+			return coordinatesFromPackage(*pkgs[0]), nil
+		}
+	}
+	return nil, nil
+}
+
+func coordinatesFromPackage(p pkg.Package) *MavenCoordinates {
+	metadata := p.Metadata.(pkg.JavaMetadata)
+	if metadata.PomProperties != nil {
+		return coordinatesFromPomProperties(*metadata.PomProperties)
+	}
+
+	groupIDs := cpe.GroupIDsFromJavaPackage(p)
+	if len(groupIDs) == 0 || p.Name == "" || p.Version == "" {
+		return nil
+	}
+	return &MavenCoordinates{
+		GroupID:    groupIDs[0],
+		ArtifactID: p.Name,
+		Version:    p.Version,
+	}
+}
+
+func coordinatesFromPomProperties(properties pkg.PomProperties) *MavenCoordinates {
+	if properties.GroupID == "" || properties.ArtifactID == "" || properties.Version == "" {
+		return nil
+	}
+	return &MavenCoordinates{
+		GroupID:    properties.GroupID,
+		ArtifactID: properties.ArtifactID,
+		Version:    properties.Version,
+	}
+}
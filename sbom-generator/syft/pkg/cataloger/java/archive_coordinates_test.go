@@ -0,0 +1,92 @@
+package java
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestJar(t *testing.T, files map[string]string) *bytes.Reader {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	for name, contents := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestIdentifyArchive(t *testing.T) {
+	tests := []struct {
+		name     string
+		jar      string
+		files    map[string]string
+		expected *MavenCoordinates
+	}{
+		{
+			name: "pom properties of the main package",
+			jar:  "commons-lang3.jar",
+			files: map[string]string{
+				"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\nImplementation-Title: Apache Commons Lang\n",
+				"META-INF/maven/org.apache.commons/commons-lang3/pom.properties": "groupId=org.apache.commons\nartifactId=commons-lang3\nversion=3.12.0\n",
+			},
+			expected: &MavenCoordinates{GroupID: "org.apache.commons", ArtifactID: "commons-lang3", Version: "3.12.0"},
+		},
+		{
+			name: "pom properties take precedence over the manifest",
+			jar:  "renamed-2.0.jar",
+			files: map[string]string{
+				"META-INF/MANIFEST.MF":                           "Manifest-Version: 1.0\nBundle-SymbolicName: org.example.renamed\n",
+				"META-INF/maven/io.example/thing/pom.properties": "groupId=io.example\nartifactId=thing\nversion=0.9\n",
+			},
+			expected: &MavenCoordinates{GroupID: "io.example", ArtifactID: "thing", Version: "0.9"},
+		},
+		{
+			name: "manifest only",
+			jar:  "example-lib-1.2.3.jar",
+			files: map[string]string{
+				"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\nBundle-SymbolicName: org.example.lib\n",
+			},
+			expected: &MavenCoordinates{GroupID: "org.example.lib", ArtifactID: "example-lib", Version: "1.2.3"},
+		},
+		{
+			name: "pom properties without manifest",
+			jar:  "renamed.jar",
+			files: map[string]string{
+				"META-INF/maven/io.example/thing/pom.properties": "groupId=io.example\nartifactId=thing\nversion=0.9\n",
+			},
+			expected: &MavenCoordinates{GroupID: "io.example", ArtifactID: "thing", Version: "0.9"},
+		},
+		{
+			name: "manifest without group",
+			jar:  "anonymous.jar",
+			files: map[string]string{
+				"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\n",
+			},
+		},
+		{
+			name: "several pom properties without manifest",
+			jar:  "shaded.jar",
+			files: map[string]string{
+				"META-INF/maven/io.example/one/pom.properties": "groupId=io.example\nartifactId=one\nversion=1\n",
+				"META-INF/maven/io.example/two/pom.properties": "groupId=io.example\nartifactId=two\nversion=2\n",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			coordinates, err := IdentifyArchive(test.jar, newTestJar(t, test.files))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, coordinates)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"path"
+	"sort"
 	"strings"
 
 	"github.com/anchore/syft/internal/file"
@@ -108,6 +109,11 @@ func (j *archiveParser) parse() ([]*pkg.Package, []artifact.Relationship, error)
 		return nil, nil, fmt.Errorf("could not generate package from %s: %w", j.virtualPath, err)
 	}
 
+	// the license files of the archive are the license of the archive itself, rather than of the packages it bundles
+	if parentPkg != nil {
+		parentPkg.DetectedLicenses = j.discoverLicenses()
+	}
+
 	// find aux packages from pom.properties/pom.xml and potentially modify the existing parentPkg
 	// NOTE: we cannot generate sha1 digests from packages discovered via pom.properties/pom.xml
 	auxPkgs, err := j.discoverPkgsFromAllMavenFiles(parentPkg)
@@ -193,6 +199,35 @@ func (j *archiveParser) discoverMainPackage() (*pkg.Package, error) {
 	}, nil
 }
 
+// discoverLicenses classifies the license files at the root and in the META-INF directory of the archive (e.g.
+// "META-INF/LICENSE.txt").
+func (j *archiveParser) discoverLicenses() []pkg.DetectedLicense {
+	var paths []string
+	for entry, info := range j.fileManifest {
+		dir := strings.Trim(path.Dir("/"+entry), "/")
+		if info.IsDir() || (dir != "" && dir != "META-INF") || !common.IsLicenseFile(entry) {
+			continue
+		}
+		paths = append(paths, entry)
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	sort.Strings(paths)
+
+	contents, err := file.ContentsFromZip(j.archivePath, paths...)
+	if err != nil {
+		log.Warnf("unable to extract license files (%s): %+v", j.virtualPath, err)
+		return nil
+	}
+
+	var results []pkg.DetectedLicense
+	for _, p := range paths {
+		results = append(results, common.DetectLicenses(j.virtualPath+":"+p, strings.NewReader(contents[p]))...)
+	}
+	return results
+}
+
 // discoverPkgsFromAllMavenFiles parses Maven POM properties/xml for a given
 // parent package, returning all listed Java packages found for each pom
 // properties discovered and potentially updating the given parentPkg with new
@@ -1,6 +1,7 @@
 package java
 
 import (
+	"archive/zip"
 	"bufio"
 	"fmt"
 	"io"
@@ -17,6 +18,7 @@ import (
 	"github.com/go-test/deep"
 	"github.com/gookit/color"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func generateJavaBuildFixture(t *testing.T, fixturePath string) {
@@ -945,3 +947,54 @@ func Test_newPackageFromMavenData(t *testing.T) {
 		})
 	}
 }
+
+func TestParseJar_licenses(t *testing.T) {
+	apacheNotice := `Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+`
+	archive := filepath.Join(t.TempDir(), "example-1.0.jar")
+	f, err := os.Create(archive)
+	require.NoError(t, err)
+	w := zip.NewWriter(f)
+	for name, contents := range map[string]string{
+		"META-INF/MANIFEST.MF":          "Manifest-Version: 1.0\nImplementation-Title: example\nImplementation-Version: 1.0\n",
+		"META-INF/LICENSE.txt":          apacheNotice,
+		"META-INF/README.txt":           apacheNotice,
+		"META-INF/bundled/lib/LICENSE":  apacheNotice,
+		"org/example/Example.class":     "",
+		"org/example/license/Empty.txt": "",
+	} {
+		entry, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	reader, err := os.Open(archive)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	parser, cleanupFn, err := newJavaArchiveParser("/app/example-1.0.jar", reader, false)
+	defer cleanupFn()
+	require.NoError(t, err)
+
+	pkgs, _, err := parser.parse()
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+
+	// only the license files of the root and the META-INF directory are the license of the archive
+	assert.Equal(t, []pkg.DetectedLicense{
+		{License: "Apache-2.0", Confidence: 1, Path: "/app/example-1.0.jar:META-INF/LICENSE.txt"},
+	}, pkgs[0].DetectedLicenses)
+}
@@ -1,6 +1,31 @@
 package java
 
+import (
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+)
+
 type Config struct {
 	SearchUnindexedArchives bool
 	SearchIndexedArchives   bool
+	// MavenLocalRepository is the local maven repository the parent poms and the imported boms of the maven projects
+	// are read from when not found within the source (by default ~/.m2/repository)
+	MavenLocalRepository string
+}
+
+func (cfg Config) localRepository() string {
+	if cfg.MavenLocalRepository != "" {
+		repository, err := homedir.Expand(cfg.MavenLocalRepository)
+		if err != nil {
+			return cfg.MavenLocalRepository
+		}
+		return repository
+	}
+
+	home, err := homedir.Dir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".m2", "repository")
 }
@@ -0,0 +1,254 @@
+package java
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+	"github.com/anchore/syft/syft/source"
+)
+
+const gradleLockfileCatalogerName = "java-gradle-lockfile-cataloger"
+
+const (
+	gradleLockfile            = "gradle.lockfile"
+	buildscriptGradleLockfile = "buildscript-gradle.lockfile"
+)
+
+// rootProjectNameExp matches the name of the root project in a settings.gradle or settings.gradle.kts file.
+// For example: rootProject.name = 'my-app' (...and the value "my-app" is captured)
+var rootProjectNameExp = regexp.MustCompile(`(?m)^\s*rootProject\.name\s*=\s*["']([^"']+)["']`)
+
+// GradleLockfileCataloger catalogs the dependencies locked by the gradle projects of a source tree.
+type GradleLockfileCataloger struct{}
+
+// NewJavaGradleLockfileCataloger returns a new cataloger for the gradle dependency lock files (gradle.lockfile and
+// buildscript-gradle.lockfile).
+func NewJavaGradleLockfileCataloger() *GradleLockfileCataloger {
+	return &GradleLockfileCataloger{}
+}
+
+// Name returns a string that uniquely describes a cataloger
+func (c *GradleLockfileCataloger) Name() string {
+	return gradleLockfileCatalogerName
+}
+
+// gradleLockedDependency is an entry of a gradle lock file, e.g.
+// "com.google.guava:guava:31.1-jre=compileClasspath,runtimeClasspath".
+type gradleLockedDependency struct {
+	MavenCoordinates
+	Configurations []string
+}
+
+// Catalog is given an object to resolve file references and content, this function returns the gradle projects and
+// their locked dependencies, related to each other according to the configurations locking them.
+func (c *GradleLockfileCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	locations, err := resolver.FilesByGlob("**/"+gradleLockfile, "**/"+buildscriptGradleLockfile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find files by glob: %w", err)
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		return locations[i].RealPath < locations[j].RealPath
+	})
+
+	var packages []*pkg.Package
+	var relationships []artifact.Relationship
+	projects := make(map[string]*pkg.Package)
+	dependencies := make(map[MavenCoordinates]*pkg.Package)
+	for _, location := range locations {
+		locked, err := readGradleLockfile(resolver, location)
+		if err != nil {
+			log.Warnf("cataloger '%s' failed to parse entries at location=%+v: %+v", gradleLockfileCatalogerName, location, err)
+			continue
+		}
+
+		// a project may lock its dependencies and the dependencies of its build script, both next to its build file
+		directory := path.Dir(location.RealPath)
+		project, exists := projects[directory]
+		if !exists {
+			project = newGradleProjectPackage(resolver, location)
+			projects[directory] = project
+			if project != nil {
+				packages = append(packages, project)
+			}
+		}
+		if project != nil {
+			project.Locations.Add(location)
+		}
+
+		buildscript := path.Base(location.RealPath) == buildscriptGradleLockfile
+		for _, dependency := range locked {
+			p, exists := dependencies[dependency.MavenCoordinates]
+			if !exists {
+				p = newMavenDependencyPackage(dependency.MavenCoordinates)
+				dependencies[dependency.MavenCoordinates] = p
+				packages = append(packages, p)
+			}
+			p.Locations.Add(location)
+			if project != nil {
+				relationships = append(relationships, common.NewDependencyRelationship(project, p, gradleRelationshipType(dependency.Configurations, buildscript)))
+			}
+		}
+	}
+
+	// the package IDs are only known once every location of the packages is known
+	result := make([]pkg.Package, len(packages))
+	for i, p := range packages {
+		p.FoundBy = gradleLockfileCatalogerName
+		p.SetID()
+		result[i] = *p
+	}
+	for i, r := range relationships {
+		relationships[i].From = *r.From.(*pkg.Package)
+		relationships[i].To = *r.To.(*pkg.Package)
+	}
+	return result, relationships, nil
+}
+
+func readGradleLockfile(resolver source.FileResolver, location source.Location) ([]gradleLockedDependency, error) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch contents: %w", err)
+	}
+	defer internal.CloseAndLogError(reader, location.VirtualPath)
+
+	return parseGradleLockfile(reader)
+}
+
+// parseGradleLockfile reads the "group:artifact:version=configuration,..." entries of a gradle lock file. The comments
+// and the "empty=" entry (listing the configurations without any dependency) are skipped.
+// See https://docs.gradle.org/current/userguide/dependency_locking.html#lock_state_location_and_format
+func parseGradleLockfile(reader io.Reader) ([]gradleLockedDependency, error) {
+	var dependencies []gradleLockedDependency
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "empty=") {
+			continue
+		}
+
+		notation, configurations := line, ""
+		if i := strings.Index(line, "="); i >= 0 {
+			notation, configurations = line[:i], line[i+1:]
+		}
+		fields := strings.Split(notation, ":")
+		if len(fields) != 3 {
+			log.Debugf("unexpected gradle lock file entry: %q", line)
+			continue
+		}
+
+		dependency := gradleLockedDependency{
+			MavenCoordinates: MavenCoordinates{
+				GroupID:    fields[0],
+				ArtifactID: fields[1],
+				Version:    fields[2],
+			},
+		}
+		for _, configuration := range strings.Split(configurations, ",") {
+			if configuration = strings.TrimSpace(configuration); configuration != "" {
+				dependency.Configurations = append(dependency.Configurations, configuration)
+			}
+		}
+		dependencies = append(dependencies, dependency)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse gradle lock file: %w", err)
+	}
+	return dependencies, nil
+}
+
+// gradleRelationshipType returns the relationship of a locked dependency to its project: the dependencies of the
+// build script and of the tooling configurations (e.g. annotationProcessor) are build dependencies, the dependencies of
+// the test configurations only are dev dependencies.
+func gradleRelationshipType(configurations []string, buildscript bool) artifact.RelationshipType {
+	if buildscript {
+		return artifact.BuildDependencyOfRelationship
+	}
+
+	var compile, runtime, test bool
+	for _, configuration := range configurations {
+		// variants prefix the configuration names, e.g. "testCompileClasspath" or "releaseRuntimeClasspath"
+		lowered := strings.ToLower(configuration)
+		switch {
+		case strings.HasPrefix(configuration, "test") || strings.Contains(configuration, "Test"):
+			test = true
+		case strings.HasSuffix(lowered, "compileclasspath"):
+			compile = true
+		case strings.HasSuffix(lowered, "runtimeclasspath"):
+			runtime = true
+		}
+	}
+
+	switch {
+	case compile:
+		return artifact.DependsOnRelationship
+	case runtime:
+		return artifact.RuntimeDependencyOfRelationship
+	case test:
+		return artifact.DevDependencyOfRelationship
+	}
+	return artifact.BuildDependencyOfRelationship
+}
+
+// newGradleProjectPackage returns the package of the gradle project locking its dependencies at the given location,
+// named after the root project name of its settings file or, lacking one, after its directory. The version of a gradle
+// project is defined by its build script and is not known.
+func newGradleProjectPackage(resolver source.FileResolver, lockfile source.Location) *pkg.Package {
+	directory := path.Dir(lockfile.RealPath)
+
+	name := gradleRootProjectName(resolver, lockfile)
+	if name == "" && directory != "/" && directory != "." {
+		name = path.Base(directory)
+	}
+	if name == "" {
+		// the root of a directory source is named after the scanned directory
+		name = path.Base(resolver.Path())
+	}
+	if name == "" || name == "." || name == "/" {
+		return nil
+	}
+
+	return &pkg.Package{
+		Name:         name,
+		Language:     pkg.Java,
+		Type:         pkg.JavaPkg,
+		MetadataType: pkg.JavaMetadataType,
+		Metadata: pkg.JavaMetadata{
+			VirtualPath: directory,
+		},
+	}
+}
+
+func gradleRootProjectName(resolver source.FileResolver, lockfile source.Location) string {
+	directory := path.Dir(lockfile.RealPath)
+	for _, settings := range []string{"settings.gradle", "settings.gradle.kts"} {
+		location := resolver.RelativeFileByPath(lockfile, path.Join(directory, settings))
+		if location == nil {
+			continue
+		}
+		reader, err := resolver.FileContentsByLocation(*location)
+		if err != nil {
+			log.Debugf("unable to read gradle settings %q: %+v", location.RealPath, err)
+			continue
+		}
+		contents, err := io.ReadAll(reader)
+		internal.CloseAndLogError(reader, location.VirtualPath)
+		if err != nil {
+			continue
+		}
+		if matches := rootProjectNameExp.FindSubmatch(contents); len(matches) > 1 {
+			return string(matches[1])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+package java
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+func TestGradleLockfileCataloger(t *testing.T) {
+	pkgs, relationships := catalogFixture(t, NewJavaGradleLockfileCataloger(), "test-fixtures/gradle-projects")
+
+	var names []string
+	for name := range pkgs {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{
+		// the root project is named by its settings, the sub project after its directory
+		"demo@",
+		"app@",
+		"guava@31.1-jre",
+		"postgresql@42.5.1",
+		"junit-jupiter-api@5.9.1",
+		"lombok@1.18.24",
+		"spring-boot-gradle-plugin@3.0.1",
+	}, names)
+
+	assert.ElementsMatch(t, []string{
+		"demo -[DEPENDS_ON]-> guava",
+		"postgresql -[runtime-dependency-of]-> demo",
+		"junit-jupiter-api -[dev-dependency-of]-> demo",
+		"lombok -[build-dependency-of]-> demo",
+		"spring-boot-gradle-plugin -[build-dependency-of]-> demo",
+		"app -[DEPENDS_ON]-> guava",
+	}, relationships)
+
+	guava := pkgs["guava@31.1-jre"]
+	assert.Equal(t, gradleLockfileCatalogerName, guava.FoundBy)
+	assert.Equal(t, "pkg:maven/com.google.guava/guava@31.1-jre", guava.Metadata.(pkg.JavaMetadata).PURL)
+	assert.Len(t, guava.Locations.ToSlice(), 2)
+	// both lock files of the root project are its locations
+	assert.Len(t, pkgs["demo@"].Locations.ToSlice(), 2)
+}
+
+func Test_parseGradleLockfile(t *testing.T) {
+	fixture := `# This is a Gradle generated file for dependency locking.
+com.google.guava:guava:31.1-jre=compileClasspath,runtimeClasspath
+org.example:unexpected=compileClasspath
+org.ow2.asm:asm:9.4=
+empty=annotationProcessor
+`
+	dependencies, err := parseGradleLockfile(strings.NewReader(fixture))
+	require.NoError(t, err)
+	assert.Equal(t, []gradleLockedDependency{
+		{
+			MavenCoordinates: MavenCoordinates{GroupID: "com.google.guava", ArtifactID: "guava", Version: "31.1-jre"},
+			Configurations:   []string{"compileClasspath", "runtimeClasspath"},
+		},
+		{
+			MavenCoordinates: MavenCoordinates{GroupID: "org.ow2.asm", ArtifactID: "asm", Version: "9.4"},
+		},
+	}, dependencies)
+}
+
+func Test_gradleRelationshipType(t *testing.T) {
+	tests := []struct {
+		name           string
+		configurations []string
+		buildscript    bool
+		expected       artifact.RelationshipType
+	}{
+		{
+			name:           "compile",
+			configurations: []string{"compileClasspath", "runtimeClasspath", "testCompileClasspath"},
+			expected:       artifact.DependsOnRelationship,
+		},
+		{
+			name:           "variant compile",
+			configurations: []string{"releaseCompileClasspath"},
+			expected:       artifact.DependsOnRelationship,
+		},
+		{
+			name:           "runtime",
+			configurations: []string{"runtimeClasspath", "testRuntimeClasspath"},
+			expected:       artifact.RuntimeDependencyOfRelationship,
+		},
+		{
+			name:           "test",
+			configurations: []string{"testCompileClasspath", "androidTestRuntimeClasspath"},
+			expected:       artifact.DevDependencyOfRelationship,
+		},
+		{
+			name:           "tooling",
+			configurations: []string{"annotationProcessor"},
+			expected:       artifact.BuildDependencyOfRelationship,
+		},
+		{
+			name:           "build script",
+			configurations: []string{"classpath"},
+			buildscript:    true,
+			expected:       artifact.BuildDependencyOfRelationship,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, gradleRelationshipType(test.configurations, test.buildscript))
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package java
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/vifraa/gopom"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/source"
+)
+
+// maxPropertyDepth bounds the nesting of property references, which protects against properties referencing each other.
+const maxPropertyDepth = 10
+
+var propertyExp = regexp.MustCompile(`\$\{([^}]+)}`)
+
+// mavenProject is a pom.xml along with the parent poms it inherits from.
+type mavenProject struct {
+	// location is the pom.xml within the source, nil for the poms of the local maven repository
+	location *source.Location
+	pom      gopom.Project
+	parent   *mavenProject
+}
+
+// mavenDependency is a dependency declared by a maven project, with its inherited and managed values resolved.
+type mavenDependency struct {
+	MavenCoordinates
+	Type       string
+	Classifier string
+	Scope      string
+	Optional   bool
+}
+
+// key identifies a dependency within the dependencies (or the managed dependencies) of a project: two declarations
+// with the same key are the same dependency.
+func (d mavenDependency) key() string {
+	return strings.Join([]string{d.GroupID, d.ArtifactID, d.Type, d.Classifier}, ":")
+}
+
+// relationshipType returns the relationship of the dependency to the project declaring it according to its scope.
+// See https://maven.apache.org/guides/introduction/introduction-to-dependency-mechanism.html#dependency-scope
+func (d mavenDependency) relationshipType() artifact.RelationshipType {
+	switch d.Scope {
+	case "test":
+		return artifact.DevDependencyOfRelationship
+	case "provided", "system":
+		return artifact.BuildDependencyOfRelationship
+	}
+	if d.Optional {
+		return artifact.OptionalDependencyOfRelationship
+	}
+	if d.Scope == "runtime" {
+		return artifact.RuntimeDependencyOfRelationship
+	}
+	return artifact.DependsOnRelationship
+}
+
+func (p *mavenProject) coordinates() MavenCoordinates {
+	declared := p.declaredCoordinates()
+	return MavenCoordinates{
+		GroupID:    p.interpolate(declared.GroupID),
+		ArtifactID: p.interpolate(declared.ArtifactID),
+		Version:    p.interpolate(declared.Version),
+	}
+}
+
+// declaredCoordinates returns the coordinates of the project before interpolation, the group and the version being
+// inherited from the parent when not declared.
+func (p *mavenProject) declaredCoordinates() MavenCoordinates {
+	coordinates := MavenCoordinates{
+		GroupID:    p.pom.GroupID,
+		ArtifactID: p.pom.ArtifactID,
+		Version:    p.pom.Version,
+	}
+	if coordinates.GroupID == "" {
+		coordinates.GroupID = p.pom.Parent.GroupID
+	}
+	if coordinates.Version == "" {
+		coordinates.Version = p.pom.Parent.Version
+	}
+	return coordinates
+}
+
+// property returns the value of a project property, either a model value (e.g. "project.version") or a property
+// declared by the project or one of its parents.
+func (p *mavenProject) property(name string) (string, bool) {
+	switch strings.TrimPrefix(strings.TrimPrefix(name, "project."), "pom.") {
+	case "groupId":
+		return p.declaredCoordinates().GroupID, true
+	case "artifactId":
+		return p.declaredCoordinates().ArtifactID, true
+	case "version":
+		return p.declaredCoordinates().Version, true
+	case "parent.groupId":
+		return p.pom.Parent.GroupID, p.pom.Parent.GroupID != ""
+	case "parent.artifactId":
+		return p.pom.Parent.ArtifactID, p.pom.Parent.ArtifactID != ""
+	case "parent.version":
+		return p.pom.Parent.Version, p.pom.Parent.Version != ""
+	}
+
+	for ancestor := p; ancestor != nil; ancestor = ancestor.parent {
+		if value, exists := ancestor.pom.Properties.Entries[name]; exists {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// interpolate replaces the property references of a value. Like maven, the values inherited from the parents are
+// interpolated in the context of the project inheriting them: "${project.version}" is the version of the child
+// project. References to unknown properties (e.g. environment variables) are left as is.
+func (p *mavenProject) interpolate(value string) string {
+	return p.interpolateWithDepth(value, 0)
+}
+
+func (p *mavenProject) interpolateWithDepth(value string, depth int) string {
+	if depth > maxPropertyDepth || !strings.Contains(value, "${") {
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(propertyExp.ReplaceAllStringFunc(value, func(reference string) string {
+		if resolved, exists := p.property(propertyExp.FindStringSubmatch(reference)[1]); exists {
+			return p.interpolateWithDepth(resolved, depth+1)
+		}
+		return reference
+	}))
+}
+
+// resolveDependency interpolates the values of a dependency declared by the project or one of its parents.
+func (p *mavenProject) resolveDependency(d gopom.Dependency) mavenDependency {
+	dependency := mavenDependency{
+		MavenCoordinates: MavenCoordinates{
+			GroupID:    p.interpolate(d.GroupID),
+			ArtifactID: p.interpolate(d.ArtifactID),
+			Version:    p.interpolate(d.Version),
+		},
+		Type:       p.interpolate(d.Type),
+		Classifier: p.interpolate(d.Classifier),
+		Scope:      p.interpolate(d.Scope),
+		Optional:   p.interpolate(d.Optional) == "true",
+	}
+	if dependency.Type == "" {
+		dependency.Type = "jar"
+	}
+	return dependency
+}
+
+// isUnresolved indicates that a value still references a property after interpolation.
+func isUnresolved(value string) bool {
+	return strings.Contains(value, "${")
+}
@@ -0,0 +1,427 @@
+package java
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+	"github.com/vifraa/gopom"
+	"golang.org/x/net/html/charset"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+	"github.com/anchore/syft/syft/source"
+)
+
+const pomCatalogerName = "java-pom-cataloger"
+
+// maxParentDepth bounds the chain of parent poms, which protects against poms inheriting from each other.
+const maxParentDepth = 20
+
+// PomCataloger catalogs the maven projects of a source tree (pom.xml) along with the dependencies they declare.
+type PomCataloger struct {
+	localRepository string
+}
+
+// NewJavaPomCataloger returns a new cataloger for the dependencies declared by maven projects. Parent poms and imported
+// boms are looked up within the source first, then within the local maven repository: nothing is downloaded.
+func NewJavaPomCataloger(cfg Config) *PomCataloger {
+	return &PomCataloger{
+		localRepository: cfg.localRepository(),
+	}
+}
+
+// Name returns a string that uniquely describes a cataloger
+func (c *PomCataloger) Name() string {
+	return pomCatalogerName
+}
+
+// Catalog is given an object to resolve file references and content, this function returns the maven projects and
+// their declared dependencies, related to each other by scope.
+func (c *PomCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	locations, err := resolver.FilesByGlob("**/pom.xml")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find files by glob: %w", err)
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		return locations[i].RealPath < locations[j].RealPath
+	})
+
+	poms := newPomResolver(resolver, c.localRepository)
+
+	var projects []*mavenProject
+	for _, location := range locations {
+		project, err := poms.projectAt(location)
+		if err != nil {
+			log.Warnf("cataloger '%s' failed to parse entries at location=%+v: %+v", pomCatalogerName, location, err)
+			continue
+		}
+		projects = append(projects, project)
+	}
+
+	return newMavenPackages(poms, projects).catalog()
+}
+
+// pomResolver reads and caches the poms of the source and of the local maven repository.
+type pomResolver struct {
+	resolver        source.FileResolver
+	localRepository string
+	bySourcePath    map[string]*mavenProject
+	byCoordinates   map[MavenCoordinates]*mavenProject
+}
+
+func newPomResolver(resolver source.FileResolver, localRepository string) *pomResolver {
+	return &pomResolver{
+		resolver:        resolver,
+		localRepository: localRepository,
+		bySourcePath:    make(map[string]*mavenProject),
+		byCoordinates:   make(map[MavenCoordinates]*mavenProject),
+	}
+}
+
+// projectAt returns the maven project of a pom.xml of the source, along with its parents.
+func (r *pomResolver) projectAt(location source.Location) (*mavenProject, error) {
+	return r.projectAtWithDepth(location, 0)
+}
+
+func (r *pomResolver) projectAtWithDepth(location source.Location, depth int) (*mavenProject, error) {
+	if project, exists := r.bySourcePath[location.RealPath]; exists {
+		if project == nil {
+			return nil, fmt.Errorf("unable to read pom.xml")
+		}
+		return project, nil
+	}
+	// a pom which is being resolved is not a parent candidate for one of its own parents
+	r.bySourcePath[location.RealPath] = nil
+
+	reader, err := r.resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch contents: %w", err)
+	}
+	defer internal.CloseAndLogError(reader, location.VirtualPath)
+
+	pom, err := decodePomXML(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	project := &mavenProject{location: &location, pom: pom}
+	project.parent = r.parentOf(project, depth)
+	r.bySourcePath[location.RealPath] = project
+	return project, nil
+}
+
+// parentOf returns the parent of a project: the pom at its relative path (by default "../pom.xml") when that pom is
+// the declared parent, the pom of the local maven repository otherwise.
+func (r *pomResolver) parentOf(project *mavenProject, depth int) *mavenProject {
+	parent := project.pom.Parent
+	if parent.ArtifactID == "" || depth >= maxParentDepth {
+		return nil
+	}
+
+	if project.location != nil {
+		relativePath := parent.RelativePath
+		if relativePath == "" {
+			relativePath = "../pom.xml"
+		}
+		candidatePath := path.Join(path.Dir(project.location.RealPath), relativePath)
+		if !strings.HasSuffix(candidatePath, ".xml") {
+			candidatePath = path.Join(candidatePath, "pom.xml")
+		}
+		// the parent may be outside of the source, which is never read
+		if candidatePath != project.location.RealPath && !strings.HasPrefix(candidatePath, "../") {
+			if location := r.resolver.RelativeFileByPath(*project.location, candidatePath); location != nil {
+				candidate, err := r.projectAtWithDepth(*location, depth+1)
+				if err == nil && candidate.isDeclaredBy(parent) {
+					return candidate
+				}
+			}
+		}
+	}
+
+	return r.fromLocalRepository(MavenCoordinates{
+		GroupID:    parent.GroupID,
+		ArtifactID: parent.ArtifactID,
+		Version:    parent.Version,
+	}, depth+1)
+}
+
+// isDeclaredBy indicates that the project is the parent the given declaration refers to.
+func (p *mavenProject) isDeclaredBy(parent gopom.Parent) bool {
+	coordinates := p.coordinates()
+	return coordinates.GroupID == parent.GroupID && coordinates.ArtifactID == parent.ArtifactID
+}
+
+// fromLocalRepository returns the project of a pom installed in the local maven repository
+// (<repository>/<group path>/<artifactId>/<version>/<artifactId>-<version>.pom), nil when not installed.
+func (r *pomResolver) fromLocalRepository(coordinates MavenCoordinates, depth int) *mavenProject {
+	if r.localRepository == "" || coordinates.GroupID == "" || coordinates.Version == "" || isUnresolved(coordinates.Version) {
+		return nil
+	}
+	if project, exists := r.byCoordinates[coordinates]; exists {
+		return project
+	}
+	r.byCoordinates[coordinates] = nil
+
+	pomPath := filepath.Join(
+		r.localRepository,
+		filepath.Join(strings.Split(coordinates.GroupID, ".")...),
+		coordinates.ArtifactID,
+		coordinates.Version,
+		fmt.Sprintf("%s-%s.pom", coordinates.ArtifactID, coordinates.Version),
+	)
+	f, err := os.Open(pomPath)
+	if err != nil {
+		log.Debugf("pom %s is not in the local maven repository: %+v", coordinates, err)
+		return nil
+	}
+	defer internal.CloseAndLogError(f, pomPath)
+
+	pom, err := decodePomXML(f)
+	if err != nil {
+		log.Warnf("unable to read pom %s from the local maven repository: %+v", coordinates, err)
+		return nil
+	}
+
+	project := &mavenProject{pom: pom}
+	project.parent = r.parentOf(project, depth)
+	r.byCoordinates[coordinates] = project
+	return project
+}
+
+// managedDependencies returns the dependency management of a project by dependency key: the entries of the project
+// and of its parents, the closest first, followed by the entries of the boms they import.
+func (r *pomResolver) managedDependencies(project *mavenProject) map[string]mavenDependency {
+	return r.managedDependenciesWithDepth(project, 0)
+}
+
+func (r *pomResolver) managedDependenciesWithDepth(project *mavenProject, depth int) map[string]mavenDependency {
+	managed := make(map[string]mavenDependency)
+	var imports []mavenDependency
+	for ancestor := project; ancestor != nil; ancestor = ancestor.parent {
+		for _, declared := range ancestor.pom.DependencyManagement.Dependencies {
+			dependency := project.resolveDependency(declared)
+			if dependency.Scope == "import" {
+				imports = append(imports, dependency)
+				continue
+			}
+			if _, exists := managed[dependency.key()]; !exists {
+				managed[dependency.key()] = dependency
+			}
+		}
+	}
+
+	if depth >= maxParentDepth {
+		return managed
+	}
+	for _, imported := range imports {
+		bom := r.fromLocalRepository(imported.MavenCoordinates, depth+1)
+		if bom == nil {
+			continue
+		}
+		for key, dependency := range r.managedDependenciesWithDepth(bom, depth+1) {
+			if _, exists := managed[key]; !exists {
+				managed[key] = dependency
+			}
+		}
+	}
+	return managed
+}
+
+// dependencies returns the dependencies of a project: the dependencies it declares and the dependencies it inherits
+// from its parents, completed by the dependency management.
+func (r *pomResolver) dependencies(project *mavenProject) []mavenDependency {
+	managed := r.managedDependencies(project)
+
+	var dependencies []mavenDependency
+	declared := internal.NewStringSet()
+	for ancestor := project; ancestor != nil; ancestor = ancestor.parent {
+		for _, d := range ancestor.pom.Dependencies {
+			dependency := project.resolveDependency(d)
+			if declared.Contains(dependency.key()) {
+				continue
+			}
+			declared.Add(dependency.key())
+
+			if management, exists := managed[dependency.key()]; exists {
+				if dependency.Version == "" {
+					dependency.Version = management.Version
+				}
+				if dependency.Scope == "" {
+					dependency.Scope = management.Scope
+				}
+			}
+			if dependency.Scope == "" {
+				dependency.Scope = "compile"
+			}
+			if isUnresolved(dependency.Version) {
+				dependency.Version = ""
+			}
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	return dependencies
+}
+
+func decodePomXML(reader io.Reader) (gopom.Project, error) {
+	var project gopom.Project
+
+	decoder := xml.NewDecoder(reader)
+	// prevent against warnings for "xml: encoding "iso-8859-1" declared but Decoder.CharsetReader is nil"
+	decoder.CharsetReader = charset.NewReaderLabel
+
+	if err := decoder.Decode(&project); err != nil {
+		return project, fmt.Errorf("unable to unmarshal pom.xml: %w", err)
+	}
+	return project, nil
+}
+
+// mavenPackages collects the packages of the maven projects and of their dependencies, a dependency declared by
+// several projects being a single package.
+type mavenPackages struct {
+	poms         *pomResolver
+	projects     []*mavenProject
+	byCoordinate map[MavenCoordinates]*pkg.Package
+	packages     []*pkg.Package
+}
+
+func newMavenPackages(poms *pomResolver, projects []*mavenProject) *mavenPackages {
+	return &mavenPackages{
+		poms:         poms,
+		projects:     projects,
+		byCoordinate: make(map[MavenCoordinates]*pkg.Package),
+	}
+}
+
+func (m *mavenPackages) catalog() ([]pkg.Package, []artifact.Relationship, error) {
+	projectPackages := make([]*pkg.Package, len(m.projects))
+	for i, project := range m.projects {
+		projectPackages[i] = m.projectPackage(project)
+	}
+
+	var relationships []artifact.Relationship
+	for i, project := range m.projects {
+		for _, dependency := range m.poms.dependencies(project) {
+			if dependency.GroupID == "" || dependency.ArtifactID == "" {
+				continue
+			}
+			p := m.dependencyPackage(dependency, *project.location)
+			if p == projectPackages[i] {
+				continue
+			}
+			relationships = append(relationships, common.NewDependencyRelationship(projectPackages[i], p, dependency.relationshipType()))
+		}
+	}
+
+	// the package IDs are only known once every location of the packages is known
+	packages := make([]pkg.Package, len(m.packages))
+	for i, p := range m.packages {
+		p.FoundBy = pomCatalogerName
+		p.SetID()
+		packages[i] = *p
+	}
+	for i, r := range relationships {
+		relationships[i].From = *r.From.(*pkg.Package)
+		relationships[i].To = *r.To.(*pkg.Package)
+	}
+	return packages, relationships, nil
+}
+
+func (m *mavenPackages) projectPackage(project *mavenProject) *pkg.Package {
+	coordinates := project.coordinates()
+	if p, exists := m.byCoordinate[coordinates]; exists {
+		p.Locations.Add(*project.location)
+		return p
+	}
+
+	p := &pkg.Package{
+		Name:         coordinates.ArtifactID,
+		Version:      coordinates.Version,
+		Locations:    source.NewLocationSet(*project.location),
+		Language:     pkg.Java,
+		Type:         pkg.JavaPkg,
+		MetadataType: pkg.JavaMetadataType,
+		Metadata: pkg.JavaMetadata{
+			VirtualPath: project.location.RealPath,
+			PomProject: &pkg.PomProject{
+				Path:        project.location.RealPath,
+				Parent:      pomParent(project.pom.Parent),
+				GroupID:     coordinates.GroupID,
+				ArtifactID:  coordinates.ArtifactID,
+				Version:     coordinates.Version,
+				Name:        project.interpolate(project.pom.Name),
+				Description: cleanDescription(project.interpolate(project.pom.Description)),
+				URL:         project.interpolate(project.pom.URL),
+			},
+			PURL: mavenPackageURL(coordinates),
+		},
+	}
+	m.add(coordinates, p)
+	return p
+}
+
+// dependencyPackage returns the package of a declared dependency, which is the package of the project when the
+// dependency is one of the projects of the source (e.g. a sibling module).
+func (m *mavenPackages) dependencyPackage(dependency mavenDependency, declaredAt source.Location) *pkg.Package {
+	if p, exists := m.byCoordinate[dependency.MavenCoordinates]; exists {
+		// the location of a project is its own pom.xml, not the poms depending on it
+		if metadata, ok := p.Metadata.(pkg.JavaMetadata); ok && metadata.PomProject == nil {
+			p.Locations.Add(declaredAt)
+		}
+		return p
+	}
+
+	p := newMavenDependencyPackage(dependency.MavenCoordinates)
+	p.Locations.Add(declaredAt)
+	m.add(dependency.MavenCoordinates, p)
+	return p
+}
+
+func (m *mavenPackages) add(coordinates MavenCoordinates, p *pkg.Package) {
+	m.byCoordinate[coordinates] = p
+	m.packages = append(m.packages, p)
+}
+
+// newMavenDependencyPackage returns the package of a maven artifact which is declared, but not built, by the source.
+func newMavenDependencyPackage(coordinates MavenCoordinates) *pkg.Package {
+	properties := pkg.PomProperties{
+		Name:       coordinates.ArtifactID,
+		GroupID:    coordinates.GroupID,
+		ArtifactID: coordinates.ArtifactID,
+		Version:    coordinates.Version,
+	}
+	return &pkg.Package{
+		Name:         coordinates.ArtifactID,
+		Version:      coordinates.Version,
+		Language:     pkg.Java,
+		Type:         properties.PkgTypeIndicated(),
+		MetadataType: pkg.JavaMetadataType,
+		Metadata: pkg.JavaMetadata{
+			PomProperties: &properties,
+			PURL:          mavenPackageURL(coordinates),
+		},
+	}
+}
+
+// mavenPackageURL returns the PURL of a maven artifact (see https://github.com/package-url/purl-spec)
+func mavenPackageURL(coordinates MavenCoordinates) string {
+	if coordinates.GroupID == "" || coordinates.ArtifactID == "" {
+		return ""
+	}
+	return packageurl.NewPackageURL(
+		packageurl.TypeMaven,
+		coordinates.GroupID,
+		coordinates.ArtifactID,
+		coordinates.Version,
+		nil,
+		"").ToString()
+}
@@ -0,0 +1,200 @@
+package java
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vifraa/gopom"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+// catalogFixture catalogs a directory of the test fixtures, returning the "name@version" of the packages and the
+// "from -[type]-> to" relationships between them.
+func catalogFixture(t *testing.T, cataloger interface {
+	Catalog(source.FileResolver) ([]pkg.Package, []artifact.Relationship, error)
+}, fixture string) (map[string]pkg.Package, []string) {
+	t.Helper()
+	src, err := source.NewFromDirectory(fixture)
+	require.NoError(t, err)
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	pkgs, relationships, err := cataloger.Catalog(resolver)
+	require.NoError(t, err)
+
+	byName := make(map[string]pkg.Package)
+	for _, p := range pkgs {
+		assert.NotEmpty(t, p.ID())
+		byName[fmt.Sprintf("%s@%s", p.Name, p.Version)] = p
+	}
+
+	var edges []string
+	for _, r := range relationships {
+		from, fromOk := r.From.(pkg.Package)
+		to, toOk := r.To.(pkg.Package)
+		require.True(t, fromOk && toOk, "relationship between unexpected elements: %+v", r)
+		assert.Contains(t, byName, fmt.Sprintf("%s@%s", from.Name, from.Version))
+		assert.Contains(t, byName, fmt.Sprintf("%s@%s", to.Name, to.Version))
+		edges = append(edges, fmt.Sprintf("%s -[%s]-> %s", from.Name, r.Type, to.Name))
+	}
+	return byName, edges
+}
+
+func TestPomCataloger(t *testing.T) {
+	cataloger := NewJavaPomCataloger(Config{MavenLocalRepository: "test-fixtures/maven-repository"})
+	pkgs, relationships := catalogFixture(t, cataloger, "test-fixtures/maven-projects")
+
+	var names []string
+	for name := range pkgs {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{
+		"app-parent@1.2.0",
+		"app-core@1.2.0",
+		"app-web@1.2.0",
+		"slf4j-api@2.0.6",
+		"guava@31.1-jre",
+		"commons-lang3@3.12.0",
+		"jackson-databind@2.14.1",
+		"junit-jupiter@5.9.1",
+		// the version of this dependency is an environment variable
+		"unknown@",
+		"jakarta.servlet-api@6.0.0",
+		"postgresql@42.5.1",
+		"lombok@1.18.24",
+	}, names)
+
+	assert.ElementsMatch(t, []string{
+		"app-parent -[DEPENDS_ON]-> slf4j-api",
+		"app-core -[DEPENDS_ON]-> slf4j-api",
+		"app-core -[DEPENDS_ON]-> guava",
+		"app-core -[DEPENDS_ON]-> commons-lang3",
+		"jackson-databind -[optional-dependency-of]-> app-core",
+		"junit-jupiter -[dev-dependency-of]-> app-core",
+		"app-core -[DEPENDS_ON]-> unknown",
+		"app-web -[DEPENDS_ON]-> slf4j-api",
+		// sibling modules relate to each other
+		"app-web -[DEPENDS_ON]-> app-core",
+		"jakarta.servlet-api -[build-dependency-of]-> app-web",
+		"postgresql -[runtime-dependency-of]-> app-web",
+		// the scope is managed by the corp parent
+		"lombok -[build-dependency-of]-> app-web",
+	}, relationships)
+
+	core := pkgs["app-core@1.2.0"]
+	assert.Equal(t, pomCatalogerName, core.FoundBy)
+	assert.Equal(t, pkg.JavaMetadataType, core.MetadataType)
+	assert.Equal(t, pkg.JavaMetadata{
+		VirtualPath: "core/pom.xml",
+		PomProject: &pkg.PomProject{
+			Path: "core/pom.xml",
+			Parent: &pkg.PomParent{
+				GroupID:    "com.example",
+				ArtifactID: "app-parent",
+				Version:    "1.2.0",
+			},
+			GroupID:    "com.example",
+			ArtifactID: "app-core",
+			Version:    "1.2.0",
+			Name:       "App Core",
+		},
+		PURL: "pkg:maven/com.example/app-core@1.2.0",
+	}, core.Metadata)
+	// the location of a project is its own pom only
+	assert.Len(t, core.Locations.ToSlice(), 1)
+
+	guava := pkgs["guava@31.1-jre"]
+	assert.Equal(t, pkg.JavaPkg, guava.Type)
+	assert.Equal(t, "pkg:maven/com.google.guava/guava@31.1-jre", guava.Metadata.(pkg.JavaMetadata).PURL)
+	assert.Equal(t, "com.google.guava", guava.Metadata.(pkg.JavaMetadata).PomProperties.GroupID)
+
+	// slf4j-api is declared by the parent and inherited by both modules
+	assert.Len(t, pkgs["slf4j-api@2.0.6"].Locations.ToSlice(), 3)
+}
+
+func TestPomCataloger_withoutLocalRepository(t *testing.T) {
+	cataloger := NewJavaPomCataloger(Config{MavenLocalRepository: "test-fixtures/missing-repository"})
+	pkgs, relationships := catalogFixture(t, cataloger, "test-fixtures/maven-projects")
+
+	// the versions and scopes managed by the corp parent and the junit bom are not known
+	assert.Contains(t, pkgs, "slf4j-api@")
+	assert.Contains(t, pkgs, "commons-lang3@")
+	assert.Contains(t, pkgs, "junit-jupiter@")
+	assert.Contains(t, relationships, "app-web -[DEPENDS_ON]-> lombok")
+	// the versions managed within the source are
+	assert.Contains(t, pkgs, "guava@31.1-jre")
+}
+
+func Test_mavenProject_interpolate(t *testing.T) {
+	parent := &mavenProject{
+		pom: gopom.Project{
+			GroupID:    "org.example",
+			ArtifactID: "parent",
+			Version:    "3",
+			Properties: gopom.Properties{Entries: map[string]string{
+				"lib.version":   "${project.version}",
+				"other.version": "2.${minor}",
+				"minor":         "5",
+				"loop":          "${loop}",
+			}},
+		},
+	}
+	project := &mavenProject{
+		pom: gopom.Project{
+			Parent:     gopom.Parent{GroupID: "org.example", ArtifactID: "parent", Version: "3"},
+			ArtifactID: "child",
+			Version:    "1.0",
+			Properties: gopom.Properties{Entries: map[string]string{
+				"minor": "7",
+			}},
+		},
+		parent: parent,
+	}
+
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{value: "${project.groupId}", expected: "org.example"},
+		{value: "${project.artifactId}-${pom.version}", expected: "child-1.0"},
+		{value: "${project.parent.version}", expected: "3"},
+		// inherited values are interpolated in the context of the child project
+		{value: "${lib.version}", expected: "1.0"},
+		{value: "${other.version}", expected: "2.7"},
+		{value: "${env.HOME}", expected: "${env.HOME}"},
+		{value: " ${minor} ", expected: "7"},
+		{value: "${loop}", expected: "${loop}"},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			assert.Equal(t, test.expected, project.interpolate(test.value))
+		})
+	}
+}
+
+func Test_mavenDependency_relationshipType(t *testing.T) {
+	tests := []struct {
+		scope    string
+		optional bool
+		expected artifact.RelationshipType
+	}{
+		{scope: "compile", expected: artifact.DependsOnRelationship},
+		{scope: "compile", optional: true, expected: artifact.OptionalDependencyOfRelationship},
+		{scope: "runtime", expected: artifact.RuntimeDependencyOfRelationship},
+		{scope: "runtime", optional: true, expected: artifact.OptionalDependencyOfRelationship},
+		{scope: "provided", expected: artifact.BuildDependencyOfRelationship},
+		{scope: "system", expected: artifact.BuildDependencyOfRelationship},
+		{scope: "test", expected: artifact.DevDependencyOfRelationship},
+		{scope: "test", optional: true, expected: artifact.DevDependencyOfRelationship},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s optional=%t", test.scope, test.optional), func(t *testing.T) {
+			assert.Equal(t, test.expected, mavenDependency{Scope: test.scope, Optional: test.optional}.relationshipType())
+		})
+	}
+}
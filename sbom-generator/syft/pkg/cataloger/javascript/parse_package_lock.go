@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
@@ -22,10 +23,13 @@ type PackageLock struct {
 
 // Dependency represents a single package dependency listed in the package.lock json file
 type Dependency struct {
-	Version   string `json:"version"`
-	Resolved  string `json:"resolved"`
-	Integrity string `json:"integrity"`
-	Requires  map[string]string
+	Version      string `json:"version"`
+	Resolved     string `json:"resolved"`
+	Integrity    string `json:"integrity"`
+	Dev          bool   `json:"dev"`
+	Optional     bool   `json:"optional"`
+	Requires     map[string]string
+	Dependencies map[string]Dependency
 }
 
 // parsePackageLock parses a package-lock.json and returns the discovered JavaScript packages.
@@ -37,6 +41,7 @@ func parsePackageLock(path string, reader io.Reader) ([]*pkg.Package, []artifact
 	}
 
 	var packages []*pkg.Package
+	var relationships []artifact.Relationship
 	dec := json.NewDecoder(reader)
 
 	for {
@@ -46,15 +51,61 @@ func parsePackageLock(path string, reader io.Reader) ([]*pkg.Package, []artifact
 		} else if err != nil {
 			return nil, nil, fmt.Errorf("failed to parse package-lock.json file: %w", err)
 		}
+		byName := make(map[string]*pkg.Package)
 		for name, pkgMeta := range lock.Dependencies {
-			packages = append(packages, &pkg.Package{
+			p := &pkg.Package{
 				Name:     name,
 				Version:  pkgMeta.Version,
 				Language: pkg.JavaScript,
 				Type:     pkg.NpmPkg,
-			})
+			}
+			byName[name] = p
+			packages = append(packages, p)
 		}
+		relationships = append(relationships, packageLockRelationships(lock, byName)...)
 	}
 
-	return packages, nil, nil
+	return packages, relationships, nil
+}
+
+// packageLockRelationships relates the top level dependencies of a package-lock.json to the top level dependencies
+// they require. Requirements satisfied by a nested (not top level) dependency are not related.
+func packageLockRelationships(lock PackageLock, byName map[string]*pkg.Package) []artifact.Relationship {
+	names := make([]string, 0, len(lock.Dependencies))
+	for name := range lock.Dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var relationships []artifact.Relationship
+	for _, name := range names {
+		pkgMeta := lock.Dependencies[name]
+
+		required := make([]string, 0, len(pkgMeta.Requires))
+		for requiredName := range pkgMeta.Requires {
+			required = append(required, requiredName)
+		}
+		sort.Strings(required)
+
+		for _, requiredName := range required {
+			if _, nested := pkgMeta.Dependencies[requiredName]; nested {
+				continue
+			}
+			dependency, exists := byName[requiredName]
+			if !exists || requiredName == name {
+				continue
+			}
+
+			// dependencies only needed during development and optional dependencies are flagged as such
+			ty := artifact.DependsOnRelationship
+			switch requiredMeta := lock.Dependencies[requiredName]; {
+			case requiredMeta.Dev:
+				ty = artifact.DevDependencyOfRelationship
+			case requiredMeta.Optional:
+				ty = artifact.OptionalDependencyOfRelationship
+			}
+			relationships = append(relationships, common.NewDependencyRelationship(byName[name], dependency, ty))
+		}
+	}
+	return relationships
 }
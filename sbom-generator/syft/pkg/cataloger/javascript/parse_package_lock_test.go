@@ -1,11 +1,13 @@
 package javascript
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
 )
 
@@ -28,6 +30,21 @@ func assertPkgsEqual(t *testing.T, actual []*pkg.Package, expected map[string]pk
 	}
 }
 
+// assertRelationshipsEqual compares relationships between discovered packages as "from -[type]-> to" strings.
+func assertRelationshipsEqual(t *testing.T, actual []artifact.Relationship, expected []string) {
+	t.Helper()
+	var actualStrings []string
+	for _, r := range actual {
+		from, fromOk := r.From.(*pkg.Package)
+		to, toOk := r.To.(*pkg.Package)
+		if !fromOk || !toOk {
+			t.Fatalf("relationship between unexpected elements: %+v", r)
+		}
+		actualStrings = append(actualStrings, fmt.Sprintf("%s -[%s]-> %s", from.Name, r.Type, to.Name))
+	}
+	assert.ElementsMatch(t, expected, actualStrings)
+}
+
 func TestParsePackageLock(t *testing.T) {
 	expected := map[string]pkg.Package{
 		"wordwrap": {
@@ -96,12 +113,42 @@ func TestParsePackageLock(t *testing.T) {
 		t.Fatalf("failed to open fixture: %+v", err)
 	}
 
-	// TODO: no relationships are under test yet
-	actual, _, err := parsePackageLock(fixture.Name(), fixture)
+	actual, relationships, err := parsePackageLock(fixture.Name(), fixture)
 	if err != nil {
 		t.Fatalf("failed to parse package-lock.json: %+v", err)
 	}
 
 	assertPkgsEqual(t, actual, expected)
+	assertRelationshipsEqual(t, relationships, []string{
+		"cowsay -[DEPENDS_ON]-> get-stdin",
+		"cowsay -[DEPENDS_ON]-> optimist",
+		"cowsay -[DEPENDS_ON]-> string-width",
+		"cowsay -[DEPENDS_ON]-> strip-eof",
+		"optimist -[DEPENDS_ON]-> minimist",
+		"optimist -[DEPENDS_ON]-> wordwrap",
+		"string-width -[DEPENDS_ON]-> is-fullwidth-code-point",
+		"string-width -[DEPENDS_ON]-> strip-ansi",
+		"strip-ansi -[DEPENDS_ON]-> ansi-regex",
+	})
+}
+
+func TestParsePackageLock_DevAndOptionalDependencies(t *testing.T) {
+	fixture, err := os.Open("test-fixtures/pkg-lock/package-lock-dev-optional.json")
+	if err != nil {
+		t.Fatalf("failed to open fixture: %+v", err)
+	}
+
+	actual, relationships, err := parsePackageLock(fixture.Name(), fixture)
+	if err != nil {
+		t.Fatalf("failed to parse package-lock.json: %+v", err)
+	}
 
+	assert.Len(t, actual, 6)
+	assertRelationshipsEqual(t, relationships, []string{
+		"express -[DEPENDS_ON]-> debug",
+		// debug requires its own nested copy of ms, not the top level one
+		"fsevents -[optional-dependency-of]-> chokidar",
+		"mocha -[DEPENDS_ON]-> chokidar",
+		"ms -[dev-dependency-of]-> mocha",
+	})
 }
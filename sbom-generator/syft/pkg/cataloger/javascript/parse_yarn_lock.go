@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"strings"
 
 	"github.com/anchore/syft/internal"
 	"github.com/anchore/syft/syft/artifact"
@@ -32,6 +33,24 @@ const (
 	noVersion = ""
 )
 
+// yarnLockEntry is an entry of a yarn.lock, resolving one or several descriptors ("name@range") to a version of a
+// package. Only the first entry of a package is cataloged.
+type yarnLockEntry struct {
+	name         string
+	version      string
+	pkg          *pkg.Package
+	dependencies []yarnLockDependency
+	optional     map[string]bool
+}
+
+// yarnLockDependency is a dependency listed by a yarn.lock entry, e.g. `"@babel/highlight" "^7.10.4"` (yarn v1) or
+// `"@babel/highlight": ^7.10.4` (yarn berry).
+type yarnLockDependency struct {
+	name     string
+	spec     string
+	optional bool
+}
+
 func parseYarnLock(path string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
 	// in the case we find yarn.lock files in the node_modules directories, skip those
 	// as the whole purpose of the lock file is for the specific dependencies of the project
@@ -40,21 +59,32 @@ func parseYarnLock(path string, reader io.Reader) ([]*pkg.Package, []artifact.Re
 	}
 
 	var packages []*pkg.Package
+	var entries []*yarnLockEntry
+	descriptors := make(map[string]*yarnLockEntry)
 	scanner := bufio.NewScanner(reader)
 	parsedPackages := internal.NewStringSet()
 	currentPackage := noPackage
+	var currentEntry *yarnLockEntry
+	var section, metaDependency string
 
 	for scanner.Scan() {
 		line := scanner.Text()
 
-		if currentPackage == noPackage {
+		if !strings.HasPrefix(line, " ") {
 			// Scan until we find the next package
+			currentEntry, currentPackage, section = nil, noPackage, ""
 
 			packageName := findPackageName(line)
 			if packageName == noPackage {
 				continue
 			}
 
+			currentEntry = &yarnLockEntry{name: packageName, optional: make(map[string]bool)}
+			entries = append(entries, currentEntry)
+			for _, descriptor := range findPackageDescriptors(line) {
+				descriptors[descriptor] = currentEntry
+			}
+
 			if parsedPackages.Contains(packageName) {
 				// We don't parse repeated package declarations.
 				continue
@@ -66,21 +96,122 @@ func parseYarnLock(path string, reader io.Reader) ([]*pkg.Package, []artifact.Re
 			continue
 		}
 
-		// We've found the package entry, now we just need the version
-
-		if version := findPackageVersion(line); version != noVersion {
-			packages = append(packages, newYarnLockPackage(currentPackage, version))
-			currentPackage = noPackage
-
+		if currentEntry == nil {
 			continue
 		}
+
+		// We've found the package entry, now we need the version and the dependencies
+
+		value := strings.TrimSpace(line)
+		switch indent := len(line) - len(strings.TrimLeft(line, " ")); {
+		case indent <= 2 && strings.HasSuffix(value, ":"):
+			section = strings.TrimSuffix(value, ":")
+		case indent <= 2:
+			section = ""
+			if version := findPackageVersion(line); version != noVersion {
+				currentEntry.version = version
+				if currentPackage != noPackage {
+					currentEntry.pkg = newYarnLockPackage(currentPackage, version)
+					packages = append(packages, currentEntry.pkg)
+					currentPackage = noPackage
+				}
+			}
+		case section == "dependencies" || section == "optionalDependencies":
+			name, spec := findDependency(value)
+			currentEntry.dependencies = append(currentEntry.dependencies, yarnLockDependency{
+				name:     name,
+				spec:     spec,
+				optional: section == "optionalDependencies",
+			})
+		case section == "dependenciesMeta" && indent <= 4:
+			metaDependency = strings.Trim(strings.TrimSuffix(value, ":"), `"`)
+		case section == "dependenciesMeta" && value == "optional: true":
+			// yarn berry lists optional dependencies along with the others, and flags them as optional
+			currentEntry.optional[metaDependency] = true
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
 		return nil, nil, fmt.Errorf("failed to parse yarn.lock file: %w", err)
 	}
 
-	return packages, nil, nil
+	return packages, yarnLockRelationships(entries, descriptors), nil
+}
+
+// yarnLockRelationships relates the cataloged packages of a yarn.lock to the cataloged packages resolving the
+// descriptors of their dependencies.
+func yarnLockRelationships(entries []*yarnLockEntry, descriptors map[string]*yarnLockEntry) []artifact.Relationship {
+	byName := make(map[string]*yarnLockEntry)
+	for _, entry := range entries {
+		if entry.pkg != nil {
+			byName[entry.name] = entry
+		}
+	}
+
+	var relationships []artifact.Relationship
+	for _, entry := range entries {
+		if entry.pkg == nil {
+			continue
+		}
+		for _, dependency := range entry.dependencies {
+			resolved, exists := descriptors[dependency.name+"@"+dependency.spec]
+			if !exists {
+				// yarn berry descriptors are qualified by their protocol
+				resolved, exists = descriptors[dependency.name+"@npm:"+dependency.spec]
+			}
+			if !exists {
+				continue
+			}
+			// repeated declarations of a package resolve to the cataloged one when of the same version
+			if resolved.pkg == nil {
+				if first, ok := byName[resolved.name]; ok && first.version == resolved.version {
+					resolved = first
+				}
+			}
+			if resolved.pkg == nil || resolved == entry {
+				continue
+			}
+
+			ty := artifact.DependsOnRelationship
+			if dependency.optional || entry.optional[dependency.name] {
+				ty = artifact.OptionalDependencyOfRelationship
+			}
+			relationships = append(relationships, common.NewDependencyRelationship(entry.pkg, resolved.pkg, ty))
+		}
+	}
+	return relationships
+}
+
+// findPackageDescriptors returns the descriptors resolved by a yarn.lock entry, e.g. `"ms@^2.0.0", "ms@^2.1.1":`
+// (yarn v1) or `"ms@npm:^2.0.0, ms@npm:^2.1.1":` (yarn berry).
+func findPackageDescriptors(line string) []string {
+	var descriptors []string
+	for _, descriptor := range strings.Split(strings.TrimSuffix(strings.TrimSpace(line), ":"), ",") {
+		if descriptor = strings.Trim(strings.TrimSpace(descriptor), `"`); descriptor != "" {
+			descriptors = append(descriptors, descriptor)
+		}
+	}
+	return descriptors
+}
+
+// findDependency returns the name and the range of a dependency listed by a yarn.lock entry.
+func findDependency(value string) (string, string) {
+	var name, spec string
+	if strings.HasPrefix(value, `"`) {
+		end := strings.Index(value[1:], `"`) + 1
+		if end == 0 {
+			return strings.Trim(value, `"`), ""
+		}
+		name, spec = value[1:end], value[end+1:]
+	} else {
+		end := strings.IndexAny(value, ": ")
+		if end < 0 {
+			return value, ""
+		}
+		name, spec = value[:end], value[end:]
+	}
+	spec = strings.TrimPrefix(strings.TrimSpace(spec), ":")
+	return name, strings.Trim(strings.TrimSpace(spec), `"`)
 }
 
 func findPackageName(line string) string {
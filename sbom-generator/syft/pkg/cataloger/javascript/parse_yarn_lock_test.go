@@ -67,24 +67,68 @@ func TestParseYarnLock(t *testing.T) {
 		},
 	}
 
+	tests := []struct {
+		fixture       string
+		relationships []string
+	}{
+		{
+			fixture: "test-fixtures/yarn/yarn.lock",
+			// none of the dependencies of these packages are locked
+		},
+		{
+			fixture: "test-fixtures/yarn-berry/yarn.lock",
+			// the workspace depends on the locked packages
+			relationships: []string{
+				"c0n-fab_u.laTION -[DEPENDS_ON]-> @babel/code-frame",
+				"c0n-fab_u.laTION -[DEPENDS_ON]-> @types/minimatch",
+				"c0n-fab_u.laTION -[DEPENDS_ON]-> @types/qs",
+				"c0n-fab_u.laTION -[DEPENDS_ON]-> ajv",
+				"c0n-fab_u.laTION -[DEPENDS_ON]-> asn1.js",
+				"c0n-fab_u.laTION -[DEPENDS_ON]-> atob",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.fixture, func(t *testing.T) {
+			t.Parallel()
+
+			fixture, err := os.Open(test.fixture)
+			require.NoError(t, err)
+
+			actual, relationships, err := parseYarnLock(fixture.Name(), fixture)
+			require.NoError(t, err)
+
+			assertPkgsEqual(t, actual, expected)
+			assertRelationshipsEqual(t, relationships, test.relationships)
+		})
+	}
+}
+
+func TestParseYarnLock_Relationships(t *testing.T) {
 	testFixtures := []string{
-		"test-fixtures/yarn/yarn.lock",
-		"test-fixtures/yarn-berry/yarn.lock",
+		"test-fixtures/yarn-dependencies/yarn.lock",
+		"test-fixtures/yarn-berry-dependencies/yarn.lock",
 	}
 
 	for _, file := range testFixtures {
 		file := file
 		t.Run(file, func(t *testing.T) {
-			t.Parallel()
-
 			fixture, err := os.Open(file)
 			require.NoError(t, err)
 
-			// TODO: no relationships are under test yet
-			actual, _, err := parseYarnLock(fixture.Name(), fixture)
+			actual, relationships, err := parseYarnLock(fixture.Name(), fixture)
 			require.NoError(t, err)
 
-			assertPkgsEqual(t, actual, expected)
+			assert.Len(t, actual, 6)
+			assertRelationshipsEqual(t, relationships, []string{
+				"@babel/code-frame -[DEPENDS_ON]-> @babel/highlight",
+				"@babel/highlight -[DEPENDS_ON]-> chalk",
+				"@babel/highlight -[DEPENDS_ON]-> js-tokens",
+				"chokidar -[DEPENDS_ON]-> chalk",
+				"fsevents -[optional-dependency-of]-> chokidar",
+			})
 		})
 	}
 }
@@ -0,0 +1,163 @@
+package cataloger
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+	"github.com/anchore/syft/syft/source"
+)
+
+var licenseFileNames = common.LicenseFileNames()
+
+// licenseDirectory is the outcome of looking for license files in a directory.
+type licenseDirectory struct {
+	found    bool
+	licenses []pkg.DetectedLicense
+}
+
+// licenseDetector classifies the license files of packages, looking for the license files of each directory once:
+// several packages may share a directory (e.g. an installed npm package and the package locking it).
+type licenseDetector struct {
+	resolver source.FileResolver
+	lock     sync.Mutex
+	dirs     map[string]licenseDirectory
+}
+
+func newLicenseDetector(resolver source.FileResolver) *licenseDetector {
+	return &licenseDetector{
+		resolver: resolver,
+		dirs:     make(map[string]licenseDirectory),
+	}
+}
+
+// detectLicenses classifies the license files (e.g. LICENSE, COPYING or NOTICE files) found in the directory of a
+// package: the directory of an installed npm package, of a go module main module or vendored module, or of a vendored
+// rust crate. The first candidate directory holding license files is used.
+func (d *licenseDetector) detectLicenses(p pkg.Package) []pkg.DetectedLicense {
+	for _, dir := range licenseDirectories(p, d.resolver) {
+		if result := d.directory(dir); result.found {
+			return result.licenses
+		}
+	}
+	return nil
+}
+
+// directory returns the licenses classified from the license files of a directory, which are looked for on first use.
+func (d *licenseDetector) directory(dir string) licenseDirectory {
+	d.lock.Lock()
+	result, exists := d.dirs[dir]
+	d.lock.Unlock()
+	if exists {
+		return result
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range licenseFileNames {
+		locations, err := d.resolver.FilesByPath(path.Join(dir, name))
+		if err != nil {
+			log.Debugf("unable to find license file %q: %+v", path.Join(dir, name), err)
+			continue
+		}
+		for _, location := range locations {
+			if seen[location.RealPath] {
+				continue
+			}
+			seen[location.RealPath] = true
+			result.licenses = append(result.licenses, detectFileLicenses(location, d.resolver)...)
+		}
+	}
+	result.found = len(seen) > 0
+
+	d.lock.Lock()
+	d.dirs[dir] = result
+	d.lock.Unlock()
+	return result
+}
+
+func detectFileLicenses(location source.Location, resolver source.FileResolver) []pkg.DetectedLicense {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		log.Warnf("unable to fetch contents at location=%v: %+v", location, err)
+		return nil
+	}
+	defer internal.CloseAndLogError(reader, location.VirtualPath)
+
+	return common.DetectLicenses(location.RealPath, reader)
+}
+
+// licenseDirectories returns the directories which may hold the license files of a package, by preference. Only the
+// directories dedicated to the package are considered: the license files next to a lock file or a go.mod file are the
+// license of the project, not of its dependencies.
+func licenseDirectories(p pkg.Package, resolver source.FileResolver) []string {
+	var results []string
+	for _, location := range p.Locations.ToSlice() {
+		dir := path.Dir(location.RealPath)
+
+		switch metadata := p.Metadata.(type) {
+		case pkg.NpmPackageJSONMetadata:
+			// an installed package
+			results = append(results, dir)
+		case pkg.GolangModMetadata:
+			switch {
+			case p.Version == "":
+				// the main module
+				results = append(results, dir)
+			case metadata.Dir != "":
+				// a module replaced by a local directory
+				results = append(results, path.Join(dir, metadata.Dir))
+			default:
+				// a vendored module, by the path of the module it replaces (if any)
+				modulePath := p.Name
+				if metadata.Replaces != "" {
+					modulePath = strings.Split(metadata.Replaces, "@")[0]
+				}
+				results = append(results, path.Join(dir, "vendor", modulePath))
+			}
+		case pkg.CargoPackageMetadata:
+			// "cargo vendor" suffixes the directories of crates vendored in several versions by their version
+			results = append(results,
+				path.Join(dir, "vendor", p.Name+"-"+p.Version),
+				path.Join(dir, "vendor", p.Name),
+			)
+		default:
+			if p.Type == pkg.NpmPkg && p.MetadataType == "" {
+				// a package of a package-lock.json or yarn.lock file, when installed at the top of node_modules
+				installed := path.Join(dir, "node_modules", p.Name)
+				if installedNpmVersion(resolver, location, installed) == p.Version {
+					results = append(results, installed)
+				}
+			}
+		}
+	}
+	return results
+}
+
+// installedNpmVersion returns the version of the npm package installed in a directory, if any.
+func installedNpmVersion(resolver source.FileResolver, location source.Location, dir string) string {
+	packageJSON := resolver.RelativeFileByPath(location, path.Join(dir, "package.json"))
+	if packageJSON == nil {
+		return ""
+	}
+
+	reader, err := resolver.FileContentsByLocation(*packageJSON)
+	if err != nil {
+		log.Warnf("unable to fetch contents at location=%v: %+v", *packageJSON, err)
+		return ""
+	}
+	defer internal.CloseAndLogError(reader, packageJSON.VirtualPath)
+
+	var contents struct {
+		Version string `json:"version"`
+	}
+	if err := json.NewDecoder(reader).Decode(&contents); err != nil {
+		log.Debugf("unable to parse %q: %+v", packageJSON.RealPath, err)
+		return ""
+	}
+	return contents.Version
+}
@@ -0,0 +1,97 @@
+package cataloger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/golang"
+	"github.com/anchore/syft/syft/pkg/cataloger/javascript"
+	"github.com/anchore/syft/syft/pkg/cataloger/rust"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestCatalog_detectLicenses(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/license-files")
+	require.NoError(t, err)
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	catalog, _, err := Catalog(resolver, nil,
+		javascript.NewJavascriptPackageCataloger(),
+		javascript.NewJavascriptLockCataloger(),
+		golang.NewGoModFileCataloger(),
+		rust.NewCargoLockCataloger(),
+	)
+	require.NoError(t, err)
+
+	expected := map[string][]pkg.DetectedLicense{
+		// an installed package, and the package locking it (installed at the top of node_modules)
+		"javascript-package-cataloger:left-pad@1.3.0": {
+			{License: "MIT", Confidence: 1, Path: "node_modules/left-pad/LICENSE"},
+		},
+		"javascript-lock-cataloger:left-pad@1.3.0": {
+			{License: "MIT", Confidence: 1, Path: "node_modules/left-pad/LICENSE"},
+		},
+		// not installed: the license of the project is not the license of its dependencies
+		"javascript-lock-cataloger:right-pad@1.0.1": nil,
+		// the main module, and a vendored module
+		"go-mod-file-cataloger:example.com/app@": {
+			{License: "BSD-3-Clause", Confidence: 0.96, Path: "LICENSE"},
+		},
+		"go-mod-file-cataloger:github.com/pkg/errors@v0.9.1": {
+			{License: "0BSD", Confidence: 1, Path: "vendor/github.com/pkg/errors/COPYING"},
+		},
+		// a vendored crate, and a crate which is not vendored
+		"rust-cataloger:itoa@1.0.1": {
+			{License: "ISC", Confidence: 1, Path: "vendor/itoa/LICENSE-MIT"},
+		},
+		"rust-cataloger:ryu@1.0.9": nil,
+	}
+
+	actual := make(map[string][]pkg.DetectedLicense)
+	for p := range catalog.Enumerate() {
+		actual[p.FoundBy+":"+p.Name+"@"+p.Version] = p.DetectedLicenses
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestCatalog_detectLicensesDisabled(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/license-files")
+	require.NoError(t, err)
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	catalog, _, err := CatalogWithConfig(resolver, nil, Config{DetectLicenses: false},
+		javascript.NewJavascriptPackageCataloger(),
+		golang.NewGoModFileCataloger(),
+	)
+	require.NoError(t, err)
+	require.NotZero(t, catalog.PackageCount())
+
+	for p := range catalog.Enumerate() {
+		assert.Empty(t, p.DetectedLicenses, p.Name)
+	}
+}
+
+func TestLicenseDetector_directory(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/license-files")
+	require.NoError(t, err)
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	detector := newLicenseDetector(resolver)
+	expected := licenseDirectory{
+		found:    true,
+		licenses: []pkg.DetectedLicense{{License: "MIT", Confidence: 1, Path: "node_modules/left-pad/LICENSE"}},
+	}
+	assert.Equal(t, expected, detector.directory("node_modules/left-pad"))
+	assert.Equal(t, licenseDirectory{}, detector.directory("node_modules/right-pad"))
+
+	// the license files of a directory are only looked for once
+	assert.Len(t, detector.dirs, 2)
+	assert.Equal(t, expected, detector.directory("node_modules/left-pad"))
+	assert.Len(t, detector.dirs, 2)
+}
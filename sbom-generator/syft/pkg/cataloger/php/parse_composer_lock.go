@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
 )
 
 type composerLock struct {
@@ -17,6 +20,7 @@ type composerLock struct {
 // parseComposerLock is a parser function for Composer.lock contents, returning "Default" php packages discovered.
 func parseComposerLock(_ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
 	packages := make([]*pkg.Package, 0)
+	var relationships []artifact.Relationship
 	dec := json.NewDecoder(reader)
 
 	for {
@@ -26,10 +30,11 @@ func parseComposerLock(_ string, reader io.Reader) ([]*pkg.Package, []artifact.R
 		} else if err != nil {
 			return nil, nil, fmt.Errorf("failed to parse composer.lock file: %w", err)
 		}
+		var lockPackages []*pkg.Package
 		for _, pkgMeta := range lock.Packages {
 			version := pkgMeta.Version
 			name := pkgMeta.Name
-			packages = append(packages, &pkg.Package{
+			lockPackages = append(lockPackages, &pkg.Package{
 				Name:         name,
 				Version:      version,
 				Language:     pkg.PHP,
@@ -38,7 +43,51 @@ func parseComposerLock(_ string, reader io.Reader) ([]*pkg.Package, []artifact.R
 				Metadata:     pkgMeta,
 			})
 		}
+		packages = append(packages, lockPackages...)
+		relationships = append(relationships, composerLockRelationships(lockPackages)...)
 	}
 
-	return packages, nil, nil
+	return packages, relationships, nil
+}
+
+// composerLockRelationships relates the packages of a composer.lock to the packages they require, or to the packages
+// providing what they require. Suggested packages which are installed are optional dependencies. Platform
+// requirements (php, extensions...) are only related when provided by a package (e.g. a polyfill).
+func composerLockRelationships(packages []*pkg.Package) []artifact.Relationship {
+	providers := make(map[string][]*pkg.Package)
+	for _, p := range packages {
+		metadata := p.Metadata.(pkg.PhpComposerJSONMetadata)
+		providers[strings.ToLower(p.Name)] = append(providers[strings.ToLower(p.Name)], p)
+		for provided := range metadata.Provide {
+			providers[strings.ToLower(provided)] = append(providers[strings.ToLower(provided)], p)
+		}
+	}
+
+	var relationships []artifact.Relationship
+	for _, p := range packages {
+		metadata := p.Metadata.(pkg.PhpComposerJSONMetadata)
+
+		// a package may both be required by name and through what it provides
+		related := make(map[*pkg.Package]bool)
+		relate := func(names map[string]string, ty artifact.RelationshipType) {
+			sorted := make([]string, 0, len(names))
+			for name := range names {
+				sorted = append(sorted, name)
+			}
+			sort.Strings(sorted)
+
+			for _, name := range sorted {
+				for _, dependency := range providers[strings.ToLower(name)] {
+					if dependency != p && !related[dependency] {
+						related[dependency] = true
+						relationships = append(relationships, common.NewDependencyRelationship(p, dependency, ty))
+					}
+				}
+			}
+		}
+
+		relate(metadata.Require, artifact.DependsOnRelationship)
+		relate(metadata.Suggest, artifact.OptionalDependencyOfRelationship)
+	}
+	return relationships
 }
@@ -1,11 +1,13 @@
 package php
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestParseComposerFileLock(t *testing.T) {
@@ -110,8 +112,7 @@ func TestParseComposerFileLock(t *testing.T) {
 		t.Fatalf("failed to open fixture: %+v", err)
 	}
 
-	// TODO: no relationships are under test yet
-	actual, _, err := parseComposerLock(fixture.Name(), fixture)
+	actual, relationships, err := parseComposerLock(fixture.Name(), fixture)
 	if err != nil {
 		t.Fatalf("failed to parse requirements: %+v", err)
 	}
@@ -119,4 +120,31 @@ func TestParseComposerFileLock(t *testing.T) {
 	for _, d := range deep.Equal(expected, actual) {
 		t.Errorf("diff: %+v", d)
 	}
+	assert.Empty(t, relationships)
+}
+
+func TestParseComposerFileLock_Relationships(t *testing.T) {
+	fixture, err := os.Open("test-fixtures/composer-dependencies/composer.lock")
+	if err != nil {
+		t.Fatalf("failed to open fixture: %+v", err)
+	}
+
+	actual, relationships, err := parseComposerLock(fixture.Name(), fixture)
+	if err != nil {
+		t.Fatalf("failed to parse requirements: %+v", err)
+	}
+	// development packages are not cataloged
+	assert.Len(t, actual, 4)
+
+	var relationshipStrings []string
+	for _, r := range relationships {
+		relationshipStrings = append(relationshipStrings, fmt.Sprintf("%s -[%s]-> %s", r.From.(*pkg.Package).Name, r.Type, r.To.(*pkg.Package).Name))
+	}
+	assert.ElementsMatch(t, []string{
+		"monolog/monolog -[DEPENDS_ON]-> psr/log",
+		// psr/log-implementation is a virtual package provided by monolog
+		"symfony/console -[DEPENDS_ON]-> monolog/monolog",
+		// the polyfill is suggested both by name and as the provider of the mbstring extension
+		"symfony/polyfill-mbstring -[optional-dependency-of]-> symfony/console",
+	}, relationshipStrings)
 }
@@ -4,17 +4,254 @@ Package python provides a concrete Cataloger implementation for Python ecosystem
 package python
 
 import (
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/pkg/cataloger/common"
+	"github.com/anchore/syft/syft/source"
 )
 
-// NewPythonIndexCataloger returns a new cataloger for python packages referenced from poetry lock files, requirements.txt files, and setup.py files.
-func NewPythonIndexCataloger() *common.GenericCataloger {
+const indexCatalogerName = "python-index-cataloger"
+
+// IndexCataloger catalogs the python packages referenced from the requirements, lock and project files of a source.
+type IndexCataloger struct {
+	lockfiles *common.GenericCataloger
+}
+
+// NewPythonIndexCataloger returns a new cataloger for python packages referenced from poetry, pdm and Pipfile lock files,
+// requirements.txt files, pyproject.toml files and setup.py files.
+func NewPythonIndexCataloger() *IndexCataloger {
 	globParsers := map[string]common.ParserFn{
-		"**/*requirements*.txt": parseRequirementsTxt,
-		"**/poetry.lock":        parsePoetryLock,
-		"**/Pipfile.lock":       parsePipfileLock,
-		"**/setup.py":           parseSetup,
+		"**/poetry.lock":    parsePoetryLock,
+		"**/pdm.lock":       parsePdmLock,
+		"**/Pipfile.lock":   parsePipfileLock,
+		"**/pyproject.toml": parsePyprojectToml,
+		"**/setup.py":       parseSetup,
 	}
 
-	return common.NewGenericCataloger(nil, globParsers, "python-index-cataloger")
+	return &IndexCataloger{
+		lockfiles: common.NewGenericCataloger(nil, globParsers, indexCatalogerName),
+	}
+}
+
+// Name returns a string that uniquely describes a cataloger
+func (c *IndexCataloger) Name() string {
+	return indexCatalogerName
+}
+
+// Catalog is given an object to resolve file references and content, this function returns the python packages locked
+// by the lock files and the requirements files of the source (following the files they include).
+func (c *IndexCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	packages, relationships, err := c.lockfiles.Catalog(resolver)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requirements, err := catalogRequirementsFiles(resolver)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return append(packages, requirements...), relationships, nil
+}
+
+// catalogRequirementsFiles returns the pinned requirements of the requirements files of a source and of the files they
+// include (-r), whose versions may be pinned by the constraints files (-c) of the files including them.
+func catalogRequirementsFiles(resolver source.FileResolver) ([]pkg.Package, error) {
+	roots, err := resolver.FilesByGlob("**/*requirements*.txt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find files by glob: %w", err)
+	}
+	sort.Slice(roots, func(i, j int) bool {
+		return roots[i].RealPath < roots[j].RealPath
+	})
+
+	reader := newRequirementsFileReader(resolver)
+
+	// the constraints apply to the requirements of the file declaring them and of the files it includes
+	constraints := make(map[string]map[string]string)
+	for _, root := range roots {
+		rootConstraints := reader.constraints(root)
+		for _, location := range reader.includedFiles(root) {
+			if constraints[location.RealPath] == nil {
+				constraints[location.RealPath] = make(map[string]string)
+			}
+			for name, version := range rootConstraints {
+				constraints[location.RealPath][name] = version
+			}
+		}
+	}
+
+	var packages []pkg.Package
+	for _, location := range reader.locations {
+		for _, requirement := range reader.files[location.RealPath].requirements {
+			version := requirement.pinnedVersion()
+			if version == "" {
+				version = constraints[location.RealPath][normalizeName(requirement.Name)]
+			}
+			if version == "" {
+				// a package without a version, or a range (unpinned) which does not tell us
+				// exactly what will be installed.
+				continue
+			}
+
+			p := requirement.newPackage(version)
+			p.FoundBy = indexCatalogerName
+			p.Locations.Add(location)
+			p.SetID()
+			packages = append(packages, *p)
+		}
+	}
+	return packages, nil
+}
+
+// requirementsFileReader reads the requirements files of a source at most once, along with the files they include.
+type requirementsFileReader struct {
+	resolver  source.FileResolver
+	files     map[string]*requirementsFile
+	locations []source.Location
+}
+
+func newRequirementsFileReader(resolver source.FileResolver) *requirementsFileReader {
+	return &requirementsFileReader{
+		resolver: resolver,
+		files:    make(map[string]*requirementsFile),
+	}
+}
+
+// read returns the content of a requirements file, which is only cataloged once read successfully.
+func (r *requirementsFileReader) read(location source.Location) *requirementsFile {
+	if f, exists := r.files[location.RealPath]; exists {
+		return f
+	}
+	// guard against the files including each other
+	r.files[location.RealPath] = nil
+
+	reader, err := r.resolver.FileContentsByLocation(location)
+	if err != nil {
+		log.Warnf("unable to fetch contents at location=%v: %+v", location, err)
+		return nil
+	}
+	defer internal.CloseAndLogError(reader, location.VirtualPath)
+
+	f, err := parseRequirementsTxt(reader)
+	if err != nil {
+		log.Warnf("cataloger '%s' failed to parse entries at location=%+v: %+v", indexCatalogerName, location, err)
+		return nil
+	}
+	r.files[location.RealPath] = f
+	r.locations = append(r.locations, location)
+	return f
+}
+
+// resolve returns the location of a file included by a requirements file, relative to it.
+func (r *requirementsFileReader) resolve(location source.Location, included string) *source.Location {
+	if !path.IsAbs(included) {
+		included = path.Join(path.Dir(location.RealPath), included)
+	}
+	resolved := r.resolver.RelativeFileByPath(location, included)
+	if resolved == nil {
+		log.Debugf("unable to find the file %q included by the requirements file %q", included, location.RealPath)
+	}
+	return resolved
+}
+
+// includedFiles returns a requirements file and the requirements files it includes, transitively.
+func (r *requirementsFileReader) includedFiles(location source.Location) []source.Location {
+	visited := make(map[string]bool)
+	var results []source.Location
+
+	var visit func(source.Location)
+	visit = func(location source.Location) {
+		if visited[location.RealPath] {
+			return
+		}
+		visited[location.RealPath] = true
+		results = append(results, location)
+
+		f := r.read(location)
+		if f == nil {
+			return
+		}
+		for _, included := range f.includes {
+			if resolved := r.resolve(location, included); resolved != nil {
+				visit(*resolved)
+			}
+		}
+	}
+	visit(location)
+
+	return results
+}
+
+// constraints returns the versions (by normalized package name) pinned by the constraints files of a requirements
+// file and of the files it includes. The requirements of the constraints files are not installed, so these files are
+// not cataloged.
+func (r *requirementsFileReader) constraints(location source.Location) map[string]string {
+	type visit struct {
+		path       string
+		constraint bool
+	}
+	results := make(map[string]string)
+	visited := make(map[visit]bool)
+
+	var visitFile func(location source.Location, constraint bool)
+	visitFile = func(location source.Location, constraint bool) {
+		if visited[visit{location.RealPath, constraint}] {
+			return
+		}
+		visited[visit{location.RealPath, constraint}] = true
+
+		f := r.files[location.RealPath]
+		if f == nil && constraint {
+			f = readConstraintsFile(r.resolver, location)
+		}
+		if f == nil {
+			return
+		}
+		if constraint {
+			for _, requirement := range f.requirements {
+				if version := requirement.pinnedVersion(); version != "" {
+					results[normalizeName(requirement.Name)] = version
+				}
+			}
+		}
+		for _, included := range f.includes {
+			if resolved := r.resolve(location, included); resolved != nil {
+				visitFile(*resolved, constraint)
+			}
+		}
+		for _, included := range f.constraints {
+			if resolved := r.resolve(location, included); resolved != nil {
+				visitFile(*resolved, true)
+			}
+		}
+	}
+
+	// the requirements files are read before being visited for their constraints
+	r.includedFiles(location)
+	visitFile(location, false)
+
+	return results
+}
+
+func readConstraintsFile(resolver source.FileResolver, location source.Location) *requirementsFile {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		log.Warnf("unable to fetch contents at location=%v: %+v", location, err)
+		return nil
+	}
+	defer internal.CloseAndLogError(reader, location.VirtualPath)
+
+	f, err := parseRequirementsTxt(reader)
+	if err != nil {
+		log.Warnf("unable to parse the constraints file at location=%+v: %+v", location, err)
+		return nil
+	}
+	return f
 }
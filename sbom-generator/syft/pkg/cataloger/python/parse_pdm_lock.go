@@ -0,0 +1,140 @@
+package python
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+)
+
+// integrity check
+var _ common.ParserFn = parsePdmLock
+
+type pdmLock struct {
+	Metadata struct {
+		// Files lists the distributions of the packages by "name version", in the lock files of pdm < 2.8
+		Files map[string][]pdmLockFile `toml:"files"`
+	} `toml:"metadata"`
+	Packages []pdmLockPackage `toml:"package"`
+}
+
+type pdmLockPackage struct {
+	Name         string        `toml:"name"`
+	Version      string        `toml:"version"`
+	Dependencies []string      `toml:"dependencies"`
+	Extras       []string      `toml:"extras"`
+	Groups       []string      `toml:"groups"`
+	Files        []pdmLockFile `toml:"files"`
+}
+
+type pdmLockFile struct {
+	File string `toml:"file"`
+	URL  string `toml:"url"`
+	Hash string `toml:"hash"`
+}
+
+// parsePdmLock is a parser function for pdm.lock contents, returning the locked python packages, related to their
+// locked dependencies. The hashes of the distributions of a package are its digests.
+func parsePdmLock(_ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	tree, err := toml.LoadReader(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load pdm.lock for parsing: %w", err)
+	}
+
+	var lock pdmLock
+	if err := tree.Unmarshal(&lock); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse pdm.lock: %w", err)
+	}
+
+	var packages []*pkg.Package
+	byName := make(map[string]*pkg.Package)
+	lockedByName := make(map[string]pdmLockPackage)
+	for _, p := range lock.Packages {
+		name := normalizeName(p.Name)
+		if existing, exists := byName[name]; exists {
+			// the extras of a package are locked as distinct entries of the same package (e.g. "requests[socks]")
+			metadata := existing.Metadata.(pkg.PythonRequirementsMetadata)
+			metadata.Extras = append(metadata.Extras, p.Extras...)
+			existing.Metadata = metadata
+			continue
+		}
+
+		files := p.Files
+		if len(files) == 0 {
+			files = lock.Metadata.Files[p.Name+" "+p.Version]
+		}
+
+		requirement := pythonRequirement{
+			Name:   p.Name,
+			Extras: p.Extras,
+		}
+		for _, f := range files {
+			if digest := newRequirementDigest(f.Hash); digest != nil {
+				requirement.Digests = append(requirement.Digests, *digest)
+			}
+		}
+
+		packages = append(packages, requirement.newPackage(p.Version))
+		byName[name] = packages[len(packages)-1]
+		lockedByName[name] = p
+	}
+
+	var relationships []artifact.Relationship
+	for _, p := range packages {
+		locked := lockedByName[normalizeName(p.Name)]
+		for _, dependency := range pdmLockDependencies(lock.Packages, p.Name) {
+			requirement := parseRequirement(dependency)
+			if requirement == nil {
+				continue
+			}
+			to, exists := byName[normalizeName(requirement.Name)]
+			if !exists || to == p {
+				continue
+			}
+			ty := artifact.DependsOnRelationship
+			if isPdmDevGroups(lockedByName[normalizeName(requirement.Name)].Groups) && !isPdmDevGroups(locked.Groups) {
+				ty = artifact.DevDependencyOfRelationship
+			}
+			relationships = append(relationships, common.NewDependencyRelationship(p, to, ty))
+		}
+	}
+
+	sort.SliceStable(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+
+	return packages, relationships, nil
+}
+
+// pdmLockDependencies returns the dependencies of all the locked entries of a package (one per set of extras), once.
+func pdmLockDependencies(locked []pdmLockPackage, name string) []string {
+	var results []string
+	seen := make(map[string]bool)
+	for _, p := range locked {
+		if normalizeName(p.Name) != normalizeName(name) {
+			continue
+		}
+		for _, dependency := range p.Dependencies {
+			if !seen[dependency] {
+				seen[dependency] = true
+				results = append(results, dependency)
+			}
+		}
+	}
+	return results
+}
+
+// isPdmDevGroups indicates if a package is only locked for development groups (the groups other than "default").
+func isPdmDevGroups(groups []string) bool {
+	for _, group := range groups {
+		if group == "default" {
+			return false
+		}
+	}
+	return len(groups) > 0
+}
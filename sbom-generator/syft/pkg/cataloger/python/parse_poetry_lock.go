@@ -26,5 +26,6 @@ func parsePoetryLock(_ string, reader io.Reader) ([]*pkg.Package, []artifact.Rel
 		return nil, nil, fmt.Errorf("unable to parse poetry.lock: %v", err)
 	}
 
-	return metadata.Pkgs(), nil, nil
+	pkgs, relationships := metadata.PkgsAndRelationships()
+	return pkgs, relationships, nil
 }
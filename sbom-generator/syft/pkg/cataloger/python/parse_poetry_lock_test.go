@@ -1,11 +1,14 @@
 package python
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParsePoetryLock(t *testing.T) {
@@ -45,8 +48,7 @@ func TestParsePoetryLock(t *testing.T) {
 		t.Fatalf("failed to open fixture: %+v", err)
 	}
 
-	// TODO: no relationships are under test yet
-	actual, _, err := parsePoetryLock(fixture.Name(), fixture)
+	actual, relationships, err := parsePoetryLock(fixture.Name(), fixture)
 	if err != nil {
 		t.Error(err)
 	}
@@ -55,4 +57,29 @@ func TestParsePoetryLock(t *testing.T) {
 	if differences != nil {
 		t.Errorf("returned package list differed from expectation: %+v", differences)
 	}
+
+	// the dependencies of added-value are not locked
+	assert.Empty(t, relationships)
+}
+
+func TestParsePoetryLock_Relationships(t *testing.T) {
+	fixture, err := os.Open("test-fixtures/poetry-dependencies/poetry.lock")
+	require.NoError(t, err)
+
+	actual, relationships, err := parsePoetryLock(fixture.Name(), fixture)
+	require.NoError(t, err)
+	assert.Len(t, actual, 7)
+
+	var relationshipStrings []string
+	for _, r := range relationships {
+		relationshipStrings = append(relationshipStrings, fmt.Sprintf("%s -[%s]-> %s", r.From.(*pkg.Package).Name, r.Type, r.To.(*pkg.Package).Name))
+	}
+	assert.ElementsMatch(t, []string{
+		"colorama -[dev-dependency-of]-> pytest",
+		"requests -[DEPENDS_ON]-> certifi",
+		// names are compared once normalized
+		"requests -[DEPENDS_ON]-> charset-normalizer",
+		"pysocks -[optional-dependency-of]-> requests",
+		"requests -[DEPENDS_ON]-> urllib3",
+	}, relationshipStrings)
 }
@@ -0,0 +1,108 @@
+package python
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+)
+
+// integrity check
+var _ common.ParserFn = parsePyprojectToml
+
+type pyprojectToml struct {
+	// Project is the PEP 621 metadata of the project, see https://peps.python.org/pep-0621/
+	Project *struct {
+		Name                 string              `toml:"name"`
+		Version              string              `toml:"version"`
+		Dependencies         []string            `toml:"dependencies"`
+		OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+	} `toml:"project"`
+	Tool struct {
+		Pdm struct {
+			DevDependencies map[string][]string `toml:"dev-dependencies"`
+		} `toml:"pdm"`
+	} `toml:"tool"`
+}
+
+// parsePyprojectToml is a parser function for the PEP 621 metadata of pyproject.toml contents, returning the project
+// and its pinned dependencies, optional dependencies (extras) and PDM development dependencies. As in requirements
+// files, the dependencies which are not pinned to a version are left out.
+func parsePyprojectToml(_ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	tree, err := toml.LoadReader(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load pyproject.toml for parsing: %w", err)
+	}
+
+	var pyproject pyprojectToml
+	if err := tree.Unmarshal(&pyproject); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse pyproject.toml: %w", err)
+	}
+
+	if pyproject.Project == nil || pyproject.Project.Name == "" {
+		// the project is managed by another tool (e.g. [tool.poetry])
+		return nil, nil, nil
+	}
+
+	// the version of a project may be dynamic (set by the build backend), in which case it is not known
+	project := &pkg.Package{
+		Name:     pyproject.Project.Name,
+		Version:  pyproject.Project.Version,
+		Language: pkg.Python,
+		Type:     pkg.PythonPkg,
+	}
+	packages := []*pkg.Package{project}
+	byNameVersion := make(map[string]*pkg.Package)
+	related := make(map[string]bool)
+	var relationships []artifact.Relationship
+
+	add := func(dependencies []string, ty artifact.RelationshipType) {
+		for _, dependency := range dependencies {
+			requirement := parseRequirement(dependency)
+			if requirement == nil {
+				continue
+			}
+			version := requirement.pinnedVersion()
+			if version == "" {
+				continue
+			}
+			// a dependency may be required by several groups, it is related to the project once per kind of group
+			key := normalizeName(requirement.Name) + "@" + version
+			p, exists := byNameVersion[key]
+			if !exists {
+				p = requirement.newPackage(version)
+				byNameVersion[key] = p
+				packages = append(packages, p)
+			}
+			if related[key+string(ty)] {
+				continue
+			}
+			related[key+string(ty)] = true
+			relationships = append(relationships, common.NewDependencyRelationship(project, p, ty))
+		}
+	}
+
+	add(pyproject.Project.Dependencies, artifact.DependsOnRelationship)
+	for _, extra := range sortedKeys(pyproject.Project.OptionalDependencies) {
+		add(pyproject.Project.OptionalDependencies[extra], artifact.OptionalDependencyOfRelationship)
+	}
+	for _, group := range sortedKeys(pyproject.Tool.Pdm.DevDependencies) {
+		add(pyproject.Tool.Pdm.DevDependencies[group], artifact.DevDependencyOfRelationship)
+	}
+
+	return packages, relationships, nil
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -4,92 +4,224 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 
-	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/pkg"
-	"github.com/anchore/syft/syft/pkg/cataloger/common"
 )
 
-// integrity check
-var _ common.ParserFn = parseRequirementsTxt
+var (
+	// commentExp matches a comment of a requirements.txt line, which starts the line or follows a whitespace (so that
+	// the fragments of urls, e.g. "...#egg=name", are kept).
+	commentExp = regexp.MustCompile(`(^|\s+)#.*$`)
 
-// parseRequirementsTxt takes a Python requirements.txt file, returning all Python packages that are locked to a
-// specific version.
-func parseRequirementsTxt(_ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
-	packages := make([]*pkg.Package, 0)
+	// requirementExp matches a PEP 508 requirement without its environment markers, capturing the name, the extras
+	// and the version specifiers, for example "requests[socks, security] >=2.8.1, ==2.8.*" (...and the values
+	// "requests", "socks, security" and ">=2.8.1, ==2.8.*" are captured).
+	requirementExp = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[([^\]]*)\])?\s*(.*)$`)
+)
+
+// pythonRequirement is a requirement of a requirements file, a pyproject.toml file or a pdm.lock file.
+type pythonRequirement struct {
+	Name              string
+	Extras            []string
+	VersionConstraint string
+	Markers           string
+	Digests           []file.Digest
+}
+
+// requirementsFile is the content of a requirements file: its requirements and the requirements (-r) and constraints
+// (-c) files it includes, relative to it.
+type requirementsFile struct {
+	requirements []pythonRequirement
+	includes     []string
+	constraints  []string
+}
+
+// parseRequirementsTxt takes a Python requirements.txt file (or a pip-tools constraints file), returning its
+// requirements along with the files it includes. Editable and direct url requirements are left out.
+// See https://pip.pypa.io/en/stable/reference/requirements-file-format/
+func parseRequirementsTxt(reader io.Reader) (*requirementsFile, error) {
+	result := &requirementsFile{}
 
 	scanner := bufio.NewScanner(reader)
+	var continued string
 	for scanner.Scan() {
 		line := scanner.Text()
-		line = trimRequirementsTxtLine(line)
 
-		if line == "" {
-			// nothing to parse on this line
+		// a line ending with a backslash continues on the next line (e.g. the --hash options written by pip-compile)
+		if strings.HasSuffix(line, `\`) {
+			continued += strings.TrimSuffix(line, `\`) + " "
 			continue
 		}
+		line, continued = continued+line, ""
 
-		if strings.HasPrefix(line, "-e") {
-			// editable packages aren't parsed (yet)
+		line = trimRequirementsTxtLine(line)
+		if line == "" {
+			// nothing to parse on this line
 			continue
 		}
 
-		if !strings.Contains(line, "==") {
-			// a package without a version, or a range (unpinned) which does not tell us
-			// exactly what will be installed.
+		if strings.HasPrefix(line, "-") {
+			parseRequirementsTxtOption(line, result)
 			continue
 		}
 
-		// parse a new requirement
-		parts := strings.Split(line, "==")
-		name := strings.TrimSpace(parts[0])
-		version := strings.TrimSpace(parts[1])
-		packages = append(packages, &pkg.Package{
-			Name:     name,
-			Version:  version,
-			Language: pkg.Python,
-			Type:     pkg.PythonPkg,
-		})
+		if requirement := parseRequirementsTxtLine(line); requirement != nil {
+			result.requirements = append(result.requirements, *requirement)
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, nil, fmt.Errorf("failed to parse python requirements file: %w", err)
+		return nil, fmt.Errorf("failed to parse python requirements file: %w", err)
 	}
 
-	return packages, nil, nil
+	return result, nil
 }
 
-// trimRequirementsTxtLine removes content from the given requirements.txt line
-// that should not be considered for parsing.
-func trimRequirementsTxtLine(line string) string {
-	line = strings.TrimSpace(line)
-	line = removeTrailingComment(line)
-	line = removeEnvironmentMarkers(line)
+// parseRequirementsTxtOption records the files included by a global option line of a requirements file ("-r file",
+// "--requirement=file", "-c file" or "--constraint file"). Other options (e.g. editable requirements or index urls)
+// are ignored.
+func parseRequirementsTxtOption(line string, result *requirementsFile) {
+	name, value := line, ""
+	if i := strings.IndexAny(line, " \t="); i >= 0 {
+		name, value = line[:i], strings.TrimSpace(strings.TrimLeft(line[i:], " \t="))
+	} else if strings.HasPrefix(line, "-r") || strings.HasPrefix(line, "-c") {
+		// the short options may be directly followed by their value, e.g. "-rother-requirements.txt"
+		name, value = line[:2], line[2:]
+	}
+	if value == "" {
+		return
+	}
 
-	return line
+	switch name {
+	case "-r", "--requirement":
+		result.includes = append(result.includes, value)
+	case "-c", "--constraint":
+		result.constraints = append(result.constraints, value)
+	}
 }
 
-// removeTrailingComment takes a requirements.txt line and strips off comment strings.
-func removeTrailingComment(line string) string {
-	parts := strings.SplitN(line, "#", 2)
-	if len(parts) < 2 {
-		// there aren't any comments
+// parseRequirementsTxtLine parses a requirement line along with its options, returning nothing for the direct url
+// requirements (e.g. "name @ https://...").
+func parseRequirementsTxtLine(line string) *pythonRequirement {
+	var specifier []string
+	var digests []file.Digest
+
+	fields := strings.Fields(line)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if !strings.HasPrefix(field, "--") {
+			specifier = append(specifier, field)
+			continue
+		}
+
+		// per-requirement options, e.g. "--hash=sha256:..." or "--hash sha256:..."
+		option, value, hasValue := strings.Cut(field, "=")
+		if !hasValue && i+1 < len(fields) && !strings.HasPrefix(fields[i+1], "--") {
+			i++
+			value = fields[i]
+		}
+		if option == "--hash" {
+			if digest := newRequirementDigest(value); digest != nil {
+				digests = append(digests, *digest)
+			}
+		}
+	}
 
-		return line
+	requirement := parseRequirement(strings.Join(specifier, " "))
+	if requirement == nil {
+		return nil
 	}
+	requirement.Digests = digests
+	return requirement
+}
+
+// parseRequirement parses a PEP 508 requirement, e.g. "requests[socks]>=2.8.1 ; python_version < '3.8'".
+func parseRequirement(specifier string) *pythonRequirement {
+	specifier, markers, _ := strings.Cut(specifier, ";")
+
+	matches := requirementExp.FindStringSubmatch(strings.TrimSpace(specifier))
+	if len(matches) < 4 {
+		return nil
+	}
+	constraint := strings.TrimSpace(matches[3])
+	if strings.HasPrefix(constraint, "@") {
+		return nil
+	}
+
+	var extras []string
+	for _, extra := range strings.Split(matches[2], ",") {
+		if extra = strings.TrimSpace(extra); extra != "" {
+			extras = append(extras, extra)
+		}
+	}
+
+	// the specifiers may be enclosed in parenthesis and written with spaces, e.g. "(>= 1.0, < 2)"
+	constraint = strings.TrimSuffix(strings.TrimPrefix(constraint, "("), ")")
+	constraint = strings.Join(strings.Fields(constraint), "")
 
-	return parts[0]
+	return &pythonRequirement{
+		Name:              matches[1],
+		Extras:            extras,
+		VersionConstraint: constraint,
+		Markers:           strings.TrimSpace(markers),
+	}
+}
+
+// pinnedVersion returns the exact version a requirement is locked to ("==1.0" or "===1.0"), if any.
+func (r pythonRequirement) pinnedVersion() string {
+	for _, specifier := range strings.Split(r.VersionConstraint, ",") {
+		if !strings.HasPrefix(specifier, "==") {
+			continue
+		}
+		version := strings.TrimLeft(specifier, "=")
+		if version != "" && !strings.Contains(version, "*") {
+			return version
+		}
+	}
+	return ""
 }
 
-// removeEnvironmentMarkers removes any instances of environment markers (delimited by ';') from the line.
-// For more information, see https://www.python.org/dev/peps/pep-0508/#environment-markers.
-func removeEnvironmentMarkers(line string) string {
-	parts := strings.SplitN(line, ";", 2)
-	if len(parts) < 2 {
-		// there aren't any environment markers
+// newPackage returns the package of a requirement locked to the given version.
+func (r pythonRequirement) newPackage(version string) *pkg.Package {
+	return &pkg.Package{
+		Name:         r.Name,
+		Version:      version,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonRequirementsMetadataType,
+		Metadata: pkg.PythonRequirementsMetadata{
+			Name:              r.Name,
+			Extras:            r.Extras,
+			VersionConstraint: r.VersionConstraint,
+			Markers:           r.Markers,
+			Digests:           r.Digests,
+		},
+	}
+}
 
-		return line
+// newRequirementDigest returns the digest of a "algorithm:value" hash, e.g. "sha256:7c1c...".
+func newRequirementDigest(hash string) *file.Digest {
+	algorithm, value, found := strings.Cut(hash, ":")
+	if !found || algorithm == "" || value == "" {
+		return nil
 	}
+	return &file.Digest{
+		Algorithm: strings.ToLower(algorithm),
+		Value:     value,
+	}
+}
+
+// trimRequirementsTxtLine removes content from the given requirements.txt line
+// that should not be considered for parsing.
+func trimRequirementsTxtLine(line string) string {
+	line = removeTrailingComment(line)
+	return strings.TrimSpace(line)
+}
 
-	return parts[0]
+// removeTrailingComment takes a requirements.txt line and strips off comment strings.
+func removeTrailingComment(line string) string {
+	return commentExp.ReplaceAllString(line, "")
 }
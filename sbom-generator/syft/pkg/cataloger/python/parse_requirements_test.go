@@ -1,56 +1,213 @@
 package python
 
 import (
-	"github.com/anchore/syft/syft/source"
+	"fmt"
 	"os"
+	"strings"
 	"testing"
 
-	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
 )
 
 func TestParseRequirementsTxt(t *testing.T) {
-	expected := []*pkg.Package{
-		{
-			Name:     "flask",
-			Version:  "4.0.0",
-			Language: pkg.Python,
-			Type:     pkg.PythonPkg,
-		},
+	fixture, err := os.Open("test-fixtures/requires/requirements.txt")
+	require.NoError(t, err)
+	defer fixture.Close()
+
+	actual, err := parseRequirementsTxt(fixture)
+	require.NoError(t, err)
+
+	var pinned []string
+	for _, requirement := range actual.requirements {
+		if version := requirement.pinnedVersion(); version != "" {
+			pinned = append(pinned, fmt.Sprintf("%s@%s", requirement.Name, version))
+		}
+	}
+	assert.Equal(t, []string{"flask@4.0.0", "foo@1.0.0", "SomeProject@5.4"}, pinned)
+	assert.Equal(t, []string{"other-requirements.txt"}, actual.includes)
+	assert.Empty(t, actual.constraints)
+
+	assert.Equal(t, pythonRequirement{
+		Name:              "SomeProject",
+		VersionConstraint: "==5.4",
+		Markers:           "python_version < '3.8'",
+	}, actual.requirements[3])
+}
+
+func TestParseRequirementsTxt_hashesAndOptions(t *testing.T) {
+	fixture := `-r base.txt
+--requirement=other.txt
+-cconstraints.txt
+--constraint more-constraints.txt
+--extra-index-url https://example.com/simple
+requests[socks, security] == 2.28.1 ; python_version >= "3.7" \
+    --hash=sha256:7c5599b102feddaa661c826c56ab4fee28bfd17f5abca1ebbe3e7f19d7c97983 \
+    --hash sha256:8fefa2a1a1365bf5520aac41836fbee479da67864514bdb821f31ce07ce65349
+    # via -r requirements.in
+wheel==0.38.4 --global-option="--no-user-cfg"
+pecan @ https://github.com/pecan/pecan/archive/master.zip#egg=pecan
+`
+	actual, err := parseRequirementsTxt(strings.NewReader(fixture))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"base.txt", "other.txt"}, actual.includes)
+	assert.Equal(t, []string{"constraints.txt", "more-constraints.txt"}, actual.constraints)
+	assert.Equal(t, []pythonRequirement{
 		{
-			Name:     "foo",
-			Version:  "1.0.0",
-			Language: pkg.Python,
-			Type:     pkg.PythonPkg,
+			Name:              "requests",
+			Extras:            []string{"socks", "security"},
+			VersionConstraint: "==2.28.1",
+			Markers:           `python_version >= "3.7"`,
+			Digests: []file.Digest{
+				{Algorithm: "sha256", Value: "7c5599b102feddaa661c826c56ab4fee28bfd17f5abca1ebbe3e7f19d7c97983"},
+				{Algorithm: "sha256", Value: "8fefa2a1a1365bf5520aac41836fbee479da67864514bdb821f31ce07ce65349"},
+			},
 		},
 		{
-			Name:     "SomeProject",
-			Version:  "5.4",
-			Language: pkg.Python,
-			Type:     pkg.PythonPkg,
+			Name:              "wheel",
+			VersionConstraint: "==0.38.4",
 		},
+	}, actual.requirements)
+}
+
+func Test_pythonRequirement_pinnedVersion(t *testing.T) {
+	tests := []struct {
+		constraint string
+		expected   string
+	}{
+		{constraint: "==1.0", expected: "1.0"},
+		{constraint: "===1.0+local", expected: "1.0+local"},
+		{constraint: ">=1.0,==1.2", expected: "1.2"},
+		{constraint: "==1.*", expected: ""},
+		{constraint: "~=1.1", expected: ""},
+		{constraint: "", expected: ""},
 	}
+	for _, test := range tests {
+		t.Run(test.constraint, func(t *testing.T) {
+			assert.Equal(t, test.expected, pythonRequirement{VersionConstraint: test.constraint}.pinnedVersion())
+		})
+	}
+}
 
-	fixture, err := os.Open("test-fixtures/requires/requirements.txt")
-	if err != nil {
-		t.Fatalf("failed to open fixture: %+v", err)
+// catalogFixture catalogs a directory of the test fixtures with the index cataloger, returning the packages by
+// "name@version" and the "from -[type]-> to" relationships between them.
+func catalogFixture(t *testing.T, fixture string) (map[string]pkg.Package, []string) {
+	t.Helper()
+	src, err := source.NewFromDirectory(fixture)
+	require.NoError(t, err)
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	pkgs, relationships, err := NewPythonIndexCataloger().Catalog(resolver)
+	require.NoError(t, err)
+
+	byName := make(map[string]pkg.Package)
+	for _, p := range pkgs {
+		assert.Equal(t, indexCatalogerName, p.FoundBy)
+		assert.NotEmpty(t, p.ID())
+		byName[fmt.Sprintf("%s@%s", p.Name, p.Version)] = p
 	}
 
-	// TODO: no relationships are under test yet
-	actual, _, err := parseRequirementsTxt(fixture.Name(), fixture)
-	if err != nil {
-		t.Fatalf("failed to parse requirements: %+v", err)
+	var edges []string
+	for _, r := range relationships {
+		from, fromOk := r.From.(pkg.Package)
+		to, toOk := r.To.(pkg.Package)
+		require.True(t, fromOk && toOk, "relationship between unexpected elements: %+v", r)
+		edges = append(edges, fmt.Sprintf("%s -[%s]-> %s", from.Name, r.Type, to.Name))
 	}
+	return byName, edges
+}
 
-	if diff := cmp.Diff(expected, actual,
-		cmp.AllowUnexported(pkg.Package{}),
-		cmp.Comparer(
-			func(x, y source.LocationSet) bool {
-				return cmp.Equal(x.ToSlice(), y.ToSlice())
-			},
-		),
-	); diff != "" {
-		t.Errorf("unexpected result from parsing (-expected +actual)\n%s", diff)
+func TestIndexCataloger_requirementsIncludes(t *testing.T) {
+	pkgs, _ := catalogFixture(t, "test-fixtures/requires-includes")
+
+	var names []string
+	for name, p := range pkgs {
+		names = append(names, fmt.Sprintf("%s (%s)", name, p.Locations.ToSlice()[0].RealPath))
+	}
+	assert.ElementsMatch(t, []string{
+		"requests@2.28.1 (requirements.txt)",
+		// the versions of the unpinned requirements are pinned by the constraints of the including file
+		"urllib3@1.26.13 (requirements.txt)",
+		"flask@2.2.2 (requirements.txt)",
+		// the included files are followed relative to the including file
+		"six@1.16.0 (base/common.txt)",
+		"idna@3.4 (base/common.txt)",
+	}, names)
+
+	assert.Equal(t, pkg.PythonRequirementsMetadata{
+		Name:              "requests",
+		Extras:            []string{"socks", "security"},
+		VersionConstraint: "==2.28.1",
+		Digests: []file.Digest{
+			{Algorithm: "sha256", Value: "7c5599b102feddaa661c826c56ab4fee28bfd17f5abca1ebbe3e7f19d7c97983"},
+			{Algorithm: "sha256", Value: "8fefa2a1a1365bf5520aac41836fbee479da67864514bdb821f31ce07ce65349"},
+		},
+	}, pkgs["requests@2.28.1"].Metadata)
+	assert.Equal(t, `python_version >= "3.7"`, pkgs["flask@2.2.2"].Metadata.(pkg.PythonRequirementsMetadata).Markers)
+}
+
+func TestIndexCataloger_pdmLock(t *testing.T) {
+	pkgs, relationships := catalogFixture(t, "test-fixtures/pdm")
+
+	var names []string
+	for name := range pkgs {
+		names = append(names, name)
 	}
+	assert.ElementsMatch(t, []string{
+		"certifi@2022.12.7",
+		"iniconfig@2.0.0",
+		"pytest@7.2.0",
+		"requests@2.28.1",
+		"pysocks@1.7.1",
+		"urllib3@1.26.13",
+	}, names)
+
+	assert.ElementsMatch(t, []string{
+		"pytest -[DEPENDS_ON]-> iniconfig",
+		"requests -[DEPENDS_ON]-> certifi",
+		"requests -[DEPENDS_ON]-> urllib3",
+		"requests -[DEPENDS_ON]-> pysocks",
+	}, relationships)
+
+	assert.Equal(t, pkg.PythonRequirementsMetadata{
+		Name: "certifi",
+		Digests: []file.Digest{
+			{Algorithm: "sha256", Value: "4ad3232f5e926d6718ec31cfc1fcadfde020920e278684144551c91769c7bc18"},
+			{Algorithm: "sha256", Value: "35824b4c3a97115964b408844d64aa14db1cc518f6562e8d7261699d1350a9e3"},
+		},
+	}, pkgs["certifi@2022.12.7"].Metadata)
+	// the extras locked as distinct entries are merged
+	assert.Equal(t, []string{"socks"}, pkgs["requests@2.28.1"].Metadata.(pkg.PythonRequirementsMetadata).Extras)
+}
+
+func TestIndexCataloger_pyprojectToml(t *testing.T) {
+	pkgs, relationships := catalogFixture(t, "test-fixtures/pyproject")
+
+	var names []string
+	for name := range pkgs {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{
+		"app@1.0.0",
+		"requests@2.28.1",
+		"six@1.16.0",
+		"PyYAML@6.0",
+		"pytest@7.2.0",
+	}, names)
+
+	assert.ElementsMatch(t, []string{
+		"app -[DEPENDS_ON]-> requests",
+		"app -[DEPENDS_ON]-> six",
+		"requests -[optional-dependency-of]-> app",
+		"PyYAML -[optional-dependency-of]-> app",
+		"pytest -[dev-dependency-of]-> app",
+	}, relationships)
+
+	assert.Equal(t, "python_version < '3'", pkgs["six@1.16.0"].Metadata.(pkg.PythonRequirementsMetadata).Markers)
 }
@@ -1,6 +1,17 @@
 package python
 
-import "github.com/anchore/syft/syft/pkg"
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+)
+
+// nameSeparatorsExp matches the runs of characters considered equivalent in python package names (see PEP 503).
+var nameSeparatorsExp = regexp.MustCompile(`[-_.]+`)
 
 type PoetryMetadata struct {
 	Packages []PoetryMetadataPackage `toml:"package"`
@@ -8,11 +19,70 @@ type PoetryMetadata struct {
 
 // Pkgs returns all of the packages referenced within the poetry.lock metadata.
 func (m PoetryMetadata) Pkgs() []*pkg.Package {
+	pkgs, _ := m.PkgsAndRelationships()
+	return pkgs
+}
+
+// PkgsAndRelationships returns all of the packages referenced within the poetry.lock metadata along with the
+// relationships to their locked dependencies. Dependencies of the "dev" category are development dependencies, and
+// dependencies declared optional (only installed for extras) are optional dependencies.
+func (m PoetryMetadata) PkgsAndRelationships() ([]*pkg.Package, []artifact.Relationship) {
 	pkgs := make([]*pkg.Package, 0)
+	byName := make(map[string]*pkg.Package)
+	metadataByName := make(map[string]PoetryMetadataPackage)
 
 	for _, p := range m.Packages {
 		pkgs = append(pkgs, p.Pkg())
+		byName[normalizeName(p.Name)] = pkgs[len(pkgs)-1]
+		metadataByName[normalizeName(p.Name)] = p
 	}
 
-	return pkgs
+	var relationships []artifact.Relationship
+	for i, p := range m.Packages {
+		names := make([]string, 0, len(p.Dependencies))
+		for name := range p.Dependencies {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			dependency, exists := byName[normalizeName(name)]
+			if !exists || dependency == pkgs[i] {
+				continue
+			}
+
+			ty := artifact.DependsOnRelationship
+			switch dependencyMetadata := metadataByName[normalizeName(name)]; {
+			case dependencyMetadata.Category == "dev":
+				ty = artifact.DevDependencyOfRelationship
+			case dependencyMetadata.Optional || isOptionalConstraint(p.Dependencies[name]):
+				ty = artifact.OptionalDependencyOfRelationship
+			}
+			relationships = append(relationships, common.NewDependencyRelationship(pkgs[i], dependency, ty))
+		}
+	}
+
+	return pkgs, relationships
+}
+
+// isOptionalConstraint indicates if a dependency constraint ("1.0", {version = "1.0", optional = true} or an array of
+// such tables) declares the dependency optional.
+func isOptionalConstraint(constraint interface{}) bool {
+	switch c := constraint.(type) {
+	case map[string]interface{}:
+		optional, _ := c["optional"].(bool)
+		return optional
+	case []map[string]interface{}:
+		for _, item := range c {
+			if !isOptionalConstraint(item) {
+				return false
+			}
+		}
+		return len(c) > 0
+	}
+	return false
+}
+
+func normalizeName(name string) string {
+	return nameSeparatorsExp.ReplaceAllString(strings.ToLower(name), "-")
 }
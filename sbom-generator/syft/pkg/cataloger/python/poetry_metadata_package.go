@@ -8,6 +8,9 @@ type PoetryMetadataPackage struct {
 	Category    string `toml:"category"`
 	Description string `toml:"description"`
 	Optional    bool   `toml:"optional"`
+	// Dependencies maps the names of the dependencies to their constraint: either a version string, a table (with
+	// a version, markers, optional flag...) or an array of tables for constraints depending on markers.
+	Dependencies map[string]interface{} `toml:"dependencies"`
 }
 
 // Pkg returns the standard `pkg.Package` representation of the package referenced within the poetry.lock metadata.
@@ -22,10 +22,14 @@ const REPODATA_ISO_SUFFIX = "iso"
 const SQLITE_FILE_NAME_SUFFIX = "-primary.sqlite.bz2"
 const REPODATA_MD_FILE_NAME = "repomd.xml"
 
-type Cataloger struct{}
+type Cataloger struct {
+	config Config
+}
 
-func NewRepodataCataloger() *Cataloger {
-	return &Cataloger{}
+func NewRepodataCataloger(cfg Config) *Cataloger {
+	return &Cataloger{
+		config: cfg,
+	}
 }
 
 func (c *Cataloger) Name() string {
@@ -33,6 +37,11 @@ func (c *Cataloger) Name() string {
 }
 
 func (c *Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	index, err := loadMavenIndex(c.config.MavenIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	isoFileSystem, err := InitIsoFileSystem(resolver)
 	if err != nil {
 		return nil, nil, err
@@ -52,30 +61,53 @@ func (c *Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []arti
 	}
 	defer repodataFileList.Close(isoFileSystem)
 
-	return parseRepodata(isoFileSystem, repodataFileList)
+	return parseRepodata(isoFileSystem, repodataFileList, index, payloadCatalogers(c.config))
 }
 
-func parseRepodata(isoFileSystem IsoFileSystem, repodataFileList RepodataFileList) ([]pkg.Package, []artifact.Relationship, error) {
+func parseRepodata(isoFileSystem IsoFileSystem, repodataFileList RepodataFileList, index mavenIndex, catalogers []payloadCataloger) ([]pkg.Package, []artifact.Relationship, error) {
 	repodataTempDir, cleanupFn, err := createRepodataTempDir()
 	defer cleanupFn()
 	if err != nil {
 		return nil, nil, err
 	}
 
-	repodataFileList, err = unBzip2ForRepodata(repodataFileList, repodataTempDir)
+	repodataFileList, err = prepareRepodata(repodataFileList, repodataTempDir)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	discoveredPkgs, err := parsePackagesInfo(isoFileSystem, repodataFileList, repodataTempDir)
+	discoveredPkgs, rpmLocations, err := parsePackagesInfo(isoFileSystem, repodataFileList, repodataTempDir, index)
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to parse repodata for package: %w", err)
 	}
 
-	discoveredShips, err := parseRelationship(repodataFileList)
+	discoveredShips, unresolvedRequires, err := parseRelationship(repodataFileList)
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to parse repodata for relationship: %w", err)
 	}
 
+	for i, p := range discoveredPkgs {
+		if requires, exists := unresolvedRequires[p.ID()]; exists {
+			metadata := p.Metadata.(pkg.RpmRepodata)
+			metadata.UnresolvedRequires = requires
+			discoveredPkgs[i].Metadata = metadata
+		}
+	}
+
+	if len(catalogers) > 0 {
+		payloadPkgs, payloadShips := catalogRpmPayloads(isoFileSystem, repodataTempDir, discoveredPkgs, rpmLocations, catalogers)
+		discoveredPkgs = append(discoveredPkgs, payloadPkgs...)
+		discoveredShips = append(discoveredShips, payloadShips...)
+	}
+
 	return discoveredPkgs, discoveredShips, nil
 }
+
+// prepareRepodata makes the primary and filelists sqlite databases available in the temp dir, whatever the
+// representation of the repodata is.
+func prepareRepodata(repodataFileList RepodataFileList, repodataTempDir string) (RepodataFileList, error) {
+	if repodataFileList.IsXml {
+		return convertXmlToSqlite(repodataFileList, repodataTempDir)
+	}
+	return decompressRepodata(repodataFileList, repodataTempDir)
+}
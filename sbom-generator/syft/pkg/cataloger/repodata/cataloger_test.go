@@ -0,0 +1,186 @@
+package repodata
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const repoFixture = "test-fixtures/repo"
+const repoXmlFixture = "test-fixtures/repo-xml"
+
+func relationshipPairs(relationships []artifact.Relationship) []string {
+	var pairs []string
+	for _, r := range relationships {
+		pairs = append(pairs, string(r.From.ID())+" -> "+string(r.To.ID())+" ("+string(r.Type)+")")
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+func TestRepodataCataloger_Repo(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir(repoFixture)))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		location string
+	}{
+		{
+			name:     "local mirror",
+			location: repoFixture,
+		},
+		{
+			name:     "baseurl",
+			location: server.URL,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src := source.NewFromRepo(test.location)
+			resolver, err := src.FileResolver(source.SquashedScope)
+			require.NoError(t, err)
+
+			pkgs, relationships, err := NewRepodataCataloger(Config{}).Catalog(resolver)
+			require.NoError(t, err)
+
+			var names []string
+			for _, p := range pkgs {
+				assert.Equal(t, pkg.RepodataPkg, p.Type)
+				assert.Equal(t, pkg.RpmRepodataType, p.MetadataType)
+				names = append(names, p.Name)
+			}
+			sort.Strings(names)
+			assert.Equal(t, []string{"bash", "filesystem", "glibc", "glibc-common", "ncurses-libs"}, names)
+
+			for _, p := range pkgs {
+				metadata := p.Metadata.(pkg.RpmRepodata)
+				switch p.Name {
+				case "bash":
+					assert.Equal(t, "0:5.1.8-6.oe2203", p.Version)
+					assert.Equal(t, "x86_64", metadata.Arch)
+					assert.Equal(t, "bash-5.1.8-6.oe2203.src.rpm", metadata.SourceRpm)
+					assert.Equal(t, "GPLv3+", metadata.License)
+					assert.Equal(t, []string{
+						"/usr/bin/bash",
+						"/usr/bin/sh",
+						"/usr/share/doc/bash/FAQ",
+						"/usr/share/doc/bash/INTRO",
+					}, metadata.OwnedFiles())
+				case "filesystem":
+					assert.Equal(t, []pkg.RepodataFileRecord{
+						{Path: "/bin", Mode: rpmFileModeDir},
+						{Path: "/etc", Mode: rpmFileModeDir},
+						{Path: "/usr", Mode: rpmFileModeDir},
+					}, metadata.Files)
+				}
+			}
+
+			assert.Equal(t, []string{
+				"rpm-bash-5.1.8 -> rpm-filesystem-3.16 (DEPENDS_ON)",
+				"rpm-bash-5.1.8 -> rpm-glibc-2.34 (DEPENDS_ON)",
+				// bash also suggests ncurses-libs, the strongest dependency wins
+				"rpm-bash-5.1.8 -> rpm-ncurses-libs-6.3 (DEPENDS_ON)",
+				"rpm-glibc-2.34 -> rpm-glibc-common-2.34 (DEPENDS_ON)",
+				"rpm-glibc-common-2.34 -> rpm-bash-5.1.8 (suggests)",
+				"rpm-glibc-common-2.34 -> rpm-glibc-2.34 (DEPENDS_ON)",
+				// ncurses-libs requires(post) ldconfig of glibc, on top of libc.so.6
+				"rpm-ncurses-libs-6.3 -> rpm-glibc-2.34 (has-prerequisite)",
+			}, relationshipPairs(relationships))
+		})
+	}
+}
+
+func TestRepodataCataloger_XmlRepo(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir(repoXmlFixture)))
+	defer server.Close()
+
+	catalog := func(location string) ([]pkg.Package, []artifact.Relationship) {
+		src := source.NewFromRepo(location)
+		resolver, err := src.FileResolver(source.SquashedScope)
+		require.NoError(t, err)
+
+		pkgs, relationships, err := NewRepodataCataloger(Config{}).Catalog(resolver)
+		require.NoError(t, err)
+		return pkgs, relationships
+	}
+
+	// the sqlite and the xml representations of the same repository yield the same packages
+	expectedPkgs, expectedRelationships := catalog(repoFixture)
+	expected := make(map[string]pkg.RpmRepodata)
+	for _, p := range expectedPkgs {
+		expected[p.Name] = p.Metadata.(pkg.RpmRepodata)
+	}
+
+	for _, location := range []string{repoXmlFixture, server.URL} {
+		t.Run(location, func(t *testing.T) {
+			pkgs, relationships := catalog(location)
+			require.Len(t, pkgs, len(expectedPkgs))
+			for _, p := range pkgs {
+				assert.Equal(t, expected[p.Name], p.Metadata, p.Name)
+			}
+			assert.Equal(t, relationshipPairs(expectedRelationships), relationshipPairs(relationships))
+		})
+	}
+}
+
+func TestRepodataCataloger_UnpackRpms(t *testing.T) {
+	src := source.NewFromRepo(repoFixture)
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	pkgs, relationships, err := NewRepodataCataloger(Config{UnpackRpms: true}).Catalog(resolver)
+	require.NoError(t, err)
+
+	// only the bash rpm is part of the fixture, the other rpms cannot be unpacked
+	bundled := make(map[string]pkg.Package)
+	for _, p := range pkgs {
+		if p.Type != pkg.RepodataPkg {
+			bundled[p.Name] = p
+		}
+	}
+	require.Len(t, bundled, 2)
+
+	bashlex := bundled["bashlex"]
+	assert.Equal(t, pkg.PythonPkg, bashlex.Type)
+	assert.Equal(t, "0.16", bashlex.Version)
+	assert.Equal(t, []source.Location{
+		source.NewVirtualLocation(
+			"/usr/lib/python3.9/site-packages/bashlex-0.16.dist-info/METADATA",
+			"Packages/bash-5.1.8-6.oe2203.x86_64.rpm:/usr/lib/python3.9/site-packages/bashlex-0.16.dist-info/METADATA",
+		),
+	}, bashlex.Locations.ToSlice())
+
+	shellQuote := bundled["shell-quote"]
+	assert.Equal(t, pkg.NpmPkg, shellQuote.Type)
+	assert.Equal(t, "1.7.3", shellQuote.Version)
+
+	var contained []string
+	for _, r := range relationships {
+		if r.Type == artifact.ContainsRelationship {
+			assert.Equal(t, artifact.ID("rpm-bash-5.1.8"), r.From.ID())
+			contained = append(contained, r.To.(pkg.Package).Name)
+		}
+	}
+	sort.Strings(contained)
+	assert.Equal(t, []string{"bashlex", "shell-quote"}, contained)
+}
+
+func TestRepodataCataloger_MissingRepomd(t *testing.T) {
+	src := source.NewFromRepo("test-fixtures")
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	pkgs, relationships, err := NewRepodataCataloger(Config{}).Catalog(resolver)
+	require.NoError(t, err)
+	assert.Empty(t, pkgs)
+	assert.Empty(t, relationships)
+}
@@ -0,0 +1,131 @@
+package repodata
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg/cataloger/common/rpm"
+	"github.com/anchore/syft/syft/source"
+)
+
+// ClosureReport lists what breaks the dependency closure of an ISO or repository, like dnf repoclosure does, along
+// with the conflicting packages both present in it and the requires whose provider had to be chosen.
+type ClosureReport struct {
+	Packages   int                  `json:"packages"`
+	Unresolved []UnresolvedRequires `json:"unresolved"`
+	Conflicts  []PackagePair        `json:"conflicts"`
+	Obsoletes  []PackagePair        `json:"obsoletes"`
+	Ambiguous  []AmbiguousRequire   `json:"ambiguous"`
+}
+
+// UnresolvedRequires are the requires of a package no package of the repository provides.
+type UnresolvedRequires struct {
+	Package  string   `json:"package"`
+	Requires []string `json:"requires"`
+}
+
+// PackagePair is a package with a conflicts or obsoletes dependency matching another package of the repository.
+type PackagePair struct {
+	Package    string `json:"package"`
+	Dependency string `json:"dependency"`
+	With       string `json:"with"`
+}
+
+// AmbiguousRequire is a require several differently named packages provide, and the provider which was chosen.
+type AmbiguousRequire struct {
+	Package      string   `json:"package"`
+	Requires     string   `json:"requires"`
+	Provider     string   `json:"provider"`
+	Alternatives []string `json:"alternatives,omitempty"`
+}
+
+// Closed reports whether every require of the repository is satisfied.
+func (r ClosureReport) Closed() bool {
+	return len(r.Unresolved) == 0
+}
+
+// CheckClosure resolves the requires of all packages of the ISO or repository the resolver gives access to.
+func CheckClosure(resolver source.FileResolver) (*ClosureReport, error) {
+	isoFileSystem, err := InitIsoFileSystem(resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	mdXmlFile, err := isoFileSystem.OpenFile(strings.Join([]string{ISO_REPODATA_FOLDER_NAME, REPODATA_MD_FILE_NAME}, ISO_PATH_SEPARATOR), os.O_RDONLY)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find %s/%s: %w", ISO_REPODATA_FOLDER_NAME, REPODATA_MD_FILE_NAME, err)
+	}
+	defer isoFileSystem.Close(mdXmlFile)
+
+	repodataFileList, err := resolverRepodataFile(isoFileSystem, mdXmlFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve repodata files: %w", err)
+	}
+	defer repodataFileList.Close(isoFileSystem)
+
+	repodataTempDir, cleanupFn, err := createRepodataTempDir()
+	defer cleanupFn()
+	if err != nil {
+		return nil, err
+	}
+
+	repodataFileList, err = prepareRepodata(repodataFileList, repodataTempDir)
+	if err != nil {
+		return nil, err
+	}
+
+	dependencyResolver, err := newRepodataDependencyResolver(repodataFileList)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load repodata dependencies: %w", err)
+	}
+
+	return newClosureReport(dependencyResolver), nil
+}
+
+func newClosureReport(r *rpm.Resolver) *ClosureReport {
+	resolution := r.Resolve()
+	report := &ClosureReport{
+		Packages:   len(r.Packages()),
+		Unresolved: []UnresolvedRequires{},
+		Conflicts:  toPackagePairs(r.Conflicts()),
+		Obsoletes:  toPackagePairs(r.Obsoletes()),
+		Ambiguous:  []AmbiguousRequire{},
+	}
+
+	for _, p := range r.Packages() {
+		if requires, exists := resolution.Unresolved[p.Key]; exists {
+			report.Unresolved = append(report.Unresolved, UnresolvedRequires{
+				Package:  p.String(),
+				Requires: requires,
+			})
+		}
+
+		for _, ambiguous := range resolution.Ambiguous[p.Key] {
+			var alternatives []string
+			for _, alternative := range ambiguous.Alternatives {
+				alternatives = append(alternatives, alternative.String())
+			}
+			report.Ambiguous = append(report.Ambiguous, AmbiguousRequire{
+				Package:      p.String(),
+				Requires:     ambiguous.Require.String(),
+				Provider:     ambiguous.Provider.String(),
+				Alternatives: alternatives,
+			})
+		}
+	}
+
+	return report
+}
+
+func toPackagePairs(pairs []rpm.PackagePair) []PackagePair {
+	result := make([]PackagePair, 0, len(pairs))
+	for _, pair := range pairs {
+		result = append(result, PackagePair{
+			Package:    pair.Package.String(),
+			Dependency: pair.Dependency.String(),
+			With:       pair.With.String(),
+		})
+	}
+	return result
+}
@@ -0,0 +1,88 @@
+package repodata
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg/cataloger/common/rpm"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckClosure(t *testing.T) {
+	for _, location := range []string{repoFixture, repoXmlFixture} {
+		t.Run(location, func(t *testing.T) {
+			src := source.NewFromRepo(location)
+			resolver, err := src.FileResolver(source.SquashedScope)
+			require.NoError(t, err)
+
+			report, err := CheckClosure(resolver)
+			require.NoError(t, err)
+			assert.True(t, report.Closed())
+			assert.Equal(t, &ClosureReport{
+				Packages:   5,
+				Unresolved: []UnresolvedRequires{},
+				Conflicts:  []PackagePair{},
+				// glibc obsoletes glibc-profile < 2.4, which is not in the repository
+				Obsoletes: []PackagePair{},
+				Ambiguous: []AmbiguousRequire{},
+			}, report)
+		})
+	}
+}
+
+func TestCheckClosure_MissingRepomd(t *testing.T) {
+	src := source.NewFromRepo("test-fixtures")
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	_, err = CheckClosure(resolver)
+	assert.Error(t, err)
+}
+
+func TestNewClosureReport(t *testing.T) {
+	requires := func(name string, flags string, evr string) rpm.Dependency {
+		return rpm.Dependency{Name: name, Flags: flags, EVR: rpm.ParseEVR(evr)}
+	}
+	newTestRepodataPackage := func(key int, name string, arch string, evr string, provides []rpm.Dependency, requires ...rpm.Dependency) *rpm.Package {
+		return &rpm.Package{
+			Key:      key,
+			Name:     name,
+			Arch:     arch,
+			EVR:      rpm.ParseEVR(evr),
+			Provides: append([]rpm.Dependency{{Name: name, Flags: "EQ", EVR: rpm.ParseEVR(evr)}}, provides...),
+			Requires: requires,
+		}
+	}
+
+	app := newTestRepodataPackage(1, "app", "x86_64", "1.0-1.oe2203", nil,
+		requires("missing", "", ""), requires("webserver", "", ""), requires("lib", "GE", "2"))
+	app.Conflicts = []rpm.Dependency{requires("nginx", "LT", "2"), requires("lib", "LT", "2")}
+	app.Obsoletes = []rpm.Dependency{requires("app-legacy", "", ""), requires("app", "LT", "1")}
+
+	packages := []*rpm.Package{
+		app,
+		newTestRepodataPackage(2, "nginx", "x86_64", "1.21-1", []rpm.Dependency{{Name: "webserver"}}),
+		newTestRepodataPackage(3, "httpd", "x86_64", "2.4-1", []rpm.Dependency{{Name: "webserver"}}),
+		newTestRepodataPackage(4, "app-legacy", "x86_64", "1:0.9-1", nil),
+		newTestRepodataPackage(5, "lib", "noarch", "2.0-1", nil),
+	}
+
+	report := newClosureReport(rpm.NewResolver(packages, nil))
+	assert.False(t, report.Closed())
+	assert.Equal(t, &ClosureReport{
+		Packages: 5,
+		Unresolved: []UnresolvedRequires{
+			{Package: "app-1.0-1.oe2203.x86_64", Requires: []string{"missing"}},
+		},
+		Conflicts: []PackagePair{
+			{Package: "app-1.0-1.oe2203.x86_64", Dependency: "nginx < 2", With: "nginx-1.21-1.x86_64"},
+		},
+		Obsoletes: []PackagePair{
+			{Package: "app-1.0-1.oe2203.x86_64", Dependency: "app-legacy", With: "app-legacy-1:0.9-1.x86_64"},
+		},
+		Ambiguous: []AmbiguousRequire{
+			{Package: "app-1.0-1.oe2203.x86_64", Requires: "webserver", Provider: "httpd-2.4-1.x86_64", Alternatives: []string{"nginx-1.21-1.x86_64"}},
+		},
+	}, report)
+}
@@ -0,0 +1,13 @@
+package repodata
+
+import "github.com/anchore/syft/syft/pkg/cataloger/java"
+
+type Config struct {
+	// MavenIndex is the path of a local file mapping the sha1 of java archives to their maven coordinates, used to
+	// identify the jars embedded in rpms which carry no maven metadata of their own
+	MavenIndex string
+	// UnpackRpms unpacks every rpm of the repository and catalogs the language packages bundled in it
+	UnpackRpms bool
+	// Java configures the java cataloger run over the unpacked rpms
+	Java java.Config
+}
@@ -0,0 +1,228 @@
+package repodata
+
+import (
+	"database/sql"
+	"path"
+	"strings"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/pkg/cataloger/common/rpm"
+)
+
+// newRepodataDependencyResolver creates a resolver from the decompressed primary and filelists databases.
+func newRepodataDependencyResolver(repodataFileList RepodataFileList) (*rpm.Resolver, error) {
+	primaryDb, err := sql.Open("sqlite", repodataFileList.PrimarySqliteUnBzFilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer primaryDb.Close()
+
+	fileListDb, err := sql.Open("sqlite", repodataFileList.FilelistsSqliteUnBzFilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer fileListDb.Close()
+
+	packages, files, err := loadRepodataPackages(primaryDb, fileListDb)
+	if err != nil {
+		return nil, err
+	}
+	return rpm.NewResolver(packages, files), nil
+}
+
+// loadRepodataPackages loads the packages with their provides, requires, conflicts, obsoletes and weak dependencies from the primary database, and the files
+// which are required by some package from the primary and filelists databases.
+func loadRepodataPackages(primaryDb *sql.DB, fileListDb *sql.DB) ([]*rpm.Package, map[string][]*rpm.Package, error) {
+	packages, byPkgId, err := queryRepodataPackages(primaryDb)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byKey := make(map[int]*rpm.Package, len(packages))
+	for _, p := range packages {
+		byKey[p.Key] = p
+	}
+
+	for _, table := range []string{"provides", "requires", "conflicts", "obsoletes", "recommends", "suggests", "supplements", "enhances"} {
+		if err := queryRepodataDependencies(primaryDb, table, byKey); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	requiredFiles := make(map[string]bool)
+	for _, p := range packages {
+		for _, f := range p.RequiredFiles() {
+			requiredFiles[f] = true
+		}
+	}
+
+	files, err := queryRequiredFiles(primaryDb, fileListDb, requiredFiles, byKey, byPkgId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return packages, files, nil
+}
+
+// queryRepodataPackages returns the packages of the primary database, along with the packages keyed by their pkgId.
+func queryRepodataPackages(primaryDb *sql.DB) ([]*rpm.Package, map[string]*rpm.Package, error) {
+	rows, err := primaryDb.Query(`SELECT pkgKey, pkgId, name, arch, ifnull( epoch, "") epoch, version, ifnull( release, "") release FROM packages`)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var packages []*rpm.Package
+	byPkgId := make(map[string]*rpm.Package)
+	for rows.Next() {
+		var pkgId string
+		p := &rpm.Package{}
+		if err = rows.Scan(&p.Key, &pkgId, &p.Name, &p.Arch, &p.EVR.Epoch, &p.EVR.Version, &p.EVR.Release); err != nil {
+			log.Error(err)
+			continue
+		}
+		packages = append(packages, p)
+		byPkgId[pkgId] = p
+	}
+	return packages, byPkgId, rows.Err()
+}
+
+func queryRepodataDependencies(primaryDb *sql.DB, table string, byKey map[int]*rpm.Package) error {
+	// only requires have the pre column, which createrepo_c fills with TRUE/FALSE and older tools with 1/0
+	preColumn := `''`
+	if table == "requires" {
+		preColumn = `ifnull( pre, '')`
+	}
+
+	rows, err := primaryDb.Query(`SELECT
+	pkgKey,
+	name,
+	ifnull( flags, "") flags,
+	ifnull( epoch, "") epoch,
+	ifnull( version, "") version,
+	ifnull( release, "") release,
+	` + preColumn + ` pre
+FROM
+	` + table + `
+ORDER BY
+	rowid`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pkgKey int
+		var dependency rpm.Dependency
+		var pre string
+		if err = rows.Scan(&pkgKey, &dependency.Name, &dependency.Flags, &dependency.EVR.Epoch, &dependency.EVR.Version, &dependency.EVR.Release, &pre); err != nil {
+			log.Error(err)
+			continue
+		}
+		dependency.Pre = strings.EqualFold(pre, "true") || pre == "1"
+
+		p, exists := byKey[pkgKey]
+		if !exists {
+			continue
+		}
+		switch table {
+		case "provides":
+			p.Provides = append(p.Provides, dependency)
+		case "requires":
+			p.Requires = append(p.Requires, dependency)
+		case "conflicts":
+			p.Conflicts = append(p.Conflicts, dependency)
+		case "obsoletes":
+			p.Obsoletes = append(p.Obsoletes, dependency)
+		case "recommends":
+			p.Recommends = append(p.Recommends, dependency)
+		case "suggests":
+			p.Suggests = append(p.Suggests, dependency)
+		case "supplements":
+			p.Supplements = append(p.Supplements, dependency)
+		case "enhances":
+			p.Enhances = append(p.Enhances, dependency)
+		}
+	}
+	return rows.Err()
+}
+
+func queryRequiredFiles(primaryDb *sql.DB, fileListDb *sql.DB, requiredFiles map[string]bool, byKey map[int]*rpm.Package, byPkgId map[string]*rpm.Package) (map[string][]*rpm.Package, error) {
+	files := make(map[string][]*rpm.Package)
+	if len(requiredFiles) == 0 {
+		return files, nil
+	}
+
+	owned := make(map[string]map[int]bool)
+	addFile := func(filePath string, p *rpm.Package) {
+		if !requiredFiles[filePath] {
+			return
+		}
+		if owned[filePath] == nil {
+			owned[filePath] = make(map[int]bool)
+		}
+		if !owned[filePath][p.Key] {
+			owned[filePath][p.Key] = true
+			files[filePath] = append(files[filePath], p)
+		}
+	}
+
+	// the primary metadata only lists a subset of the files (binaries and /etc), the filelists has all of them
+	rows, err := primaryDb.Query(`SELECT name, pkgKey FROM files`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var name string
+		var pkgKey int
+		if err = rows.Scan(&name, &pkgKey); err != nil {
+			log.Error(err)
+			continue
+		}
+		if p, exists := byKey[pkgKey]; exists {
+			addFile(name, p)
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	requiredDirs := make(map[string]bool)
+	for filePath := range requiredFiles {
+		requiredDirs[path.Dir(filePath)] = true
+	}
+
+	rows, err = fileListDb.Query(`SELECT
+	p.pkgId,
+	f.dirname,
+	f.filenames
+FROM
+	filelist f
+	JOIN packages p ON p.pkgKey = f.pkgKey`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pkgId string
+		var dirname string
+		var filenames string
+		if err = rows.Scan(&pkgId, &dirname, &filenames); err != nil {
+			log.Error(err)
+			continue
+		}
+		if !requiredDirs[dirname] {
+			continue
+		}
+		p, exists := byPkgId[pkgId]
+		if !exists {
+			continue
+		}
+		for _, filename := range strings.Split(filenames, "/") {
+			addFile(path.Join(dirname, filename), p)
+		}
+	}
+	return files, rows.Err()
+}
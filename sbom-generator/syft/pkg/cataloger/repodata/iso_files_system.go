@@ -12,10 +12,11 @@ import (
 	"github.com/diskfs/go-diskfs/filesystem"
 )
 
-// 针对ISO的两种格式：镜像文件和文件夹，抽象为统一的结构体，方便后续逻辑统一操作
+// 针对ISO的两种格式：镜像文件和文件夹，以及远程的yum/dnf仓库，抽象为统一的结构体，方便后续逻辑统一操作
 type IsoFileSystem struct {
 	isIsoFile bool
 	isIsoDir  bool
+	isRepo    bool
 	fs        filesystem.FileSystem
 	resolver  source.FileResolver
 }
@@ -52,7 +53,19 @@ func InitIsoFileSystem(resolver source.FileResolver) (IsoFileSystem, error) {
 		return isoFileSystem, nil
 	}
 
-	// init for input of iso dir
+	// init for input of remote repository (baseurl), files are fetched through the resolver
+	if !isLocalDirInput(resolver) {
+		log.Infof("resolver repodata from input repository: %q", resolver.Path())
+		return IsoFileSystem{
+			isIsoFile: false,
+			isIsoDir:  false,
+			isRepo:    true,
+			fs:        nil,
+			resolver:  resolver,
+		}, nil
+	}
+
+	// init for input of iso dir (or local repository mirror)
 	return IsoFileSystem{
 		isIsoFile: false,
 		isIsoDir:  true,
@@ -72,6 +85,11 @@ func isIsoFileInput(resolver source.FileResolver) bool {
 	return false
 }
 
+func isLocalDirInput(resolver source.FileResolver) bool {
+	fileMeta, err := os.Stat(resolver.Path())
+	return err == nil && fileMeta.IsDir()
+}
+
 func (isoFS IsoFileSystem) OpenFile(filePath string, openMode int) (io.Reader, error) {
 	if isoFS.isIsoFile {
 		filePath = strings.Join([]string{"", filePath}, ISO_PATH_SEPARATOR)
@@ -79,6 +97,8 @@ func (isoFS IsoFileSystem) OpenFile(filePath string, openMode int) (io.Reader, e
 	} else if isoFS.isIsoDir {
 		filePath = strings.Join([]string{isoFS.resolver.Path(), filePath}, ISO_PATH_SEPARATOR)
 		return os.OpenFile(filePath, openMode, 0)
+	} else if isoFS.isRepo {
+		return isoFS.resolver.FileContentsByLocation(source.NewLocation(filePath))
 	}
 	return nil, fmt.Errorf("the IOS type is neither file nor dir")
 }
@@ -0,0 +1,74 @@
+package repodata
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg/cataloger/java"
+)
+
+// mavenIndex maps the (lowercase) sha1 of java archives to their maven coordinates
+type mavenIndex map[string]java.MavenCoordinates
+
+// loadMavenIndex reads a maven index file, one archive per line:
+//
+//	# sha1 groupId:artifactId:version
+//	5b1a1e8c1d1e1c1c1f4e3d2c1b0a9f8e7d6c5b4a org.apache.commons:commons-lang3:3.12.0
+//
+// Blank lines and lines starting with "#" are ignored, the sha1 and the coordinates may also be separated by a comma.
+func loadMavenIndex(path string) (mavenIndex, error) {
+	if path == "" {
+		return mavenIndex{}, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open maven index: %w", err)
+	}
+	defer f.Close()
+
+	index, err := parseMavenIndex(f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse maven index %q: %w", path, err)
+	}
+	return index, nil
+}
+
+func parseMavenIndex(reader io.Reader) (mavenIndex, error) {
+	index := make(mavenIndex)
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected a sha1 and maven coordinates, got %q", lineNumber, line)
+		}
+
+		gav := strings.Split(fields[1], ":")
+		if len(gav) != 3 || gav[0] == "" || gav[1] == "" || gav[2] == "" {
+			return nil, fmt.Errorf("line %d: invalid maven coordinates %q", lineNumber, fields[1])
+		}
+
+		index[strings.ToLower(fields[0])] = java.MavenCoordinates{
+			GroupID:    gav[0],
+			ArtifactID: gav[1],
+			Version:    gav[2],
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
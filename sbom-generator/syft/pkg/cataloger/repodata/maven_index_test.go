@@ -0,0 +1,49 @@
+package repodata
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg/cataloger/java"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMavenIndex(t *testing.T) {
+	index, err := parseMavenIndex(strings.NewReader(`# sha1 groupId:artifactId:version
+5B1A1E8C1D1E1C1C1F4E3D2C1B0A9F8E7D6C5B4A org.apache.commons:commons-lang3:3.12.0
+
+0a1b2c3d4e5f60718293a4b5c6d7e8f901234567,com.google.guava:guava:31.0.1-jre
+`))
+	require.NoError(t, err)
+	assert.Equal(t, mavenIndex{
+		"5b1a1e8c1d1e1c1c1f4e3d2c1b0a9f8e7d6c5b4a": {GroupID: "org.apache.commons", ArtifactID: "commons-lang3", Version: "3.12.0"},
+		"0a1b2c3d4e5f60718293a4b5c6d7e8f901234567": {GroupID: "com.google.guava", ArtifactID: "guava", Version: "31.0.1-jre"},
+	}, index)
+}
+
+func TestParseMavenIndex_Invalid(t *testing.T) {
+	for _, line := range []string{
+		"5b1a1e8c1d1e1c1c1f4e3d2c1b0a9f8e7d6c5b4a",
+		"5b1a1e8c1d1e1c1c1f4e3d2c1b0a9f8e7d6c5b4a org.apache.commons:commons-lang3",
+		"5b1a1e8c1d1e1c1c1f4e3d2c1b0a9f8e7d6c5b4a org.apache.commons::3.12.0",
+	} {
+		t.Run(line, func(t *testing.T) {
+			_, err := parseMavenIndex(strings.NewReader(line))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadMavenIndex(t *testing.T) {
+	index, err := loadMavenIndex("")
+	require.NoError(t, err)
+	assert.Empty(t, index)
+
+	_, err = loadMavenIndex("test-fixtures/missing-maven-index")
+	assert.Error(t, err)
+
+	index, err = loadMavenIndex("test-fixtures/maven-index")
+	require.NoError(t, err)
+	assert.Equal(t, java.MavenCoordinates{GroupID: "org.example", ArtifactID: "plain", Version: "1.0"}, index["da39a3ee5e6b4b0d3255bfef95601890afd80709"])
+}
@@ -4,8 +4,10 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -14,6 +16,8 @@ import (
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common/rpm"
+	"github.com/anchore/syft/syft/pkg/cataloger/java"
 	"github.com/anchore/syft/syft/source"
 	"github.com/codingsince1985/checksum"
 	_ "modernc.org/sqlite"
@@ -24,16 +28,18 @@ const purlDefaultChecksumNamespace = "sha1"
 const purlDefaultChecksumVersion = "1.0.0"
 const packageIdPattern = "rpm-%s-%s"
 
-func parsePackagesInfo(isoFileSystem IsoFileSystem, repodataFileList RepodataFileList, unzipDir string) ([]pkg.Package, error) {
+// parsePackagesInfo returns the packages of the repository, along with the location of the rpm of each package (by
+// index: package IDs are not unique across the architectures of the same package).
+func parsePackagesInfo(isoFileSystem IsoFileSystem, repodataFileList RepodataFileList, unzipDir string, index mavenIndex) ([]pkg.Package, []string, error) {
 	primaryDb, err := sql.Open("sqlite", repodataFileList.PrimarySqliteUnBzFilePath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer primaryDb.Close()
 
 	fileListDb, err := sql.Open("sqlite", repodataFileList.FilelistsSqliteUnBzFilePath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer fileListDb.Close()
 
@@ -54,17 +60,19 @@ func parsePackagesInfo(isoFileSystem IsoFileSystem, repodataFileList RepodataFil
 	size_installed size,
 	ifnull( url, "") homepage,
 	checksum_type checksumType,
-	location_href locationHref
+	location_href locationHref,
+	ifnull( time_build, 0) buildTime
 FROM
 	packages`
 
 	rows, err := primaryDb.Query(sql)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 
 	allPkgs := make([]pkg.Package, 0)
+	rpmLocations := make([]string, 0)
 
 	for rows.Next() {
 		var pkgId string
@@ -84,8 +92,9 @@ FROM
 		var homepage string
 		var checksumType string
 		var locationHref string
+		var buildTime int64
 
-		if err = rows.Scan(&pkgId, &pkgKey, &name, &arch, &version, &epoch, &release, &summary, &description, &sourceRpm, &vendor, &packager, &license, &size, &homepage, &checksumType, &locationHref); err != nil {
+		if err = rows.Scan(&pkgId, &pkgKey, &name, &arch, &version, &epoch, &release, &summary, &description, &sourceRpm, &vendor, &packager, &license, &size, &homepage, &checksumType, &locationHref, &buildTime); err != nil {
 			log.Error(err)
 			continue
 		}
@@ -95,18 +104,22 @@ FROM
 			epoch_int10 = 0
 		}
 
-		rpmProvides, closeFn, err := queryMvnProvidesForPackage(*primaryDb, pkgKey, version)
-		defer closeFn()
+		rpmProvides, err := queryMvnProvidesForPackage(primaryDb, pkgKey, version)
+		if err != nil {
+			log.Error(err)
+		}
+
+		javaFileList, err := queryJavaFileListForPackage(fileListDb, pkgKey)
 		if err != nil {
 			log.Error(err)
 		}
 
-		javaFileList, err := queryJavaFileListForPackage(*fileListDb, pkgKey)
+		javaPackages, err := covertJavaFileToPackage(javaFileList, isoFileSystem, unzipDir, locationHref, index)
 		if err != nil {
 			log.Error(err)
 		}
 
-		javaPackages, err := covertJavaFileToPackage(javaFileList, isoFileSystem, unzipDir, locationHref)
+		fileRecords, err := queryFileRecordsForPackage(fileListDb, pkgKey)
 		if err != nil {
 			log.Error(err)
 		}
@@ -125,19 +138,20 @@ FROM
 			Homepage:    homepage,
 			Summary:     summary,
 			Description: description,
+			BuildTime:   buildTime,
 			RpmDigests: []file.Digest{{
 				Algorithm: checksumType,
 				Value:     pkgId,
 			}},
 			RpmProvides: rpmProvides,
 			ExtPackage:  javaPackages,
-			// Files:       extractRpmdbFileRecords(resolver, entry),
+			Files:       fileRecords,
 		}
 
 		p := pkg.Package{
 			Name:         name,
 			Version:      toELVersion(metadata),
-			Locations:    source.NewLocationSet(source.NewLocation(repodataFileList.PrimarySqliteBzFilePath)),
+			Locations:    source.NewLocationSet(source.NewLocation(repodataFileList.PrimaryFilePath())),
 			Licenses:     []string{license},
 			FoundBy:      catalogerName,
 			Type:         pkg.RepodataPkg,
@@ -149,13 +163,14 @@ FROM
 		p.OverrideID(artifact.ID(fmt.Sprintf(packageIdPattern, name, version)))
 
 		allPkgs = append(allPkgs, p)
+		rpmLocations = append(rpmLocations, locationHref)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return allPkgs, nil
+	return allPkgs, rpmLocations, nil
 }
 
 func toELVersion(metadata pkg.RpmRepodata) string {
@@ -165,7 +180,7 @@ func toELVersion(metadata pkg.RpmRepodata) string {
 	return fmt.Sprintf("%s-%s", metadata.Version, metadata.Release)
 }
 
-func queryMvnProvidesForPackage(primaryDb sql.DB, pkgKey int, pkgVersion string) ([]pkg.RepodataPackageRecord, func(), error) {
+func queryMvnProvidesForPackage(primaryDb *sql.DB, pkgKey int, pkgVersion string) ([]pkg.RepodataPackageRecord, error) {
 	sql := `SELECT
 		name,
 		ifnull( version, "") version
@@ -180,17 +195,15 @@ func queryMvnProvidesForPackage(primaryDb sql.DB, pkgKey int, pkgVersion string)
 		AND name NOT LIKE '%:sources-feature:%'`
 
 	rows, err := primaryDb.Query(sql, pkgKey)
-	defer primaryDb.Close()
-
 	if err != nil {
-		return []pkg.RepodataPackageRecord{}, func() {}, err
+		return []pkg.RepodataPackageRecord{}, err
 	}
-	closeFn := func() {
+	defer func() {
 		err := rows.Close()
 		if err != nil {
-			log.Errorf("unable to close primaryDb query rows, pkgKey: %s, %+v", pkgKey, err)
+			log.Errorf("unable to close primaryDb query rows, pkgKey: %d, %+v", pkgKey, err)
 		}
-	}
+	}()
 
 	mvnRegexp := regexp.MustCompile(mvnRegexpStr)
 	rpmProvideMap := make(map[string]pkg.RepodataPackageRecord, 0)
@@ -224,14 +237,14 @@ func queryMvnProvidesForPackage(primaryDb sql.DB, pkgKey int, pkgVersion string)
 	}
 
 	if err = rows.Err(); err != nil {
-		return []pkg.RepodataPackageRecord{}, closeFn, err
+		return []pkg.RepodataPackageRecord{}, err
 	}
 
 	rpmProvides := mapToSlice(rpmProvideMap)
-	return rpmProvides, closeFn, nil
+	return rpmProvides, nil
 }
 
-func queryJavaFileListForPackage(fileListDb sql.DB, pkgKey int) (map[string]string, error) {
+func queryJavaFileListForPackage(fileListDb *sql.DB, pkgKey int) (map[string]string, error) {
 	javaFileList := make(map[string]string, 0)
 
 	sql := `SELECT
@@ -243,8 +256,6 @@ WHERE
 	AND filenames LIKE '%.jar%' 
 	AND dirname NOT LIKE '/usr/share/java%'`
 	rows, err := fileListDb.Query(sql, pkgKey)
-	defer fileListDb.Close()
-
 	if err != nil {
 		return javaFileList, err
 	}
@@ -273,13 +284,92 @@ WHERE
 	return javaFileList, nil
 }
 
-func covertJavaFileToPackage(javaFileList map[string]string, isoFileSystem IsoFileSystem, unzipDir string, locationHref string) ([]pkg.RepodataPackageRecord, error) {
+// file types of the filetypes column of the filelists database, one character per file name
+const (
+	filelistTypeFile  = 'f'
+	filelistTypeDir   = 'd'
+	filelistTypeGhost = 'g'
+)
+
+// file type bits of the rpm file modes (S_IFREG and S_IFDIR)
+const (
+	rpmFileModeRegular pkg.RepodataFileMode = 0o100000
+	rpmFileModeDir     pkg.RepodataFileMode = 0o040000
+)
+
+// queryFileRecordsForPackage lists the files of a package from the filelists database. The database only knows the
+// path and the type of every file, so the mode carries the file type bits only and ghost files are flagged as such.
+func queryFileRecordsForPackage(fileListDb *sql.DB, pkgKey int) ([]pkg.RepodataFileRecord, error) {
+	var records []pkg.RepodataFileRecord
+
+	sql := `SELECT
+	dirname, filenames, filetypes
+FROM
+	filelist
+WHERE
+	pkgKey = ?`
+	rows, err := fileListDb.Query(sql, pkgKey)
+	if err != nil {
+		return records, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dirname string
+		var filenames string
+		var filetypes string
+		if err = rows.Scan(&dirname, &filenames, &filetypes); err != nil {
+			log.Error(err)
+			continue
+		}
+
+		for i, filename := range strings.Split(filenames, "/") {
+			if filename == "" {
+				continue
+			}
+			var fileType byte = filelistTypeFile
+			if i < len(filetypes) {
+				fileType = filetypes[i]
+			}
+			records = append(records, newRepodataFileRecord(path.Join(dirname, filename), fileType))
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Path < records[j].Path
+	})
+	return records, nil
+}
+
+func newRepodataFileRecord(filePath string, fileType byte) pkg.RepodataFileRecord {
+	record := pkg.RepodataFileRecord{Path: filePath}
+	switch fileType {
+	case filelistTypeDir:
+		record.Mode = rpmFileModeDir
+	case filelistTypeGhost:
+		record.Flags = "ghost"
+	default:
+		record.Mode = rpmFileModeRegular
+	}
+	return record
+}
+
+func covertJavaFileToPackage(javaFileList map[string]string, isoFileSystem IsoFileSystem, unzipDir string, locationHref string, index mavenIndex) ([]pkg.RepodataPackageRecord, error) {
 	javaPackages := make([]pkg.RepodataPackageRecord, 0)
 	if len(javaFileList) == 0 {
 		return javaPackages, nil
 	}
 
-	rpmUnzipDirPath, cleanupRpmTempDirFn, err := extractRpmToTempDir(isoFileSystem, unzipDir, locationHref)
+	jarPaths := make([]string, 0, len(javaFileList))
+	for _, jarPath := range javaFileList {
+		jarPaths = append(jarPaths, jarPath)
+	}
+
+	rpmUnzipDirPath, cleanupRpmTempDirFn, err := extractRpmToTempDir(isoFileSystem, unzipDir, locationHref, jarPaths...)
 	defer cleanupRpmTempDirFn()
 	if err != nil {
 		return javaPackages, err
@@ -292,51 +382,62 @@ func covertJavaFileToPackage(javaFileList map[string]string, isoFileSystem IsoFi
 			log.Debugf("file:%s is not exists, not calculate checksum", jarAbsolutePath)
 			continue
 		}
-		sha1, err := checksum.SHA1sum(jarAbsolutePath)
+		jarPackage, err := identifyJar(jarAbsolutePath, jarPath, index)
 		if err != nil {
 			log.Error(err)
 			continue
 		}
+		javaPackagesMap[strings.Join([]string{jarPackage.PkgType, jarPackage.GroupId, jarPackage.ArtifactId, jarPackage.Version}, ":")] = jarPackage
+	}
+	javaPackages = mapToSlice(javaPackagesMap)
+	return javaPackages, nil
+}
+
+// identifyJar returns the maven coordinates of a jar extracted from a rpm: from the pom.properties and manifest of the
+// jar first, then from the maven index by the sha1 of the jar. Unidentified jars are recorded by their sha1.
+func identifyJar(jarAbsolutePath string, jarPath string, index mavenIndex) (pkg.RepodataPackageRecord, error) {
+	sha1, err := checksum.SHA1sum(jarAbsolutePath)
+	if err != nil {
+		return pkg.RepodataPackageRecord{}, err
+	}
+
+	coordinates, err := identifyJarFromMetadata(jarAbsolutePath, jarPath)
+	if err != nil {
+		log.Debugf("unable to read maven metadata of jar=%q: %+v", jarPath, err)
+	}
+	if coordinates == nil {
+		if indexed, ok := index[sha1]; ok {
+			coordinates = &indexed
+		}
+	}
 
-		// restResult, err := resty.New().R().SetQueryParams(map[string]string{
-		// 	"q":    "1:" + sha1,
-		// 	"rows": "1",
-		// 	"wt":   "json",
-		// }).SetHeader("Accept", "application/json").
-		// 	Get("https://search.maven.org/solrsearch/select")
-		// if err != nil {
-		// 	log.Error(err)
-		// 	continue
-		// }
-		// mvnResult, err := jsonquery.Parse(bytes.NewReader(restResult.Body()))
-		// if err != nil {
-		// 	log.Errorf("%s`s result body: %s, error info: %v", jarPath, restResult.Body(), err)
-		// 	continue
-		// }
-		// if numFound := jsonquery.FindOne(mvnResult, "response/numFound"); numFound == nil || numFound.InnerText() == "0" {
-		// 	log.Warnf("%s `s jar:%s, can not find artifact in maven central. sha1: %s", locationHref, jarPath, sha1)
-		// 	continue
-		// }
-
-		// groupId := jsonquery.FindOne(mvnResult, "response/docs/*/g").InnerText()
-		// artifactId := jsonquery.FindOne(mvnResult, "response/docs/*/a").InnerText()
-		// version := jsonquery.FindOne(mvnResult, "response/docs/*/v").InnerText()
-
-		// TODO use config param to switch between GAV or checksum
-
-		jarPackage := pkg.RepodataPackageRecord{
+	if coordinates == nil {
+		return pkg.RepodataPackageRecord{
 			PkgType:    packageurl.TypeMaven,
 			GroupId:    purlDefaultChecksumNamespace,
 			ArtifactId: sha1,
 			Version:    purlDefaultChecksumVersion,
-		}
-		javaPackagesMap[strings.Join([]string{jarPackage.PkgType, jarPackage.GroupId, jarPackage.ArtifactId, jarPackage.Version}, ":")] = jarPackage
+		}, nil
 	}
-	javaPackages = mapToSlice(javaPackagesMap)
-	return javaPackages, nil
+	return pkg.RepodataPackageRecord{
+		PkgType:    packageurl.TypeMaven,
+		GroupId:    coordinates.GroupID,
+		ArtifactId: coordinates.ArtifactID,
+		Version:    coordinates.Version,
+	}, nil
+}
+
+func identifyJarFromMetadata(jarAbsolutePath string, jarPath string) (*java.MavenCoordinates, error) {
+	jarFile, err := os.Open(jarAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer jarFile.Close()
+
+	return java.IdentifyArchive(jarPath, jarFile)
 }
 
-func extractRpmToTempDir(isoFileSystem IsoFileSystem, unzipDir string, rpmPath string) (string, func(), error) {
+func extractRpmToTempDir(isoFileSystem IsoFileSystem, unzipDir string, rpmPath string, paths ...string) (string, func(), error) {
 	rpmFileName := filepath.Base(rpmPath)
 	rpmName := strings.TrimSuffix(rpmFileName, ".rpm")
 	rpmUnzipDirPath := filepath.Join(unzipDir, rpmName)
@@ -359,7 +460,7 @@ func extractRpmToTempDir(isoFileSystem IsoFileSystem, unzipDir string, rpmPath s
 	}
 	defer isoFileSystem.Close(rpmFile)
 
-	err = ExtractRPM(rpmFile, rpmUnzipDirPath)
+	err = ExtractRPM(rpmFile, rpmUnzipDirPath, paths...)
 	if err != nil {
 		return "", cleanupFn, err
 	}
@@ -383,66 +484,53 @@ func mapToSlice(input map[string]pkg.RepodataPackageRecord) []pkg.RepodataPackag
 	return output
 }
 
-func parseRelationship(repodataFileList RepodataFileList) ([]artifact.Relationship, error) {
-	primaryDb, err := sql.Open("sqlite", repodataFileList.PrimarySqliteUnBzFilePath)
+// parseRelationship resolves the requires of every package to DEPENDS_ON (or HAS_PREREQUISITE for pre requires)
+// relationships with its providers, and its weak dependencies to recommends, suggests, supplements and enhances
+// relationships. It also returns the requires which couldn't be resolved keyed by the id of the requiring package.
+func parseRelationship(repodataFileList RepodataFileList) ([]artifact.Relationship, map[artifact.ID][]string, error) {
+	resolver, err := newRepodataDependencyResolver(repodataFileList)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	defer primaryDb.Close()
-
-	sql := `SELECT DISTINCT
-					fromPkg.name fromPkgName,
-					fromPkg.version fromPkgVersion,
-					toPkg.name toPkgName,
-					toPkg.version toPkgVersion
-				FROM
-					requires r,
-					provides pro
-					LEFT JOIN packages AS fromPkg ON fromPkg.pkgKey = r.pkgKey 
-					LEFT JOIN packages AS toPkg ON toPkg.pkgKey = pro.pkgKey 
-				WHERE
-					r.name = pro.name 
-				ORDER BY
-					r.pkgKey,
-					pro.pkgKey`
+	packages := resolver.Packages()
+	resolution := resolver.Resolve()
 
-	rows, err := primaryDb.Query(sql)
-	if err != nil {
-		return nil, err
+	byKey := make(map[int]*rpm.Package, len(packages))
+	for _, p := range packages {
+		byKey[p.Key] = p
 	}
-	defer rows.Close()
 
 	allRelationships := make([]artifact.Relationship, 0)
-
-	for rows.Next() {
-		var fromPkgName string
-		var fromPkgVersion string
-		var toPkgName string
-		var toPkgVersion string
-
-		if err = rows.Scan(&fromPkgName, &fromPkgVersion, &toPkgName, &toPkgVersion); err != nil {
-			log.Error(err)
-			continue
-		}
-
+	seen := make(map[string]bool)
+	unresolved := make(map[artifact.ID][]string)
+	for _, p := range packages {
 		fromPkg := pkg.Package{}
-		fromPkg.OverrideID(artifact.ID(fmt.Sprintf(packageIdPattern, fromPkgName, fromPkgVersion)))
+		fromPkg.OverrideID(artifact.ID(fmt.Sprintf(packageIdPattern, p.Name, p.EVR.Version)))
 
-		toPkg := pkg.Package{}
-		toPkg.OverrideID(artifact.ID(fmt.Sprintf(packageIdPattern, toPkgName, toPkgVersion)))
+		for _, resolved := range resolution.Dependencies[p.Key] {
+			dependency := byKey[resolved.Key]
+			toPkg := pkg.Package{}
+			toPkg.OverrideID(artifact.ID(fmt.Sprintf(packageIdPattern, dependency.Name, dependency.EVR.Version)))
 
-		r := artifact.Relationship{
-			From: fromPkg,
-			To:   toPkg,
-			Type: artifact.DependsOnRelationship,
-		}
+			// packages of several architectures share the same id
+			pair := string(fromPkg.ID()) + "|" + string(toPkg.ID()) + "|" + string(resolved.Type)
+			if fromPkg.ID() == toPkg.ID() || seen[pair] {
+				continue
+			}
+			seen[pair] = true
 
-		allRelationships = append(allRelationships, r)
-	}
+			allRelationships = append(allRelationships, artifact.Relationship{
+				From: fromPkg,
+				To:   toPkg,
+				Type: resolved.Type,
+			})
+		}
 
-	if err = rows.Err(); err != nil {
-		return nil, err
+		if requires, exists := resolution.Unresolved[p.Key]; exists {
+			log.Debugf("package %s-%s has unresolved requires: %s", p.Name, p.EVR, strings.Join(requires, ", "))
+			unresolved[fromPkg.ID()] = append(unresolved[fromPkg.ID()], requires...)
+		}
 	}
 
-	return allRelationships, nil
+	return allRelationships, unresolved, nil
 }
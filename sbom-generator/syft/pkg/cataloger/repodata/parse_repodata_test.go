@@ -1,13 +1,19 @@
 package repodata
 
 import (
+	"archive/zip"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/source"
+	"github.com/codingsince1985/checksum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type excludeFn func(string) bool
@@ -103,10 +109,101 @@ func TestParseRepodata(t *testing.T) {
 	}
 	defer repodataFileList.Close(isoFileSystem)
 
-	allPkgs, _, err := parseRepodata(isoFileSystem, repodataFileList)
+	allPkgs, _, err := parseRepodata(isoFileSystem, repodataFileList, mavenIndex{}, nil)
 	if err != nil {
 		t.Errorf("Failed to parse repodata file: %+v", err)
 	} else {
 		t.Logf("all packages info length: %d", len(allPkgs))
 	}
 }
+
+func TestNewRepodataFileRecord(t *testing.T) {
+	tests := []struct {
+		fileType byte
+		expected pkg.RepodataFileRecord
+	}{
+		{
+			fileType: filelistTypeFile,
+			expected: pkg.RepodataFileRecord{Path: "/etc/ld.so.conf", Mode: rpmFileModeRegular},
+		},
+		{
+			fileType: filelistTypeDir,
+			expected: pkg.RepodataFileRecord{Path: "/etc/ld.so.conf", Mode: rpmFileModeDir},
+		},
+		{
+			fileType: filelistTypeGhost,
+			expected: pkg.RepodataFileRecord{Path: "/etc/ld.so.conf", Flags: "ghost"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.fileType), func(t *testing.T) {
+			assert.Equal(t, test.expected, newRepodataFileRecord("/etc/ld.so.conf", test.fileType))
+		})
+	}
+}
+
+func writeTestJar(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	jarPath := filepath.Join(t.TempDir(), "test.jar")
+	f, err := os.Create(jarPath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	for name, contents := range files {
+		entry, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return jarPath
+}
+
+func TestIdentifyJar(t *testing.T) {
+	withPom := writeTestJar(t, map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\n",
+		"META-INF/maven/org.apache.commons/commons-lang3/pom.properties": "groupId=org.apache.commons\nartifactId=commons-lang3\nversion=3.12.0\n",
+	})
+	anonymous := writeTestJar(t, map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\n",
+	})
+	anonymousSha1, err := checksum.SHA1sum(anonymous)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		jar      string
+		index    mavenIndex
+		expected pkg.RepodataPackageRecord
+	}{
+		{
+			name:     "pom properties",
+			jar:      withPom,
+			expected: pkg.RepodataPackageRecord{PkgType: "maven", GroupId: "org.apache.commons", ArtifactId: "commons-lang3", Version: "3.12.0"},
+		},
+		{
+			name: "maven index",
+			jar:  anonymous,
+			index: mavenIndex{
+				anonymousSha1: {GroupID: "org.example", ArtifactID: "anonymous", Version: "2.0"},
+			},
+			expected: pkg.RepodataPackageRecord{PkgType: "maven", GroupId: "org.example", ArtifactId: "anonymous", Version: "2.0"},
+		},
+		{
+			name:     "unidentified",
+			jar:      anonymous,
+			expected: pkg.RepodataPackageRecord{PkgType: "maven", GroupId: "sha1", ArtifactId: anonymousSha1, Version: "1.0.0"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			record, err := identifyJar(test.jar, "/usr/lib/java/test.jar", test.index)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, record)
+		})
+	}
+}
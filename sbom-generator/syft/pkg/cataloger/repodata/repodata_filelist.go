@@ -15,6 +15,13 @@ type RepodataFileList struct {
 	OtherSqliteBzFilePath       string
 	OtherSqliteBzFile           io.Reader
 	OtherSqliteUnBzFilePath     string
+	// xml metadata, used when the repository ships no sqlite databases (e.g. createrepo_c --no-database)
+	IsXml                bool
+	PrimaryXmlFilePath   string
+	PrimaryXmlFile       io.Reader
+	FilelistsXmlFilePath string
+	FilelistsXmlFile     io.Reader
+	OtherXmlFilePath     string
 }
 
 func (fileList RepodataFileList) IsFindAllFilesPath() error {
@@ -33,6 +40,26 @@ func (fileList RepodataFileList) IsFindAllFilesPath() error {
 	return nil
 }
 
+func (fileList RepodataFileList) IsFindAllXmlFilesPath() error {
+	if fileList.PrimaryXmlFilePath == "" {
+		return fmt.Errorf("primary xml file of not found")
+	}
+
+	if fileList.FilelistsXmlFilePath == "" {
+		return fmt.Errorf("filelists xml file of not found")
+	}
+
+	return nil
+}
+
+// PrimaryFilePath returns the path of the primary metadata the packages are read from (sqlite database or xml).
+func (fileList RepodataFileList) PrimaryFilePath() string {
+	if fileList.IsXml {
+		return fileList.PrimaryXmlFilePath
+	}
+	return fileList.PrimarySqliteBzFilePath
+}
+
 func (fileList RepodataFileList) Close(isoFS IsoFileSystem) bool {
 	if fileList.PrimarySqliteBzFile != nil {
 		isoFS.Close(fileList.PrimarySqliteBzFile)
@@ -46,5 +73,13 @@ func (fileList RepodataFileList) Close(isoFS IsoFileSystem) bool {
 		isoFS.Close(fileList.OtherSqliteBzFile)
 	}
 
+	if fileList.PrimaryXmlFile != nil {
+		isoFS.Close(fileList.PrimaryXmlFile)
+	}
+
+	if fileList.FilelistsXmlFile != nil {
+		isoFS.Close(fileList.FilelistsXmlFile)
+	}
+
 	return true
 }
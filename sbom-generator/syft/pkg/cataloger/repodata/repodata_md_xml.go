@@ -49,6 +49,12 @@ func readRepoMdXML(reader io.Reader) (RepodataFileList, error) {
 			repodataFileList.FilelistsSqliteBzFilePath = data.Location.Path
 		} else if data.Type == "other_db" {
 			repodataFileList.OtherSqliteBzFilePath = data.Location.Path
+		} else if data.Type == "primary" {
+			repodataFileList.PrimaryXmlFilePath = data.Location.Path
+		} else if data.Type == "filelists" {
+			repodataFileList.FilelistsXmlFilePath = data.Location.Path
+		} else if data.Type == "other" {
+			repodataFileList.OtherXmlFilePath = data.Location.Path
 		}
 	}
 
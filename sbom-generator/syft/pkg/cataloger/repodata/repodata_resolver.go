@@ -2,6 +2,7 @@ package repodata
 
 import (
 	"compress/bzip2"
+	"compress/gzip"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -13,10 +14,8 @@ import (
 	"github.com/anchore/syft/internal/file"
 	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/syft/source"
+	"github.com/klauspost/compress/zstd"
 	"github.com/xi2/xz"
-
-	"github.com/cavaliergopher/cpio"
-	"github.com/cavaliergopher/rpm"
 )
 
 func resolverRepodataFile(isoFS IsoFileSystem, xmlReader io.Reader) (RepodataFileList, error) {
@@ -24,7 +23,11 @@ func resolverRepodataFile(isoFS IsoFileSystem, xmlReader io.Reader) (RepodataFil
 	if err != nil {
 		return RepodataFileList{}, err
 	} else if err := repodataFileList.IsFindAllFilesPath(); err != nil { // 需要三个文件地址同时都获取到
-		return RepodataFileList{}, err
+		// repositories generated without databases only ship the xml metadata
+		if xmlErr := repodataFileList.IsFindAllXmlFilesPath(); xmlErr != nil {
+			return RepodataFileList{}, fmt.Errorf("%v, and %w", err, xmlErr)
+		}
+		return resolverRepodataXmlFile(isoFS, repodataFileList)
 	}
 
 	primarySqliteBzFile, err := isoFS.OpenFile(repodataFileList.PrimarySqliteBzFilePath, os.O_RDONLY)
@@ -48,6 +51,25 @@ func resolverRepodataFile(isoFS IsoFileSystem, xmlReader io.Reader) (RepodataFil
 	return repodataFileList, err
 }
 
+func resolverRepodataXmlFile(isoFS IsoFileSystem, repodataFileList RepodataFileList) (RepodataFileList, error) {
+	repodataFileList.IsXml = true
+
+	primaryXmlFile, err := isoFS.OpenFile(repodataFileList.PrimaryXmlFilePath, os.O_RDONLY)
+	if err != nil {
+		return RepodataFileList{}, err
+	}
+	repodataFileList.PrimaryXmlFile = primaryXmlFile
+
+	filelistsXmlFile, err := isoFS.OpenFile(repodataFileList.FilelistsXmlFilePath, os.O_RDONLY)
+	if err != nil {
+		isoFS.Close(primaryXmlFile)
+		return RepodataFileList{}, err
+	}
+	repodataFileList.FilelistsXmlFile = filelistsXmlFile
+
+	return repodataFileList, nil
+}
+
 func isLocationDir(location source.Location) bool {
 	fileMeta, err := os.Stat(location.RealPath)
 	if err != nil {
@@ -79,25 +101,24 @@ func createRepodataTempDir() (string, func(), error) {
 	}
 
 	// FIXME: remove Println
-	fmt.Printf("repodata temp dir: %s \n", repodataTempPath)
 	log.Infof("repodata temp dir: %s", repodataTempPath)
 	return repodataTempPath, cleanupFn, nil
 }
 
-func unBzip2ForRepodata(repodataFileList RepodataFileList, unzipDir string) (RepodataFileList, error) {
-	primarySqliteUnBzFilePath, err := unBzip2SqliteFile(repodataFileList.PrimarySqliteBzFilePath, repodataFileList.PrimarySqliteBzFile, unzipDir)
+func decompressRepodata(repodataFileList RepodataFileList, unzipDir string) (RepodataFileList, error) {
+	primarySqliteUnBzFilePath, err := decompressSqliteFile(repodataFileList.PrimarySqliteBzFilePath, repodataFileList.PrimarySqliteBzFile, unzipDir)
 	if err != nil {
 		return repodataFileList, err
 	}
 	repodataFileList.PrimarySqliteUnBzFilePath = primarySqliteUnBzFilePath
 
-	filelistsSqliteUnBzFilePath, err := unBzip2SqliteFile(repodataFileList.FilelistsSqliteBzFilePath, repodataFileList.FilelistsSqliteBzFile, unzipDir)
+	filelistsSqliteUnBzFilePath, err := decompressSqliteFile(repodataFileList.FilelistsSqliteBzFilePath, repodataFileList.FilelistsSqliteBzFile, unzipDir)
 	if err != nil {
 		return repodataFileList, err
 	}
 	repodataFileList.FilelistsSqliteUnBzFilePath = filelistsSqliteUnBzFilePath
 
-	otherSqliteUnBzFilePath, err := unBzip2SqliteFile(repodataFileList.OtherSqliteBzFilePath, repodataFileList.OtherSqliteBzFile, unzipDir)
+	otherSqliteUnBzFilePath, err := decompressSqliteFile(repodataFileList.OtherSqliteBzFilePath, repodataFileList.OtherSqliteBzFile, unzipDir)
 	if err != nil {
 		return repodataFileList, err
 	}
@@ -106,23 +127,66 @@ func unBzip2ForRepodata(repodataFileList RepodataFileList, unzipDir string) (Rep
 	return repodataFileList, nil
 }
 
-func unBzip2SqliteFile(bzip2FilePath string, bzip2File io.Reader, unzipDir string) (string, error) {
-	bzip2FileName := filepath.Base(bzip2FilePath)
-	unBzip2FileName := strings.TrimSuffix(bzip2FileName, ".bz2")
-	unBzip2FilePath := filepath.Join(unzipDir, unBzip2FileName)
-	dstWriter, err := os.Create(unBzip2FilePath)
+func decompressSqliteFile(compressedFilePath string, compressedFile io.Reader, unzipDir string) (string, error) {
+	sourceReader, closeFn, err := newDecompressReader(compressedFilePath, compressedFile)
 	if err != nil {
-		return unBzip2FilePath, err
+		return "", err
 	}
-	defer dstWriter.Close()
+	defer closeFn()
 
-	sourceReader := bzip2.NewReader(bzip2File)
+	unzipFileName := strings.TrimSuffix(filepath.Base(compressedFilePath), filepath.Ext(compressedFilePath))
+	if !isCompressedFile(compressedFilePath) {
+		unzipFileName = filepath.Base(compressedFilePath)
+	}
+	unzipFilePath := filepath.Join(unzipDir, unzipFileName)
+	dstWriter, err := os.Create(unzipFilePath)
+	if err != nil {
+		return unzipFilePath, err
+	}
+	defer dstWriter.Close()
 
 	if err := file.SafeCopy(dstWriter, sourceReader); err != nil {
-		return unBzip2FilePath, fmt.Errorf("unable to copy source=%q for tar=%q: %w", bzip2File, unBzip2FilePath, err)
+		return unzipFilePath, fmt.Errorf("unable to copy source=%q for file=%q: %w", compressedFilePath, unzipFilePath, err)
+	}
+
+	return unzipFilePath, nil
+}
+
+func isCompressedFile(filePath string) bool {
+	switch filepath.Ext(filePath) {
+	case ".bz2", ".gz", ".xz", ".zst":
+		return true
 	}
+	return false
+}
 
-	return unBzip2FilePath, nil
+// newDecompressReader wraps the reader of a repodata file with the decompression matching the file extension,
+// createrepo_c compresses the metadata with bzip2 (default for databases), gzip (default for xml), xz or zstd.
+func newDecompressReader(filePath string, reader io.Reader) (io.Reader, func(), error) {
+	noopFn := func() {}
+	switch filepath.Ext(filePath) {
+	case ".bz2":
+		return bzip2.NewReader(reader), noopFn, nil
+	case ".gz":
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, noopFn, fmt.Errorf("unable to read gzip file=%q: %w", filePath, err)
+		}
+		return gzipReader, func() { gzipReader.Close() }, nil
+	case ".xz":
+		xzReader, err := xz.NewReader(reader, 0)
+		if err != nil {
+			return nil, noopFn, fmt.Errorf("unable to read xz file=%q: %w", filePath, err)
+		}
+		return xzReader, noopFn, nil
+	case ".zst":
+		zstdReader, err := zstd.NewReader(reader)
+		if err != nil {
+			return nil, noopFn, fmt.Errorf("unable to read zstd file=%q: %w", filePath, err)
+		}
+		return zstdReader, zstdReader.Close, nil
+	}
+	return reader, noopFn, nil
 }
 
 func createDirIfNotExist(targetPath string) error {
@@ -145,54 +209,8 @@ func createDirIfNotExist(targetPath string) error {
 	return err
 }
 
-func ExtractRPM(rpmFile io.Reader, targetDir string) error {
-	pkg, err := rpm.Read(rpmFile)
-	if err != nil {
-		return err
-	}
-
-	xzReader, err := xz.NewReader(rpmFile, 0)
-	if err != nil {
-		return err
-	}
-
-	if format := pkg.PayloadFormat(); format != "cpio" {
-		return fmt.Errorf("unsupported payload format: %s", format)
-	}
-
-	cpioReader := cpio.NewReader(xzReader)
-	for {
-		hdr, err := cpioReader.Next()
-		if err == io.EOF {
-			break // no more files
-		}
-		if err != nil {
-			return err
-		}
-
-		// Skip directories and other irregular file types in this example
-		if !hdr.Mode.IsRegular() {
-			continue
-		}
-		if !strings.HasSuffix(hdr.Name, ".jar") {
-			continue
-		}
-
-		if dirName := filepath.Dir(hdr.Name); dirName != "" {
-			if err := os.MkdirAll(filepath.Join(targetDir, dirName), 0o755); err != nil {
-				return err
-			}
-		}
-
-		outFile, err := os.Create(filepath.Join(targetDir, hdr.Name))
-		if err != nil {
-			return err
-		}
-		if _, err := io.Copy(outFile, cpioReader); err != nil {
-			outFile.Close()
-			return err
-		}
-		outFile.Close()
-	}
-	return nil
+// ExtractRPM extracts select paths (all files when none are given) of the payload of the given rpm to the target
+// directory, whatever the payload compression of the rpm.
+func ExtractRPM(rpmFile io.Reader, targetDir string, paths ...string) error {
+	return file.ExtractRpmToDir(rpmFile, targetDir, paths...)
 }
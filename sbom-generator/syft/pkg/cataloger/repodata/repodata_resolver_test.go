@@ -0,0 +1,48 @@
+package repodata
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDecompressReader(t *testing.T) {
+	expected, err := ioutil.ReadFile("test-fixtures/compressed/repomd.xml")
+	require.NoError(t, err)
+
+	tests := []string{
+		"test-fixtures/compressed/repomd.xml",
+		"test-fixtures/compressed/repomd.xml.gz",
+		"test-fixtures/compressed/repomd.xml.bz2",
+		"test-fixtures/compressed/repomd.xml.xz",
+		"test-fixtures/compressed/repomd.xml.zst",
+	}
+
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			f, err := os.Open(test)
+			require.NoError(t, err)
+			defer f.Close()
+
+			reader, closeFn, err := newDecompressReader(test, f)
+			require.NoError(t, err)
+			defer closeFn()
+
+			actual, err := ioutil.ReadAll(reader)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(actual))
+		})
+	}
+}
+
+func TestIsCompressedFile(t *testing.T) {
+	assert.True(t, isCompressedFile("repodata/primary.sqlite.bz2"))
+	assert.True(t, isCompressedFile("repodata/primary.xml.gz"))
+	assert.True(t, isCompressedFile("repodata/primary.xml.xz"))
+	assert.True(t, isCompressedFile("repodata/primary.xml.zst"))
+	assert.False(t, isCompressedFile("repodata/primary.sqlite"))
+	assert.False(t, isCompressedFile("repodata/repomd.xml"))
+}
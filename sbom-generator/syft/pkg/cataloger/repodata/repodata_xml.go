@@ -0,0 +1,378 @@
+package repodata
+
+import (
+	"database/sql"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/anchore/syft/internal/log"
+)
+
+// the xml metadata is loaded into databases with the same schema createrepo_c generates (see sqliterepo_c), so that
+// packages and relationships are parsed the same way from either representation.
+const primarySchema = `
+CREATE TABLE db_info (dbversion INTEGER, checksum TEXT);
+CREATE TABLE packages (  pkgKey INTEGER PRIMARY KEY,  pkgId TEXT,  name TEXT,  arch TEXT,  version TEXT,  epoch TEXT,  release TEXT,  summary TEXT,  description TEXT,  url TEXT,  time_file INTEGER,  time_build INTEGER,  rpm_license TEXT,  rpm_vendor TEXT,  rpm_group TEXT,  rpm_buildhost TEXT,  rpm_sourcerpm TEXT,  rpm_header_start INTEGER,  rpm_header_end INTEGER,  rpm_packager TEXT,  size_package INTEGER,  size_installed INTEGER,  size_archive INTEGER,  location_href TEXT,  location_base TEXT,  checksum_type TEXT);
+CREATE TABLE files (  name TEXT,  type TEXT,  pkgKey INTEGER);
+CREATE TABLE requires (  name TEXT,  flags TEXT,  epoch TEXT,  version TEXT,  release TEXT,  pkgKey INTEGER , pre BOOLEAN DEFAULT FALSE);
+CREATE TABLE provides (  name TEXT,  flags TEXT,  epoch TEXT,  version TEXT,  release TEXT,  pkgKey INTEGER );
+CREATE TABLE conflicts (  name TEXT,  flags TEXT,  epoch TEXT,  version TEXT,  release TEXT,  pkgKey INTEGER );
+CREATE TABLE obsoletes (  name TEXT,  flags TEXT,  epoch TEXT,  version TEXT,  release TEXT,  pkgKey INTEGER );
+CREATE TABLE suggests (  name TEXT,  flags TEXT,  epoch TEXT,  version TEXT,  release TEXT,  pkgKey INTEGER );
+CREATE TABLE enhances (  name TEXT,  flags TEXT,  epoch TEXT,  version TEXT,  release TEXT,  pkgKey INTEGER );
+CREATE TABLE recommends (  name TEXT,  flags TEXT,  epoch TEXT,  version TEXT,  release TEXT,  pkgKey INTEGER );
+CREATE TABLE supplements (  name TEXT,  flags TEXT,  epoch TEXT,  version TEXT,  release TEXT,  pkgKey INTEGER );`
+
+const filelistsSchema = `
+CREATE TABLE db_info (dbversion INTEGER, checksum TEXT);
+CREATE TABLE packages (  pkgKey INTEGER PRIMARY KEY,  pkgId TEXT);
+CREATE TABLE filelist (  pkgKey INTEGER,  dirname TEXT,  filenames TEXT,  filetypes TEXT);`
+
+const primaryXmlSqliteFileName = "primary.sqlite"
+const filelistsXmlSqliteFileName = "filelists.sqlite"
+
+var dependencyTables = []string{"provides", "requires", "conflicts", "obsoletes", "suggests", "enhances", "recommends", "supplements"}
+
+type XmlPackage struct {
+	Name        string      `xml:"name"`
+	Arch        string      `xml:"arch"`
+	Version     XmlVersion  `xml:"version"`
+	Checksum    XmlChecksum `xml:"checksum"`
+	Summary     string      `xml:"summary"`
+	Description string      `xml:"description"`
+	Packager    string      `xml:"packager"`
+	Url         string      `xml:"url"`
+	Time        XmlTime     `xml:"time"`
+	Size        XmlSize     `xml:"size"`
+	Location    SLocation   `xml:"location"`
+	Format      XmlFormat   `xml:"format"`
+}
+
+type XmlVersion struct {
+	Epoch   string `xml:"epoch,attr"`
+	Version string `xml:"ver,attr"`
+	Release string `xml:"rel,attr"`
+}
+
+type XmlChecksum struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type XmlTime struct {
+	File  int `xml:"file,attr"`
+	Build int `xml:"build,attr"`
+}
+
+type XmlSize struct {
+	Package   int `xml:"package,attr"`
+	Installed int `xml:"installed,attr"`
+	Archive   int `xml:"archive,attr"`
+}
+
+type XmlHeaderRange struct {
+	Start int `xml:"start,attr"`
+	End   int `xml:"end,attr"`
+}
+
+type XmlFormat struct {
+	License     string         `xml:"license"`
+	Vendor      string         `xml:"vendor"`
+	Group       string         `xml:"group"`
+	BuildHost   string         `xml:"buildhost"`
+	SourceRpm   string         `xml:"sourcerpm"`
+	HeaderRange XmlHeaderRange `xml:"header-range"`
+	Provides    []XmlEntry     `xml:"provides>entry"`
+	Requires    []XmlEntry     `xml:"requires>entry"`
+	Conflicts   []XmlEntry     `xml:"conflicts>entry"`
+	Obsoletes   []XmlEntry     `xml:"obsoletes>entry"`
+	Suggests    []XmlEntry     `xml:"suggests>entry"`
+	Enhances    []XmlEntry     `xml:"enhances>entry"`
+	Recommends  []XmlEntry     `xml:"recommends>entry"`
+	Supplements []XmlEntry     `xml:"supplements>entry"`
+	Files       []XmlFile      `xml:"file"`
+}
+
+func (f XmlFormat) entries(table string) []XmlEntry {
+	switch table {
+	case "provides":
+		return f.Provides
+	case "requires":
+		return f.Requires
+	case "conflicts":
+		return f.Conflicts
+	case "obsoletes":
+		return f.Obsoletes
+	case "suggests":
+		return f.Suggests
+	case "enhances":
+		return f.Enhances
+	case "recommends":
+		return f.Recommends
+	case "supplements":
+		return f.Supplements
+	}
+	return nil
+}
+
+type XmlEntry struct {
+	Name    string `xml:"name,attr"`
+	Flags   string `xml:"flags,attr"`
+	Epoch   string `xml:"epoch,attr"`
+	Version string `xml:"ver,attr"`
+	Release string `xml:"rel,attr"`
+	Pre     string `xml:"pre,attr"`
+}
+
+type XmlFile struct {
+	Type string `xml:"type,attr"`
+	Path string `xml:",chardata"`
+}
+
+type XmlFilelistsPackage struct {
+	PkgId string    `xml:"pkgid,attr"`
+	Name  string    `xml:"name,attr"`
+	Arch  string    `xml:"arch,attr"`
+	Files []XmlFile `xml:"file"`
+}
+
+// convertXmlToSqlite streams the primary and filelists xml metadata into sqlite databases within the temp dir.
+// The other metadata (changelogs) is not used by the cataloger and is not converted.
+func convertXmlToSqlite(repodataFileList RepodataFileList, unzipDir string) (RepodataFileList, error) {
+	primarySqliteFilePath := filepath.Join(unzipDir, primaryXmlSqliteFileName)
+	pkgKeys, err := convertPrimaryXml(repodataFileList.PrimaryXmlFilePath, repodataFileList.PrimaryXmlFile, primarySqliteFilePath)
+	if err != nil {
+		return repodataFileList, fmt.Errorf("unable to convert primary xml=%q: %w", repodataFileList.PrimaryXmlFilePath, err)
+	}
+	repodataFileList.PrimarySqliteUnBzFilePath = primarySqliteFilePath
+
+	filelistsSqliteFilePath := filepath.Join(unzipDir, filelistsXmlSqliteFileName)
+	err = convertFilelistsXml(repodataFileList.FilelistsXmlFilePath, repodataFileList.FilelistsXmlFile, filelistsSqliteFilePath, pkgKeys)
+	if err != nil {
+		return repodataFileList, fmt.Errorf("unable to convert filelists xml=%q: %w", repodataFileList.FilelistsXmlFilePath, err)
+	}
+	repodataFileList.FilelistsSqliteUnBzFilePath = filelistsSqliteFilePath
+
+	return repodataFileList, nil
+}
+
+// streamXmlPackages decodes each <package> element of a (compressed) xml metadata file one at a time, so that the
+// whole document is never held in memory.
+func streamXmlPackages(xmlFilePath string, xmlFile io.Reader, handleFn func(*xml.Decoder, *xml.StartElement) error) error {
+	reader, closeFn, err := newDecompressReader(xmlFilePath, xmlFile)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	decoder := xml.NewDecoder(reader)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if element, ok := token.(xml.StartElement); ok && element.Name.Local == "package" {
+			if err := handleFn(decoder, &element); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func createSqlite(sqliteFilePath string, schema string) (*sql.DB, *sql.Tx, error) {
+	db, err := sql.Open("sqlite", sqliteFilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+	return db, tx, nil
+}
+
+func convertPrimaryXml(xmlFilePath string, xmlFile io.Reader, sqliteFilePath string) (map[string]int64, error) {
+	db, tx, err := createSqlite(sqliteFilePath, primarySchema)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	defer tx.Rollback()
+
+	packageStmt, err := tx.Prepare(`INSERT INTO packages (pkgId, name, arch, version, epoch, release, summary, description, url, time_file, time_build, rpm_license, rpm_vendor, rpm_group, rpm_buildhost, rpm_sourcerpm, rpm_header_start, rpm_header_end, rpm_packager, size_package, size_installed, size_archive, location_href, location_base, checksum_type) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return nil, err
+	}
+	defer packageStmt.Close()
+
+	fileStmt, err := tx.Prepare(`INSERT INTO files (name, type, pkgKey) VALUES (?, ?, ?)`)
+	if err != nil {
+		return nil, err
+	}
+	defer fileStmt.Close()
+
+	entryStmts := make(map[string]*sql.Stmt, len(dependencyTables))
+	for _, table := range dependencyTables {
+		insertSql := fmt.Sprintf(`INSERT INTO %s (name, flags, epoch, version, release, pkgKey) VALUES (?, ?, ?, ?, ?, ?)`, table)
+		if table == "requires" {
+			insertSql = `INSERT INTO requires (name, flags, epoch, version, release, pkgKey, pre) VALUES (?, ?, ?, ?, ?, ?, ?)`
+		}
+		stmt, err := tx.Prepare(insertSql)
+		if err != nil {
+			return nil, err
+		}
+		defer stmt.Close()
+		entryStmts[table] = stmt
+	}
+
+	pkgKeys := make(map[string]int64)
+	err = streamXmlPackages(xmlFilePath, xmlFile, func(decoder *xml.Decoder, element *xml.StartElement) error {
+		var p XmlPackage
+		if err := decoder.DecodeElement(&p, element); err != nil {
+			return err
+		}
+
+		result, err := packageStmt.Exec(p.Checksum.Value, p.Name, p.Arch, p.Version.Version, p.Version.Epoch, p.Version.Release,
+			p.Summary, p.Description, p.Url, p.Time.File, p.Time.Build, p.Format.License, p.Format.Vendor, p.Format.Group,
+			p.Format.BuildHost, p.Format.SourceRpm, p.Format.HeaderRange.Start, p.Format.HeaderRange.End, p.Packager,
+			p.Size.Package, p.Size.Installed, p.Size.Archive, p.Location.Path, nil, p.Checksum.Type)
+		if err != nil {
+			return err
+		}
+		pkgKey, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		pkgKeys[p.Checksum.Value] = pkgKey
+
+		for _, table := range dependencyTables {
+			for _, entry := range p.Format.entries(table) {
+				args := []interface{}{entry.Name, nullString(entry.Flags), nullString(entry.Epoch), nullString(entry.Version), nullString(entry.Release), pkgKey}
+				if table == "requires" {
+					args = append(args, entry.Pre == "1")
+				}
+				if _, err := entryStmts[table].Exec(args...); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, f := range p.Format.Files {
+			if _, err := fileStmt.Exec(f.Path, xmlFileType(f.Type), pkgKey); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pkgKeys, tx.Commit()
+}
+
+// convertFilelistsXml loads the file lists, keyed by the pkgKey of the package with the same pkgId in the primary
+// database. Files are grouped per directory like createrepo_c does ("/" separated names with one type char each).
+func convertFilelistsXml(xmlFilePath string, xmlFile io.Reader, sqliteFilePath string, pkgKeys map[string]int64) error {
+	db, tx, err := createSqlite(sqliteFilePath, filelistsSchema)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	defer tx.Rollback()
+
+	packageStmt, err := tx.Prepare(`INSERT INTO packages (pkgKey, pkgId) VALUES (?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer packageStmt.Close()
+
+	filelistStmt, err := tx.Prepare(`INSERT INTO filelist (pkgKey, dirname, filenames, filetypes) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer filelistStmt.Close()
+
+	err = streamXmlPackages(xmlFilePath, xmlFile, func(decoder *xml.Decoder, element *xml.StartElement) error {
+		var p XmlFilelistsPackage
+		if err := decoder.DecodeElement(&p, element); err != nil {
+			return err
+		}
+
+		pkgKey, exists := pkgKeys[p.PkgId]
+		if !exists {
+			log.Debugf("package %s (pkgid=%s) of filelists is not found in primary", p.Name, p.PkgId)
+			return nil
+		}
+
+		if _, err := packageStmt.Exec(pkgKey, p.PkgId); err != nil {
+			return err
+		}
+
+		var dirnames []string
+		filenames := make(map[string][]string)
+		filetypes := make(map[string]string)
+		for _, f := range p.Files {
+			dirname, filename := path.Split(f.Path)
+			if dirname != "/" {
+				dirname = strings.TrimSuffix(dirname, "/")
+			}
+			if _, exists := filenames[dirname]; !exists {
+				dirnames = append(dirnames, dirname)
+			}
+			filenames[dirname] = append(filenames[dirname], filename)
+			filetypes[dirname] += xmlFileTypeChar(f.Type)
+		}
+
+		for _, dirname := range dirnames {
+			if _, err := filelistStmt.Exec(pkgKey, dirname, strings.Join(filenames[dirname], "/"), filetypes[dirname]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func xmlFileType(fileType string) string {
+	if fileType == "" {
+		return "file"
+	}
+	return fileType
+}
+
+func xmlFileTypeChar(fileType string) string {
+	switch fileType {
+	case "dir":
+		return "d"
+	case "ghost":
+		return "g"
+	}
+	return "f"
+}
+
+func nullString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,137 @@
+package repodata
+
+import (
+	"path"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/golang"
+	"github.com/anchore/syft/syft/pkg/cataloger/java"
+	"github.com/anchore/syft/syft/pkg/cataloger/javascript"
+	"github.com/anchore/syft/syft/pkg/cataloger/python"
+	"github.com/anchore/syft/syft/source"
+)
+
+// payloadCataloger is a cataloger run over the contents of a rpm (see the Cataloger interface of the cataloger package)
+type payloadCataloger interface {
+	Name() string
+	Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error)
+}
+
+// payloadCatalogers returns the language catalogers run over the payload of every rpm when unpacking rpms, nil when
+// the rpms are not unpacked.
+func payloadCatalogers(cfg Config) []payloadCataloger {
+	if !cfg.UnpackRpms {
+		return nil
+	}
+	return []payloadCataloger{
+		golang.NewGoModuleBinaryCataloger(),
+		python.NewPythonPackageCataloger(),
+		javascript.NewJavascriptPackageCataloger(),
+		java.NewJavaCataloger(cfg.Java),
+	}
+}
+
+// catalogRpmPayloads unpacks the rpm of every package (rpmLocations[i] is the rpm of rpmPkgs[i]) and catalogs its
+// contents with the given catalogers, the packages found are contained by the rpm package. Every rpm is unpacked once,
+// even when several packages of the repodata share it.
+func catalogRpmPayloads(isoFileSystem IsoFileSystem, unzipDir string, rpmPkgs []pkg.Package, rpmLocations []string, catalogers []payloadCataloger) ([]pkg.Package, []artifact.Relationship) {
+	var allPkgs []pkg.Package
+	var allRelationships []artifact.Relationship
+
+	unpacked := make(map[string]bool)
+	for i, rpmPkg := range rpmPkgs {
+		locationHref := rpmLocations[i]
+		if unpacked[locationHref] {
+			continue
+		}
+		unpacked[locationHref] = true
+
+		pkgs, relationships, err := catalogRpmPayload(isoFileSystem, unzipDir, locationHref, catalogers)
+		if err != nil {
+			log.Warnf("unable to catalog the contents of rpm=%q: %+v", locationHref, err)
+			continue
+		}
+
+		for _, p := range pkgs {
+			allRelationships = append(allRelationships, artifact.Relationship{
+				From: rpmPkg,
+				To:   p,
+				Type: artifact.ContainsRelationship,
+			})
+		}
+		allPkgs = append(allPkgs, pkgs...)
+		allRelationships = append(allRelationships, relationships...)
+	}
+
+	return allPkgs, allRelationships
+}
+
+func catalogRpmPayload(isoFileSystem IsoFileSystem, unzipDir string, locationHref string, catalogers []payloadCataloger) ([]pkg.Package, []artifact.Relationship, error) {
+	rpmUnzipDirPath, cleanupFn, err := extractRpmToTempDir(isoFileSystem, unzipDir, locationHref)
+	defer cleanupFn()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	src, err := source.NewFromDirectory(rpmUnzipDirPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	resolver, err := src.FileResolver(source.SquashedScope)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var allPkgs []pkg.Package
+	var allRelationships []artifact.Relationship
+	for _, c := range catalogers {
+		pkgs, relationships, err := c.Catalog(resolver)
+		if err != nil {
+			log.Warnf("cataloger=%q failed on the contents of rpm=%q: %+v", c.Name(), locationHref, err)
+			continue
+		}
+
+		pkgs, relationships = relocatePayloadPackages(locationHref, pkgs, relationships)
+		allPkgs = append(allPkgs, pkgs...)
+		allRelationships = append(allRelationships, relationships...)
+	}
+
+	return allPkgs, allRelationships, nil
+}
+
+// relocatePayloadPackages locates the packages found within the payload of a rpm by their path within the rpm (the
+// path of the extracted files is meaningless once the rpm is cleaned up). Relationships between the packages are kept,
+// relationships to the extracted files are not.
+func relocatePayloadPackages(locationHref string, pkgs []pkg.Package, relationships []artifact.Relationship) ([]pkg.Package, []artifact.Relationship) {
+	relocated := make(map[artifact.ID]pkg.Package)
+	for i, p := range pkgs {
+		var locations []source.Location
+		for _, l := range p.Locations.ToSlice() {
+			payloadPath := path.Join("/", l.RealPath)
+			locations = append(locations, source.NewVirtualLocation(payloadPath, locationHref+":"+payloadPath))
+		}
+
+		id := p.ID()
+		pkgs[i].Locations = source.NewLocationSet(locations...)
+		pkgs[i].SetID()
+		relocated[id] = pkgs[i]
+	}
+
+	var kept []artifact.Relationship
+	for _, r := range relationships {
+		from, fromOk := relocated[r.From.ID()]
+		to, toOk := relocated[r.To.ID()]
+		if !fromOk || !toOk {
+			continue
+		}
+		kept = append(kept, artifact.Relationship{
+			From: from,
+			To:   to,
+			Type: r.Type,
+			Data: r.Data,
+		})
+	}
+	return pkgs, kept
+}
@@ -0,0 +1,58 @@
+package repodata
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogRpmPayloads(t *testing.T) {
+	src := source.NewFromRepo(repoFixture)
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+	isoFileSystem, err := InitIsoFileSystem(resolver)
+	require.NoError(t, err)
+
+	newRpmPkg := func(arch string) pkg.Package {
+		p := pkg.Package{
+			Name:         "bash",
+			Version:      "5.1.8-6.oe2203",
+			Type:         pkg.RepodataPkg,
+			MetadataType: pkg.RpmRepodataType,
+			Metadata:     pkg.RpmRepodata{Name: "bash", Version: "5.1.8", Release: "6.oe2203", Arch: arch},
+		}
+		// the ID of a repodata package doesn't tell the architectures apart
+		p.OverrideID("rpm-bash-5.1.8")
+		return p
+	}
+	rpmPkgs := []pkg.Package{newRpmPkg("x86_64"), newRpmPkg("aarch64"), newRpmPkg("x86_64")}
+	rpmLocations := []string{
+		"Packages/bash-5.1.8-6.oe2203.x86_64.rpm",
+		// not part of the fixture
+		"Packages/bash-5.1.8-6.oe2203.aarch64.rpm",
+		// listed twice, it is unpacked once
+		"Packages/bash-5.1.8-6.oe2203.x86_64.rpm",
+	}
+
+	pkgs, relationships := catalogRpmPayloads(isoFileSystem, t.TempDir(), rpmPkgs, rpmLocations, payloadCatalogers(Config{UnpackRpms: true}))
+
+	var names []string
+	for _, p := range pkgs {
+		names = append(names, p.Name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"bashlex", "shell-quote"}, names)
+
+	var contained int
+	for _, r := range relationships {
+		if r.Type == artifact.ContainsRelationship {
+			contained++
+		}
+	}
+	assert.Equal(t, 2, contained)
+}
@@ -0,0 +1,85 @@
+#!/usr/bin/env bash
+# generates the yum repository fixtures from the sources in repo-src, the same way createrepo_c lays out the metadata
+# (compressed, named by checksum and referenced from repomd.xml):
+#   repo/repodata       the sqlite databases (bzip2), as found on the openEuler ISOs
+#   repo-xml/repodata   only the xml metadata (gzip), as generated with "createrepo_c --no-database"
+#   compressed          a sample file for each supported compression
+set -eux
+
+SRC_DIR=repo-src
+REPODATA_DIR=repo/repodata
+XML_REPODATA_DIR=repo-xml/repodata
+COMPRESSED_DIR=compressed
+TIMESTAMP=1648000000
+
+rm -rf "${REPODATA_DIR}"
+mkdir -p "${REPODATA_DIR}"
+
+data_entries=""
+for db in primary filelists other; do
+  sqlite3 "${REPODATA_DIR}/${db}.sqlite" < "${SRC_DIR}/${db}.sql"
+  open_checksum=$(sha256sum "${REPODATA_DIR}/${db}.sqlite" | cut -d' ' -f1)
+  open_size=$(stat -c %s "${REPODATA_DIR}/${db}.sqlite")
+
+  bzip2 -9 "${REPODATA_DIR}/${db}.sqlite"
+  checksum=$(sha256sum "${REPODATA_DIR}/${db}.sqlite.bz2" | cut -d' ' -f1)
+  size=$(stat -c %s "${REPODATA_DIR}/${db}.sqlite.bz2")
+  mv "${REPODATA_DIR}/${db}.sqlite.bz2" "${REPODATA_DIR}/${checksum}-${db}.sqlite.bz2"
+
+  data_entries+="  <data type=\"${db}_db\">
+    <checksum type=\"sha256\">${checksum}</checksum>
+    <open-checksum type=\"sha256\">${open_checksum}</open-checksum>
+    <location href=\"repodata/${checksum}-${db}.sqlite.bz2\"/>
+    <timestamp>${TIMESTAMP}</timestamp>
+    <size>${size}</size>
+    <open-size>${open_size}</open-size>
+    <database_version>10</database_version>
+  </data>
+"
+done
+
+cat > "${REPODATA_DIR}/repomd.xml" <<REPOMD
+<?xml version="1.0" encoding="UTF-8"?>
+<repomd xmlns="http://linux.duke.edu/metadata/repo" xmlns:rpm="http://linux.duke.edu/metadata/rpm">
+  <revision>${TIMESTAMP}</revision>
+${data_entries}</repomd>
+REPOMD
+
+rm -rf "${XML_REPODATA_DIR}"
+mkdir -p "${XML_REPODATA_DIR}"
+
+data_entries=""
+for md in primary filelists other; do
+  open_checksum=$(sha256sum "${SRC_DIR}/${md}.xml" | cut -d' ' -f1)
+  open_size=$(stat -c %s "${SRC_DIR}/${md}.xml")
+
+  gzip -9 -n -c "${SRC_DIR}/${md}.xml" > "${XML_REPODATA_DIR}/${md}.xml.gz"
+  checksum=$(sha256sum "${XML_REPODATA_DIR}/${md}.xml.gz" | cut -d' ' -f1)
+  size=$(stat -c %s "${XML_REPODATA_DIR}/${md}.xml.gz")
+  mv "${XML_REPODATA_DIR}/${md}.xml.gz" "${XML_REPODATA_DIR}/${checksum}-${md}.xml.gz"
+
+  data_entries+="  <data type=\"${md}\">
+    <checksum type=\"sha256\">${checksum}</checksum>
+    <open-checksum type=\"sha256\">${open_checksum}</open-checksum>
+    <location href=\"repodata/${checksum}-${md}.xml.gz\"/>
+    <timestamp>${TIMESTAMP}</timestamp>
+    <size>${size}</size>
+    <open-size>${open_size}</open-size>
+  </data>
+"
+done
+
+cat > "${XML_REPODATA_DIR}/repomd.xml" <<REPOMD
+<?xml version="1.0" encoding="UTF-8"?>
+<repomd xmlns="http://linux.duke.edu/metadata/repo" xmlns:rpm="http://linux.duke.edu/metadata/rpm">
+  <revision>${TIMESTAMP}</revision>
+${data_entries}</repomd>
+REPOMD
+
+rm -rf "${COMPRESSED_DIR}"
+mkdir -p "${COMPRESSED_DIR}"
+cp "${XML_REPODATA_DIR}/repomd.xml" "${COMPRESSED_DIR}/repomd.xml"
+gzip -9 -n -k "${COMPRESSED_DIR}/repomd.xml"
+bzip2 -9 -k "${COMPRESSED_DIR}/repomd.xml"
+xz -9 -k "${COMPRESSED_DIR}/repomd.xml"
+zstd -19 -q -k "${COMPRESSED_DIR}/repomd.xml"
@@ -0,0 +1,21 @@
+-- schema as generated by createrepo_c (filelists_db, dbversion 10)
+CREATE TABLE db_info (dbversion INTEGER, checksum TEXT);
+CREATE TABLE packages (  pkgKey INTEGER PRIMARY KEY,  pkgId TEXT);
+CREATE TABLE filelist (  pkgKey INTEGER,  dirname TEXT,  filenames TEXT,  filetypes TEXT);
+
+INSERT INTO db_info VALUES (10, '0a9b8c7d6e5f4a3b2c1d0e9f8a7b6c5d4e3f2a1b0c9d8e7f6a5b4c3d2e1f0a9b');
+
+INSERT INTO packages VALUES (1, 'b1e0a8d2c8bb6e5b2b3cb5b25a8f86b3a8b4bd5e0c1d4a0c2e6f3a9d8c7b6a51');
+INSERT INTO packages VALUES (2, 'c2f1b9e3d9cc7f6c3c4dc6c36b9f97c4b9c5ce6f1d2e5b1d3f7a4b0e9d8c7b62');
+INSERT INTO packages VALUES (3, 'd3a2c0f4e0dd8a7d4d5ed7d47c0a08d5c0d6df7a2e3f6c2e4a8b5c1f0e9d8c73');
+INSERT INTO packages VALUES (4, 'e4b3d1a5f1ee9b8e5e6fe8e58d1b19e6d1e7ea8b3f4a7d3f5b9c6d2a1f0e9d84');
+INSERT INTO packages VALUES (5, 'f5c4e2b6a2ffac9f6f7af9f69e2c2af7e2f8fb9c4a5b8e4a6cad7e3b2a1f0e95');
+
+INSERT INTO filelist VALUES (1, '/usr/bin', 'bash/sh', 'ff');
+INSERT INTO filelist VALUES (1, '/usr/share/doc/bash', 'FAQ/INTRO', 'ff');
+INSERT INTO filelist VALUES (2, '/etc', 'ld.so.conf', 'f');
+INSERT INTO filelist VALUES (2, '/usr/lib64', 'libc.so.6/libm.so.6', 'ff');
+INSERT INTO filelist VALUES (2, '/usr/sbin', 'ldconfig', 'f');
+INSERT INTO filelist VALUES (3, '/usr/bin', 'iconv/locale', 'ff');
+INSERT INTO filelist VALUES (4, '/usr/lib64', 'libtinfo.so.6/libncursesw.so.6', 'ff');
+INSERT INTO filelist VALUES (5, '/', 'bin/etc/usr', 'ddd');
@@ -0,0 +1,54 @@
+-- schema as generated by createrepo_c (primary_db, dbversion 10)
+CREATE TABLE db_info (dbversion INTEGER, checksum TEXT);
+CREATE TABLE packages (  pkgKey INTEGER PRIMARY KEY,  pkgId TEXT,  name TEXT,  arch TEXT,  version TEXT,  epoch TEXT,  release TEXT,  summary TEXT,  description TEXT,  url TEXT,  time_file INTEGER,  time_build INTEGER,  rpm_license TEXT,  rpm_vendor TEXT,  rpm_group TEXT,  rpm_buildhost TEXT,  rpm_sourcerpm TEXT,  rpm_header_start INTEGER,  rpm_header_end INTEGER,  rpm_packager TEXT,  size_package INTEGER,  size_installed INTEGER,  size_archive INTEGER,  location_href TEXT,  location_base TEXT,  checksum_type TEXT);
+CREATE TABLE files (  name TEXT,  type TEXT,  pkgKey INTEGER);
+CREATE TABLE requires (  name TEXT,  flags TEXT,  epoch TEXT,  version TEXT,  release TEXT,  pkgKey INTEGER , pre BOOLEAN DEFAULT FALSE);
+CREATE TABLE provides (  name TEXT,  flags TEXT,  epoch TEXT,  version TEXT,  release TEXT,  pkgKey INTEGER );
+CREATE TABLE conflicts (  name TEXT,  flags TEXT,  epoch TEXT,  version TEXT,  release TEXT,  pkgKey INTEGER );
+CREATE TABLE obsoletes (  name TEXT,  flags TEXT,  epoch TEXT,  version TEXT,  release TEXT,  pkgKey INTEGER );
+CREATE TABLE suggests (  name TEXT,  flags TEXT,  epoch TEXT,  version TEXT,  release TEXT,  pkgKey INTEGER );
+CREATE TABLE enhances (  name TEXT,  flags TEXT,  epoch TEXT,  version TEXT,  release TEXT,  pkgKey INTEGER );
+CREATE TABLE recommends (  name TEXT,  flags TEXT,  epoch TEXT,  version TEXT,  release TEXT,  pkgKey INTEGER );
+CREATE TABLE supplements (  name TEXT,  flags TEXT,  epoch TEXT,  version TEXT,  release TEXT,  pkgKey INTEGER );
+
+INSERT INTO db_info VALUES (10, 'c5b4b8d0a2b4b2f5e1c3d0e7f7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c2d1e0f9a8');
+
+INSERT INTO packages VALUES (1, 'b1e0a8d2c8bb6e5b2b3cb5b25a8f86b3a8b4bd5e0c1d4a0c2e6f3a9d8c7b6a51', 'bash', 'x86_64', '5.1.8', '0', '6.oe2203', 'The GNU Bourne Again shell', 'The GNU Bourne Again shell (Bash) is a shell or command language interpreter.', 'https://www.gnu.org/software/bash', 1648000000, 1647000000, 'GPLv3+', 'openEuler', 'Unspecified', 'dc-64g.compass-ci', 'bash-5.1.8-6.oe2203.src.rpm', 4504, 60000, 'http://openeuler.org', 1200000, 3900000, 3950000, 'Packages/bash-5.1.8-6.oe2203.x86_64.rpm', NULL, 'sha256');
+INSERT INTO packages VALUES (2, 'c2f1b9e3d9cc7f6c3c4dc6c36b9f97c4b9c5ce6f1d2e5b1d3f7a4b0e9d8c7b62', 'glibc', 'x86_64', '2.34', '0', '70.oe2203', 'The GNU libc libraries', 'The glibc package contains standard libraries which are used by multiple programs on the system.', 'http://www.gnu.org/software/glibc/', 1648000000, 1647000000, 'LGPLv2+ and LGPLv2+ with exceptions and GPLv2+', 'openEuler', 'Unspecified', 'dc-64g.compass-ci', 'glibc-2.34-70.oe2203.src.rpm', 4504, 90000, 'http://openeuler.org', 3200000, 14000000, 14100000, 'Packages/glibc-2.34-70.oe2203.x86_64.rpm', NULL, 'sha256');
+INSERT INTO packages VALUES (3, 'd3a2c0f4e0dd8a7d4d5ed7d47c0a08d5c0d6df7a2e3f6c2e4a8b5c1f0e9d8c73', 'glibc-common', 'x86_64', '2.34', '0', '70.oe2203', 'Common binaries and locale data for glibc', 'The glibc-common package includes common binaries for the GNU libc libraries.', 'http://www.gnu.org/software/glibc/', 1648000000, 1647000000, 'LGPLv2+ and LGPLv2+ with exceptions and GPLv2+', 'openEuler', 'Unspecified', 'dc-64g.compass-ci', 'glibc-2.34-70.oe2203.src.rpm', 4504, 30000, 'http://openeuler.org', 900000, 2800000, 2850000, 'Packages/glibc-common-2.34-70.oe2203.x86_64.rpm', NULL, 'sha256');
+INSERT INTO packages VALUES (4, 'e4b3d1a5f1ee9b8e5e6fe8e58d1b19e6d1e7ea8b3f4a7d3f5b9c6d2a1f0e9d84', 'ncurses-libs', 'x86_64', '6.3', '0', '5.oe2203', 'Ncurses libraries', 'The curses library routines are a terminal-independent method of updating character screens.', 'https://invisible-island.net/ncurses/ncurses.html', 1648000000, 1647000000, 'MIT', 'openEuler', 'Unspecified', 'dc-64g.compass-ci', 'ncurses-6.3-5.oe2203.src.rpm', 4504, 20000, 'http://openeuler.org', 300000, 1000000, 1010000, 'Packages/ncurses-libs-6.3-5.oe2203.x86_64.rpm', NULL, 'sha256');
+INSERT INTO packages VALUES (5, 'f5c4e2b6a2ffac9f6f7af9f69e2c2af7e2f8fb9c4a5b8e4a6cad7e3b2a1f0e95', 'filesystem', 'noarch', '3.16', '0', '2.oe2203', 'The basic directory layout for a Linux system', 'The filesystem package is one of the basic packages that is installed on a Linux system.', 'https://pagure.io/filesystem', 1648000000, 1647000000, 'Public Domain', 'openEuler', 'Unspecified', 'dc-64g.compass-ci', 'filesystem-3.16-2.oe2203.src.rpm', 4504, 8000, 'http://openeuler.org', 100000, 0, 0, 'Packages/filesystem-3.16-2.oe2203.noarch.rpm', NULL, 'sha256');
+
+INSERT INTO files VALUES ('/usr/bin/bash', 'file', 1);
+INSERT INTO files VALUES ('/usr/bin/sh', 'file', 1);
+INSERT INTO files VALUES ('/etc/ld.so.conf', 'file', 2);
+INSERT INTO files VALUES ('/usr/sbin/ldconfig', 'file', 2);
+
+INSERT INTO requires VALUES ('filesystem', 'GE', '0', '3', NULL, 1, 0);
+INSERT INTO requires VALUES ('libc.so.6()(64bit)', NULL, NULL, NULL, NULL, 1, 0);
+INSERT INTO requires VALUES ('libtinfo.so.6()(64bit)', NULL, NULL, NULL, NULL, 1, 0);
+INSERT INTO requires VALUES ('/usr/bin/sh', NULL, NULL, NULL, NULL, 1, 0);
+INSERT INTO requires VALUES ('glibc-common', 'EQ', '0', '2.34', '70.oe2203', 2, 0);
+INSERT INTO requires VALUES ('/usr/sbin/ldconfig', NULL, NULL, NULL, NULL, 2, 1);
+INSERT INTO requires VALUES ('glibc', 'EQ', '0', '2.34', '70.oe2203', 3, 0);
+INSERT INTO requires VALUES ('libc.so.6()(64bit)', NULL, NULL, NULL, NULL, 4, 0);
+INSERT INTO requires VALUES ('/usr/sbin/ldconfig', NULL, NULL, NULL, NULL, 4, 1);
+
+INSERT INTO provides VALUES ('bash', 'EQ', '0', '5.1.8', '6.oe2203', 1);
+INSERT INTO provides VALUES ('bash(x86-64)', 'EQ', '0', '5.1.8', '6.oe2203', 1);
+INSERT INTO provides VALUES ('/bin/bash', NULL, NULL, NULL, NULL, 1);
+INSERT INTO provides VALUES ('/bin/sh', NULL, NULL, NULL, NULL, 1);
+INSERT INTO provides VALUES ('glibc', 'EQ', '0', '2.34', '70.oe2203', 2);
+INSERT INTO provides VALUES ('glibc(x86-64)', 'EQ', '0', '2.34', '70.oe2203', 2);
+INSERT INTO provides VALUES ('libc.so.6()(64bit)', NULL, NULL, NULL, NULL, 2);
+INSERT INTO provides VALUES ('glibc-common', 'EQ', '0', '2.34', '70.oe2203', 3);
+INSERT INTO provides VALUES ('glibc-common(x86-64)', 'EQ', '0', '2.34', '70.oe2203', 3);
+INSERT INTO provides VALUES ('ncurses-libs', 'EQ', '0', '6.3', '5.oe2203', 4);
+INSERT INTO provides VALUES ('ncurses-libs(x86-64)', 'EQ', '0', '6.3', '5.oe2203', 4);
+INSERT INTO provides VALUES ('libtinfo.so.6()(64bit)', NULL, NULL, NULL, NULL, 4);
+INSERT INTO provides VALUES ('filesystem', 'EQ', '0', '3.16', '2.oe2203', 5);
+
+INSERT INTO obsoletes VALUES ('glibc-profile', 'LT', '0', '2.4', NULL, 2);
+INSERT INTO recommends VALUES ('bash-completion', NULL, NULL, NULL, NULL, 1);
+INSERT INTO suggests VALUES ('ncurses-libs', NULL, NULL, NULL, NULL, 1);
+INSERT INTO suggests VALUES ('bash', NULL, NULL, NULL, NULL, 3);
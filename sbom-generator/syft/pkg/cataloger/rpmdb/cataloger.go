@@ -29,25 +29,27 @@ func (c *Cataloger) Name() string {
 
 // Catalog is given an object to resolve file references and content, this function returns any discovered Packages after analyzing rpm db installation.
 func (c *Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
-	fileMatches, err := resolver.FilesByGlob(pkg.RpmDBGlob)
+	fileMatches, err := resolver.FilesByGlob(pkg.RpmDBGlob, pkg.RpmSysimageDBGlob)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to find rpmdb's by glob: %w", err)
 	}
 
 	var pkgs []pkg.Package
+	var relationships []artifact.Relationship
 	for _, location := range fileMatches {
 		dbContentReader, err := resolver.FileContentsByLocation(location)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		discoveredPkgs, err := parseRpmDB(resolver, location, dbContentReader)
+		discoveredPkgs, discoveredRelationships, err := parseRpmDB(resolver, location, dbContentReader)
 		internal.CloseAndLogError(dbContentReader, location.VirtualPath)
 		if err != nil {
 			return nil, nil, fmt.Errorf("unable to catalog rpmdb package=%+v: %w", location.RealPath, err)
 		}
 
 		pkgs = append(pkgs, discoveredPkgs...)
+		relationships = append(relationships, discoveredRelationships...)
 	}
-	return pkgs, nil, nil
+	return pkgs, relationships, nil
 }
@@ -1,25 +1,33 @@
 package rpmdb
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/anchore/syft/syft/file"
 
 	rpmdb "github.com/anchore/go-rpmdb/pkg"
+	"github.com/anchore/go-rpmdb/pkg/bdb"
 	"github.com/anchore/syft/internal"
 	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common/rpm"
 	"github.com/anchore/syft/syft/source"
 )
 
-// parseApkDb parses an "Packages" RPM DB and returns the Packages listed within it.
-func parseRpmDB(resolver source.FilePathResolver, dbLocation source.Location, reader io.Reader) ([]pkg.Package, error) {
+// parseRpmDB parses a RPM DB (berkeley db "Packages", sqlite "rpmdb.sqlite" or ndb "Packages.db") and returns the
+// Packages listed within it, related to the installed packages satisfying their requires.
+func parseRpmDB(resolver source.FilePathResolver, dbLocation source.Location, reader io.Reader) ([]pkg.Package, []artifact.Relationship, error) {
 	f, err := ioutil.TempFile("", internal.ApplicationName+"-rpmdb")
 	if err != nil {
-		return nil, fmt.Errorf("failed to create temp rpmdb file: %w", err)
+		return nil, nil, fmt.Errorf("failed to create temp rpmdb file: %w", err)
 	}
 
 	defer func() {
@@ -32,33 +40,37 @@ func parseRpmDB(resolver source.FilePathResolver, dbLocation source.Location, re
 
 	_, err = io.Copy(f, reader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to copy rpmdb contents to temp file: %w", err)
+		return nil, nil, fmt.Errorf("failed to copy rpmdb contents to temp file: %w", err)
 	}
 
-	db, err := rpmdb.Open(f.Name())
+	pkgList, err := listRpmDBPackages(f)
 	if err != nil {
-		return nil, err
-	}
-
-	pkgList, err := db.ListPackages()
-	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	allPkgs := make([]pkg.Package, 0)
+	installed := make([]rpm.InstalledPackage, 0, len(pkgList))
 
 	for _, entry := range pkgList {
 		metadata := pkg.RpmdbMetadata{
-			Name:      entry.Name,
-			Version:   entry.Version,
-			Epoch:     entry.Epoch,
-			Arch:      entry.Arch,
-			Release:   entry.Release,
-			SourceRpm: entry.SourceRpm,
-			Vendor:    entry.Vendor,
-			License:   entry.License,
-			Size:      entry.Size,
-			Files:     extractRpmdbFileRecords(resolver, entry),
+			Name:        entry.Name,
+			Version:     entry.Version,
+			Epoch:       entry.Epoch,
+			Arch:        entry.Arch,
+			Release:     entry.Release,
+			SourceRpm:   entry.SourceRpm,
+			Vendor:      entry.Vendor,
+			License:     entry.License,
+			Size:        entry.Size,
+			Packager:    entry.Packager,
+			Homepage:    entry.URL,
+			Summary:     entry.Summary,
+			Description: entry.Description,
+			BuildTime:   entry.BuildTime,
+			Files:       extractRpmdbFileRecords(resolver, &entry.PackageInfo),
+			RpmDigests:  extractRpmdbDigests(entry),
+			Provides:    capabilityStrings(entry.Provides),
+			Requires:    capabilityStrings(entry.Requires),
 		}
 
 		p := pkg.Package{
@@ -74,9 +86,109 @@ func parseRpmDB(resolver source.FilePathResolver, dbLocation source.Location, re
 		p.SetID()
 
 		allPkgs = append(allPkgs, p)
+		installed = append(installed, newInstalledRpm(p, entry))
+	}
+
+	relationships, unresolved := rpm.ResolveInstalledDependencies(installed)
+	for _, p := range allPkgs {
+		if requires, exists := unresolved[p.ID()]; exists {
+			log.Debugf("installed package %s-%s has unresolved requires: %s", p.Name, p.Version, strings.Join(requires, ", "))
+		}
+	}
+
+	return allPkgs, relationships, nil
+}
+
+// listRpmDBPackages reads the packages of a RPM DB, detecting its backend from the magic at the start of the file (the
+// berkeley db magic is not at the start of the file, so it is assumed for anything else).
+func listRpmDBPackages(f *os.File) ([]*rpmdbEntry, error) {
+	blobs, err := readRpmDBHeaderBlobs(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgList []*rpmdbEntry
+	for _, blob := range blobs {
+		entry, err := parseRpmHeaderBlob(blob)
+		if err != nil {
+			return nil, fmt.Errorf("invalid package info: %w", err)
+		}
+		pkgList = append(pkgList, entry)
+	}
+	return pkgList, nil
+}
+
+func readRpmDBHeaderBlobs(f *os.File) ([][]byte, error) {
+	magic := make([]byte, len(sqliteMagic))
+	n, err := f.ReadAt(magic, 0)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read rpmdb magic: %w", err)
 	}
+	magic = magic[:n]
 
-	return allPkgs, nil
+	switch {
+	case bytes.Equal(magic, []byte(sqliteMagic)):
+		return readSqliteHeaderBlobs(f.Name())
+	case len(magic) >= 4 && binary.LittleEndian.Uint32(magic) == ndbHeaderMagic:
+		return readNdbHeaderBlobs(f.Name())
+	}
+	return readBerkeleyDBHeaderBlobs(f.Name())
+}
+
+// readBerkeleyDBHeaderBlobs reads the package header blobs of a berkeley db rpmdb ("Packages", the backend before
+// rpm 4.16), which is a hash database of header blobs keyed by package number.
+func readBerkeleyDBHeaderBlobs(dbPath string) ([][]byte, error) {
+	db, err := bdb.Open(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var blobs [][]byte
+	for entry := range db.Read() {
+		if entry.Err != nil {
+			return nil, entry.Err
+		}
+		blobs = append(blobs, entry.Value)
+	}
+	return blobs, nil
+}
+
+func newInstalledRpm(p pkg.Package, entry *rpmdbEntry) rpm.InstalledPackage {
+	installed := rpm.InstalledPackage{
+		Package:  p,
+		Arch:     entry.Arch,
+		Version:  entry.Version,
+		Release:  entry.Release,
+		Provides: entry.Provides,
+		Requires: entry.Requires,
+	}
+	if entry.Epoch != nil {
+		installed.Epoch = strconv.Itoa(*entry.Epoch)
+	}
+	for _, f := range entry.Files {
+		installed.Files = append(installed.Files, f.Path)
+	}
+	return installed
+}
+
+// extractRpmdbDigests returns the digests recorded in the header of the installed package.
+func extractRpmdbDigests(entry *rpmdbEntry) []file.Digest {
+	var digests []file.Digest
+	if entry.SHA256Header != "" {
+		digests = append(digests, file.Digest{Algorithm: "sha256", Value: entry.SHA256Header})
+	}
+	if entry.SigMD5 != "" {
+		digests = append(digests, file.Digest{Algorithm: "md5", Value: entry.SigMD5})
+	}
+	return digests
+}
+
+func capabilityStrings(capabilities []rpm.Capability) []string {
+	var result []string
+	for _, c := range capabilities {
+		result = append(result, c.String())
+	}
+	return result
 }
 
 // The RPM naming scheme is [name]-[version]-[release]-[arch], where version is implicitly expands to [epoch]:[version].
@@ -1,11 +1,15 @@
 package rpmdb
 
 import (
+	"database/sql"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
+	rpmdb "github.com/anchore/go-rpmdb/pkg"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/syft/syft/file"
 
@@ -62,13 +66,20 @@ func (r *rpmdbTestFileResolverMock) Path() string {
 func TestParseRpmDB(t *testing.T) {
 	dbLocation := source.NewLocation("test-path")
 
+	// every rpmdb backend holds the same package
+	fixtures := []string{
+		"test-fixtures/Packages",
+		"test-fixtures/Packages.db",
+		"test-fixtures/rpmdb.sqlite",
+	}
+
 	tests := []struct {
-		fixture     string
+		name        string
 		expected    map[string]pkg.Package
 		ignorePaths bool
 	}{
 		{
-			fixture: "test-fixtures/Packages",
+			name: "without existing files",
 			// we only surface package paths for files that exist (here we DO NOT expect a path)
 			ignorePaths: true,
 			expected: map[string]pkg.Package{
@@ -89,13 +100,26 @@ func TestParseRpmDB(t *testing.T) {
 						Size:      12406784,
 						License:   "MIT",
 						Vendor:    "",
-						Files:     []pkg.RpmdbFileRecord{},
+						Packager:  "Alex Goodman",
+						Homepage:  "https://github.com/wagoodman/dive/",
+						Summary:   "no description given",
+						// fpm sets the description to the summary
+						Description: "no description given",
+						BuildTime:   1581279813,
+						RpmDigests: []file.Digest{
+							{
+								Algorithm: "sha256",
+								Value:     "d9c6fc2236147daeea9e1489e2501d5cc5ddebbc7373381fa5b81cc894a993f1",
+							},
+						},
+						Provides: []string{"dive = 0.9.2-1"},
+						Files:    []pkg.RpmdbFileRecord{},
 					},
 				},
 			},
 		},
 		{
-			fixture: "test-fixtures/Packages",
+			name: "with existing files",
 			// we only surface package paths for files that exist (here we expect a path)
 			ignorePaths: false,
 			expected: map[string]pkg.Package{
@@ -116,6 +140,19 @@ func TestParseRpmDB(t *testing.T) {
 						Size:      12406784,
 						License:   "MIT",
 						Vendor:    "",
+						Packager:  "Alex Goodman",
+						Homepage:  "https://github.com/wagoodman/dive/",
+						Summary:   "no description given",
+						// fpm sets the description to the summary
+						Description: "no description given",
+						BuildTime:   1581279813,
+						RpmDigests: []file.Digest{
+							{
+								Algorithm: "sha256",
+								Value:     "d9c6fc2236147daeea9e1489e2501d5cc5ddebbc7373381fa5b81cc894a993f1",
+							},
+						},
+						Provides: []string{"dive = 0.9.2-1"},
 						Files: []pkg.RpmdbFileRecord{
 							{
 								Path: "/usr/local/bin/dive",
@@ -135,36 +172,38 @@ func TestParseRpmDB(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		t.Run(test.fixture, func(t *testing.T) {
-			fixture, err := os.Open(test.fixture)
-			if err != nil {
-				t.Fatalf("failed to open fixture: %+v", err)
-			}
+		for _, fixturePath := range fixtures {
+			t.Run(test.name+" "+fixturePath, func(t *testing.T) {
+				fixture, err := os.Open(fixturePath)
+				if err != nil {
+					t.Fatalf("failed to open fixture: %+v", err)
+				}
 
-			fileResolver := newTestFileResolver(test.ignorePaths)
+				fileResolver := newTestFileResolver(test.ignorePaths)
 
-			actual, err := parseRpmDB(fileResolver, dbLocation, fixture)
-			if err != nil {
-				t.Fatalf("failed to parse rpmdb: %+v", err)
-			}
+				actual, _, err := parseRpmDB(fileResolver, dbLocation, fixture)
+				if err != nil {
+					t.Fatalf("failed to parse rpmdb: %+v", err)
+				}
 
-			if len(actual) != len(test.expected) {
-				for _, a := range actual {
-					t.Log("   ", a)
+				if len(actual) != len(test.expected) {
+					for _, a := range actual {
+						t.Log("   ", a)
+					}
+					t.Fatalf("unexpected package count: %d!=%d", len(actual), len(test.expected))
 				}
-				t.Fatalf("unexpected package count: %d!=%d", len(actual), len(test.expected))
-			}
-
-			for _, a := range actual {
-				e := test.expected[a.Name]
-				diffs := deep.Equal(a, e)
-				if len(diffs) > 0 {
-					for _, d := range diffs {
-						t.Errorf("diff: %+v", d)
+
+				for _, a := range actual {
+					e := test.expected[a.Name]
+					diffs := deep.Equal(a, e)
+					if len(diffs) > 0 {
+						for _, d := range diffs {
+							t.Errorf("diff: %+v", d)
+						}
 					}
 				}
-			}
-		})
+			})
+		}
 	}
 
 }
@@ -216,3 +255,74 @@ func TestToElVersion(t *testing.T) {
 func intRef(i int) *int {
 	return &i
 }
+
+func newTestSqliteRpmDB(t *testing.T, blobs ...[]byte) string {
+	t.Helper()
+
+	dbPath := filepath.Join(t.TempDir(), "rpmdb.sqlite")
+	db, err := sql.Open("sqlite", dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("CREATE TABLE 'Packages' (hnum INTEGER PRIMARY KEY AUTOINCREMENT, blob BLOB NOT NULL)")
+	require.NoError(t, err)
+	for _, blob := range blobs {
+		_, err = db.Exec("INSERT INTO Packages (blob) VALUES (?)", blob)
+		require.NoError(t, err)
+	}
+	return dbPath
+}
+
+func TestParseRpmDB_Relationships(t *testing.T) {
+	dbPath := newTestSqliteRpmDB(t,
+		newTestRpmHeaderBlob(
+			stringTag(rpmdb.RPMTAG_NAME, "bash"),
+			stringTag(rpmdb.RPMTAG_VERSION, "5.1.8"),
+			stringTag(rpmdb.RPMTAG_RELEASE, "6"),
+			stringTag(rpmdb.RPMTAG_ARCH, "x86_64"),
+			stringArrayTag(rpmTagProvideName, "bash"),
+			int32Tag(rpmTagProvideFlags, 8),
+			stringArrayTag(rpmTagProvideVersion, "5.1.8-6"),
+			stringArrayTag(rpmTagRequireName, "filesystem", "/usr/bin/coreutils", "rpmlib(CompressedFileNames)", "missing"),
+			int32Tag(rpmTagRequireFlags, 1<<9|12, 0, 1<<24|12, 0),
+			stringArrayTag(rpmTagRequireVersion, "3", "", "3.0.4-1", ""),
+		),
+		newTestRpmHeaderBlob(
+			stringTag(rpmdb.RPMTAG_NAME, "filesystem"),
+			stringTag(rpmdb.RPMTAG_VERSION, "3.16"),
+			stringTag(rpmdb.RPMTAG_RELEASE, "1"),
+			stringTag(rpmdb.RPMTAG_ARCH, "x86_64"),
+			stringArrayTag(rpmTagProvideName, "filesystem"),
+			int32Tag(rpmTagProvideFlags, 8),
+			stringArrayTag(rpmTagProvideVersion, "3.16-1"),
+		),
+		newTestRpmHeaderBlob(
+			stringTag(rpmdb.RPMTAG_NAME, "coreutils"),
+			stringTag(rpmdb.RPMTAG_VERSION, "9.0"),
+			stringTag(rpmdb.RPMTAG_RELEASE, "2"),
+			stringTag(rpmdb.RPMTAG_ARCH, "x86_64"),
+			stringArrayTag(rpmdb.RPMTAG_DIRNAMES, "/usr/bin/"),
+			int32Tag(rpmdb.RPMTAG_DIRINDEXES, 0),
+			stringArrayTag(rpmdb.RPMTAG_BASENAMES, "coreutils"),
+		),
+	)
+
+	fixture, err := os.Open(dbPath)
+	require.NoError(t, err)
+	defer fixture.Close()
+
+	pkgs, relationships, err := parseRpmDB(newTestFileResolver(true), source.NewLocation("test-path"), fixture)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 3)
+
+	assert.Equal(t, []string{"filesystem >= 3", "/usr/bin/coreutils", "rpmlib(CompressedFileNames) >= 3.0.4-1", "missing"}, pkgs[0].Metadata.(pkg.RpmdbMetadata).Requires)
+
+	var actual []string
+	for _, r := range relationships {
+		actual = append(actual, fmt.Sprintf("%s %s %s", r.From.(pkg.Package).Name, r.Type, r.To.(pkg.Package).Name))
+	}
+	assert.ElementsMatch(t, []string{
+		"bash has-prerequisite filesystem",
+		"bash DEPENDS_ON coreutils",
+	}, actual)
+}
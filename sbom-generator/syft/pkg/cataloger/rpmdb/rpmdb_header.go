@@ -0,0 +1,382 @@
+package rpmdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	rpmdb "github.com/anchore/go-rpmdb/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common/rpm"
+)
+
+const (
+	rpmHeaderIndexEntrySize = 16
+	rpmNoneValue            = "(none)"
+)
+
+// tags read beside the ones go-rpmdb reads (see rpmTag_e in rpm), the signature tags are merged into the header of
+// installed packages.
+// ref. https://github.com/rpm-software-management/rpm/blob/rpm-4.16.0-release/lib/rpmtag.h
+const (
+	rpmTagSigMD5         = 261  /* x */
+	rpmTagSHA256Header   = 273  /* s */
+	rpmTagSummary        = 1004 /* s{} */
+	rpmTagDescription    = 1005 /* s{} */
+	rpmTagBuildTime      = 1006 /* i */
+	rpmTagPackager       = 1015 /* s */
+	rpmTagURL            = 1020 /* s */
+	rpmTagProvideName    = 1047 /* s[] */
+	rpmTagRequireFlags   = 1048 /* i[] */
+	rpmTagRequireName    = 1049 /* s[] */
+	rpmTagRequireVersion = 1050 /* s[] */
+	rpmTagProvideFlags   = 1112 /* i[] */
+	rpmTagProvideVersion = 1113 /* s[] */
+)
+
+// rpmdbEntry is a package of a rpmdb: the package info go-rpmdb reads, and the tags it does not read.
+type rpmdbEntry struct {
+	rpmdb.PackageInfo
+	Packager     string
+	URL          string
+	Summary      string
+	Description  string
+	SHA256Header string
+	SigMD5       string
+	BuildTime    int64
+	Provides     []rpm.Capability
+	Requires     []rpm.Capability
+}
+
+// rpmHeaderEntry is a single tag of a rpm header, with its data as stored in the header data store.
+type rpmHeaderEntry struct {
+	tag   int32
+	kind  uint32
+	count uint32
+	data  []byte
+}
+
+// parseRpmHeaderBlob reads a package entry as stored by every rpmdb backend (an "immutable" rpm header blob without
+// the header magic: the index length, the data length, the index entries and the data store, all big endian) into the
+// same package info the berkeley db reader of go-rpmdb yields, along with the digests, dependencies and descriptive
+// tags of the package.
+// ref. https://github.com/rpm-software-management/rpm/blob/rpm-4.16.0-release/lib/header.c#L794
+func parseRpmHeaderBlob(blob []byte) (*rpmdbEntry, error) {
+	entries, err := parseRpmHeaderEntries(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := newRpmPackageInfo(entries)
+	if err != nil {
+		return nil, err
+	}
+	entry := &rpmdbEntry{PackageInfo: *info}
+
+	stringTags := []struct {
+		tag   int32
+		value *string
+	}{
+		{rpmTagPackager, &entry.Packager},
+		{rpmTagURL, &entry.URL},
+		{rpmTagSummary, &entry.Summary},
+		{rpmTagDescription, &entry.Description},
+		{rpmTagSHA256Header, &entry.SHA256Header},
+	}
+	for _, s := range stringTags {
+		e, ok := entries[s.tag]
+		if !ok {
+			continue
+		}
+		// summaries and descriptions are translatable, the first translation is the untranslated one
+		if e.kind != rpmdb.RPM_I18NSTRING_TYPE {
+			if err := e.expect(rpmdb.RPM_STRING_TYPE); err != nil {
+				return nil, err
+			}
+		}
+		if values := e.strings(); len(values) > 0 && values[0] != rpmNoneValue {
+			*s.value = values[0]
+		}
+	}
+
+	if e, ok := entries[rpmTagSigMD5]; ok {
+		if err := e.expect(rpmdb.RPM_BIN_TYPE); err != nil {
+			return nil, err
+		}
+		entry.SigMD5 = hex.EncodeToString(e.data)
+	}
+
+	if e, ok := entries[rpmTagBuildTime]; ok {
+		if err := e.expect(rpmdb.RPM_INT32_TYPE); err != nil {
+			return nil, err
+		}
+		if values := e.int32s(); len(values) > 0 {
+			// the build time is stored as an unsigned count of seconds since the epoch
+			entry.BuildTime = int64(uint32(values[0]))
+		}
+	}
+
+	if entry.Provides, err = newRpmCapabilities(entries, rpmTagProvideName, rpmTagProvideFlags, rpmTagProvideVersion); err != nil {
+		return nil, err
+	}
+	if entry.Requires, err = newRpmCapabilities(entries, rpmTagRequireName, rpmTagRequireFlags, rpmTagRequireVersion); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// newRpmCapabilities reads the provides or requires of a package, which are stored as parallel name, flags and
+// version arrays.
+func newRpmCapabilities(entries map[int32]rpmHeaderEntry, nameTag, flagsTag, versionTag int32) ([]rpm.Capability, error) {
+	for tag, kind := range map[int32]uint32{nameTag: rpmdb.RPM_STRING_ARRAY_TYPE, flagsTag: rpmdb.RPM_INT32_TYPE, versionTag: rpmdb.RPM_STRING_ARRAY_TYPE} {
+		if entry, ok := entries[tag]; ok {
+			if err := entry.expect(kind); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	names := entries[nameTag].strings()
+	flags := entries[flagsTag].int32s()
+	versions := entries[versionTag].strings()
+
+	var capabilities []rpm.Capability
+	for i, name := range names {
+		capability := rpm.Capability{Name: name}
+		if i < len(flags) {
+			capability.Flags = int(flags[i])
+		}
+		if i < len(versions) {
+			capability.EVR = versions[i]
+		}
+		capabilities = append(capabilities, capability)
+	}
+	return capabilities, nil
+}
+
+func parseRpmHeaderEntries(blob []byte) (map[int32]rpmHeaderEntry, error) {
+	if len(blob) < 8 {
+		return nil, fmt.Errorf("rpm header too short: %d bytes", len(blob))
+	}
+	indexLength := int(binary.BigEndian.Uint32(blob[0:4]))
+	dataLength := int(binary.BigEndian.Uint32(blob[4:8]))
+
+	dataStart := 8 + indexLength*rpmHeaderIndexEntrySize
+	if indexLength < 0 || dataLength < 0 || dataStart+dataLength > len(blob) {
+		return nil, fmt.Errorf("invalid rpm header: index length=%d data length=%d size=%d", indexLength, dataLength, len(blob))
+	}
+	store := blob[dataStart : dataStart+dataLength]
+
+	entries := make(map[int32]rpmHeaderEntry, indexLength)
+	for i := 0; i < indexLength; i++ {
+		raw := blob[8+i*rpmHeaderIndexEntrySize : 8+(i+1)*rpmHeaderIndexEntrySize]
+		entry := rpmHeaderEntry{
+			tag:   int32(binary.BigEndian.Uint32(raw[0:4])),
+			kind:  binary.BigEndian.Uint32(raw[4:8]),
+			count: binary.BigEndian.Uint32(raw[12:16]),
+		}
+		offset := int(int32(binary.BigEndian.Uint32(raw[8:12])))
+		if offset < 0 || offset > len(store) {
+			// region tags (e.g. the immutable header region) point outside of the data store
+			continue
+		}
+
+		data, err := rpmHeaderEntryData(store[offset:], entry.kind, entry.count)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rpm header tag=%d: %w", entry.tag, err)
+		}
+		entry.data = data
+		entries[entry.tag] = entry
+	}
+	return entries, nil
+}
+
+// rpmHeaderEntryData returns the bytes of the data store holding the value of a tag of the given type.
+func rpmHeaderEntryData(store []byte, kind, count uint32) ([]byte, error) {
+	var size int
+	switch kind {
+	case rpmdb.RPM_NULL_TYPE:
+		return nil, nil
+	case rpmdb.RPM_CHAR_TYPE, rpmdb.RPM_INT8_TYPE, rpmdb.RPM_BIN_TYPE:
+		size = int(count)
+	case rpmdb.RPM_INT16_TYPE:
+		size = 2 * int(count)
+	case rpmdb.RPM_INT32_TYPE:
+		size = 4 * int(count)
+	case rpmdb.RPM_INT64_TYPE:
+		size = 8 * int(count)
+	case rpmdb.RPM_STRING_TYPE, rpmdb.RPM_STRING_ARRAY_TYPE, rpmdb.RPM_I18NSTRING_TYPE:
+		for i := uint32(0); i < count; i++ {
+			end := bytes.IndexByte(store[size:], 0)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			size += end + 1
+		}
+	default:
+		return nil, fmt.Errorf("unknown type=%d", kind)
+	}
+
+	if size > len(store) {
+		return nil, fmt.Errorf("data exceeds the header data store")
+	}
+	return store[:size], nil
+}
+
+func (e rpmHeaderEntry) expect(kind uint32) error {
+	if e.kind != kind {
+		return fmt.Errorf("invalid type=%d of tag=%d (expected type=%d)", e.kind, e.tag, kind)
+	}
+	return nil
+}
+
+func (e rpmHeaderEntry) strings() []string {
+	if len(e.data) == 0 {
+		return nil
+	}
+	return strings.Split(string(e.data[:len(e.data)-1]), "\x00")
+}
+
+func (e rpmHeaderEntry) int32s() []int32 {
+	values := make([]int32, len(e.data)/4)
+	for i := range values {
+		values[i] = int32(binary.BigEndian.Uint32(e.data[i*4:]))
+	}
+	return values
+}
+
+func (e rpmHeaderEntry) uint16s() []uint16 {
+	values := make([]uint16, len(e.data)/2)
+	for i := range values {
+		values[i] = binary.BigEndian.Uint16(e.data[i*2:])
+	}
+	return values
+}
+
+// newRpmPackageInfo mirrors the package info extraction of go-rpmdb, which is not exposed for headers read elsewhere.
+// ref. https://github.com/rpm-software-management/rpm/blob/rpm-4.11.3-release/lib/tagexts.c#L649
+func newRpmPackageInfo(entries map[int32]rpmHeaderEntry) (*rpmdb.PackageInfo, error) {
+	info := &rpmdb.PackageInfo{}
+
+	stringTags := []struct {
+		tag   int32
+		value *string
+	}{
+		{rpmdb.RPMTAG_NAME, &info.Name},
+		{rpmdb.RPMTAG_VERSION, &info.Version},
+		{rpmdb.RPMTAG_RELEASE, &info.Release},
+		{rpmdb.RPMTAG_ARCH, &info.Arch},
+		{rpmdb.RPMTAG_SOURCERPM, &info.SourceRpm},
+		{rpmdb.RPMTAG_LICENSE, &info.License},
+		{rpmdb.RPMTAG_VENDOR, &info.Vendor},
+	}
+	for _, s := range stringTags {
+		entry, ok := entries[s.tag]
+		if !ok {
+			continue
+		}
+		if err := entry.expect(rpmdb.RPM_STRING_TYPE); err != nil {
+			return nil, err
+		}
+		if values := entry.strings(); len(values) > 0 && values[0] != rpmNoneValue {
+			*s.value = values[0]
+		}
+	}
+
+	intTags := []struct {
+		tag   int32
+		value func(int)
+	}{
+		{rpmdb.RPMTAG_EPOCH, func(v int) { info.Epoch = &v }},
+		{rpmdb.RPMTAG_SIZE, func(v int) { info.Size = v }},
+		{rpmdb.RPMTAG_FILEDIGESTALGO, func(v int) { info.DigestAlgorithm = rpmdb.DigestAlgorithm(v) }},
+	}
+	for _, i := range intTags {
+		entry, ok := entries[i.tag]
+		if !ok {
+			continue
+		}
+		if err := entry.expect(rpmdb.RPM_INT32_TYPE); err != nil {
+			return nil, err
+		}
+		if values := entry.int32s(); len(values) > 0 {
+			i.value(int(values[0]))
+		}
+	}
+
+	files, err := newRpmFileInfos(entries)
+	if err != nil {
+		return nil, err
+	}
+	info.Files = files
+
+	return info, nil
+}
+
+func newRpmFileInfos(entries map[int32]rpmHeaderEntry) ([]rpmdb.FileInfo, error) {
+	expected := map[int32]uint32{
+		rpmdb.RPMTAG_BASENAMES:     rpmdb.RPM_STRING_ARRAY_TYPE,
+		rpmdb.RPMTAG_DIRNAMES:      rpmdb.RPM_STRING_ARRAY_TYPE,
+		rpmdb.RPMTAG_DIRINDEXES:    rpmdb.RPM_INT32_TYPE,
+		rpmdb.RPMTAG_FILEDIGESTS:   rpmdb.RPM_STRING_ARRAY_TYPE,
+		rpmdb.RPMTAG_FILEMODES:     rpmdb.RPM_INT16_TYPE,
+		rpmdb.RPMTAG_FILESIZES:     rpmdb.RPM_INT32_TYPE,
+		rpmdb.RPMTAG_FILEUSERNAME:  rpmdb.RPM_STRING_ARRAY_TYPE,
+		rpmdb.RPMTAG_FILEGROUPNAME: rpmdb.RPM_STRING_ARRAY_TYPE,
+		rpmdb.RPMTAG_FILEFLAGS:     rpmdb.RPM_INT32_TYPE,
+	}
+	for tag, kind := range expected {
+		if entry, ok := entries[tag]; ok {
+			if err := entry.expect(kind); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	basenames := entries[rpmdb.RPMTAG_BASENAMES].strings()
+	dirnames := entries[rpmdb.RPMTAG_DIRNAMES].strings()
+	dirIndexes := entries[rpmdb.RPMTAG_DIRINDEXES].int32s()
+	if len(dirnames) == 0 || len(dirIndexes) == 0 {
+		return nil, nil
+	}
+
+	digests := entries[rpmdb.RPMTAG_FILEDIGESTS].strings()
+	modes := entries[rpmdb.RPMTAG_FILEMODES].uint16s()
+	sizes := entries[rpmdb.RPMTAG_FILESIZES].int32s()
+	usernames := entries[rpmdb.RPMTAG_FILEUSERNAME].strings()
+	groupnames := entries[rpmdb.RPMTAG_FILEGROUPNAME].strings()
+	flags := entries[rpmdb.RPMTAG_FILEFLAGS].int32s()
+
+	var files []rpmdb.FileInfo
+	for i, basename := range basenames {
+		if i >= len(dirIndexes) || int(dirIndexes[i]) < 0 || int(dirIndexes[i]) >= len(dirnames) {
+			return nil, fmt.Errorf("invalid directory index of file=%q", basename)
+		}
+
+		record := rpmdb.FileInfo{
+			Path: dirnames[dirIndexes[i]] + basename,
+		}
+		if i < len(digests) {
+			record.Digest = digests[i]
+		}
+		if i < len(modes) {
+			record.Mode = modes[i]
+		}
+		if i < len(sizes) {
+			record.Size = sizes[i]
+		}
+		if i < len(usernames) {
+			record.Username = usernames[i]
+		}
+		if i < len(groupnames) {
+			record.Groupname = groupnames[i]
+		}
+		if i < len(flags) {
+			record.Flags = rpmdb.FileFlags(flags[i])
+		}
+		files = append(files, record)
+	}
+	return files, nil
+}
@@ -0,0 +1,179 @@
+package rpmdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	rpmdb "github.com/anchore/go-rpmdb/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common/rpm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRpmHeaderTag struct {
+	tag   int32
+	kind  uint32
+	count uint32
+	data  []byte
+}
+
+func newTestRpmHeaderBlob(tags ...testRpmHeaderTag) []byte {
+	var index, store bytes.Buffer
+	for _, tag := range tags {
+		entry := make([]byte, rpmHeaderIndexEntrySize)
+		binary.BigEndian.PutUint32(entry[0:4], uint32(tag.tag))
+		binary.BigEndian.PutUint32(entry[4:8], tag.kind)
+		binary.BigEndian.PutUint32(entry[8:12], uint32(store.Len()))
+		binary.BigEndian.PutUint32(entry[12:16], tag.count)
+		index.Write(entry)
+		store.Write(tag.data)
+	}
+
+	blob := make([]byte, 8)
+	binary.BigEndian.PutUint32(blob[0:4], uint32(len(tags)))
+	binary.BigEndian.PutUint32(blob[4:8], uint32(store.Len()))
+	return append(append(blob, index.Bytes()...), store.Bytes()...)
+}
+
+func stringTag(tag int32, value string) testRpmHeaderTag {
+	return testRpmHeaderTag{tag: tag, kind: rpmdb.RPM_STRING_TYPE, count: 1, data: []byte(value + "\x00")}
+}
+
+func stringArrayTag(tag int32, values ...string) testRpmHeaderTag {
+	var data []byte
+	for _, v := range values {
+		data = append(data, []byte(v+"\x00")...)
+	}
+	return testRpmHeaderTag{tag: tag, kind: rpmdb.RPM_STRING_ARRAY_TYPE, count: uint32(len(values)), data: data}
+}
+
+func int32Tag(tag int32, values ...int32) testRpmHeaderTag {
+	data := make([]byte, 4*len(values))
+	for i, v := range values {
+		binary.BigEndian.PutUint32(data[i*4:], uint32(v))
+	}
+	return testRpmHeaderTag{tag: tag, kind: rpmdb.RPM_INT32_TYPE, count: uint32(len(values)), data: data}
+}
+
+func int16Tag(tag int32, values ...uint16) testRpmHeaderTag {
+	data := make([]byte, 2*len(values))
+	for i, v := range values {
+		binary.BigEndian.PutUint16(data[i*2:], v)
+	}
+	return testRpmHeaderTag{tag: tag, kind: rpmdb.RPM_INT16_TYPE, count: uint32(len(values)), data: data}
+}
+
+func TestParseRpmHeaderBlob(t *testing.T) {
+	blob := newTestRpmHeaderBlob(
+		stringTag(rpmdb.RPMTAG_NAME, "bash"),
+		stringTag(rpmdb.RPMTAG_VERSION, "5.1.8"),
+		stringTag(rpmdb.RPMTAG_RELEASE, "6.oe2203"),
+		int32Tag(rpmdb.RPMTAG_EPOCH, 1),
+		stringTag(rpmdb.RPMTAG_ARCH, "x86_64"),
+		stringTag(rpmdb.RPMTAG_SOURCERPM, "bash-5.1.8-6.oe2203.src.rpm"),
+		int32Tag(rpmdb.RPMTAG_SIZE, 1024),
+		stringTag(rpmdb.RPMTAG_LICENSE, "GPLv3+"),
+		stringTag(rpmdb.RPMTAG_VENDOR, rpmNoneValue),
+		int32Tag(rpmdb.RPMTAG_FILEDIGESTALGO, int32(rpmdb.PGPHASHALGO_SHA256)),
+		stringArrayTag(rpmdb.RPMTAG_DIRNAMES, "/usr/bin/", "/usr/share/doc/bash/"),
+		int32Tag(rpmdb.RPMTAG_DIRINDEXES, 0, 1),
+		stringArrayTag(rpmdb.RPMTAG_BASENAMES, "bash", "FAQ"),
+		int16Tag(rpmdb.RPMTAG_FILEMODES, 0o100755, 0o100644),
+		int32Tag(rpmdb.RPMTAG_FILESIZES, 1000, 24),
+		stringArrayTag(rpmdb.RPMTAG_FILEDIGESTS, "abc", "def"),
+		stringArrayTag(rpmdb.RPMTAG_FILEUSERNAME, "root", "root"),
+		stringArrayTag(rpmdb.RPMTAG_FILEGROUPNAME, "root", "root"),
+		int32Tag(rpmdb.RPMTAG_FILEFLAGS, 0, int32(rpmdb.RPMFILE_DOC)),
+		testRpmHeaderTag{tag: rpmTagSigMD5, kind: rpmdb.RPM_BIN_TYPE, count: 4, data: []byte{0xde, 0xad, 0xbe, 0xef}},
+		stringTag(rpmTagSHA256Header, "0123abcd"),
+		testRpmHeaderTag{tag: rpmTagSummary, kind: rpmdb.RPM_I18NSTRING_TYPE, count: 1, data: []byte("The GNU Bourne Again shell\x00")},
+		testRpmHeaderTag{tag: rpmTagDescription, kind: rpmdb.RPM_I18NSTRING_TYPE, count: 1, data: []byte("The GNU Bourne Again shell (Bash) is a shell.\x00")},
+		stringTag(rpmTagPackager, "openEuler"),
+		stringTag(rpmTagURL, "https://www.gnu.org/software/bash"),
+		int32Tag(rpmTagBuildTime, 1650000000),
+		stringArrayTag(rpmTagProvideName, "bash", "/bin/sh"),
+		int32Tag(rpmTagProvideFlags, 8, 0),
+		stringArrayTag(rpmTagProvideVersion, "5.1.8-6.oe2203", ""),
+		stringArrayTag(rpmTagRequireName, "filesystem", "libc.so.6()(64bit)"),
+		int32Tag(rpmTagRequireFlags, 1<<9|12, 0),
+		stringArrayTag(rpmTagRequireVersion, "3", ""),
+	)
+
+	epoch := 1
+	expected := &rpmdbEntry{
+		PackageInfo: rpmdb.PackageInfo{
+			Epoch:           &epoch,
+			Name:            "bash",
+			Version:         "5.1.8",
+			Release:         "6.oe2203",
+			Arch:            "x86_64",
+			SourceRpm:       "bash-5.1.8-6.oe2203.src.rpm",
+			Size:            1024,
+			License:         "GPLv3+",
+			DigestAlgorithm: rpmdb.PGPHASHALGO_SHA256,
+			Files: []rpmdb.FileInfo{
+				{Path: "/usr/bin/bash", Mode: 0o100755, Digest: "abc", Size: 1000, Username: "root", Groupname: "root"},
+				{Path: "/usr/share/doc/bash/FAQ", Mode: 0o100644, Digest: "def", Size: 24, Username: "root", Groupname: "root", Flags: rpmdb.FileFlags(rpmdb.RPMFILE_DOC)},
+			},
+		},
+		Packager:     "openEuler",
+		URL:          "https://www.gnu.org/software/bash",
+		Summary:      "The GNU Bourne Again shell",
+		Description:  "The GNU Bourne Again shell (Bash) is a shell.",
+		SHA256Header: "0123abcd",
+		SigMD5:       "deadbeef",
+		BuildTime:    1650000000,
+		Provides: []rpm.Capability{
+			{Name: "bash", Flags: 8, EVR: "5.1.8-6.oe2203"},
+			{Name: "/bin/sh"},
+		},
+		Requires: []rpm.Capability{
+			{Name: "filesystem", Flags: 1<<9 | 12, EVR: "3"},
+			{Name: "libc.so.6()(64bit)"},
+		},
+	}
+
+	actual, err := parseRpmHeaderBlob(blob)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestParseRpmHeaderBlob_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		blob []byte
+	}{
+		{
+			name: "too short",
+			blob: []byte{0, 0, 0},
+		},
+		{
+			name: "truncated data store",
+			blob: newTestRpmHeaderBlob(stringTag(rpmdb.RPMTAG_NAME, "bash"))[:20],
+		},
+		{
+			name: "unterminated string",
+			blob: newTestRpmHeaderBlob(testRpmHeaderTag{tag: rpmdb.RPMTAG_NAME, kind: rpmdb.RPM_STRING_TYPE, count: 1, data: []byte("bash")}),
+		},
+		{
+			name: "unexpected type",
+			blob: newTestRpmHeaderBlob(int32Tag(rpmdb.RPMTAG_NAME, 1)),
+		},
+		{
+			name: "invalid directory index",
+			blob: newTestRpmHeaderBlob(
+				stringArrayTag(rpmdb.RPMTAG_DIRNAMES, "/usr/bin/"),
+				int32Tag(rpmdb.RPMTAG_DIRINDEXES, 1),
+				stringArrayTag(rpmdb.RPMTAG_BASENAMES, "bash"),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseRpmHeaderBlob(test.blob)
+			assert.Error(t, err)
+		})
+	}
+}
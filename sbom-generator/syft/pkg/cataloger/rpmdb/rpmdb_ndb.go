@@ -0,0 +1,129 @@
+package rpmdb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/anchore/syft/internal/log"
+)
+
+// the layout of a ndb rpmdb ("Packages.db", used by SUSE), all values are little endian.
+// ref. https://github.com/rpm-software-management/rpm/blob/rpm-4.16.0-release/lib/backend/ndb/rpmpkg.c
+const (
+	ndbHeaderMagic    = 'R' | 'p'<<8 | 'm'<<16 | 'P'<<24
+	ndbSlotMagic      = 'S' | 'l'<<8 | 'o'<<16 | 't'<<24
+	ndbBlobMagic      = 'B' | 'l'<<8 | 'b'<<16 | 'S'<<24
+	ndbVersion        = 0
+	ndbHeaderSize     = 32
+	ndbPageSize       = 4096
+	ndbSlotSize       = 16
+	ndbBlockSize      = 16
+	ndbBlobHeaderSize = 16
+)
+
+type ndbHeader struct {
+	Magic      uint32
+	Version    uint32
+	Generation uint32
+	SlotNPages uint32
+	_          [4]uint32
+}
+
+type ndbSlot struct {
+	Magic     uint32
+	PkgIndex  uint32
+	BlkOffset uint32
+	BlkCount  uint32
+}
+
+type ndbBlobHeader struct {
+	Magic      uint32
+	PkgIndex   uint32
+	Generation uint32
+	BlobLength uint32
+}
+
+// readNdbHeaderBlobs reads the package header blobs of a ndb rpmdb: a header, the slots locating the blob of every
+// package in the following pages, and the blobs themselves.
+func readNdbHeaderBlobs(dbPath string) ([][]byte, error) {
+	f, err := os.Open(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open ndb rpmdb: %w", err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Errorf("unable to close ndb rpmdb: %+v", err)
+		}
+	}()
+
+	var header ndbHeader
+	if err := binary.Read(f, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("unable to read ndb rpmdb header: %w", err)
+	}
+	if header.Magic != ndbHeaderMagic {
+		return nil, fmt.Errorf("invalid ndb rpmdb header magic: %x", header.Magic)
+	}
+	if header.Version != ndbVersion {
+		return nil, fmt.Errorf("unsupported ndb rpmdb version: %d", header.Version)
+	}
+
+	// the header takes the place of the first slots of the first page
+	slotCount := int(header.SlotNPages)*ndbPageSize/ndbSlotSize - ndbHeaderSize/ndbSlotSize
+	if slotCount < 0 {
+		return nil, fmt.Errorf("invalid ndb rpmdb slot page count: %d", header.SlotNPages)
+	}
+
+	var slots []ndbSlot
+	for i := 0; i < slotCount; i++ {
+		var slot ndbSlot
+		if err := binary.Read(f, binary.LittleEndian, &slot); err != nil {
+			return nil, fmt.Errorf("unable to read ndb rpmdb slot: %w", err)
+		}
+		if slot.Magic != ndbSlotMagic {
+			return nil, fmt.Errorf("invalid ndb rpmdb slot magic: %x", slot.Magic)
+		}
+		// free slots have no package index
+		if slot.PkgIndex == 0 {
+			continue
+		}
+		slots = append(slots, slot)
+	}
+
+	var blobs [][]byte
+	for _, slot := range slots {
+		blob, err := readNdbBlob(f, slot)
+		if err != nil {
+			return nil, err
+		}
+		blobs = append(blobs, blob)
+	}
+	return blobs, nil
+}
+
+func readNdbBlob(f io.ReadSeeker, slot ndbSlot) ([]byte, error) {
+	if _, err := f.Seek(int64(slot.BlkOffset)*ndbBlockSize, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("unable to seek ndb rpmdb blob of package=%d: %w", slot.PkgIndex, err)
+	}
+
+	var header ndbBlobHeader
+	if err := binary.Read(f, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("unable to read ndb rpmdb blob header of package=%d: %w", slot.PkgIndex, err)
+	}
+	if header.Magic != ndbBlobMagic {
+		return nil, fmt.Errorf("invalid ndb rpmdb blob magic of package=%d: %x", slot.PkgIndex, header.Magic)
+	}
+	if header.PkgIndex != slot.PkgIndex {
+		return nil, fmt.Errorf("ndb rpmdb blob of package=%d belongs to package=%d", slot.PkgIndex, header.PkgIndex)
+	}
+	if uint64(header.BlobLength)+ndbBlobHeaderSize > uint64(slot.BlkCount)*ndbBlockSize {
+		return nil, fmt.Errorf("ndb rpmdb blob of package=%d exceeds its slot", slot.PkgIndex)
+	}
+
+	blob := make([]byte, header.BlobLength)
+	if _, err := io.ReadFull(f, blob); err != nil {
+		return nil, fmt.Errorf("unable to read ndb rpmdb blob of package=%d: %w", slot.PkgIndex, err)
+	}
+	return blob, nil
+}
@@ -0,0 +1,43 @@
+package rpmdb
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/anchore/syft/internal/log"
+	_ "modernc.org/sqlite"
+)
+
+// sqliteMagic is the header string of every sqlite database file.
+const sqliteMagic = "SQLite format 3\x00"
+
+// readSqliteHeaderBlobs reads the package header blobs of a sqlite rpmdb ("rpmdb.sqlite", the default backend since
+// rpm 4.16), which stores one header blob per row of the "Packages" table.
+// ref. https://github.com/rpm-software-management/rpm/blob/rpm-4.16.0-release/lib/backend/sqlite.c
+func readSqliteHeaderBlobs(dbPath string) ([][]byte, error) {
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open sqlite rpmdb: %w", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Errorf("unable to close sqlite rpmdb: %+v", err)
+		}
+	}()
+
+	rows, err := db.Query("SELECT blob FROM Packages ORDER BY hnum")
+	if err != nil {
+		return nil, fmt.Errorf("unable to query sqlite rpmdb: %w", err)
+	}
+	defer rows.Close()
+
+	var blobs [][]byte
+	for rows.Next() {
+		var blob []byte
+		if err := rows.Scan(&blob); err != nil {
+			return nil, fmt.Errorf("unable to read sqlite rpmdb row: %w", err)
+		}
+		blobs = append(blobs, blob)
+	}
+	return blobs, rows.Err()
+}
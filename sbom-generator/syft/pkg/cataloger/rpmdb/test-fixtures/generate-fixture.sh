@@ -1,23 +1,41 @@
 #!/usr/bin/env bash
 set -eux
 
-docker create --name generate-rpmdb-fixture centos:8 sh -c 'tail -f /dev/null'
+# generate_fixture <image> <rpmdb file> [rpm args...] installs dive into an empty rpmdb of the backend used by the
+# given image (or selected by the given rpm args) and copies the rpmdb out of the container.
+function generate_fixture {
+  local image=$1
+  local db=$2
+  shift 2
+  local rpm_args="$*"
+
+  docker create --name generate-rpmdb-fixture "${image}" sh -c 'tail -f /dev/null'
+  trap cleanup EXIT
+
+  docker start generate-rpmdb-fixture
+  docker exec -i --tty=false generate-rpmdb-fixture bash <<-EOF
+    mkdir -p /scratch
+    cd /scratch
+    rpm ${rpm_args} --initdb --dbpath /scratch
+    curl -sSLO https://github.com/wagoodman/dive/releases/download/v0.9.2/dive_0.9.2_linux_amd64.rpm
+    rpm ${rpm_args} --dbpath /scratch -ivh dive_0.9.2_linux_amd64.rpm
+    rm dive_0.9.2_linux_amd64.rpm
+    rpm ${rpm_args} --dbpath /scratch -qa
+EOF
+
+  docker cp "generate-rpmdb-fixture:/scratch/${db}" .
+  cleanup
+  trap - EXIT
+}
 
 function cleanup {
   docker kill generate-rpmdb-fixture
   docker rm generate-rpmdb-fixture
 }
-trap cleanup EXIT
-
-docker start generate-rpmdb-fixture
-docker exec -i --tty=false generate-rpmdb-fixture bash <<-EOF
-  mkdir -p /scratch
-  cd /scratch
-  rpm --initdb --dbpath /scratch
-  curl -sSLO https://github.com/wagoodman/dive/releases/download/v0.9.2/dive_0.9.2_linux_amd64.rpm
-  rpm --dbpath /scratch -ivh dive_0.9.2_linux_amd64.rpm
-  rm dive_0.9.2_linux_amd64.rpm
-  rpm --dbpath /scratch -qa
-EOF
 
-docker cp generate-rpmdb-fixture:/scratch/Packages .
+# berkeley db
+generate_fixture centos:8 Packages
+# sqlite (the default backend since rpm 4.16)
+generate_fixture fedora:35 rpmdb.sqlite
+# ndb (the default backend of SUSE)
+generate_fixture opensuse/leap:15.4 Packages.db --define "'_db_backend ndb'"
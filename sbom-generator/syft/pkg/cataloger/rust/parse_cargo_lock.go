@@ -3,6 +3,7 @@ package rust
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
@@ -26,5 +27,43 @@ func parseCargoLock(_ string, reader io.Reader) ([]*pkg.Package, []artifact.Rela
 		return nil, nil, fmt.Errorf("unable to parse Cargo.lock: %v", err)
 	}
 
-	return metadata.Pkgs(), nil, nil
+	pkgs := metadata.Pkgs()
+	return pkgs, cargoLockRelationships(pkgs), nil
+}
+
+// cargoLockRelationships relates the crates of a Cargo.lock to the crates they depend on. Dependencies are listed by
+// name, and qualified by version (and source) only when several versions of the crate are locked, e.g. "winapi" or
+// "winapi 0.3.9" or "winapi 0.3.9 (registry+https://github.com/rust-lang/crates.io-index)".
+func cargoLockRelationships(pkgs []*pkg.Package) []artifact.Relationship {
+	byName := make(map[string][]*pkg.Package)
+	for _, p := range pkgs {
+		byName[p.Name] = append(byName[p.Name], p)
+	}
+
+	var relationships []artifact.Relationship
+	for _, p := range pkgs {
+		metadata, ok := p.Metadata.(pkg.CargoPackageMetadata)
+		if !ok {
+			continue
+		}
+		for _, dependency := range metadata.Dependencies {
+			fields := strings.Fields(dependency)
+			if len(fields) == 0 {
+				continue
+			}
+
+			var resolved *pkg.Package
+			for _, candidate := range byName[fields[0]] {
+				if len(fields) == 1 || candidate.Version == fields[1] {
+					resolved = candidate
+					break
+				}
+			}
+			if resolved == nil || resolved == p {
+				continue
+			}
+			relationships = append(relationships, common.NewDependencyRelationship(p, resolved, artifact.DependsOnRelationship))
+		}
+	}
+	return relationships
 }
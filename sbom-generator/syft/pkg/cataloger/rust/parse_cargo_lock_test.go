@@ -1,11 +1,14 @@
 package rust
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
+	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestParseCargoLock(t *testing.T) {
@@ -177,8 +180,7 @@ func TestParseCargoLock(t *testing.T) {
 		t.Fatalf("failed to open fixture: %+v", err)
 	}
 
-	// TODO: no relationships are under test yet
-	actual, _, err := parseCargoLock(fixture.Name(), fixture)
+	actual, relationships, err := parseCargoLock(fixture.Name(), fixture)
 	if err != nil {
 		t.Error(err)
 	}
@@ -187,4 +189,44 @@ func TestParseCargoLock(t *testing.T) {
 	if differences != nil {
 		t.Errorf("returned package list differed from expectation: %+v", differences)
 	}
+
+	assert.ElementsMatch(t, []string{
+		"ansi_term@0.12.1 -> winapi@0.3.9",
+		"nom@4.2.3 -> memchr@2.3.3",
+		"nom@4.2.3 -> version_check@0.1.5",
+		"unicode-bidi@0.3.4 -> matches@0.1.8",
+		"winapi@0.3.9 -> winapi-i686-pc-windows-gnu@0.4.0",
+		"winapi@0.3.9 -> winapi-x86_64-pc-windows-gnu@0.4.0",
+	}, relationshipStrings(t, relationships))
+}
+
+func TestParseCargoLock_MultipleVersions(t *testing.T) {
+	fixture, err := os.Open("test-fixtures/multiple-versions/Cargo.lock")
+	if err != nil {
+		t.Fatalf("failed to open fixture: %+v", err)
+	}
+
+	actual, relationships, err := parseCargoLock(fixture.Name(), fixture)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Len(t, actual, 4)
+	assert.ElementsMatch(t, []string{
+		"app@0.1.0 -> bitflags@1.3.2",
+		"app@0.1.0 -> syn@2.0.15",
+		"syn@2.0.15 -> bitflags@2.2.1",
+	}, relationshipStrings(t, relationships))
+}
+
+// relationshipStrings represents DEPENDS_ON relationships between discovered crates as "from@version -> to@version".
+func relationshipStrings(t *testing.T, relationships []artifact.Relationship) []string {
+	t.Helper()
+	var result []string
+	for _, r := range relationships {
+		assert.Equal(t, artifact.DependsOnRelationship, r.Type)
+		from, to := r.From.(*pkg.Package), r.To.(*pkg.Package)
+		result = append(result, fmt.Sprintf("%s@%s -> %s@%s", from.Name, from.Version, to.Name, to.Version))
+	}
+	return result
 }
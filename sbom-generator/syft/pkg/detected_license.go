@@ -0,0 +1,22 @@
+package pkg
+
+// DetectedLicense is a license classified from the contents of a license file found with a package (e.g. a LICENSE,
+// COPYING or NOTICE file), rather than declared by the package metadata.
+type DetectedLicense struct {
+	License    string  `json:"license"`    // the SPDX license ID (or the OR expression of the IDs the file matches alike, e.g. "GPL-2.0-only OR GPL-2.0-or-later")
+	Confidence float64 `json:"confidence"` // the share of the license template found in the file (from 0 to 1)
+	Path       string  `json:"path"`       // the path of the license file (within the archive path, for files in archives)
+}
+
+// mergeDetectedLicenses returns the detected licenses of both packages, without duplicates.
+func mergeDetectedLicenses(a, b []DetectedLicense) (result []DetectedLicense) {
+	seen := make(map[DetectedLicense]bool)
+	for _, l := range append(append([]DetectedLicense{}, a...), b...) {
+		if seen[l] {
+			continue
+		}
+		seen[l] = true
+		result = append(result, l)
+	}
+	return result
+}
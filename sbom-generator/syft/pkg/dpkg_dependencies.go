@@ -0,0 +1,255 @@
+package pkg
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/anchore/syft/syft/artifact"
+)
+
+// dpkgDependency is a single alternative of a dpkg package relationship, e.g. "libc6:any (>= 2.34)".
+type dpkgDependency struct {
+	Name     string
+	Operator string
+	Version  string
+}
+
+// parseDpkgRelationship parses the alternatives of a package relationship entry ("gpgv | gpgv2 (>= 2.1)"), dropping
+// the architecture qualifiers, architecture restrictions and build profiles.
+// See https://www.debian.org/doc/debian-policy/ch-relationships.html
+func parseDpkgRelationship(entry string) []dpkgDependency {
+	var alternatives []dpkgDependency
+	for _, alternative := range strings.Split(entry, "|") {
+		alternative = strings.TrimSpace(alternative)
+		if i := strings.IndexAny(alternative, "[<"); i >= 0 && !strings.Contains(alternative[:i], "(") {
+			alternative = strings.TrimSpace(alternative[:i])
+		}
+
+		var dependency dpkgDependency
+		if i := strings.Index(alternative, "("); i >= 0 {
+			constraint := strings.TrimSuffix(strings.TrimSpace(alternative[i+1:]), ")")
+			if j := strings.Index(constraint, ")"); j >= 0 {
+				constraint = constraint[:j]
+			}
+			constraint = strings.TrimSpace(constraint)
+			operatorEnd := strings.IndexFunc(constraint, func(r rune) bool {
+				return !strings.ContainsRune("<>=", r)
+			})
+			if operatorEnd > 0 {
+				dependency.Operator = constraint[:operatorEnd]
+				dependency.Version = strings.TrimSpace(constraint[operatorEnd:])
+			}
+			alternative = strings.TrimSpace(alternative[:i])
+		}
+
+		// multi-arch qualifiers (e.g. "python3:any") don't change the name of the package
+		if i := strings.Index(alternative, ":"); i >= 0 {
+			alternative = alternative[:i]
+		}
+		dependency.Name = alternative
+		if dependency.Name != "" {
+			alternatives = append(alternatives, dependency)
+		}
+	}
+	return alternatives
+}
+
+func (d dpkgDependency) satisfiedBy(c providedCapability) bool {
+	if d.Operator == "" {
+		return true
+	}
+	// unversioned virtual packages never satisfy versioned dependencies
+	if c.Version == "" {
+		return false
+	}
+
+	comparison := compareDpkgVersions(c.Version, d.Version)
+	switch d.Operator {
+	case "<<":
+		return comparison < 0
+	case "<=", "<":
+		return comparison <= 0
+	case "=":
+		return comparison == 0
+	case ">=", ">":
+		return comparison >= 0
+	case ">>":
+		return comparison > 0
+	}
+	return false
+}
+
+func dpkgDependencyRelationships(catalog *Catalog) []artifact.Relationship {
+	pkgs := catalog.Sorted(DebPkg)
+
+	providers := make(capabilityIndex)
+	for _, p := range pkgs {
+		metadata, ok := p.Metadata.(DpkgMetadata)
+		if !ok {
+			continue
+		}
+		providers.add(p.Name, p, metadata.Version)
+		for _, provide := range metadata.Provides {
+			for _, virtual := range parseDpkgRelationship(provide) {
+				// only exactly versioned provides give a version to the virtual package
+				var version string
+				if virtual.Operator == "=" {
+					version = virtual.Version
+				}
+				providers.add(virtual.Name, p, version)
+			}
+		}
+	}
+
+	edges := newDependencyEdges()
+	for _, p := range pkgs {
+		metadata, ok := p.Metadata.(DpkgMetadata)
+		if !ok {
+			continue
+		}
+		for _, entry := range metadata.PreDepends {
+			for _, dependency := range resolveDpkgRelationship(p, metadata, entry, providers) {
+				edges.add(p, dependency, artifact.HasPrerequisiteRelationship)
+			}
+		}
+		for _, entry := range metadata.Depends {
+			for _, dependency := range resolveDpkgRelationship(p, metadata, entry, providers) {
+				edges.add(p, dependency, artifact.DependsOnRelationship)
+			}
+		}
+	}
+	return edges.relationships()
+}
+
+// resolveDpkgRelationship returns the installed packages satisfying the first satisfiable alternative of a package
+// relationship, preferring the packages of the same architecture as the depending package.
+func resolveDpkgRelationship(p Package, metadata DpkgMetadata, entry string, providers capabilityIndex) []Package {
+	for _, alternative := range parseDpkgRelationship(entry) {
+		var satisfying []providedCapability
+		for _, c := range providers[alternative.Name] {
+			if alternative.satisfiedBy(c) {
+				satisfying = append(satisfying, c)
+			}
+		}
+		if len(satisfying) == 0 {
+			continue
+		}
+		if satisfiedBySelf(p, satisfying) {
+			return nil
+		}
+
+		var sameArch []providedCapability
+		for _, c := range satisfying {
+			if arch := c.Package.Metadata.(DpkgMetadata).Architecture; arch == metadata.Architecture || arch == "all" || metadata.Architecture == "all" {
+				sameArch = append(sameArch, c)
+			}
+		}
+		if len(sameArch) > 0 {
+			satisfying = sameArch
+		}
+		return uniquePackages(satisfying)
+	}
+	return nil
+}
+
+// compareDpkgVersions compares two "[epoch:]upstream[-revision]" versions the way dpkg does.
+// See https://www.debian.org/doc/debian-policy/ch-controlfields.html#version
+func compareDpkgVersions(a, b string) int {
+	aEpoch, aUpstream, aRevision := splitDpkgVersion(a)
+	bEpoch, bUpstream, bRevision := splitDpkgVersion(b)
+
+	if aEpoch != bEpoch {
+		if aEpoch < bEpoch {
+			return -1
+		}
+		return 1
+	}
+	if c := compareDpkgVersionPart(aUpstream, bUpstream); c != 0 {
+		return c
+	}
+	return compareDpkgVersionPart(aRevision, bRevision)
+}
+
+func splitDpkgVersion(version string) (int, string, string) {
+	var epoch int
+	if i := strings.Index(version, ":"); i >= 0 {
+		epoch, _ = strconv.Atoi(version[:i])
+		version = version[i+1:]
+	}
+	var revision string
+	if i := strings.LastIndex(version, "-"); i >= 0 {
+		revision = version[i+1:]
+		version = version[:i]
+	}
+	return epoch, version, revision
+}
+
+// dpkgCharacterOrder sorts "~" before anything (even the end of the part), then letters, then the other characters.
+func dpkgCharacterOrder(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+	c := rune(s[i])
+	switch {
+	case unicode.IsDigit(c):
+		return 0
+	case unicode.IsLetter(c):
+		return int(c)
+	case c == '~':
+		return -1
+	}
+	return int(c) + 256
+}
+
+// compareDpkgVersionPart compares alternating non-digit and digit runs of an upstream version or revision (see
+// verrevcmp in dpkg).
+func compareDpkgVersionPart(a, b string) int {
+	isDigit := func(s string, i int) bool {
+		return i < len(s) && s[i] >= '0' && s[i] <= '9'
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for (i < len(a) && !isDigit(a, i)) || (j < len(b) && !isDigit(b, j)) {
+			ac, bc := dpkgCharacterOrder(a, i), dpkgCharacterOrder(b, j)
+			if ac != bc {
+				if ac < bc {
+					return -1
+				}
+				return 1
+			}
+			i++
+			j++
+		}
+
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+
+		firstDiff := 0
+		for isDigit(a, i) && isDigit(b, j) {
+			if firstDiff == 0 {
+				firstDiff = int(a[i]) - int(b[j])
+			}
+			i++
+			j++
+		}
+		if isDigit(a, i) {
+			return 1
+		}
+		if isDigit(b, j) {
+			return -1
+		}
+		if firstDiff != 0 {
+			if firstDiff < 0 {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,120 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDpkgRelationship(t *testing.T) {
+	tests := []struct {
+		entry    string
+		expected []dpkgDependency
+	}{
+		{
+			entry:    "libc6 (>= 2.34)",
+			expected: []dpkgDependency{{Name: "libc6", Operator: ">=", Version: "2.34"}},
+		},
+		{
+			entry: "gpgv | gpgv2 (>>2.1)",
+			expected: []dpkgDependency{
+				{Name: "gpgv"},
+				{Name: "gpgv2", Operator: ">>", Version: "2.1"},
+			},
+		},
+		{
+			entry:    "python3:any (<< 3.11) [amd64] <!nocheck>",
+			expected: []dpkgDependency{{Name: "python3", Operator: "<<", Version: "3.11"}},
+		},
+		{
+			entry:    "debconf [!s390x] <!stage1>",
+			expected: []dpkgDependency{{Name: "debconf"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.entry, func(t *testing.T) {
+			assert.Equal(t, test.expected, parseDpkgRelationship(test.entry))
+		})
+	}
+}
+
+func TestCompareDpkgVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "1.0", b: "1.0", expected: 0},
+		{a: "1.0", b: "1.00", expected: 0},
+		{a: "1.0", b: "1.1", expected: -1},
+		{a: "1.10", b: "1.9", expected: 1},
+		{a: "1:0.1", b: "2.0", expected: 1},
+		{a: "2.0~rc1", b: "2.0", expected: -1},
+		{a: "2.0~rc1", b: "2.0~~", expected: 1},
+		{a: "2.0", b: "2.0+deb11u1", expected: -1},
+		{a: "2.0a", b: "2.0+", expected: -1},
+		{a: "2.31-13+deb11u3", b: "2.31-13", expected: 1},
+		{a: "2.31-13", b: "2.31-9", expected: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.a+" vs "+test.b, func(t *testing.T) {
+			assert.Equal(t, test.expected, compareDpkgVersions(test.a, test.b))
+			assert.Equal(t, -test.expected, compareDpkgVersions(test.b, test.a))
+		})
+	}
+}
+
+func TestDpkgDependencyRelationships(t *testing.T) {
+	newDpkg := func(metadata DpkgMetadata) Package {
+		p := Package{
+			Name:         metadata.Package,
+			Version:      metadata.Version,
+			Type:         DebPkg,
+			MetadataType: DpkgMetadataType,
+			Metadata:     metadata,
+		}
+		p.SetID()
+		return p
+	}
+
+	app := newDpkg(DpkgMetadata{
+		Package:      "app",
+		Version:      "1.0-1",
+		Architecture: "amd64",
+		PreDepends:   []string{"libc6 (>= 2.34)"},
+		Depends: []string{
+			"libc6 (>= 2.31)",
+			"missing | mawk | gawk",
+			"default-mta | mail-transport-agent",
+			"libold (>= 2)",
+			"app-data (= 1.0-1)",
+		},
+	})
+	libc := newDpkg(DpkgMetadata{Package: "libc6", Version: "2.36-9", Architecture: "amd64"})
+	libcI386 := newDpkg(DpkgMetadata{Package: "libc6", Version: "2.36-9", Architecture: "i386"})
+	mawk := newDpkg(DpkgMetadata{Package: "mawk", Version: "1.3.4", Architecture: "amd64", Provides: []string{"awk"}})
+	gawk := newDpkg(DpkgMetadata{Package: "gawk", Version: "1:5.2.1", Architecture: "amd64", Provides: []string{"awk"}})
+	postfix := newDpkg(DpkgMetadata{Package: "postfix", Version: "3.7", Architecture: "amd64", Provides: []string{"mail-transport-agent"}})
+	libnew := newDpkg(DpkgMetadata{Package: "libnew", Version: "3.0", Architecture: "amd64", Provides: []string{"libold (= 3)"}})
+	appData := newDpkg(DpkgMetadata{Package: "app-data", Version: "1.0-2", Architecture: "all"})
+	self := newDpkg(DpkgMetadata{Package: "awk-user", Version: "1", Architecture: "amd64", Provides: []string{"awk"}, Depends: []string{"awk"}})
+	versionedVirtual := newDpkg(DpkgMetadata{Package: "strict", Version: "1", Architecture: "amd64", Depends: []string{"mail-transport-agent (>= 1)"}})
+
+	catalog := NewCatalog(app, libc, libcI386, mawk, gawk, postfix, libnew, appData, self, versionedVirtual)
+
+	assert.ElementsMatch(t, []artifact.Relationship{
+		// the pre-dependency is the stronger of the two relationships to libc6, of the same architecture only
+		{From: app, To: libc, Type: artifact.HasPrerequisiteRelationship},
+		// the first satisfiable alternative wins
+		{From: app, To: mawk, Type: artifact.DependsOnRelationship},
+		// virtual packages relate to every provider
+		{From: app, To: postfix, Type: artifact.DependsOnRelationship},
+		// versioned provides satisfy versioned dependencies
+		{From: app, To: libnew, Type: artifact.DependsOnRelationship},
+		// "app-data (= 1.0-1)" is not satisfied by the installed 1.0-2
+		// the awk-user package provides "awk" itself, and unversioned virtual packages don't satisfy versioned
+		// dependencies of the strict package
+	}, RelationshipsByDependencies(catalog))
+}
@@ -19,7 +19,9 @@ var (
 )
 
 // DpkgMetadata represents all captured data for a Debian package DB entry; available fields are described
-// at http://manpages.ubuntu.com/manpages/xenial/man1/dpkg-query.1.html in the --showformat section.
+// at http://manpages.ubuntu.com/manpages/xenial/man1/dpkg-query.1.html in the --showformat section. Depends, PreDepends
+// and Provides hold one entry per package relationship (e.g. "libc6 (>= 2.34)"), with the alternatives of an entry
+// kept together (e.g. "default-mta | mail-transport-agent").
 type DpkgMetadata struct {
 	Package       string           `mapstructure:"Package" json:"package"`
 	Source        string           `mapstructure:"Source" json:"source" cyclonedx:"source"`
@@ -28,6 +30,9 @@ type DpkgMetadata struct {
 	Architecture  string           `mapstructure:"Architecture" json:"architecture"`
 	Maintainer    string           `mapstructure:"Maintainer" json:"maintainer"`
 	InstalledSize int              `mapstructure:"InstalledSize" json:"installedSize" cyclonedx:"installedSize"`
+	Depends       []string         `mapstructure:"-" json:"depends,omitempty"`
+	PreDepends    []string         `mapstructure:"-" json:"preDepends,omitempty"`
+	Provides      []string         `mapstructure:"-" json:"provides,omitempty"`
 	Files         []DpkgFileRecord `json:"files"`
 }
 
@@ -0,0 +1,38 @@
+package pkg
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"github.com/anchore/syft/syft/file"
+)
+
+// h1DigestPrefix is the prefix of the go module hashes of go.sum files and build info (the "h1" hash is the SHA-256 of a
+// summary of the SHA-256 of each file of the module, see golang.org/x/mod/sumdb/dirhash).
+const h1DigestPrefix = "h1:"
+
+// golangDigests returns the SHA-256 digest encoded by a go module hash, which identifies the module content the way a
+// package checksum does (though it is the digest of the dirhash summary of the module files, not of an archive).
+func golangDigests(h1Digest string) []file.Digest {
+	if !strings.HasPrefix(h1Digest, h1DigestPrefix) {
+		return nil
+	}
+	sum, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(h1Digest, h1DigestPrefix))
+	if err != nil || len(sum) != 32 {
+		return nil
+	}
+	return []file.Digest{{Algorithm: "sha256", Value: hex.EncodeToString(sum)}}
+}
+
+// GolangModuleDigests returns the checksum of a go module package, from its hash in the build info of a binary or in a
+// go.sum file.
+func GolangModuleDigests(p Package) []file.Digest {
+	switch metadata := p.Metadata.(type) {
+	case GolangBinMetadata:
+		return golangDigests(metadata.H1Digest)
+	case GolangModMetadata:
+		return golangDigests(metadata.H1Digest)
+	}
+	return nil
+}
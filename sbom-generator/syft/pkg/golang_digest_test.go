@@ -0,0 +1,49 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/file"
+)
+
+func TestGolangModuleDigests(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata interface{}
+		expected []file.Digest
+	}{
+		{
+			name:     "go.sum hash",
+			metadata: GolangModMetadata{H1Digest: "h1:Oe5jF1o9GB6ZvAu10bqfSEJYWkVWJtPOmKGp1JEWk3M="},
+			expected: []file.Digest{{Algorithm: "sha256", Value: "39ee63175a3d181e99bc0bb5d1ba9f4842585a455626d3ce98a1a9d491169373"}},
+		},
+		{
+			name:     "build info hash",
+			metadata: GolangBinMetadata{H1Digest: "h1:Oe5jF1o9GB6ZvAu10bqfSEJYWkVWJtPOmKGp1JEWk3M="},
+			expected: []file.Digest{{Algorithm: "sha256", Value: "39ee63175a3d181e99bc0bb5d1ba9f4842585a455626d3ce98a1a9d491169373"}},
+		},
+		{
+			name:     "missing hash",
+			metadata: GolangModMetadata{},
+		},
+		{
+			name:     "unknown hash",
+			metadata: GolangModMetadata{H1Digest: "h2:Oe5jF1o9GB6ZvAu10bqfSEJYWkVWJtPOmKGp1JEWk3M="},
+		},
+		{
+			name:     "malformed hash",
+			metadata: GolangModMetadata{H1Digest: "h1:bogus"},
+		},
+		{
+			name:     "other metadata",
+			metadata: JavaMetadata{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, GolangModuleDigests(Package{Metadata: test.metadata}))
+		})
+	}
+}
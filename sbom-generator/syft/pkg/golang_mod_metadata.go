@@ -0,0 +1,16 @@
+package pkg
+
+// GolangModMetadata represents all captured data for a Go module required by a go.mod file
+type GolangModMetadata struct {
+	// GoVersion is the go directive of the main module
+	GoVersion string `json:"goVersion,omitempty" cyclonedx:"goVersion"`
+	// Indirect indicates that the module is only required by the dependencies of the main module ("// indirect")
+	Indirect bool `json:"indirect,omitempty" cyclonedx:"indirect"`
+	// Replaces is the module ("path@version") which a replace directive replaces by this module
+	Replaces string `json:"replaces,omitempty" cyclonedx:"replaces"`
+	// Dir is the local directory of a module replaced by a directory (e.g. "replace example.com/lib => ../lib")
+	Dir string `json:"dir,omitempty" cyclonedx:"dir"`
+	// H1Digest is the go.sum hash of the module ("h1:" and the base64 SHA-256 of the dirhash summary of its files, see
+	// golang.org/x/mod/sumdb/dirhash), also reported as the SHA-256 checksum of the package (see GolangModuleDigests)
+	H1Digest string `json:"h1Digest,omitempty" cyclonedx:"h1Digest"`
+}
@@ -0,0 +1,30 @@
+package pkg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// golangVCSHosts are the hosts whose go module paths start with the path of the git repository holding the module
+// (e.g. github.com/anchore/syft/v2 is held by https://github.com/anchore/syft).
+var golangVCSHosts = map[string]bool{
+	"github.com":    true,
+	"gitlab.com":    true,
+	"bitbucket.org": true,
+}
+
+// GolangVCS returns the repository and the revision a go binary was built from, as stamped by go 1.18+ into the build
+// settings of its main module ("vcs", "vcs.revision"). The repository is only known for the modules of the well-known
+// git hosts.
+func GolangVCS(p Package) (repository, revision string) {
+	metadata, ok := p.Metadata.(GolangBinMetadata)
+	if !ok || metadata.BuildSettings["vcs"] != "git" || metadata.BuildSettings["vcs.revision"] == "" {
+		return "", ""
+	}
+
+	fields := strings.Split(p.Name, "/")
+	if len(fields) < 3 || !golangVCSHosts[fields[0]] {
+		return "", ""
+	}
+	return fmt.Sprintf("https://%s", strings.Join(fields[:3], "/")), metadata.BuildSettings["vcs.revision"]
+}
@@ -23,7 +23,7 @@ var jenkinsPluginPomPropertiesGroupIDs = []string{
 type JavaMetadata struct {
 	VirtualPath    string         `json:"virtualPath" cyclonedx:"virtualPath"` // we need to include the virtual path in cyclonedx documents to prevent deduplication of jars within jars
 	Manifest       *JavaManifest  `mapstructure:"Manifest" json:"manifest,omitempty"`
-	PomProperties  *PomProperties `mapstructure:"PomProperties" json:"pomProperties,omitempty" cyclonedx:"-"`
+	PomProperties  *PomProperties `mapstructure:"PomProperties" json:"pomProperties,omitempty"`
 	PomProject     *PomProject    `mapstructure:"PomProject" json:"pomProject,omitempty"`
 	ArchiveDigests []file.Digest  `hash:"ignore" json:"digest,omitempty"`
 	PURL           string         `hash:"ignore" json:"-"` // pURLs and CPEs are ignored for package IDs
@@ -10,21 +10,23 @@ type MetadataType string
 const (
 	// this is the full set of data shapes that can be represented within the pkg.Package.Metadata field
 
-	UnknownMetadataType          MetadataType = "UnknownMetadata"
-	ApkMetadataType              MetadataType = "ApkMetadata"
-	DpkgMetadataType             MetadataType = "DpkgMetadata"
-	GemMetadataType              MetadataType = "GemMetadata"
-	JavaMetadataType             MetadataType = "JavaMetadata"
-	NpmPackageJSONMetadataType   MetadataType = "NpmPackageJsonMetadata"
-	RpmdbMetadataType            MetadataType = "RpmdbMetadata"
-	RpmRepodataType              MetadataType = "RpmRepodata"
-	DartPubMetadataType          MetadataType = "DartPubMetadata"
-	DotnetDepsMetadataType       MetadataType = "DotnetDepsMetadata"
-	PythonPackageMetadataType    MetadataType = "PythonPackageMetadata"
-	RustCargoPackageMetadataType MetadataType = "RustCargoPackageMetadata"
-	KbPackageMetadataType        MetadataType = "KbPackageMetadata"
-	GolangBinMetadataType        MetadataType = "GolangBinMetadata"
-	PhpComposerJSONMetadataType  MetadataType = "PhpComposerJsonMetadata"
+	UnknownMetadataType            MetadataType = "UnknownMetadata"
+	ApkMetadataType                MetadataType = "ApkMetadata"
+	DpkgMetadataType               MetadataType = "DpkgMetadata"
+	GemMetadataType                MetadataType = "GemMetadata"
+	JavaMetadataType               MetadataType = "JavaMetadata"
+	NpmPackageJSONMetadataType     MetadataType = "NpmPackageJsonMetadata"
+	RpmdbMetadataType              MetadataType = "RpmdbMetadata"
+	RpmRepodataType                MetadataType = "RpmRepodata"
+	DartPubMetadataType            MetadataType = "DartPubMetadata"
+	DotnetDepsMetadataType         MetadataType = "DotnetDepsMetadata"
+	PythonPackageMetadataType      MetadataType = "PythonPackageMetadata"
+	PythonRequirementsMetadataType MetadataType = "PythonRequirementsMetadata"
+	RustCargoPackageMetadataType   MetadataType = "RustCargoPackageMetadata"
+	KbPackageMetadataType          MetadataType = "KbPackageMetadata"
+	GolangBinMetadataType          MetadataType = "GolangBinMetadata"
+	GolangModMetadataType          MetadataType = "GolangModMetadata"
+	PhpComposerJSONMetadataType    MetadataType = "PhpComposerJsonMetadata"
 )
 
 var AllMetadataTypes = []MetadataType{
@@ -38,25 +40,29 @@ var AllMetadataTypes = []MetadataType{
 	DartPubMetadataType,
 	DotnetDepsMetadataType,
 	PythonPackageMetadataType,
+	PythonRequirementsMetadataType,
 	RustCargoPackageMetadataType,
 	KbPackageMetadataType,
 	GolangBinMetadataType,
+	GolangModMetadataType,
 	PhpComposerJSONMetadataType,
 }
 
 var MetadataTypeByName = map[MetadataType]reflect.Type{
-	ApkMetadataType:              reflect.TypeOf(ApkMetadata{}),
-	DpkgMetadataType:             reflect.TypeOf(DpkgMetadata{}),
-	GemMetadataType:              reflect.TypeOf(GemMetadata{}),
-	JavaMetadataType:             reflect.TypeOf(JavaMetadata{}),
-	NpmPackageJSONMetadataType:   reflect.TypeOf(NpmPackageJSONMetadata{}),
-	RpmdbMetadataType:            reflect.TypeOf(RpmdbMetadata{}),
-	RpmRepodataType:              reflect.TypeOf(RpmRepodata{}),
-	DartPubMetadataType:          reflect.TypeOf(DartPubMetadata{}),
-	DotnetDepsMetadataType:       reflect.TypeOf(DotnetDepsMetadata{}),
-	PythonPackageMetadataType:    reflect.TypeOf(PythonPackageMetadata{}),
-	RustCargoPackageMetadataType: reflect.TypeOf(CargoMetadata{}),
-	KbPackageMetadataType:        reflect.TypeOf(KbPackageMetadata{}),
-	GolangBinMetadataType:        reflect.TypeOf(GolangBinMetadata{}),
-	PhpComposerJSONMetadataType:  reflect.TypeOf(PhpComposerJSONMetadata{}),
+	ApkMetadataType:                reflect.TypeOf(ApkMetadata{}),
+	DpkgMetadataType:               reflect.TypeOf(DpkgMetadata{}),
+	GemMetadataType:                reflect.TypeOf(GemMetadata{}),
+	JavaMetadataType:               reflect.TypeOf(JavaMetadata{}),
+	NpmPackageJSONMetadataType:     reflect.TypeOf(NpmPackageJSONMetadata{}),
+	RpmdbMetadataType:              reflect.TypeOf(RpmdbMetadata{}),
+	RpmRepodataType:                reflect.TypeOf(RpmRepodata{}),
+	DartPubMetadataType:            reflect.TypeOf(DartPubMetadata{}),
+	DotnetDepsMetadataType:         reflect.TypeOf(DotnetDepsMetadata{}),
+	PythonPackageMetadataType:      reflect.TypeOf(PythonPackageMetadata{}),
+	PythonRequirementsMetadataType: reflect.TypeOf(PythonRequirementsMetadata{}),
+	RustCargoPackageMetadataType:   reflect.TypeOf(CargoPackageMetadata{}),
+	KbPackageMetadataType:          reflect.TypeOf(KbPackageMetadata{}),
+	GolangBinMetadataType:          reflect.TypeOf(GolangBinMetadata{}),
+	GolangModMetadataType:          reflect.TypeOf(GolangModMetadata{}),
+	PhpComposerJSONMetadataType:    reflect.TypeOf(PhpComposerJSONMetadata{}),
 }
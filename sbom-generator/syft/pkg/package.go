@@ -14,20 +14,21 @@ import (
 // Package represents an application or library that has been bundled into a distributable format.
 // TODO: if we ignore FoundBy for ID generation should we merge the field to show it was found in two places?
 type Package struct {
-	id            artifact.ID        `hash:"ignore"`
-	Name          string             // the package name
-	Version       string             // the version of the package
-	FoundBy       string             `cyclonedx:"foundBy"` // the specific cataloger that discovered this package
-	Locations     source.LocationSet // the locations that lead to the discovery of this package (note: this is not necessarily the locations that make up this package)
-	Licenses      []string           // licenses discovered with the package metadata
-	Language      Language           `cyclonedx:"language"` // the language ecosystem this package belongs to (e.g. JavaScript, Python, etc)
-	Type          Type               `cyclonedx:"type"`     // the package type (e.g. Npm, Yarn, Python, Rpm, Deb, etc)
-	CPEs          []CPE              `hash:"ignore"`        // all possible Common Platform Enumerators (note: this is NOT included in the definition of the ID since all fields on a CPE are derived from other fields)
-	PURL          string             `hash:"ignore"`        // the Package URL (see https://github.com/package-url/purl-spec)
-	ProvidesPurls []string           `hash:"ignore"`
-	ExtPkgPurls   []string           `hash:"ignore"`
-	MetadataType  MetadataType       `cyclonedx:"metadataType"` // the shape of the additional data in the "metadata" field
-	Metadata      interface{}        // additional data found while parsing the package source
+	id               artifact.ID        `hash:"ignore"`
+	Name             string             // the package name
+	Version          string             // the version of the package
+	FoundBy          string             `cyclonedx:"foundBy"` // the specific cataloger that discovered this package
+	Locations        source.LocationSet // the locations that lead to the discovery of this package (note: this is not necessarily the locations that make up this package)
+	Licenses         []string           // licenses discovered with the package metadata
+	DetectedLicenses []DetectedLicense  `hash:"ignore"`        // licenses classified from the license files found with the package (note: this is not included in the definition of the ID since the files are not part of the package metadata)
+	Language         Language           `cyclonedx:"language"` // the language ecosystem this package belongs to (e.g. JavaScript, Python, etc)
+	Type             Type               `cyclonedx:"type"`     // the package type (e.g. Npm, Yarn, Python, Rpm, Deb, etc)
+	CPEs             []CPE              `hash:"ignore"`        // all possible Common Platform Enumerators (note: this is NOT included in the definition of the ID since all fields on a CPE are derived from other fields)
+	PURL             string             `hash:"ignore"`        // the Package URL (see https://github.com/package-url/purl-spec)
+	ProvidesPurls    []string           `hash:"ignore" cyclonedx:"providesPurls"`
+	ExtPkgPurls      []string           `hash:"ignore" cyclonedx:"extPkgPurls"`
+	MetadataType     MetadataType       `cyclonedx:"metadataType"` // the shape of the additional data in the "metadata" field
+	Metadata         interface{}        // additional data found while parsing the package source
 }
 
 func (p *Package) OverrideID(id artifact.ID) {
@@ -70,6 +71,7 @@ func (p *Package) merge(other Package) error {
 	}
 	p.ProvidesPurls = mergePURLs(p.ProvidesPurls, other.ProvidesPurls)
 	p.ExtPkgPurls = mergePURLs(p.ExtPkgPurls, other.ExtPkgPurls)
+	p.DetectedLicenses = mergeDetectedLicenses(p.DetectedLicenses, other.DetectedLicenses)
 
 	return nil
 }
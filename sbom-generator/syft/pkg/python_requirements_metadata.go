@@ -0,0 +1,18 @@
+package pkg
+
+import "github.com/anchore/syft/syft/file"
+
+// PythonRequirementsMetadata represents all captured data for a python package required by a requirements file, a
+// pyproject.toml file or locked by a pdm.lock file.
+type PythonRequirementsMetadata struct {
+	Name string `json:"name"`
+	// Extras are the optional features of the package requested by the requirement (e.g. "requests[socks]")
+	Extras []string `json:"extras,omitempty"`
+	// VersionConstraint is the version specifier of the requirement (e.g. "==2.28.1" or ">=2.0,<3")
+	VersionConstraint string `json:"versionConstraint,omitempty"`
+	// Markers is the environment marker restricting where the requirement applies (e.g. "python_version < '3.8'")
+	Markers string `json:"markers,omitempty"`
+	// Digests are the hashes pinning the distributions of the package (e.g. "--hash=sha256:..."), one for each of its
+	// wheels and sdists: none of them is the checksum of the package as a whole.
+	Digests []file.Digest `json:"digests,omitempty"`
+}
@@ -0,0 +1,95 @@
+package pkg
+
+import (
+	"sort"
+
+	"github.com/anchore/syft/syft/artifact"
+)
+
+// RelationshipsByDependencies relates installed OS packages to the packages of the catalog satisfying the dependencies
+// declared by their package manager (dpkg Depends and Pre-Depends, apk D:), considering virtual packages (dpkg
+// Provides, apk p:) and version constraints.
+func RelationshipsByDependencies(catalog *Catalog) []artifact.Relationship {
+	if catalog == nil {
+		return nil
+	}
+
+	var relationships []artifact.Relationship
+	relationships = append(relationships, dpkgDependencyRelationships(catalog)...)
+	relationships = append(relationships, apkDependencyRelationships(catalog)...)
+	return relationships
+}
+
+// providedCapability is a package or virtual package provided by an installed package. Unversioned virtual packages
+// have no version and only satisfy unversioned dependencies.
+type providedCapability struct {
+	Package Package
+	Version string
+}
+
+type capabilityIndex map[string][]providedCapability
+
+func (c capabilityIndex) add(name string, p Package, version string) {
+	c[name] = append(c[name], providedCapability{Package: p, Version: version})
+}
+
+// dependencyEdges collects the dependency relationships of packages, keeping a single (the strongest) relationship
+// between two packages.
+type dependencyEdges struct {
+	order []string
+	edges map[string]artifact.Relationship
+}
+
+func newDependencyEdges() *dependencyEdges {
+	return &dependencyEdges{edges: make(map[string]artifact.Relationship)}
+}
+
+func (d *dependencyEdges) add(from, to Package, ty artifact.RelationshipType) {
+	if from.ID() == to.ID() {
+		return
+	}
+	key := string(from.ID()) + "|" + string(to.ID())
+	existing, exists := d.edges[key]
+	if !exists {
+		d.order = append(d.order, key)
+	} else if existing.Type == artifact.HasPrerequisiteRelationship {
+		return
+	}
+	d.edges[key] = artifact.Relationship{From: from, To: to, Type: ty}
+}
+
+func (d *dependencyEdges) relationships() []artifact.Relationship {
+	relationships := make([]artifact.Relationship, 0, len(d.order))
+	for _, key := range d.order {
+		relationships = append(relationships, d.edges[key])
+	}
+	return relationships
+}
+
+// satisfiedBySelf indicates if a package satisfies its own dependency, in which case it's not related to the other
+// packages satisfying the dependency.
+func satisfiedBySelf(p Package, capabilities []providedCapability) bool {
+	for _, c := range capabilities {
+		if c.Package.ID() == p.ID() {
+			return true
+		}
+	}
+	return false
+}
+
+// uniquePackages returns the distinct packages of the given capabilities in a stable order.
+func uniquePackages(capabilities []providedCapability) []Package {
+	seen := make(map[artifact.ID]bool)
+	var pkgs []Package
+	for _, c := range capabilities {
+		if seen[c.Package.ID()] {
+			continue
+		}
+		seen[c.Package.ID()] = true
+		pkgs = append(pkgs, c.Package)
+	}
+	sort.SliceStable(pkgs, func(i, j int) bool {
+		return pkgs[i].Name < pkgs[j].Name
+	})
+	return pkgs
+}
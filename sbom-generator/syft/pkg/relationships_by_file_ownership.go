@@ -13,6 +13,7 @@ var globsForbiddenFromBeingOwned = []string{
 	ApkDBGlob,
 	DpkgDBGlob,
 	RpmDBGlob,
+	RpmSysimageDBGlob,
 	// DEB packages share common copyright info between, this does not mean that sharing these paths implies ownership.
 	"/usr/share/doc/**/copyright",
 }
@@ -35,6 +35,10 @@ type RpmRepodata struct {
 	Files       []RepodataFileRecord    `json:"files"`
 	RpmProvides []RepodataPackageRecord `json:"rpmProvides"`
 	ExtPackage  []RepodataPackageRecord `json:"extPackage"`
+	// UnresolvedRequires are the requires no package of the repository satisfies
+	UnresolvedRequires []string `json:"unresolvedRequires,omitempty"`
+	// BuildTime is the time the package was built at, in seconds since the epoch
+	BuildTime int64 `hash:"ignore" json:"buildTime,omitempty"`
 }
 
 type RepodataFileRecord struct {
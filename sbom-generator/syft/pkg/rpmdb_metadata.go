@@ -11,7 +11,13 @@ import (
 	"github.com/scylladb/go-set/strset"
 )
 
-const RpmDBGlob = "**/var/lib/rpm/Packages"
+const (
+	// RpmDBGlob matches the rpmdb of every backend: berkeley db ("Packages"), ndb ("Packages.db") and sqlite
+	// ("rpmdb.sqlite"), found in /var/lib/rpm.
+	RpmDBGlob = "**/var/lib/rpm/{Packages,Packages.db,rpmdb.sqlite}"
+	// RpmSysimageDBGlob matches the rpmdb of every backend on newer distributions, found in /usr/lib/sysimage/rpm.
+	RpmSysimageDBGlob = "**/usr/lib/sysimage/rpm/{Packages,Packages.db,rpmdb.sqlite}"
+)
 
 var (
 	_ FileOwner     = (*RpmdbMetadata)(nil)
@@ -20,18 +26,29 @@ var (
 
 // RpmdbMetadata represents all captured data for a RPM DB package entry.
 type RpmdbMetadata struct {
-	Name      string            `json:"name"`
-	Version   string            `json:"version"`
-	Epoch     *int              `json:"epoch"  cyclonedx:"epoch" jsonschema:"nullable"`
-	Arch      string            `json:"architecture"`
-	Release   string            `json:"release" cyclonedx:"release"`
-	SourceRpm string            `json:"sourceRpm" cyclonedx:"sourceRpm"`
-	Size      int               `json:"size" cyclonedx:"size"`
-	License   string            `json:"license"`
-	Vendor    string            `json:"vendor"`
-	Files     []RpmdbFileRecord `json:"files"`
-	// FIXME add support rpmdb
-	// RpmDigests []file.Digest     `hash:"ignore" json:"digest,omitempty"`
+	Name        string            `json:"name"`
+	Version     string            `json:"version"`
+	Epoch       *int              `json:"epoch"  cyclonedx:"epoch" jsonschema:"nullable"`
+	Arch        string            `json:"architecture"`
+	Release     string            `json:"release" cyclonedx:"release"`
+	SourceRpm   string            `json:"sourceRpm" cyclonedx:"sourceRpm"`
+	Size        int               `json:"size" cyclonedx:"size"`
+	License     string            `json:"license"`
+	Vendor      string            `json:"vendor"`
+	Packager    string            `json:"packager,omitempty"`
+	Homepage    string            `json:"homepage,omitempty"`
+	Summary     string            `json:"summary,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Files       []RpmdbFileRecord `json:"files"`
+	// RpmDigests are the digests recorded in the header of the installed package (the sha256 of the header and the
+	// md5 of the header and payload)
+	RpmDigests []file.Digest `hash:"ignore" json:"digest,omitempty"`
+	// Provides and Requires are the capabilities the package provides and requires, with their version constraint
+	// (e.g. "libc.so.6()(64bit)", "bash >= 5.0")
+	Provides []string `json:"provides,omitempty"`
+	Requires []string `json:"requires,omitempty"`
+	// BuildTime is the time the package was built at, in seconds since the epoch
+	BuildTime int64 `hash:"ignore" json:"buildTime,omitempty"`
 }
 
 // RpmdbFileRecord represents the file metadata for a single file attributed to a RPM package.
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/go-test/deep"
 
 	"github.com/anchore/syft/syft/linux"
@@ -127,3 +128,33 @@ func TestRpmMetadata_FileOwner(t *testing.T) {
 func intRef(i int) *int {
 	return &i
 }
+
+func TestRpmDBGlobs(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{path: "/var/lib/rpm/Packages", expected: true},
+		{path: "/var/lib/rpm/rpmdb.sqlite", expected: true},
+		{path: "/usr/lib/sysimage/rpm/Packages.db", expected: true},
+		{path: "/usr/lib/sysimage/rpm/rpmdb.sqlite", expected: true},
+		{path: "/usr/share/tests/rpm/Packages", expected: false},
+		{path: "/src/vendor/rpm/rpmdb.sqlite", expected: false},
+		{path: "/var/lib/rpm/Packages.bak", expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			var matched bool
+			for _, glob := range []string{RpmDBGlob, RpmSysimageDBGlob} {
+				m, err := doublestar.Match(glob, test.path)
+				if err != nil {
+					t.Fatalf("unable to match %q: %+v", glob, err)
+				}
+				matched = matched || m
+			}
+			if matched != test.expected {
+				t.Errorf("unexpected match of %q: %v", test.path, matched)
+			}
+		})
+	}
+}
@@ -39,6 +39,7 @@ func URL(p Package, release *linux.Release) string {
 	var purlType = p.Type.PackageURLType()
 	var name = p.Name
 	var namespace = ""
+	var qualifiers packageurl.Qualifiers
 
 	switch {
 	case purlType == "":
@@ -46,10 +47,18 @@ func URL(p Package, release *linux.Release) string {
 		// TODO: should this be a "generic" purl type instead?
 		return ""
 	case p.Type == GoModulePkg:
-		re := regexp.MustCompile(`(/)[^/]*$`)
-		fields := re.Split(p.Name, -1)
-		namespace = fields[0]
-		name = strings.TrimPrefix(p.Name, namespace+"/")
+		// the go stdlib has no namespace (pkg:golang/stdlib@go1.19.3)
+		if strings.Contains(p.Name, "/") {
+			re := regexp.MustCompile(`(/)[^/]*$`)
+			fields := re.Split(p.Name, -1)
+			namespace = fields[0]
+			name = strings.TrimPrefix(p.Name, namespace+"/")
+		}
+		if repository, revision := GolangVCS(p); repository != "" {
+			qualifiers = purlQualifiers(map[string]string{
+				PURLQualifierVCSURL: fmt.Sprintf("git+%s@%s", repository, revision),
+			}, nil)
+		}
 	case p.Type == NpmPkg:
 		fields := strings.SplitN(p.Name, "/", 2)
 		if len(fields) > 1 {
@@ -63,7 +72,7 @@ func URL(p Package, release *linux.Release) string {
 		namespace,
 		name,
 		p.Version,
-		nil,
+		qualifiers,
 		"",
 	).ToString()
 }
@@ -25,6 +25,30 @@ func TestPackageURL(t *testing.T) {
 			},
 			expected: "pkg:golang/github.com/anchore/syft@v0.1.0",
 		},
+		{
+			name: "golang with vcs info",
+			pkg: Package{
+				Name:    "github.com/anchore/syft/v2",
+				Version: "v2.0.0-20221012143321-a8f0c32b0bfe",
+				Type:    GoModulePkg,
+				Metadata: GolangBinMetadata{
+					BuildSettings: map[string]string{
+						"vcs":          "git",
+						"vcs.revision": "a8f0c32b0bfe6ef8cc9e4f4a8a09e1bf6a4bd3c1",
+					},
+				},
+			},
+			expected: "pkg:golang/github.com/anchore/syft/v2@v2.0.0-20221012143321-a8f0c32b0bfe?vcs_url=git+https://github.com/anchore/syft%40a8f0c32b0bfe6ef8cc9e4f4a8a09e1bf6a4bd3c1",
+		},
+		{
+			name: "golang stdlib",
+			pkg: Package{
+				Name:    "stdlib",
+				Version: "go1.19.3",
+				Type:    GoModulePkg,
+			},
+			expected: "pkg:golang/stdlib@go1.19.3",
+		},
 		{
 			name: "pub",
 			pkg: Package{
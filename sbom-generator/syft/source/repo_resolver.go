@@ -0,0 +1,185 @@
+package source
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const repoRequestTimeout = 5 * time.Minute
+
+var _ FileResolver = (*repoResolver)(nil)
+
+// repoResolver implements path and content access for a yum/dnf repository data source. A repository may be a
+// baseurl served over http(s) or a local mirror directory. Since a remote repository cannot be listed, paths are
+// never indexed up front and are instead resolved lazily relative to the repository root.
+type repoResolver struct {
+	root   string
+	remote bool
+	client *http.Client
+}
+
+func newRepoResolver(root string) (*repoResolver, error) {
+	if isRemoteRepoLocation(root) {
+		return &repoResolver{
+			root:   strings.TrimSuffix(root, "/"),
+			remote: true,
+			client: &http.Client{Timeout: repoRequestTimeout},
+		}, nil
+	}
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get absolute path for repository=%q: %w", root, err)
+	}
+
+	fileMeta, err := os.Stat(absRoot)
+	if err != nil {
+		return nil, fmt.Errorf("unable to stat repository=%q: %w", absRoot, err)
+	}
+
+	if !fileMeta.IsDir() {
+		return nil, fmt.Errorf("given repository path is not a directory (path=%q)", absRoot)
+	}
+
+	return &repoResolver{
+		root: absRoot,
+	}, nil
+}
+
+// isRemoteRepoLocation indicates if the given repository location is a baseurl rather than a local path.
+func isRemoteRepoLocation(location string) bool {
+	u, err := url.Parse(location)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "http" || u.Scheme == "https"
+}
+
+// requestPath converts a repository relative path into a URL (remote) or absolute file path (local).
+func (r repoResolver) requestPath(userPath string) string {
+	cleanPath := strings.TrimPrefix(path.Clean("/"+userPath), "/")
+	if r.remote {
+		return r.root + "/" + cleanPath
+	}
+	return filepath.Join(r.root, filepath.FromSlash(cleanPath))
+}
+
+func (r repoResolver) String() string {
+	return fmt.Sprintf("repo:%s", r.root)
+}
+
+// HasPath indicates if the given path exists in the underlying repository.
+func (r *repoResolver) HasPath(userPath string) bool {
+	_, err := r.stat(userPath)
+	return err == nil
+}
+
+// FilesByPath returns all file locations that exist for the given paths relative to the repository root.
+func (r repoResolver) FilesByPath(userPaths ...string) ([]Location, error) {
+	var references = make([]Location, 0)
+
+	for _, userPath := range userPaths {
+		if !r.HasPath(userPath) {
+			continue
+		}
+		references = append(references, NewLocation(userPath))
+	}
+
+	return references, nil
+}
+
+// FilesByGlob returns no results: a repository exposes well known paths only (see repodata/repomd.xml) and cannot be
+// walked, so the glob based catalogers are not applicable to this source.
+func (r repoResolver) FilesByGlob(...string) ([]Location, error) {
+	return nil, nil
+}
+
+// FilesByMIMEType returns no results, for the same reason as FilesByGlob.
+func (r repoResolver) FilesByMIMEType(...string) ([]Location, error) {
+	return nil, nil
+}
+
+// RelativeFileByPath fetches a single file at the given path relative to the repository root.
+func (r *repoResolver) RelativeFileByPath(_ Location, userPath string) *Location {
+	if !r.HasPath(userPath) {
+		return nil
+	}
+	location := NewLocation(userPath)
+	return &location
+}
+
+// FileContentsByLocation fetches file contents for a single file reference relative to the repository root.
+func (r repoResolver) FileContentsByLocation(location Location) (io.ReadCloser, error) {
+	requestPath := r.requestPath(location.RealPath)
+	if !r.remote {
+		return os.Open(requestPath)
+	}
+
+	resp, err := r.client.Get(requestPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch repository file=%q: %w", requestPath, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unable to fetch repository file=%q: %s", requestPath, resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+// AllLocations returns no locations, since a repository cannot be listed.
+func (r *repoResolver) AllLocations() <-chan Location {
+	results := make(chan Location)
+	close(results)
+	return results
+}
+
+func (r *repoResolver) FileMetadataByLocation(location Location) (FileMetadata, error) {
+	return r.stat(location.RealPath)
+}
+
+func (r repoResolver) Path() string {
+	return r.root
+}
+
+func (r repoResolver) stat(userPath string) (FileMetadata, error) {
+	if strings.Trim(path.Clean("/"+userPath), "/") == "" {
+		return FileMetadata{}, fmt.Errorf("repository root is not a file")
+	}
+
+	requestPath := r.requestPath(userPath)
+	if !r.remote {
+		info, err := os.Stat(requestPath)
+		if err != nil {
+			return FileMetadata{}, err
+		}
+		if info.IsDir() {
+			return FileMetadata{}, fmt.Errorf("path is a directory: %q", requestPath)
+		}
+		return fileMetadataFromPath(requestPath, info, false), nil
+	}
+
+	resp, err := r.client.Head(requestPath)
+	if err != nil {
+		return FileMetadata{}, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return FileMetadata{}, fmt.Errorf("unable to stat repository file=%q: %s", requestPath, resp.Status)
+	}
+
+	return FileMetadata{
+		Type:     RegularFile,
+		Size:     resp.ContentLength,
+		MIMEType: resp.Header.Get("Content-Type"),
+	}, nil
+}
@@ -0,0 +1,100 @@
+package source
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRepoFixture(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "repodata"), 0o755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, "repodata", "repomd.xml"), []byte("<repomd/>"), 0o644))
+	return root
+}
+
+func TestRepoResolver(t *testing.T) {
+	root := newRepoFixture(t)
+	server := httptest.NewServer(http.FileServer(http.Dir(root)))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		location string
+		remote   bool
+	}{
+		{
+			name:     "local mirror",
+			location: root,
+		},
+		{
+			name:     "baseurl",
+			location: server.URL + "/",
+			remote:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src := NewFromRepo(test.location)
+			resolver, err := src.FileResolver(SquashedScope)
+			require.NoError(t, err)
+
+			r, ok := resolver.(*repoResolver)
+			require.True(t, ok)
+			assert.Equal(t, test.remote, r.remote)
+
+			assert.True(t, resolver.HasPath("repodata/repomd.xml"))
+			assert.True(t, resolver.HasPath("/repodata/repomd.xml"))
+			assert.False(t, resolver.HasPath("repodata/missing.xml"))
+			assert.False(t, resolver.HasPath(""), "the repository root is not a file")
+			if !test.remote {
+				// an http server may serve an index page for a directory, which cannot be told apart from a file
+				assert.False(t, resolver.HasPath("repodata"), "directories are not files")
+			}
+
+			locations, err := resolver.FilesByPath("repodata/repomd.xml", "repodata/missing.xml")
+			require.NoError(t, err)
+			require.Len(t, locations, 1)
+			assert.Equal(t, "repodata/repomd.xml", locations[0].RealPath)
+
+			reader, err := resolver.FileContentsByLocation(locations[0])
+			require.NoError(t, err)
+			contents, err := ioutil.ReadAll(reader)
+			require.NoError(t, reader.Close())
+			require.NoError(t, err)
+			assert.Equal(t, "<repomd/>", string(contents))
+
+			metadata, err := resolver.FileMetadataByLocation(locations[0])
+			require.NoError(t, err)
+			assert.Equal(t, RegularFile, metadata.Type)
+			assert.Equal(t, int64(len(contents)), metadata.Size)
+
+			_, err = resolver.FileContentsByLocation(NewLocation("repodata/missing.xml"))
+			assert.Error(t, err)
+
+			globbed, err := resolver.FilesByGlob("**/repomd.xml")
+			require.NoError(t, err)
+			assert.Empty(t, globbed)
+
+			var all []Location
+			for l := range resolver.AllLocations() {
+				all = append(all, l)
+			}
+			assert.Empty(t, all)
+		})
+	}
+}
+
+func TestNewRepoResolver_NotADirectory(t *testing.T) {
+	root := newRepoFixture(t)
+	_, err := newRepoResolver(filepath.Join(root, "repodata", "repomd.xml"))
+	assert.Error(t, err)
+}
@@ -21,12 +21,15 @@ const (
 	ImageScheme Scheme = "ImageScheme"
 	// FileScheme indicates the source being cataloged is a single file
 	FileScheme Scheme = "FileScheme"
+	// RepoScheme indicates the source being cataloged is a yum/dnf repository (a baseurl or a local mirror)
+	RepoScheme Scheme = "RepoScheme"
 )
 
 var AllSchemes = []Scheme{
 	DirectoryScheme,
 	ImageScheme,
 	FileScheme,
+	RepoScheme,
 }
 
 func DetectScheme(fs afero.Fs, imageDetector sourceDetector, userInput string) (Scheme, image.Source, string, error) {
@@ -44,6 +47,17 @@ func DetectScheme(fs afero.Fs, imageDetector sourceDetector, userInput string) (
 			return UnknownScheme, image.UnknownSource, "", fmt.Errorf("unable to expand directory path: %w", err)
 		}
 		return FileScheme, image.UnknownSource, fileLocation, nil
+
+	case strings.HasPrefix(userInput, "repo:"):
+		repoLocation := strings.TrimPrefix(userInput, "repo:")
+		if isRemoteRepoLocation(repoLocation) {
+			return RepoScheme, image.UnknownSource, repoLocation, nil
+		}
+		repoLocation, err := homedir.Expand(repoLocation)
+		if err != nil {
+			return UnknownScheme, image.UnknownSource, "", fmt.Errorf("unable to expand repository path: %w", err)
+		}
+		return RepoScheme, image.UnknownSource, repoLocation, nil
 	}
 
 	// try the most specific sources first and move out towards more generic sources.
@@ -174,6 +174,33 @@ func TestDetectScheme(t *testing.T) {
 			expectedScheme:   FileScheme,
 			expectedLocation: "some/path-to-file",
 		},
+		{
+			name:      "explicit-repo-dir",
+			userInput: "repo:some/path-to-mirror",
+			detection: detectorResult{
+				src: image.UnknownSource,
+				ref: "",
+			},
+			dirs:             []string{"some/path-to-mirror"},
+			expectedScheme:   RepoScheme,
+			expectedLocation: "some/path-to-mirror",
+		},
+		{
+			name:      "explicit-repo-baseurl",
+			userInput: "repo:https://repo.openeuler.org/openEuler-22.03-LTS/everything/x86_64/",
+			detection: detectorResult{
+				src: image.UnknownSource,
+				ref: "",
+			},
+			expectedScheme:   RepoScheme,
+			expectedLocation: "https://repo.openeuler.org/openEuler-22.03-LTS/everything/x86_64/",
+		},
+		{
+			name:             "tilde-expansion-repo-explicit",
+			userInput:        "repo:~/some-mirror",
+			expectedScheme:   RepoScheme,
+			expectedLocation: "~/some-mirror",
+		},
 		{
 			name:      "implicit-file",
 			userInput: "some/path-to-file",
@@ -28,6 +28,7 @@ type Source struct {
 	Image             *image.Image // the image object to be cataloged (image only)
 	Metadata          Metadata
 	directoryResolver *directoryResolver
+	repoResolver      *repoResolver
 	path              string
 	mutex             *sync.Mutex
 	Exclusions        []string
@@ -111,6 +112,8 @@ func New(in Input, registryOptions *image.RegistryOptions, exclusions []string)
 		source, cleanupFn, err = generateDirectorySource(fs, in.Location)
 	case ImageScheme:
 		source, cleanupFn, err = generateImageSource(in, registryOptions)
+	case RepoScheme:
+		source, cleanupFn, err = generateRepoSource(fs, in.Location)
 	default:
 		err = fmt.Errorf("unable to process input for scanning: %q", in.UserInput)
 	}
@@ -236,6 +239,23 @@ func generateFileSource(fs afero.Fs, location string) (*Source, func(), error) {
 	return &s, cleanupFn, nil
 }
 
+func generateRepoSource(fs afero.Fs, location string) (*Source, func(), error) {
+	if !isRemoteRepoLocation(location) {
+		fileMeta, err := fs.Stat(location)
+		if err != nil {
+			return nil, func() {}, fmt.Errorf("unable to stat repository dir=%q: %w", location, err)
+		}
+
+		if !fileMeta.IsDir() {
+			return nil, func() {}, fmt.Errorf("given repository path is not a directory (path=%q)", location)
+		}
+	}
+
+	s := NewFromRepo(location)
+
+	return &s, func() {}, nil
+}
+
 // NewFromDirectory creates a new source object tailored to catalog a given filesystem directory recursively.
 func NewFromDirectory(path string) (Source, error) {
 	return Source{
@@ -262,6 +282,19 @@ func NewFromFile(path string) (Source, func()) {
 	}, cleanupFn
 }
 
+// NewFromRepo creates a new source object tailored to catalog a yum/dnf repository, given either as a baseurl
+// (http or https) or as a path to a local mirror.
+func NewFromRepo(location string) Source {
+	return Source{
+		mutex: &sync.Mutex{},
+		Metadata: Metadata{
+			Scheme: RepoScheme,
+			Path:   location,
+		},
+		path: location,
+	}
+}
+
 // fileAnalysisPath returns the path given, or in the case the path is an archive, the location where the archive
 // contents have been made available. A cleanup function is provided for any temp files created (if any).
 func fileAnalysisPath(path string) (string, func()) {
@@ -321,6 +354,17 @@ func (s *Source) FileResolver(scope Scope) (FileResolver, error) {
 			s.directoryResolver = resolver
 		}
 		return s.directoryResolver, nil
+	case RepoScheme:
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		if s.repoResolver == nil {
+			resolver, err := newRepoResolver(s.path)
+			if err != nil {
+				return nil, fmt.Errorf("unable to create repository resolver: %w", err)
+			}
+			s.repoResolver = resolver
+		}
+		return s.repoResolver, nil
 	case ImageScheme:
 		var resolver FileResolver
 		var err error
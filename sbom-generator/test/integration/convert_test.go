@@ -10,8 +10,13 @@ import (
 	"github.com/anchore/syft/internal/config"
 	"github.com/anchore/syft/internal/formats/cyclonedxjson"
 	"github.com/anchore/syft/internal/formats/cyclonedxxml"
+	"github.com/anchore/syft/internal/formats/github"
 	"github.com/anchore/syft/internal/formats/spdx22json"
 	"github.com/anchore/syft/internal/formats/spdx22tagvalue"
+	"github.com/anchore/syft/internal/formats/spdx23json"
+	"github.com/anchore/syft/internal/formats/spdx23tagvalue"
+	"github.com/anchore/syft/internal/formats/spdx23yaml"
+	"github.com/anchore/syft/internal/formats/spdx30json"
 	"github.com/anchore/syft/internal/formats/syftjson"
 	"github.com/anchore/syft/internal/formats/table"
 	"github.com/anchore/syft/syft"
@@ -24,8 +29,13 @@ var convertibleFormats = []sbom.Format{
 	syftjson.Format(),
 	spdx22json.Format(),
 	spdx22tagvalue.Format(),
+	spdx23json.Format(),
+	spdx23tagvalue.Format(),
+	spdx23yaml.Format(),
+	spdx30json.Format(),
 	cyclonedxjson.Format(),
 	cyclonedxxml.Format(),
+	github.Format(),
 }
 
 // TestConvertCmd tests if the converted SBOM is a valid document according
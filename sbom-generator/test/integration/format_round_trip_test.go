@@ -0,0 +1,393 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/formats/common"
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// roundTripGuarantees describes what survives converting a syft JSON document to a format and back.
+type roundTripGuarantees struct {
+	// decodable formats can be converted back to syft JSON at all
+	decodable bool
+	// licenses of the packages are kept
+	licenses bool
+	// metadata of the packages is restored (along with the purls of the rpm provides and external packages)
+	metadata bool
+	// relationships tells what is kept of the relationships of the packages
+	relationships relationshipGuarantee
+}
+
+// relationshipGuarantee tells what is kept of the relationships, none of them by default.
+type relationshipGuarantee int
+
+const (
+	// dependencies are kept: which package depends on which, whatever the type of the relationship relating them
+	dependencies relationshipGuarantee = iota + 1
+	// allRelationships are kept, with their type, along with the files the packages contain
+	allRelationships
+)
+
+// formatRoundTripGuarantees are the guarantees of every format: a format missing here fails the test, so that what a
+// new format keeps is decided on purpose.
+var formatRoundTripGuarantees = map[sbom.FormatID]roundTripGuarantees{
+	syft.JSONFormatID:           {decodable: true, licenses: true, metadata: true, relationships: allRelationships},
+	syft.SPDXTagValueFormatID:   {decodable: true, licenses: true, metadata: true, relationships: allRelationships},
+	syft.SPDXJSONFormatID:       {decodable: true, licenses: true, metadata: true, relationships: allRelationships},
+	syft.SPDX23TagValueFormatID: {decodable: true, licenses: true, metadata: true, relationships: allRelationships},
+	syft.SPDX23JSONFormatID:     {decodable: true, licenses: true, metadata: true, relationships: allRelationships},
+	syft.SPDX23YAMLFormatID:     {decodable: true, licenses: true, metadata: true, relationships: allRelationships},
+	syft.SPDX30JSONFormatID:     {decodable: true, licenses: true, metadata: true, relationships: allRelationships},
+	// CycloneDX only lists the dependencies of the components
+	syft.CycloneDxXMLFormatID:  {decodable: true, licenses: true, metadata: true, relationships: dependencies},
+	syft.CycloneDxJSONFormatID: {decodable: true, licenses: true, metadata: true, relationships: dependencies},
+	// GitHub dependency snapshots only list the purls of the packages and what they depend on
+	syft.GitHubID:      {decodable: true, relationships: dependencies},
+	syft.TableFormatID: {},
+	syft.TextFormatID:  {},
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	original := roundTripSBOM()
+
+	for _, id := range syft.FormatIDs() {
+		t.Run(string(id), func(t *testing.T) {
+			guarantees, ok := formatRoundTripGuarantees[id]
+			require.True(t, ok, "no round trip guarantees for format %q", id)
+
+			// syftjson -> X -> syftjson
+			start := roundTrip(t, original, syft.FormatByID(syft.JSONFormatID))
+			expected := roundTripPackages(original, guarantees)
+			expectedRelationships := roundTripRelationships(original, guarantees)
+			require.Equal(t, expected, roundTripPackages(start, guarantees), "lossy syft JSON round trip")
+			require.ElementsMatch(t, expectedRelationships, roundTripRelationships(start, guarantees), "lossy syft JSON round trip")
+
+			by, err := syft.Encode(start, syft.FormatByID(id))
+			require.NoError(t, err)
+
+			if !guarantees.decodable {
+				_, err = syft.FormatByID(id).Decode(bytes.NewReader(by))
+				assert.True(t, errors.Is(err, sbom.ErrDecodingNotSupported), "expected %q not to be decodable", id)
+				return
+			}
+
+			decoded, f, err := syft.Decode(bytes.NewReader(by))
+			require.NoError(t, err)
+			require.Equal(t, id, f.ID(), "document identified as another format")
+
+			end := roundTrip(t, *decoded, syft.FormatByID(syft.JSONFormatID))
+
+			assert.Equal(t, expected, roundTripPackages(end, guarantees))
+			assert.ElementsMatch(t, expectedRelationships, roundTripRelationships(end, guarantees))
+		})
+	}
+}
+
+// roundTrip encodes and decodes an SBOM with the given format.
+func roundTrip(t *testing.T, s sbom.SBOM, f sbom.Format) sbom.SBOM {
+	t.Helper()
+	by, err := syft.Encode(s, f)
+	require.NoError(t, err)
+	decoded, err := f.Decode(bytes.NewReader(by))
+	require.NoError(t, err)
+	return *decoded
+}
+
+// roundTripPackage is what is compared of a package after a round trip.
+type roundTripPackage struct {
+	Name          string
+	Version       string
+	Type          pkg.Type
+	PURL          string
+	Licenses      []string
+	ProvidesPurls []string
+	ExtPkgPurls   []string
+	MetadataType  pkg.MetadataType
+	Metadata      string
+}
+
+func roundTripPackages(s sbom.SBOM, guarantees roundTripGuarantees) map[string]roundTripPackage {
+	packages := make(map[string]roundTripPackage)
+	for _, p := range s.Artifacts.PackageCatalog.Sorted() {
+		rp := roundTripPackage{
+			Name:    p.Name,
+			Version: p.Version,
+			Type:    p.Type,
+			PURL:    p.PURL,
+		}
+		if guarantees.licenses && len(p.Licenses) > 0 {
+			rp.Licenses = p.Licenses
+		}
+		if guarantees.metadata {
+			rp.ProvidesPurls = p.ProvidesPurls
+			rp.ExtPkgPurls = p.ExtPkgPurls
+			rp.MetadataType = p.MetadataType
+			by, err := json.Marshal(p.Metadata)
+			if err != nil {
+				panic(err)
+			}
+			rp.Metadata = string(by)
+		}
+		packages[p.Name] = rp
+	}
+	return packages
+}
+
+func roundTripRelationships(s sbom.SBOM, guarantees roundTripGuarantees) []string {
+	relationships := internal.NewStringSet()
+	for _, r := range s.Relationships {
+		from, ok := r.From.(pkg.Package)
+		if !ok {
+			continue
+		}
+		switch to := r.To.(type) {
+		case pkg.Package:
+			switch guarantees.relationships {
+			case allRelationships:
+				relationships.Add(fmt.Sprintf("%s %s %s", from.Name, r.Type, to.Name))
+			case dependencies:
+				// the relationships are told apart only by which package is the dependency
+				if common.IsDependencyOfRelationship(r.Type) {
+					from, to = to, from
+				}
+				relationships.Add(fmt.Sprintf("%s -> %s", from.Name, to.Name))
+			}
+		case source.Coordinates:
+			if guarantees.relationships == allRelationships {
+				relationships.Add(fmt.Sprintf("%s %s %s", from.Name, r.Type, to.RealPath))
+			}
+		}
+	}
+	return relationships.ToSlice()
+}
+
+// roundTripSBOM returns an SBOM with packages of several ecosystems and all the relationships between them.
+func roundTripSBOM() sbom.SBOM {
+	epoch := 1
+	bash := pkg.Package{
+		Name:      "bash",
+		Version:   "1:5.1.8-4.el9",
+		FoundBy:   "rpm-repodata-cataloger",
+		Locations: source.NewLocationSet(source.NewLocation("/repodata/primary.xml")),
+		Licenses:  []string{"GPL-3.0-or-later"},
+		Language:  pkg.UnknownLanguage,
+		Type:      pkg.RpmPkg,
+		CPEs:      []pkg.CPE{pkg.MustCPE("cpe:2.3:a:gnu:bash:5.1.8:*:*:*:*:*:*:*")},
+		PURL:      "pkg:rpm/rhel/bash@5.1.8-4.el9?arch=x86_64&epoch=1&upstream=bash-5.1.8-4.el9.src.rpm",
+		ProvidesPurls: []string{
+			"pkg:rpm/rhel/sh@5.1.8-4.el9",
+		},
+		ExtPkgPurls: []string{
+			"pkg:maven/sha1/0123456789abcdef0123456789abcdef01234567",
+			"pkg:rpm/rhel/glibc@2.34",
+		},
+		MetadataType: pkg.RpmRepodataType,
+		Metadata: pkg.RpmRepodata{
+			Name:      "bash",
+			Version:   "5.1.8",
+			Epoch:     &epoch,
+			Arch:      "x86_64",
+			Release:   "4.el9",
+			SourceRpm: "bash-5.1.8-4.el9.src.rpm",
+			Size:      1234,
+			License:   "GPLv3+",
+			Vendor:    "Red Hat, Inc.",
+			Packager:  "Red Hat, Inc. <http://bugzilla.redhat.com/bugzilla>",
+			Homepage:  "https://www.gnu.org/software/bash",
+			Summary:   "The GNU Bourne Again shell",
+			RpmDigests: []file.Digest{
+				{Algorithm: "sha256", Value: "a1b2c3"},
+			},
+			Files: []pkg.RepodataFileRecord{
+				{
+					Path:      "/usr/bin/bash",
+					Mode:      0755,
+					Size:      1234,
+					Digest:    file.Digest{Algorithm: "sha256", Value: "d4e5f6"},
+					UserName:  "root",
+					GroupName: "root",
+				},
+			},
+			RpmProvides: []pkg.RepodataPackageRecord{
+				{PkgType: "rpm", ArtifactId: "sh", Version: "5.1.8-4.el9"},
+			},
+			ExtPackage: []pkg.RepodataPackageRecord{
+				{PkgType: "rpm", ArtifactId: "glibc", Version: "2.34"},
+			},
+			UnresolvedRequires: []string{"libtinfo.so.6()(64bit)"},
+			BuildTime:          1650000000,
+		},
+	}
+	bash.SetID()
+
+	glibc := pkg.Package{
+		Name:         "glibc",
+		Version:      "2.34",
+		FoundBy:      "rpm-repodata-cataloger",
+		Locations:    source.NewLocationSet(source.NewLocation("/repodata/primary.xml")),
+		Licenses:     []string{"LGPL-2.1-or-later"},
+		Type:         pkg.RpmPkg,
+		PURL:         "pkg:rpm/rhel/glibc@2.34?arch=x86_64",
+		MetadataType: pkg.RpmRepodataType,
+		Metadata: pkg.RpmRepodata{
+			Name:    "glibc",
+			Version: "2.34",
+			Arch:    "x86_64",
+			License: "LGPLv2+",
+		},
+	}
+	glibc.SetID()
+
+	libc6 := pkg.Package{
+		Name:         "libc6",
+		Version:      "2.31-13",
+		FoundBy:      "dpkgdb-cataloger",
+		Locations:    source.NewLocationSet(source.NewLocation("/var/lib/dpkg/status")),
+		Licenses:     []string{"GPL-2.0-only", "LGPL-2.1-only"},
+		Type:         pkg.DebPkg,
+		PURL:         "pkg:deb/debian/libc6@2.31-13?arch=amd64",
+		MetadataType: pkg.DpkgMetadataType,
+		Metadata: pkg.DpkgMetadata{
+			Package:       "libc6",
+			Source:        "glibc",
+			Version:       "2.31-13",
+			Architecture:  "amd64",
+			Maintainer:    "GNU Libc Maintainers <debian-glibc@lists.debian.org>",
+			InstalledSize: 12837,
+			Depends:       []string{"libgcc-s1", "libcrypt1 (>= 1:4.4.10-10~)"},
+			Files: []pkg.DpkgFileRecord{
+				{
+					Path:   "/lib/x86_64-linux-gnu/libc.so.6",
+					Digest: &file.Digest{Algorithm: "md5", Value: "2d5a7a8b0e3b1c2f"},
+				},
+			},
+		},
+	}
+	libc6.SetID()
+
+	app := pkg.Package{
+		Name:         "app",
+		Version:      "1.0.0",
+		FoundBy:      "java-cataloger",
+		Locations:    source.NewLocationSet(source.NewLocation("/app/app.jar")),
+		Licenses:     []string{"Apache-2.0"},
+		Language:     pkg.Java,
+		Type:         pkg.JavaPkg,
+		PURL:         "pkg:maven/org.example/app@1.0.0",
+		MetadataType: pkg.JavaMetadataType,
+		Metadata: pkg.JavaMetadata{
+			VirtualPath: "/app/app.jar",
+			Manifest: &pkg.JavaManifest{
+				Main: map[string]string{
+					"Manifest-Version": "1.0",
+					"Main-Class":       "org.example.App",
+				},
+			},
+			PomProperties: &pkg.PomProperties{
+				Path:       "META-INF/maven/org.example/app/pom.properties",
+				Name:       "app",
+				GroupID:    "org.example",
+				ArtifactID: "app",
+				Version:    "1.0.0",
+			},
+			ArchiveDigests: []file.Digest{{Algorithm: "sha1", Value: "0123456789abcdef"}},
+		},
+	}
+	app.SetID()
+
+	lib := pkg.Package{
+		Name:         "@scope/lib",
+		Version:      "2.0.0",
+		FoundBy:      "javascript-package-cataloger",
+		Locations:    source.NewLocationSet(source.NewLocation("/app/node_modules/@scope/lib/package.json")),
+		Licenses:     []string{"MIT"},
+		Language:     pkg.JavaScript,
+		Type:         pkg.NpmPkg,
+		PURL:         "pkg:npm/%40scope/lib@2.0.0",
+		MetadataType: pkg.NpmPackageJSONMetadataType,
+		Metadata: pkg.NpmPackageJSONMetadata{
+			Name:        "@scope/lib",
+			Version:     "2.0.0",
+			Author:      "Jane Doe",
+			Licenses:    []string{"MIT"},
+			Homepage:    "https://example.com/lib",
+			Description: "a library",
+			URL:         "https://github.com/example/lib",
+		},
+	}
+	lib.SetID()
+
+	tool := pkg.Package{
+		Name:         "github.com/example/tool",
+		Version:      "v0.1.0",
+		FoundBy:      "go-module-binary-cataloger",
+		Locations:    source.NewLocationSet(source.NewLocation("/usr/local/bin/tool")),
+		Language:     pkg.Go,
+		Type:         pkg.GoModulePkg,
+		PURL:         "pkg:golang/github.com/example/tool@v0.1.0",
+		MetadataType: pkg.GolangBinMetadataType,
+		Metadata: pkg.GolangBinMetadata{
+			BuildSettings: map[string]string{
+				"GOARCH":       "amd64",
+				"-ldflags":     "-s -w",
+				"vcs.revision": "abc123",
+			},
+			GoCompiledVersion: "go1.18",
+			Architecture:      "amd64",
+			H1Digest:          "h1:0123456789abcdef=",
+		},
+	}
+	tool.SetID()
+
+	libcSo := source.Coordinates{RealPath: "/lib/x86_64-linux-gnu/libc.so.6"}
+
+	return sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(bash, glibc, libc6, app, lib, tool),
+			LinuxDistribution: &linux.Release{
+				ID:        "rhel",
+				VersionID: "9.0",
+				IDLike:    []string{"fedora"},
+			},
+		},
+		Relationships: []artifact.Relationship{
+			{From: bash, To: glibc, Type: artifact.DependsOnRelationship},
+			{From: glibc, To: bash, Type: artifact.RuntimeDependencyOfRelationship},
+			{From: glibc, To: libc6, Type: artifact.OwnershipByFileOverlapRelationship},
+			{From: bash, To: glibc, Type: artifact.HasPrerequisiteRelationship},
+			{From: bash, To: libc6, Type: artifact.RecommendsRelationship},
+			{From: bash, To: tool, Type: artifact.SuggestsRelationship},
+			{From: tool, To: bash, Type: artifact.SupplementsRelationship},
+			{From: libc6, To: bash, Type: artifact.EnhancesRelationship},
+			{From: lib, To: app, Type: artifact.DependencyOfRelationship},
+			{From: lib, To: app, Type: artifact.DevDependencyOfRelationship},
+			{From: lib, To: app, Type: artifact.OptionalDependencyOfRelationship},
+			{From: tool, To: app, Type: artifact.BuildDependencyOfRelationship},
+			{From: app, To: lib, Type: artifact.ContainsRelationship},
+			{From: libc6, To: libcSo, Type: artifact.ContainsRelationship},
+		},
+		Source: source.Metadata{
+			Scheme: source.DirectoryScheme,
+			Path:   "/",
+		},
+		Descriptor: sbom.Descriptor{
+			Name:    "syft",
+			Version: "v0.42.0-bogus",
+		},
+	}
+}